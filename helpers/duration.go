@@ -0,0 +1,49 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration is time.ParseDuration extended with a leading number of days, e.g. `3d` or
+// `1d12h`, for the long periods of log retention and queries.
+func ParseDuration(value string) (time.Duration, error) {
+	negative := strings.HasPrefix(value, "-")
+	rest := strings.TrimPrefix(value, "-")
+
+	days, hours, found := strings.Cut(rest, "d")
+	if !found {
+		return time.ParseDuration(value)
+	}
+
+	count, err := strconv.ParseUint(days, 10, 32)
+	if err != nil {
+		return time.ParseDuration(value) // for its error message
+	}
+
+	duration := time.Duration(count) * 24 * time.Hour
+	if hours != "" {
+		extra, err := time.ParseDuration(hours)
+		if err != nil {
+			return 0, err
+		}
+		duration += extra
+	}
+
+	if negative {
+		duration = -duration
+	}
+	return duration, nil
+}
@@ -0,0 +1,42 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers_test
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseDuration", func() {
+	It("parses plain durations", func() {
+		Expect(helpers.ParseDuration("90m")).To(Equal(90 * time.Minute))
+	})
+
+	It("parses days", func() {
+		Expect(helpers.ParseDuration("3d")).To(Equal(72 * time.Hour))
+		Expect(helpers.ParseDuration("1d12h")).To(Equal(36 * time.Hour))
+		Expect(helpers.ParseDuration("-1d")).To(Equal(-24 * time.Hour))
+	})
+
+	It("rejects bad values", func() {
+		_, err := helpers.ParseDuration("xd")
+		Expect(err).To(HaveOccurred())
+		_, err = helpers.ParseDuration("2dx")
+		Expect(err).To(HaveOccurred())
+		_, err = helpers.ParseDuration("later")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -25,6 +25,7 @@ import (
 	kind "github.com/epinio/epinio/helpers/kubernetes/platform/kind"
 	minikube "github.com/epinio/epinio/helpers/kubernetes/platform/minikube"
 	"github.com/epinio/epinio/internal/cli/termui"
+	"github.com/epinio/epinio/internal/tracing"
 
 	apibatchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
@@ -49,13 +50,14 @@ const (
 )
 
 var (
-	EpinioNamespaceLabelKey         = "app.kubernetes.io/component"
-	EpinioNamespaceLabelValue       = "epinio-namespace"
-	EpinioAPISecretLabelKey         = fmt.Sprintf("%s/%s", APISGroupName, "api-user-credentials")
-	EpinioAPISecretLabelValue       = "true"
-	EpinioAPIGitCredentialsLabelKey = fmt.Sprintf("%s/%s", APISGroupName, "api-git-credentials")
-	EpinioAPISecretRoleLabelKey     = fmt.Sprintf("%s/%s", APISGroupName, "role")
-	EpinioAPIExportRegistryLabelKey = fmt.Sprintf("%s/%s", APISGroupName, "api-export-registry")
+	EpinioNamespaceLabelKey          = "app.kubernetes.io/component"
+	EpinioNamespaceLabelValue        = "epinio-namespace"
+	EpinioAPISecretLabelKey          = fmt.Sprintf("%s/%s", APISGroupName, "api-user-credentials")
+	EpinioAPISecretLabelValue        = "true"
+	EpinioAPIGitCredentialsLabelKey  = fmt.Sprintf("%s/%s", APISGroupName, "api-git-credentials")
+	EpinioAPISecretRoleLabelKey      = fmt.Sprintf("%s/%s", APISGroupName, "role")
+	EpinioAPIExportRegistryLabelKey  = fmt.Sprintf("%s/%s", APISGroupName, "api-export-registry")
+	EpinioRegistryCredentialLabelKey = fmt.Sprintf("%s/%s", APISGroupName, "registry-credential")
 
 	EpinioAPIConfigMapRolesLabelKey   = fmt.Sprintf("%s/%s", APISGroupName, "role")
 	EpinioAPISecretRolesAnnotationKey = fmt.Sprintf("%s/%s", APISGroupName, "roles")
@@ -132,6 +134,8 @@ func GetCluster(ctx context.Context) (*Cluster, error) {
 	config := restclient.CopyConfig(restConfig)
 	// set the warning handler for this client to ignore warnings
 	config.WarningHandler = restclient.NoWarnings{}
+	// trace the requests made on behalf of traced operations
+	config.Wrap(tracing.Transport)
 
 	c.RestConfig = restConfig
 	clientset, err := kubernetes.NewForConfig(config)
@@ -29,16 +29,17 @@ import (
 )
 
 type Config struct {
-	Namespace             string           // Name of the namespace to monitor
-	PodQuery              *regexp.Regexp   // Limit monitoring to pods matching the RE
-	Timestamps            bool             // Print timestamps before each entry.
-	ContainerQuery        *regexp.Regexp   // Limit monitoring to containers matching the RE
-	ExcludeContainerQuery *regexp.Regexp   // Exclusion list if the above alone is not enough.
-	ContainerState        ContainerState   // Limit monitoring to containers in this state.
-	Exclude               []*regexp.Regexp // If specified suppress all log entries matching the RE
-	Include               []*regexp.Regexp // If specified show only log entries matching this RE
-	Since                 time.Duration    // Show only log entries younger than the duration.
-	SinceTime             *time.Time       // Show only log entries newer than the time.
+	Namespace             string            // Name of the namespace to monitor
+	PodQuery              *regexp.Regexp    // Limit monitoring to pods matching the RE
+	Timestamps            bool              // Print timestamps before each entry.
+	ContainerQuery        *regexp.Regexp    // Limit monitoring to containers matching the RE
+	ExcludeContainerQuery *regexp.Regexp    // Exclusion list if the above alone is not enough.
+	ContainerState        ContainerState    // Limit monitoring to containers in this state.
+	Exclude               []*regexp.Regexp  // If specified suppress all log entries matching the RE
+	Include               []*regexp.Regexp  // If specified show only log entries matching this RE
+	Match                 func(string) bool // If specified show only log entries accepted by the function
+	Since                 time.Duration     // Show only log entries younger than the duration.
+	SinceTime             *time.Time        // Show only log entries newer than the time.
 	AllNamespaces         bool
 	LabelSelector         labels.Selector
 	TailLines             *int64
@@ -54,6 +55,7 @@ type ContainerLogLine struct {
 	PodName       string
 	Namespace     string
 	Timestamp     string
+	Labels        map[string]string `json:"-"` // Of the pod, for the consumers routing the lines
 }
 
 // FetchLogs writes all the logs of the matching containers to the logChan.
@@ -86,6 +88,7 @@ func FetchLogs(
 		SinceTime:  config.SinceTime,
 		Exclude:    config.Exclude,
 		Include:    config.Include,
+		Match:      config.Match,
 		Namespace:  config.AllNamespaces,
 		TailLines:  config.TailLines,
 	}
@@ -112,11 +115,13 @@ func FetchLogs(
 	tails := []*Tail{}
 	newTail := func(pod corev1.Pod, c corev1.Container) *Tail {
 		// Convert zap logger to logr for tailer functions (compatibility bridge for logr.Logger interface)
-		return NewTail(pod.Namespace, pod.Name, c.Name,
+		tail := NewTail(pod.Namespace, pod.Name, c.Name,
 			helpers.SugaredLoggerToLogr(helpers.Logger.With("component", "log-tracing")).V(4),
 			cluster.Kubectl,
 			tailOptions,
 		)
+		tail.Labels = pod.Labels
+		return tail
 	}
 
 	acceptable := func(c corev1.Container) bool {
@@ -263,9 +268,11 @@ func StreamLogs(ctx context.Context, logChan chan ContainerLogLine, wg *sync.Wai
 					SinceSeconds: int64(config.Since.Seconds()),
 					Exclude:      config.Exclude,
 					Include:      config.Include,
+					Match:        config.Match,
 					Namespace:    config.AllNamespaces,
 					TailLines:    config.TailLines,
 				})
+			tail.Labels = p.Labels
 			tails[id] = tail
 
 			wg.Add(1)
@@ -31,6 +31,7 @@ type Tail struct {
 	Namespace     string
 	PodName       string
 	ContainerName string
+	Labels        map[string]string // Of the pod, passed on with the lines
 	Options       *TailOptions
 	logger        logr.Logger
 	clientSet     kubernetes.Interface
@@ -43,6 +44,7 @@ type TailOptions struct {
 	SinceSeconds int64
 	Exclude      []*regexp.Regexp
 	Include      []*regexp.Regexp
+	Match        func(string) bool
 	Namespace    bool
 	TailLines    *int64
 	Logger       logr.Logger
@@ -166,6 +168,10 @@ OUTER:
 			}
 		}
 
+		if t.Options.Match != nil && !t.Options.Match(message) {
+			continue OUTER
+		}
+
 		helpers.Logger.Debugw("passing", "container", ident, "message", message)
 		logChan <- ContainerLogLine{
 			Message:       message,
@@ -173,6 +179,7 @@ OUTER:
 			PodName:       t.PodName,
 			Namespace:     t.Namespace,
 			Timestamp:     timestamp,
+			Labels:        t.Labels,
 		}
 	}
 }
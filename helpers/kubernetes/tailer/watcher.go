@@ -31,6 +31,7 @@ type Target struct {
 	Namespace string
 	Pod       string
 	Container string
+	Labels    map[string]string // Of the pod
 }
 
 // GetID returns the ID of the object
@@ -110,6 +111,7 @@ func Watch(ctx context.Context, i v1.PodInterface, podFilter *regexp.Regexp,
 								Namespace: pod.Namespace,
 								Pod:       pod.Name,
 								Container: c.Name,
+								Labels:    pod.Labels,
 							}
 						}
 					}
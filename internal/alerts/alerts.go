@@ -0,0 +1,203 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package alerts checks the health of applications against the alerts defined for them,
+// i.e. crash loops, unready instances, failed stagings, memory pressure and expiring
+// certificates, and notifies webhook, Slack-compatible and email endpoints when an alert
+// fires, and when it resolves. The alerts of an application are kept in a secret of the
+// application, together with their state.
+package alerts
+
+import (
+	"encoding/json"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strings"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// AreaLabel is the value of the `epinio.io/area` label of the secrets holding alerts.
+	AreaLabel = "alerts"
+
+	alertKeyPrefix  = "alert."
+	statusKeyPrefix = "status."
+)
+
+// Validate checks the name, kind, threshold and endpoints of the alert. It returns a bad
+// request API error for any issue.
+func Validate(alert models.AppAlert) error {
+	if alert.Name == "" {
+		return apierror.NewBadRequestError("name of alert not found")
+	}
+	if len(validation.IsDNS1123Label(alert.Name)) > 0 {
+		return apierror.NewBadRequestErrorf("Alert name must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name', or '123-abc').")
+	}
+
+	known := false
+	for _, kind := range models.AlertKinds {
+		if alert.Kind == kind {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return apierror.NewBadRequestErrorf("bad alert kind '%s', expected one of %s",
+			alert.Kind, strings.Join(models.AlertKinds, ", "))
+	}
+	if alert.Threshold < 0 {
+		return apierror.NewBadRequestErrorf("bad alert threshold %d, must not be negative", alert.Threshold)
+	}
+	if alert.Kind == models.AlertMemory && alert.Threshold > 100 {
+		return apierror.NewBadRequestErrorf("bad memory alert threshold %d, must be a percentage", alert.Threshold)
+	}
+
+	if len(alert.Notify) == 0 {
+		return apierror.NewBadRequestError("alert has no endpoints to notify")
+	}
+	for _, endpoint := range alert.Notify {
+		if err := validateEndpoint(endpoint); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateEndpoint checks the URL of a notification endpoint.
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("bad alert endpoint '%s'", models.RedactedEndpoint(endpoint)).WithDetails(err.Error())
+	}
+
+	switch u.Scheme {
+	case models.AlertNotifyHTTP, models.AlertNotifyHTTPS, models.AlertNotifySlack:
+		if u.Hostname() == "" {
+			return apierror.NewBadRequestErrorf("alert endpoint '%s' has no host", models.RedactedEndpoint(endpoint))
+		}
+	case models.AlertNotifyEmail:
+		if _, err := mail.ParseAddressList(u.Opaque); err != nil {
+			return apierror.NewBadRequestErrorf("bad alert email address '%s'", u.Opaque).WithDetails(err.Error())
+		}
+		if viper.GetString("smtp-server") == "" {
+			return apierror.NewBadRequestError("email alerts are not available, the server has no smtp-server configured")
+		}
+	default:
+		return apierror.NewBadRequestErrorf("bad alert endpoint scheme '%s', expected one of %s",
+			u.Scheme, strings.Join(models.AlertNotifyKinds, ", "))
+	}
+
+	return nil
+}
+
+// FromSecret returns the alerts held by the secret, with their state, ordered by name.
+func FromSecret(secret *v1.Secret) ([]models.AppAlertStatus, error) {
+	result := []models.AppAlertStatus{}
+
+	for key, value := range secret.Data {
+		if !strings.HasPrefix(key, alertKeyPrefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(key, alertKeyPrefix)
+
+		// The state is decoded first, as the alert fields it has are empty.
+		status := models.AppAlertStatus{State: models.AlertOK}
+		if raw, found := secret.Data[statusKeyPrefix+name]; found {
+			if err := json.Unmarshal(raw, &status); err != nil {
+				return nil, errors.Wrapf(err, "bad state of alert '%s'", name)
+			}
+		}
+		status.AppAlert = models.AppAlert{}
+		if err := json.Unmarshal(value, &status.AppAlert); err != nil {
+			return nil, errors.Wrapf(err, "bad alert '%s'", name)
+		}
+
+		result = append(result, status)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// Redact returns a copy of the alerts with their endpoints in redacted form, for display.
+func Redact(alerts []models.AppAlertStatus) []models.AppAlertStatus {
+	redacted := make([]models.AppAlertStatus, len(alerts))
+	for idx, alert := range alerts {
+		notify := make([]string, len(alert.Notify))
+		for i, endpoint := range alert.Notify {
+			notify[i] = models.RedactedEndpoint(endpoint)
+		}
+		alert.Notify = notify
+		redacted[idx] = alert
+	}
+	return redacted
+}
+
+// AddToSecret adds the alert to the secret. It returns a conflict API error if the secret
+// already has an alert of the same name.
+func AddToSecret(secret *v1.Secret, alert models.AppAlert) error {
+	if _, found := secret.Data[alertKeyPrefix+alert.Name]; found {
+		return apierror.NewConflictError("alert", alert.Name)
+	}
+
+	spec, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[alertKeyPrefix+alert.Name] = spec
+	return nil
+}
+
+// RemoveFromSecret removes the named alert, and its state, from the secret. It returns a
+// not found API error if the secret has no such alert.
+func RemoveFromSecret(secret *v1.Secret, name string) error {
+	if _, found := secret.Data[alertKeyPrefix+name]; !found {
+		return apierror.NewNotFoundError("alert", name)
+	}
+
+	delete(secret.Data, alertKeyPrefix+name)
+	delete(secret.Data, statusKeyPrefix+name)
+	return nil
+}
+
+// RecordInSecret records the state of the named alert in the secret. It returns false if
+// the secret has no such alert, or the state is unchanged.
+func RecordInSecret(secret *v1.Secret, name string, status models.AppAlertStatus) (bool, error) {
+	if _, found := secret.Data[alertKeyPrefix+name]; !found {
+		return false, nil
+	}
+
+	// The alert itself is not part of the recorded state.
+	status.AppAlert = models.AppAlert{}
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return false, err
+	}
+	if string(secret.Data[statusKeyPrefix+name]) == string(raw) {
+		return false, nil
+	}
+
+	secret.Data[statusKeyPrefix+name] = raw
+	return true, nil
+}
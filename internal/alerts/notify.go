@@ -0,0 +1,188 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// notifyTimeout limits the time spent on notifying a single endpoint.
+const notifyTimeout = 10 * time.Second
+
+// Notify sends the notification to the endpoint. The scheme of the endpoint selects the
+// kind of notification, see models.AlertNotifyKinds.
+func Notify(ctx context.Context, endpoint string, notification models.AlertNotification) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	switch u.Scheme {
+	case models.AlertNotifyHTTP, models.AlertNotifyHTTPS:
+		return post(ctx, u, notification)
+	case models.AlertNotifySlack:
+		u.Scheme = "https"
+		return post(ctx, u, map[string]string{"text": Text(notification)})
+	case models.AlertNotifyEmail:
+		recipients := strings.Split(u.Opaque, ",")
+		for i := range recipients {
+			recipients[i] = strings.TrimSpace(recipients[i])
+		}
+		return email(recipients, notification)
+	}
+
+	return fmt.Errorf("unknown alert endpoint kind '%s'", u.Scheme)
+}
+
+// Text returns the notification as a single line of text, e.g. `[FIRING] workspace/app:
+// alert oom (memory): instance app-1 uses 95% of its memory limit 512Mi`.
+func Text(notification models.AlertNotification) string {
+	text := fmt.Sprintf("[%s] %s/%s: alert %s (%s)",
+		strings.ToUpper(notification.State), notification.Namespace, notification.App,
+		notification.Alert, notification.Kind)
+	if notification.Message != "" {
+		text += ": " + notification.Message
+	}
+	return text
+}
+
+// post sends the body as JSON to the URL. User info of the URL is sent as basic
+// authentication.
+func post(ctx context.Context, u *url.URL, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+
+	target := *u
+	target.User = nil
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if u.User != nil {
+		password, _ := u.User.Password()
+		request.SetBasicAuth(u.User.Username(), password)
+	}
+
+	response, err := http.DefaultClient.Do(request) // nolint:gosec // alert endpoint configured by the app owner
+	if err != nil {
+		return errors.Wrapf(err, "notifying %s", models.RedactedEndpoint(u.String()))
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("notifying %s: %s", models.RedactedEndpoint(u.String()), response.Status)
+	}
+	return nil
+}
+
+// email sends the notification to the recipients, through the SMTP server configured for
+// Epinio.
+func email(recipients []string, notification models.AlertNotification) error {
+	server := viper.GetString("smtp-server")
+	if server == "" {
+		return errors.New("no smtp-server configured")
+	}
+	from := viper.GetString("smtp-from")
+	if from == "" {
+		from = "epinio@localhost"
+	}
+
+	host, _, err := net.SplitHostPort(server)
+	if err != nil {
+		return errors.Wrap(err, "bad smtp-server")
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", from)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", Text(notification))
+	fmt.Fprintf(&message, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&message, "Application: %s\r\nNamespace: %s\r\nAlert: %s (%s)\r\nState: %s\r\nTime: %s\r\n",
+		notification.App, notification.Namespace, notification.Alert, notification.Kind,
+		notification.State, notification.Time)
+	if notification.Message != "" {
+		fmt.Fprintf(&message, "\r\n%s\r\n", notification.Message)
+	}
+
+	// This is smtp.SendMail, with a timeout.
+	conn, err := net.DialTimeout("tcp", server, notifyTimeout)
+	if err != nil {
+		return errors.Wrapf(err, "connecting to %s", server)
+	}
+	_ = conn.SetDeadline(time.Now().Add(notifyTimeout))
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return errors.Wrapf(err, "connecting to %s", server)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return errors.Wrap(err, "starting tls")
+		}
+	}
+	if username := viper.GetString("smtp-username"); username != "" {
+		if err := client.Auth(smtp.PlainAuth("", username, viper.GetString("smtp-password"), host)); err != nil {
+			return errors.Wrap(err, "authenticating")
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return errors.Wrapf(err, "sending email to %s", recipient)
+		}
+	}
+	data, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := data.Write(message.Bytes()); err != nil {
+		return err
+	}
+	if err := data.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,236 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Certificate is a TLS certificate serving routes of an application.
+type Certificate struct {
+	Hosts    []string
+	NotAfter time.Time
+}
+
+// Condition is the outcome of checking an alert against the state of its application.
+type Condition struct {
+	Active  bool
+	Message string // Why the condition holds
+}
+
+// Check returns whether the condition of the alert holds for the application, and its
+// certificates.
+func Check(alert models.AppAlert, app *models.App, certificates []Certificate, now time.Time) Condition {
+	threshold := alert.EffectiveThreshold()
+
+	switch alert.Kind {
+	case models.AlertCrashLoop:
+		for _, name := range replicaNames(app) {
+			replica := app.Workload.Replicas[name]
+			if replica.Ready || int64(replica.Restarts) < threshold {
+				continue
+			}
+
+			message := fmt.Sprintf("instance %s restarted %d times", name, replica.Restarts)
+			if replica.Diagnostics != nil {
+				message = fmt.Sprintf("%s, it %s", message, replica.Diagnostics.Summary())
+			}
+			return Condition{Active: true, Message: message}
+		}
+
+	case models.AlertUnready:
+		workload := app.Workload
+		if workload != nil && workload.DesiredReplicas > 0 && workload.ReadyReplicas < workload.DesiredReplicas {
+			return Condition{Active: true, Message: fmt.Sprintf("%d of %d instances ready",
+				workload.ReadyReplicas, workload.DesiredReplicas)}
+		}
+
+	case models.AlertStagingFailed:
+		if app.StagingStatus == models.ApplicationStagingFailed {
+			return Condition{Active: true, Message: fmt.Sprintf("staging %s failed", app.StageID)}
+		}
+
+	case models.AlertMemory:
+		limit := memoryLimit(app)
+		if limit == 0 {
+			return Condition{}
+		}
+		for _, name := range replicaNames(app) {
+			replica := app.Workload.Replicas[name]
+			if !replica.MetricsOk {
+				continue
+			}
+
+			percent := replica.MemoryBytes * 100 / limit
+			if percent >= threshold {
+				return Condition{Active: true, Message: fmt.Sprintf("instance %s uses %d%% of its memory limit %s",
+					name, percent, app.Configuration.Resources.Limits.Memory)}
+			}
+		}
+
+	case models.AlertCertificate:
+		deadline := now.Add(time.Duration(threshold) * 24 * time.Hour)
+		for _, certificate := range certificates {
+			if certificate.NotAfter.After(deadline) {
+				continue
+			}
+
+			hosts := strings.Join(certificate.Hosts, ", ")
+			if !certificate.NotAfter.After(now) {
+				return Condition{Active: true, Message: fmt.Sprintf("certificate of %s expired on %s",
+					hosts, certificate.NotAfter.UTC().Format(time.RFC3339))}
+			}
+			days := int64(certificate.NotAfter.Sub(now).Hours() / 24)
+			return Condition{Active: true, Message: fmt.Sprintf("certificate of %s expires in %d days, on %s",
+				hosts, days, certificate.NotAfter.UTC().Format(time.RFC3339))}
+		}
+	}
+
+	return Condition{}
+}
+
+// For returns how long the condition of the alert has to hold before the alert fires.
+func For(alert models.AppAlert) time.Duration {
+	if alert.Kind == models.AlertUnready {
+		return time.Duration(alert.EffectiveThreshold()) * time.Minute
+	}
+	return 0
+}
+
+// Advance moves the state of the alert according to the condition, at the given time. It
+// returns the state to notify the endpoints of, if any. A state is notified once, i.e.
+// until the notification succeeded, see Notified.
+func Advance(status *models.AppAlertStatus, condition Condition, now time.Time) string {
+	stamp := now.UTC().Format(time.RFC3339)
+
+	if condition.Active {
+		if status.State != models.AlertPending && status.State != models.AlertFiring {
+			status.State = models.AlertPending
+			status.Since = stamp
+			status.Message = condition.Message
+		}
+		if status.State == models.AlertPending {
+			since, err := time.Parse(time.RFC3339, status.Since)
+			if err != nil || now.Sub(since) >= For(status.AppAlert) {
+				status.State = models.AlertFiring
+				status.Since = stamp
+				status.Message = condition.Message
+			}
+		}
+	} else if status.State != models.AlertOK {
+		status.State = models.AlertOK
+		status.Since = stamp
+		status.Message = ""
+	}
+
+	switch {
+	case status.State == models.AlertFiring && status.Notified != models.AlertNotifyFiring:
+		return models.AlertNotifyFiring
+	case status.State == models.AlertOK && status.Notified == models.AlertNotifyFiring:
+		return models.AlertNotifyResolved
+	}
+	return ""
+}
+
+// Notified records the successful notification of the state, or the failure to notify.
+func Notified(status *models.AppAlertStatus, state string, err error) {
+	if err != nil {
+		status.LastError = err.Error()
+		return
+	}
+	status.Notified = state
+	status.LastError = ""
+}
+
+// Certificates returns the TLS certificates of the ingresses of the application. Secrets
+// which are missing, or do not hold a parseable certificate, are skipped.
+func Certificates(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) ([]Certificate, error) {
+	ingresses, err := cluster.Kubectl.NetworkingV1().Ingresses(appRef.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/name=" + appRef.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Certificate{}
+	for _, ingress := range ingresses.Items {
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName == "" {
+				continue
+			}
+
+			secret, err := cluster.GetSecret(ctx, appRef.Namespace, tls.SecretName)
+			if err != nil {
+				continue
+			}
+
+			notAfter, ok := certificateExpiry(secret.Data["tls.crt"])
+			if !ok {
+				continue
+			}
+			result = append(result, Certificate{Hosts: tls.Hosts, NotAfter: notAfter})
+		}
+	}
+
+	return result, nil
+}
+
+// certificateExpiry returns the end of validity of the leaf certificate of the PEM data.
+func certificateExpiry(data []byte) (time.Time, bool) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return time.Time{}, false
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return certificate.NotAfter, true
+}
+
+// memoryLimit returns the memory limit of the instances of the application, in bytes, or
+// zero if there is none.
+func memoryLimit(app *models.App) int64 {
+	resources := app.Configuration.Resources
+	if resources == nil || resources.Limits.Memory == "" {
+		return 0
+	}
+	quantity, err := resource.ParseQuantity(resources.Limits.Memory)
+	if err != nil {
+		return 0
+	}
+	return quantity.Value()
+}
+
+// replicaNames returns the names of the replicas of the application, sorted.
+func replicaNames(app *models.App) []string {
+	if app.Workload == nil {
+		return nil
+	}
+	names := make([]string, 0, len(app.Workload.Replicas))
+	for name := range app.Workload.Replicas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
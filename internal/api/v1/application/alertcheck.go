@@ -0,0 +1,169 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/alerts"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/background"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// alertCheckInterval is the period of the checks of the alerts of all applications.
+	alertCheckInterval = 30 * time.Second
+	// alertCheckTimeout limits the time spent on checking the alerts of a single application.
+	alertCheckTimeout = time.Minute
+)
+
+// AlertEvaluator periodically checks the alerts of all applications against their
+// workload, and notifies the endpoints of the alerts which fire, or resolve. The state of
+// the alerts is kept in their secrets, so that restarts of the server neither lose, nor
+// repeat, notifications.
+type AlertEvaluator struct {
+	*background.Worker
+}
+
+// NewAlertEvaluator returns a new, not yet started, evaluator.
+func NewAlertEvaluator() *AlertEvaluator {
+	e := &AlertEvaluator{}
+	e.Worker = background.Periodic(alertCheckInterval, e.scan)
+	return e
+}
+
+// scan checks the alerts of all applications having some.
+func (e *AlertEvaluator) scan(ctx context.Context) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		helpers.Logger.Errorw("alerts: cluster access", "error", err)
+		return
+	}
+
+	secrets, err := cluster.Kubectl.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		LabelSelector: "epinio.io/area=" + alerts.AreaLabel,
+	})
+	if err != nil {
+		helpers.Logger.Errorw("alerts: listing alerts", "error", err)
+		return
+	}
+
+	for idx := range secrets.Items {
+		if ctx.Err() != nil {
+			return
+		}
+
+		secret := &secrets.Items[idx]
+		appName := secret.Labels["app.kubernetes.io/name"]
+		if appName == "" {
+			continue
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, alertCheckTimeout)
+		e.check(checkCtx, cluster, secret, models.NewAppRef(appName, secret.Namespace))
+		cancel()
+	}
+}
+
+// check evaluates the alerts held by the secret, notifies their endpoints of changes, and
+// records their new state in the secret. Conflicts are left for the next scan to resolve.
+func (e *AlertEvaluator) check(ctx context.Context, cluster *kubernetes.Cluster, secret *v1.Secret, appRef models.AppRef) {
+	appAlerts, err := alerts.FromSecret(secret)
+	if err != nil {
+		helpers.Logger.Infow("alerts: bad alerts", "namespace", appRef.Namespace, "app", appRef.Name, "error", err)
+		return
+	}
+	if len(appAlerts) == 0 {
+		return
+	}
+
+	app, err := application.Lookup(ctx, cluster, appRef.Namespace, appRef.Name)
+	if err != nil {
+		helpers.Logger.Infow("alerts: app lookup", "namespace", appRef.Namespace, "app", appRef.Name, "error", err)
+		return
+	}
+	if app == nil {
+		// Gone. The secret goes away with the application.
+		return
+	}
+
+	var certificates []alerts.Certificate
+	for _, alert := range appAlerts {
+		if alert.Kind != models.AlertCertificate {
+			continue
+		}
+		certificates, err = alerts.Certificates(ctx, cluster, appRef)
+		if err != nil {
+			helpers.Logger.Infow("alerts: certificates", "namespace", appRef.Namespace, "app", appRef.Name, "error", err)
+		}
+		break
+	}
+
+	now := time.Now()
+	changed := false
+	for _, status := range appAlerts {
+		condition := alerts.Check(status.AppAlert, app, certificates, now)
+
+		if state := alerts.Advance(&status, condition, now); state != "" {
+			helpers.Logger.Infow("alerts: notify", "namespace", appRef.Namespace, "app", appRef.Name,
+				"alert", status.Name, "state", state)
+
+			alerts.Notified(&status, state, notify(ctx, appRef, status, state, now))
+		}
+
+		updated, err := alerts.RecordInSecret(secret, status.Name, status)
+		if err != nil {
+			helpers.Logger.Infow("alerts: recording state", "namespace", appRef.Namespace, "app", appRef.Name,
+				"alert", status.Name, "error", err)
+			continue
+		}
+		changed = changed || updated
+	}
+	if !changed {
+		return
+	}
+
+	_, err = cluster.Kubectl.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		helpers.Logger.Infow("alerts: saving state", "namespace", secret.Namespace, "secret", secret.Name, "error", err)
+	}
+}
+
+// notify sends the state of the alert to all its endpoints. It returns the last error,
+// if any. The state is sent to all endpoints again on the next scan, after an error.
+func notify(ctx context.Context, appRef models.AppRef, status models.AppAlertStatus, state string, now time.Time) error {
+	notification := models.AlertNotification{
+		Namespace: appRef.Namespace,
+		App:       appRef.Name,
+		Alert:     status.Name,
+		Kind:      status.Kind,
+		State:     state,
+		Message:   status.Message,
+		Time:      now.UTC().Format(time.RFC3339),
+	}
+
+	var lastErr error
+	for _, endpoint := range status.Notify {
+		if err := alerts.Notify(ctx, endpoint, notification); err != nil {
+			helpers.Logger.Infow("alerts: notification failed", "namespace", appRef.Namespace, "app", appRef.Name,
+				"alert", status.Name, "endpoint", models.RedactedEndpoint(endpoint), "error", err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
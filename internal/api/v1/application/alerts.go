@@ -0,0 +1,142 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/alerts"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// AlertList handles the API endpoint GET /namespaces/:namespace/applications/:app/alerts
+// It returns the alerts of the application, with their state.
+func AlertList(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+	exists, err := application.Exists(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	appAlerts, err := application.Alerts(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.AppAlertsResponse(alerts.Redact(appAlerts)))
+	return nil
+}
+
+// AlertAdd handles the API endpoint POST /namespaces/:namespace/applications/:app/alerts
+// It adds an alert to the application.
+func AlertAdd(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	var addRequest models.AppAlertAddRequest
+	if err := c.BindJSON(&addRequest); err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	alert := models.AppAlert{
+		Name:      addRequest.Name,
+		Kind:      addRequest.Kind,
+		Threshold: addRequest.Threshold,
+		Notify:    addRequest.Notify,
+	}
+	if err := alerts.Validate(alert); err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+	exists, err := application.Exists(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	log.Infow("adding alert", "namespace", namespace, "app", appName, "alert", alert.Name, "kind", alert.Kind)
+
+	if err := application.AlertAdd(ctx, cluster, appRef, alert); err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.Created(c)
+	return nil
+}
+
+// AlertRemove handles the API endpoint DELETE /namespaces/:namespace/applications/:app/alerts/:alert
+// It removes the named alert from the application.
+func AlertRemove(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+	alertName := c.Param("alert")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+	exists, err := application.Exists(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	log.Infow("removing alert", "namespace", namespace, "app", appName, "alert", alertName)
+
+	if err := application.AlertRemove(ctx, cluster, appRef, alertName); err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
@@ -0,0 +1,437 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/epinio/epinio/internal/s3manager"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Labels of the image configuration written by the buildpack lifecycle.
+const (
+	buildpackBuildLabel     = "io.buildpacks.build.metadata"
+	buildpackLifecycleLabel = "io.buildpacks.lifecycle.metadata"
+)
+
+// blobChecksumKey is the key of the blob meta data recording the digest of the sources. It is
+// computed when the sources are uploaded, to not download them again for the build metadata.
+const blobChecksumKey = "checksum"
+
+// buildMetadataArtifactType is the type of the OCI artifact holding the build metadata and
+// SBOM of an exported image.
+const buildMetadataArtifactType = "application/vnd.epinio.build.v1+json"
+
+// fetchAppSBOM returns the build metadata of the current stage of the application, with
+// the SBOM documents produced by the buildpack lifecycle.
+func fetchAppSBOM(
+	c *gin.Context,
+	ctx context.Context,
+	cluster *kubernetes.Cluster,
+	theApp *models.App,
+) apierror.APIErrors {
+	meta, apierr := appBuildMetadata(ctx, cluster, theApp)
+	if apierr != nil {
+		return apierr
+	}
+
+	response.OKReturn(c, meta)
+	return nil
+}
+
+// appBuildMetadata returns the build metadata of the current stage of the application, with
+// the SBOM documents retrieved from the image.
+func appBuildMetadata(ctx context.Context, cluster *kubernetes.Cluster, theApp *models.App) (*models.BuildMetadata, apierror.APIErrors) {
+	if theApp.StageID == "" {
+		return nil, apierror.NewBadRequestError("no build metadata available for application without staging")
+	}
+
+	meta, err := application.BuildMetadata(ctx, cluster, theApp.Meta, theApp.StageID)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+	if meta == nil {
+		return nil, apierror.NewNotFoundError("build metadata for stage", theApp.StageID)
+	}
+
+	if meta.SBOM != nil {
+		documents, err := sbomDocuments(ctx, cluster, meta.Image, *meta.SBOM)
+		if err != nil {
+			return nil, apierror.InternalError(err, "retrieving the SBOM")
+		}
+		meta.SBOM.Documents = documents
+	}
+
+	return meta, nil
+}
+
+// recordBuildMetadata captures and records the build metadata of the successful staging job,
+// unless already recorded. Failures are logged, and otherwise ignored.
+func recordBuildMetadata(ctx context.Context, cluster *kubernetes.Cluster, job batchv1.Job) {
+	appRef := models.NewAppRef(job.Labels["app.kubernetes.io/name"], job.Labels["app.kubernetes.io/part-of"])
+	stageID := job.Labels[models.EpinioStageIDLabel]
+	if appRef.Name == "" || appRef.Namespace == "" || stageID == "" {
+		return
+	}
+
+	known, err := application.BuildMetadata(ctx, cluster, appRef, stageID)
+	if err != nil {
+		helpers.Logger.Infow("build metadata: reading", "app", appRef.Name,
+			"namespace", appRef.Namespace, "error", err)
+		return
+	}
+	if known != nil {
+		return
+	}
+
+	meta := captureBuildMetadata(ctx, cluster, appRef, job)
+
+	err = application.BuildMetadataRecord(ctx, cluster, appRef, meta)
+	if err != nil {
+		helpers.Logger.Infow("build metadata: recording", "app", appRef.Name,
+			"namespace", appRef.Namespace, "error", err)
+	}
+}
+
+// captureBuildMetadata assembles the build metadata of the staging job, from the job and its
+// pod, the source blob, the application origin and the built image. Parts which cannot be
+// determined are logged and left empty.
+func captureBuildMetadata(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, job batchv1.Job) models.BuildMetadata {
+	log := helpers.Logger.With("app", appRef.Name, "namespace", appRef.Namespace, "job", job.Name)
+
+	meta := models.BuildMetadata{
+		StageID:       job.Labels[models.EpinioStageIDLabel],
+		SourceBlobUID: job.Labels[models.EpinioStageBlobUIDLabel],
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+	}
+
+	builder := builderContainer(job)
+	if builder != nil {
+		meta.BuildMode = models.BuildModeBuildpack
+		if builder.Name == "dockerfile" {
+			meta.BuildMode = models.BuildModeDockerfile
+		}
+		meta.BuilderImage = builder.Image
+		for _, ev := range builder.Env {
+			if ev.Name == "APPIMAGE" {
+				meta.Image = ev.Value
+			}
+		}
+
+		imageDigest, err := builderImageDigest(ctx, cluster, job.Name, builder.Name)
+		if err != nil {
+			log.Infow("build metadata: builder image digest", "error", err)
+		}
+		meta.BuilderImageDigest = imageDigest
+	}
+
+	if meta.SourceBlobUID != "" {
+		checksum, err := sourceBlobChecksum(ctx, cluster, meta.SourceBlobUID)
+		if err != nil {
+			log.Infow("build metadata: source checksum", "error", err)
+		}
+		meta.SourceChecksum = checksum
+	}
+
+	appCR, err := application.Get(ctx, cluster, appRef)
+	if err == nil {
+		var origin models.ApplicationOrigin
+		origin, err = application.Origin(appCR)
+		if err == nil && origin.Git != nil {
+			meta.GitRevision = origin.Git.Revision
+		}
+	}
+	if err != nil {
+		log.Infow("build metadata: origin", "error", err)
+	}
+
+	if meta.Image != "" {
+		err := inspectBuiltImage(ctx, cluster, &meta)
+		if err != nil {
+			log.Infow("build metadata: image", "error", err)
+		}
+	}
+
+	return meta
+}
+
+// builderContainer returns the container of the staging job running the build.
+func builderContainer(job batchv1.Job) *corev1.Container {
+	for idx, container := range job.Spec.Template.Spec.Containers {
+		if container.Name == "buildpack" || container.Name == "dockerfile" {
+			return &job.Spec.Template.Spec.Containers[idx]
+		}
+	}
+	return nil
+}
+
+// builderImageDigest returns the digest of the image run by the named container of the
+// staging job, as resolved by the node.
+func builderImageDigest(ctx context.Context, cluster *kubernetes.Cluster, jobName, containerName string) (string, error) {
+	pods, err := cluster.Kubectl.CoreV1().Pods(helmchart.Namespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: "job-name=" + jobName,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == containerName {
+				return imageIDDigest(status.ImageID), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// imageIDDigest extracts the digest from the image id of a container status, e.g.
+// `docker-pullable://paketobuildpacks/builder@sha256:...`.
+func imageIDDigest(imageID string) string {
+	if idx := strings.LastIndex(imageID, "@"); idx >= 0 {
+		return imageID[idx+1:]
+	}
+	if _, err := digest.Parse(imageID); err == nil {
+		return imageID
+	}
+	return ""
+}
+
+// sourceBlobChecksum returns the digest of the source blob, as recorded with the blob when it
+// was uploaded. The result is empty for blobs uploaded without.
+func sourceBlobChecksum(ctx context.Context, cluster *kubernetes.Cluster, blobUID string) (string, error) {
+	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return "", errors.Wrap(err, "fetching the S3 connection details from the Kubernetes secret")
+	}
+	manager, err := s3manager.New(connectionDetails)
+	if err != nil {
+		return "", errors.Wrap(err, "creating an S3 manager")
+	}
+
+	meta, err := manager.Meta(ctx, blobUID)
+	if err != nil {
+		return "", err
+	}
+	return meta[blobChecksumKey], nil
+}
+
+// sourceChecksum returns the digest of the sources to upload, and rewinds them for the
+// upload.
+func sourceChecksum(source io.ReadSeeker) (string, error) {
+	checksum, err := digest.SHA256.FromReader(source)
+	if err != nil {
+		return "", err
+	}
+	if _, err := source.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return checksum.String(), nil
+}
+
+// inspectBuiltImage fills the image digest, buildpacks and SBOM layer of the metadata from
+// the built image.
+func inspectBuiltImage(ctx context.Context, cluster *kubernetes.Cluster, meta *models.BuildMetadata) error {
+	imageURL, credentials, tlsConfig, err := imageRegistryAccess(ctx, cluster, meta.Image)
+	if err != nil {
+		return err
+	}
+
+	info, err := registry.Inspect(ctx, imageURL, credentials, tlsConfig)
+	if err != nil {
+		return err
+	}
+
+	meta.ImageDigest = info.Manifest.Digest.String()
+
+	if value, ok := info.Labels[buildpackBuildLabel]; ok {
+		build := struct {
+			Buildpacks []models.BuildpackInfo `json:"buildpacks"`
+		}{}
+		if err := json.Unmarshal([]byte(value), &build); err != nil {
+			return errors.Wrap(err, "bad buildpack build metadata")
+		}
+		meta.Buildpacks = build.Buildpacks
+	}
+
+	if value, ok := info.Labels[buildpackLifecycleLabel]; ok {
+		lifecycle := struct {
+			SBOM *struct {
+				SHA string `json:"sha"`
+			} `json:"sbom"`
+		}{}
+		if err := json.Unmarshal([]byte(value), &lifecycle); err != nil {
+			return errors.Wrap(err, "bad buildpack lifecycle metadata")
+		}
+		if lifecycle.SBOM != nil {
+			meta.SBOM = sbomLayer(info, lifecycle.SBOM.SHA)
+		}
+	}
+
+	return nil
+}
+
+// sbomLayer returns the image layer with the given diff id, i.e. digest of the uncompressed
+// content, as recorded by the lifecycle for the SBOM layer. The result is nil if the image
+// has no such layer.
+func sbomLayer(info *registry.ImageInfo, diffID string) *models.BuildSBOM {
+	for idx, id := range info.DiffIDs {
+		if id.String() != diffID || idx >= len(info.Layers) {
+			continue
+		}
+		layer := info.Layers[idx]
+		return &models.BuildSBOM{
+			Digest:    layer.Digest.String(),
+			MediaType: layer.MediaType,
+			Size:      layer.Size,
+		}
+	}
+	return nil
+}
+
+// sbomDocuments retrieves the SBOM layer of the image and returns the JSON documents found
+// in it, by path.
+func sbomDocuments(ctx context.Context, cluster *kubernetes.Cluster, image string, sbom models.BuildSBOM) (map[string]json.RawMessage, error) {
+	imageURL, credentials, tlsConfig, err := imageRegistryAccess(ctx, cluster, image)
+	if err != nil {
+		return nil, err
+	}
+
+	layer, err := registry.FetchBlob(ctx, imageURL, sbom.Digest, credentials, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return layerDocuments(layer)
+}
+
+// layerDocuments returns the JSON documents of the (possibly gzipped) layer tarball, by path.
+func layerDocuments(layer []byte) (map[string]json.RawMessage, error) {
+	var content io.Reader = bytes.NewReader(layer)
+	if bytes.HasPrefix(layer, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(content)
+		if err != nil {
+			return nil, errors.Wrap(err, "decompressing SBOM layer")
+		}
+		defer func() {
+			_ = gz.Close()
+		}()
+		content = gz
+	}
+
+	documents := map[string]json.RawMessage{}
+	archive := tar.NewReader(content)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading SBOM layer")
+		}
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, ".json") {
+			continue
+		}
+
+		data, err := io.ReadAll(archive) // nolint:gosec // layer size bounded by the registry blob
+		if err != nil {
+			return nil, errors.Wrap(err, "reading SBOM layer")
+		}
+		if !json.Valid(data) {
+			continue
+		}
+		documents[strings.TrimPrefix(header.Name, "/")] = data
+	}
+
+	return documents, nil
+}
+
+// imageRegistryAccess returns the URL of the image as reachable from the server, with the
+// credentials and TLS configuration for accessing its registry.
+func imageRegistryAccess(ctx context.Context, cluster *kubernetes.Cluster, image string) (string, registry.RegistryCredentials, *tls.Config, error) {
+	connectionDetails, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
+	if err != nil {
+		return "", registry.RegistryCredentials{}, nil, errors.Wrap(err, "getting registry connection details")
+	}
+
+	imageURL, err := connectionDetails.ReplaceWithInternalRegistry(image)
+	if err != nil {
+		return "", registry.RegistryCredentials{}, nil, err
+	}
+
+	credentials, tlsConfig, err := application.RegistryAccess(ctx, cluster, imageURL)
+	if err != nil {
+		return "", registry.RegistryCredentials{}, nil, err
+	}
+
+	return imageURL, credentials, tlsConfig, nil
+}
+
+// attachBuildMetadata attaches the build metadata, with the SBOM documents, to the exported
+// image, as an OCI artifact.
+func attachBuildMetadata(
+	ctx context.Context,
+	meta *models.BuildMetadata,
+	imageURL string,
+	destination registry.RegistryCredentials,
+	certFile string,
+) error {
+	content, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	var tlsConfig *tls.Config
+	if certFile != "" {
+		pemData, err := os.ReadFile(certFile) // nolint:gosec // certFile under imageExportVolume, see loadCerts
+		if err != nil {
+			return err
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(pemData) {
+			return errors.New("export destination certificate is not usable")
+		}
+		tlsConfig = &tls.Config{
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	_, err = registry.AttachArtifact(ctx, imageURL, buildMetadataArtifactType,
+		content, destination, tlsConfig)
+	return err
+}
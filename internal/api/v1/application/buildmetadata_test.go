@@ -0,0 +1,122 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestImageIDDigest(t *testing.T) {
+	sha := "sha256:" + digest.FromString("builder").Encoded()
+
+	cases := map[string]string{
+		"docker-pullable://paketobuildpacks/builder@" + sha: sha,
+		"docker.io/paketobuildpacks/builder@" + sha:         sha,
+		sha:                               sha,
+		"paketobuildpacks/builder:latest": "",
+		"":                                "",
+	}
+	for imageID, want := range cases {
+		if got := imageIDDigest(imageID); got != want {
+			t.Errorf("imageIDDigest(%q) = %q, want %q", imageID, got, want)
+		}
+	}
+}
+
+func TestSBOMLayer(t *testing.T) {
+	info := &registry.ImageInfo{
+		Layers: []ocispec.Descriptor{
+			{MediaType: ocispec.MediaTypeImageLayerGzip, Digest: digest.FromString("l1"), Size: 1},
+			{MediaType: ocispec.MediaTypeImageLayerGzip, Digest: digest.FromString("l2"), Size: 2},
+		},
+		DiffIDs: []digest.Digest{digest.FromString("d1"), digest.FromString("d2")},
+	}
+
+	sbom := sbomLayer(info, digest.FromString("d2").String())
+	if sbom == nil {
+		t.Fatal("expected the SBOM layer to be found")
+	}
+	if sbom.Digest != digest.FromString("l2").String() || sbom.Size != 2 {
+		t.Errorf("unexpected SBOM layer %+v", sbom)
+	}
+
+	if sbomLayer(info, digest.FromString("d3").String()) != nil {
+		t.Error("expected no SBOM layer for an unknown diff id")
+	}
+}
+
+func TestLayerDocuments(t *testing.T) {
+	var layer bytes.Buffer
+	archive := tar.NewWriter(&layer)
+	files := map[string]string{
+		"/layers/sbom/launch/bp/layer/sbom.cdx.json": `{"bomFormat":"CycloneDX"}`,
+		"/layers/sbom/launch/bp/layer/sbom.txt":      "not a document",
+		"/layers/sbom/launch/bp/layer/bad.json":      "{",
+	}
+	for name, content := range files {
+		err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(layer.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for label, data := range map[string][]byte{"plain": layer.Bytes(), "gzip": compressed.Bytes()} {
+		documents, err := layerDocuments(data)
+		if err != nil {
+			t.Fatalf("%s: %v", label, err)
+		}
+		if len(documents) != 1 {
+			t.Fatalf("%s: expected 1 document, got %d", label, len(documents))
+		}
+		doc, ok := documents["layers/sbom/launch/bp/layer/sbom.cdx.json"]
+		if !ok || string(doc) != `{"bomFormat":"CycloneDX"}` {
+			t.Errorf("%s: unexpected documents %v", label, documents)
+		}
+	}
+}
+
+func TestSourceChecksum(t *testing.T) {
+	source := bytes.NewReader([]byte("sources"))
+
+	checksum, err := sourceChecksum(source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checksum != digest.FromString("sources").String() {
+		t.Fatalf("expected the digest of the sources, got %s", checksum)
+	}
+	if source.Len() != len("sources") {
+		t.Fatalf("expected the sources to be rewound")
+	}
+}
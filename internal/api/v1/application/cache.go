@@ -0,0 +1,171 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/helmchart"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// cacheUsageTrap makes the buildpack container report the size of the build cache, in
+// KiB, through its termination message.
+const cacheUsageTrap = `trap 'du -sk /workspace/cache 2>/dev/null | cut -f1 > /dev/termination-log' EXIT`
+
+// CacheClear handles the API endpoint POST /namespaces/:namespace/applications/:app/cache/clear
+// It removes the build cache of the application. The next staging starts from scratch.
+func CacheClear(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+	exists, err := application.Exists(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	// The cache is in use while staging.
+	staging, err := application.IsCurrentlyStaging(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if staging {
+		return apierror.NewBadRequestError("cannot clear the build cache while the application is staging")
+	}
+
+	if err := application.BuildCacheClear(ctx, cluster, appRef); err != nil {
+		return apierror.InternalError(err, "clearing the build cache")
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// prepareBuildCache clears the build cache of the application before staging, when
+// requested, or when its last recorded usage exceeds the configured maximum size.
+func prepareBuildCache(ctx context.Context, cluster *kubernetes.Cluster, req models.StageRequest) apierror.APIErrors {
+	drop := req.ClearCache
+
+	if !drop {
+		limit, err := maxCacheSize()
+		if err != nil {
+			return apierror.InternalError(err, "bad maximum cache size")
+		}
+		if limit != nil {
+			cache, err := application.BuildCache(ctx, cluster, req.App)
+			if err != nil {
+				return apierror.InternalError(err, "reading the build cache")
+			}
+			if application.BuildCacheExceeds(cache, *limit) {
+				helpers.Logger.Infow("pruning build cache", "app", req.App.Name, "namespace", req.App.Namespace,
+					"usage", cache.UsageBytes, "limit", limit.String())
+				drop = true
+			}
+		}
+	}
+
+	if !drop {
+		return nil
+	}
+
+	if err := application.BuildCacheClear(ctx, cluster, req.App); err != nil {
+		return apierror.InternalError(err, "clearing the build cache")
+	}
+	return nil
+}
+
+// maxCacheSize returns the configured maximum size of the build caches. The result is nil
+// if the size is not limited.
+func maxCacheSize() (*resource.Quantity, error) {
+	value := viper.GetString("max-cache-size")
+	if value == "" {
+		return nil, nil
+	}
+
+	limit, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil, err
+	}
+	return &limit, nil
+}
+
+// recordBuildCacheUsage records the build cache usage reported by the pod of the successful
+// staging job on the cache volume. Failures are logged, and otherwise ignored.
+func recordBuildCacheUsage(ctx context.Context, cluster *kubernetes.Cluster, job batchv1.Job) {
+	appRef := models.NewAppRef(job.Labels["app.kubernetes.io/name"], job.Labels["app.kubernetes.io/part-of"])
+	if appRef.Name == "" || appRef.Namespace == "" {
+		return
+	}
+
+	pods, err := cluster.Kubectl.CoreV1().Pods(helmchart.Namespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: "job-name=" + job.Name,
+	})
+	if err != nil {
+		helpers.Logger.Infow("build cache: listing staging pods", "job", job.Name, "error", err)
+		return
+	}
+
+	for _, pod := range pods.Items {
+		usage, ok := cacheUsage(pod)
+		if !ok {
+			continue
+		}
+
+		err := application.BuildCacheRecordUsage(ctx, cluster, appRef, usage)
+		if err != nil {
+			helpers.Logger.Infow("build cache: recording usage", "app", appRef.Name,
+				"namespace", appRef.Namespace, "error", err)
+		}
+		return
+	}
+}
+
+// cacheUsage returns the build cache usage in bytes, as reported by the buildpack container
+// of the staging pod.
+func cacheUsage(pod corev1.Pod) (int64, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != "buildpack" || status.State.Terminated == nil {
+			continue
+		}
+
+		kib, err := strconv.ParseInt(strings.TrimSpace(status.State.Terminated.Message), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return kib * 1024, true
+	}
+
+	return 0, false
+}
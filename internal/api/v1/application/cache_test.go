@@ -0,0 +1,62 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCacheUsage(t *testing.T) {
+	pod := func(name, message string) corev1.Pod {
+		return corev1.Pod{
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: name,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: message},
+					},
+				}},
+			},
+		}
+	}
+
+	usage, ok := cacheUsage(pod("buildpack", "12\n"))
+	if !ok || usage != 12*1024 {
+		t.Fatalf("expected 12 KiB, got %d (%v)", usage, ok)
+	}
+
+	if _, ok := cacheUsage(pod("buildpack", "")); ok {
+		t.Fatalf("expected no usage for an empty message")
+	}
+	if _, ok := cacheUsage(pod("dockerfile", "12")); ok {
+		t.Fatalf("expected no usage from another container")
+	}
+}
+
+func TestNewJobRunReportsCacheUsage(t *testing.T) {
+	params := stageParam{
+		AppRef:    models.NewAppRef("app", "workspace"),
+		BuildMode: models.BuildModeBuildpack,
+		Stage:     models.NewStage("stage"),
+	}
+
+	job, _ := newJobRun(params)
+
+	args := job.Spec.Template.Spec.Containers[0].Args
+	if !strings.HasPrefix(args[len(args)-1], cacheUsageTrap) {
+		t.Fatalf("expected the build to report the cache usage, got %v", args)
+	}
+}
@@ -136,21 +136,90 @@ func Create(c *gin.Context) apierror.APIErrors {
 		return apierror.AppChartIsNotKnown(chart)
 	}
 
+	desired := DefaultInstances
+	if createRequest.Configuration.Instances != nil {
+		desired = *createRequest.Configuration.Instances
+	}
+
+	// Check the namespace quota and resources up front, to avoid a partially created application.
+
+	err = application.CheckCreateQuota(ctx, cluster, namespace, desired)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	err = application.ValidateResources(ctx, cluster, appRef, createRequest.Configuration.Resources, desired)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	err = application.ValidateHealthcheck(createRequest.Configuration.Healthcheck)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	err = application.ValidateDependencies(ctx, cluster, appRef, createRequest.Configuration.DependsOn)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	err = application.ValidateContainers(createRequest.Name, createRequest.Configuration.Sidecars, createRequest.Configuration.InitContainers)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	registryCredential := ""
+	if createRequest.Configuration.RegistryCredential != nil {
+		registryCredential = *createRequest.Configuration.RegistryCredential
+	}
+
+	err = application.ValidateRegistryCredential(ctx, cluster, namespace, registryCredential)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	err = application.ValidateImageWatch(createRequest.Configuration.ImageWatch)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
 	// Arguments found OK, now we can modify the system state
 
 	err = application.Create(ctx, cluster, appRef, username, routes, chart,
 		createRequest.Configuration.Settings)
 	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
 		return apierror.InternalError(err)
 	}
 
-	desired := DefaultInstances
-	if createRequest.Configuration.Instances != nil {
-		desired = *createRequest.Configuration.Instances
-	}
-
 	err = application.ScalingSetWithEventOnCreate(ctx, cluster, appRef, desired, username)
 	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
 		return apierror.InternalError(err)
 	}
 
@@ -168,6 +237,55 @@ func Create(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	// Save resource requests and limits
+	if !createRequest.Configuration.Resources.IsEmpty() {
+		err = application.ResourcesSet(ctx, cluster, appRef, createRequest.Configuration.Resources)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Save health check configuration
+	if createRequest.Configuration.Healthcheck != nil {
+		err = application.HealthcheckSet(ctx, cluster, appRef, createRequest.Configuration.Healthcheck)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Save dependencies
+	if len(createRequest.Configuration.DependsOn) > 0 {
+		err = application.DependenciesSet(ctx, cluster, appRef, createRequest.Configuration.DependsOn)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Save sidecars and init containers
+	if len(createRequest.Configuration.Sidecars) > 0 || len(createRequest.Configuration.InitContainers) > 0 {
+		err = application.ContainersSet(ctx, cluster, appRef,
+			createRequest.Configuration.Sidecars, createRequest.Configuration.InitContainers)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Save the registry credentials used to pull the image
+	if registryCredential != "" {
+		err = application.RegistryCredentialSet(ctx, cluster, appRef, registryCredential)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Save the watch of the image tag
+	if createRequest.Configuration.ImageWatch != nil {
+		err = application.ImageWatchSet(ctx, cluster, appRef, createRequest.Configuration.ImageWatch, username)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
 	response.Created(c)
 	return nil
 }
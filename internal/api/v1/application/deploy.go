@@ -65,6 +65,14 @@ func Deploy(c *gin.Context) apierror.APIErrors {
 			return apierror.NewBadRequestErrorf("validating git url: `%s`", err.Error())
 		}
 	}
+	if req.Origin.Git != nil {
+		if err := application.ValidateGitPollInterval(req.Origin.Git.PollInterval); err != nil {
+			if apiErr, ok := err.(apierror.APIError); ok {
+				return apiErr
+			}
+			return apierror.InternalError(err)
+		}
+	}
 
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
@@ -79,9 +87,17 @@ func Deploy(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err, "failed to get the application resource")
 	}
 
-	err = deploy.UpdateImageURL(ctx, cluster, applicationCR, req.ImageURL)
-	if err != nil {
-		return apierror.InternalError(err, "failed to set application's image url")
+	// Images staged by epinio have to pass the image scan, if configured. They have to be
+	// scanned already.
+	apierr := imageScanGate(ctx, cluster, req.App, req.ImageURL, req.App.Namespace, false)
+	if apierr != nil {
+		return apierr
+	}
+
+	// Prebuilt images have to be pullable, with the credentials of the application.
+	digest, apierr := imageReachable(ctx, cluster, applicationCR, req.Stage.ID, req.ImageURL)
+	if apierr != nil {
+		return apierr
 	}
 
 	desiredRoutes, found, err := unstructured.NestedStringSlice(applicationCR.Object, "spec", "routes")
@@ -93,7 +109,7 @@ func Deploy(c *gin.Context) apierror.APIErrors {
 		desiredRoutes = []string{}
 	}
 
-	apierr := validateRoutes(ctx, cluster, name, namespace, desiredRoutes)
+	apierr = validateRoutes(ctx, cluster, name, namespace, desiredRoutes)
 	if apierr != nil {
 		return apierr
 	}
@@ -104,6 +120,19 @@ func Deploy(c *gin.Context) apierror.APIErrors {
 		return apierr
 	}
 
+	// Arguments found OK, now we can modify the system state
+
+	err = deploy.UpdateImageURL(ctx, cluster, applicationCR, req.ImageURL)
+	if err != nil {
+		return apierror.InternalError(err, "failed to set application's image url")
+	}
+
+	// Pin the image to the digest its tag resolved to. Staged images are not pinned.
+	err = application.ImageDigestSet(ctx, cluster, req.App, digest)
+	if err != nil {
+		return apierror.InternalError(err, "failed to set application's image digest")
+	}
+
 	deployResult, apierr := deploy.DeployApp(ctx, cluster, req.App, username, req.Stage.ID)
 	if apierr != nil {
 		return apierr
@@ -114,6 +143,11 @@ func Deploy(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err, "saving the app origin")
 	}
 
+	err = application.GitPollSet(ctx, cluster, req.App, gitPollInterval(req.Origin), username)
+	if err != nil {
+		return apierror.InternalError(err, "saving the app git polling")
+	}
+
 	response.OKReturn(c, models.DeployResponse{
 		Routes:   deployResult.Routes,
 		Warnings: deployResult.Warnings,
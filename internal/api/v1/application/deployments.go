@@ -16,7 +16,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,13 +25,18 @@ import (
 	"github.com/epinio/epinio/internal/api/v1/deploy"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/auth"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/metrics"
 	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/epinio/epinio/internal/tracing"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type asyncDeployJob struct {
@@ -71,12 +75,34 @@ func DeploymentsStart(c *gin.Context) apierror.APIErrors {
 	if req.ImageURL == "" && req.BlobUID == "" {
 		return apierror.NewBadRequestError("async deploy requires either `image` or `blobuid`")
 	}
+	if req.Origin.Git != nil {
+		if err := application.ValidateGitPollInterval(req.Origin.Git.PollInterval); err != nil {
+			if apiErr, ok := err.(apierror.APIError); ok {
+				return apiErr
+			}
+			return apierror.InternalError(err)
+		}
+	}
 
-	id, err := asyncDeployJobID()
+	status, err := startAsyncDeployment(ctx, requestctx.User(ctx), req)
 	if err != nil {
 		return apierror.InternalError(err, "failed to generate async deploy id")
 	}
 
+	// Help clients recover deployment id even when intermediaries strip 202 bodies.
+	c.Header("Location", c.Request.URL.Path+"/"+status.ID)
+	c.JSON(202, status)
+	return nil
+}
+
+// startAsyncDeployment registers a new asynchronous deployment for the request, starts it
+// in the background for the given user, and returns its initial status.
+func startAsyncDeployment(ctx context.Context, user auth.User, req models.AsyncDeployRequest) (models.AsyncDeployStatus, error) {
+	id, err := asyncDeployJobID()
+	if err != nil {
+		return models.AsyncDeployStatus{}, err
+	}
+
 	now := time.Now().UTC().Format(time.RFC3339)
 	job := &asyncDeployJob{
 		status: models.AsyncDeployStatus{
@@ -91,18 +117,18 @@ func DeploymentsStart(c *gin.Context) apierror.APIErrors {
 	asyncDeployJobs[id] = job
 	asyncDeployJobsMu.Unlock()
 
-	user := requestctx.User(ctx)
 	// Detach from the request lifecycle (background), but carry the authenticated
 	// user so the async origin authorization (authorizeOrigin) can still see who
 	// triggered the deployment. context.Background() alone has no user, which
-	// would reject any non-global gitconfig.
-	asyncCtx := requestctx.WithUser(context.Background(), user)
-	go runAsyncDeployment(asyncCtx, id, req, user.Username)
-
-	// Help clients recover deployment id even when intermediaries strip 202 bodies.
-	c.Header("Location", c.Request.URL.Path+"/"+id)
-	c.JSON(202, job.status)
-	return nil
+	// would reject any non-global gitconfig. The trace of the request is kept.
+	asyncCtx := requestctx.WithUser(tracing.Detach(ctx), user)
+	finished := metrics.DeployQueued()
+	go func() {
+		defer finished()
+		runAsyncDeployment(asyncCtx, id, req, user.Username)
+	}()
+
+	return job.status, nil
 }
 
 // DeploymentsStatus handles GET /namespaces/:namespace/applications/:app/deployments/:deployment_id
@@ -124,6 +150,16 @@ func DeploymentsStatus(c *gin.Context) apierror.APIErrors {
 func runAsyncDeployment(ctx context.Context, deploymentID string, req models.AsyncDeployRequest, username string) {
 	log := requestctx.Logger(ctx).With("component", "async-deploy", "deploymentID", deploymentID)
 
+	ctx, span := tracing.Start(ctx, "async deploy",
+		attribute.String("epinio.namespace", req.App.Namespace),
+		attribute.String("epinio.app", req.App.Name),
+		attribute.String("epinio.deployment_id", deploymentID),
+	)
+	var failure error
+	defer func() {
+		tracing.End(span, failure)
+	}()
+
 	update := func(mut func(*models.AsyncDeployStatus)) {
 		asyncDeployJobsMu.Lock()
 		defer asyncDeployJobsMu.Unlock()
@@ -136,6 +172,7 @@ func runAsyncDeployment(ctx context.Context, deploymentID string, req models.Asy
 	}
 
 	failErr := func(err error) {
+		failure = err
 		update(func(s *models.AsyncDeployStatus) {
 			s.Status = "failed"
 			s.Error = err.Error()
@@ -151,6 +188,7 @@ func runAsyncDeployment(ctx context.Context, deploymentID string, req models.Asy
 				msg = errs[0].Title
 			}
 		}
+		failure = errors.New(msg)
 		update(func(s *models.AsyncDeployStatus) {
 			s.Status = "failed"
 			s.Error = msg
@@ -165,6 +203,45 @@ func runAsyncDeployment(ctx context.Context, deploymentID string, req models.Asy
 		return
 	}
 
+	// Wait for the dependencies of the application before staging it.
+	dependencies, err := application.Dependencies(ctx, cluster, req.App)
+	if err != nil {
+		failErr(err)
+		return
+	}
+	if len(dependencies) > 0 {
+		update(func(s *models.AsyncDeployStatus) { s.Status = "waiting" })
+
+		if err := application.WaitForDependencies(ctx, cluster, req.App, dependencies); err != nil {
+			failErr(err)
+			return
+		}
+	}
+
+	// Import the sources of deployments triggered by a git push. See gittrigger.go
+	if req.BlobUID == "" && req.ImageURL == "" && req.Origin.Git != nil {
+		update(func(s *models.AsyncDeployStatus) { s.Status = "importing" })
+
+		gitConfig, apiErr := resolveGitconfig(cluster, requestctx.User(ctx), req.Origin.Git.Gitconfig)
+		if apiErr != nil {
+			failAPI(apiErr)
+			return
+		}
+
+		blobUID, branch, revision, apiErr := cloneAndUpload(ctx, cluster, req.Origin.Git.URL,
+			req.Origin.Git.Revision, req.App.Namespace, req.App.Name, username, gitConfig)
+		if apiErr != nil {
+			failAPI(apiErr)
+			return
+		}
+
+		req.BlobUID = blobUID
+		if branch != "" {
+			req.Origin.Git.Branch = branch
+		}
+		req.Origin.Git.Revision = revision
+	}
+
 	var stageID string
 	var imageURL string
 
@@ -172,7 +249,7 @@ func runAsyncDeployment(ctx context.Context, deploymentID string, req models.Asy
 	if req.BlobUID != "" {
 		update(func(s *models.AsyncDeployStatus) { s.Status = "staging" })
 
-		stageResp, apiErr := stageForAsyncDeploy(ctx, cluster, req.App, req.BlobUID, req.BuilderImage, username)
+		stageResp, apiErr := stageForAsyncDeploy(ctx, cluster, req.App, req.BlobUID, req.BuilderImage, req.ClearCache, username)
 		if apiErr != nil {
 			failAPI(apiErr)
 			return
@@ -185,6 +262,23 @@ func runAsyncDeployment(ctx context.Context, deploymentID string, req models.Asy
 			s.ImageURL = imageURL
 		})
 
+		if stageResp.QueuePosition > 0 {
+			err := application.Staging.Wait(ctx, stageID, func(position int) {
+				update(func(s *models.AsyncDeployStatus) {
+					s.Status = "queued"
+					s.QueuePosition = position
+				})
+			})
+			if err != nil {
+				failErr(err)
+				return
+			}
+			update(func(s *models.AsyncDeployStatus) {
+				s.Status = "staging"
+				s.QueuePosition = 0
+			})
+		}
+
 		jobs, apiErr := stageJobs(ctx, cluster, req.App.Namespace, stageID)
 		if apiErr != nil {
 			failAPI(apiErr)
@@ -205,6 +299,16 @@ func runAsyncDeployment(ctx context.Context, deploymentID string, req models.Asy
 		update(func(s *models.AsyncDeployStatus) { s.ImageURL = imageURL })
 	}
 
+	// Images of the epinio registry are scanned, also when given by the request.
+	if viper.GetString("image-scanner") != "" {
+		update(func(s *models.AsyncDeployStatus) { s.Status = "scanning" })
+
+		if apiErr := imageScanGate(ctx, cluster, req.App, imageURL, req.App.Namespace, true); apiErr != nil {
+			failAPI(apiErr)
+			return
+		}
+	}
+
 	update(func(s *models.AsyncDeployStatus) { s.Status = "deploying" })
 
 	applicationCR, err := application.Get(ctx, cluster, req.App)
@@ -217,11 +321,22 @@ func runAsyncDeployment(ctx context.Context, deploymentID string, req models.Asy
 		return
 	}
 
+	digest, apiErr := imageReachable(ctx, cluster, applicationCR, stageID, imageURL)
+	if apiErr != nil {
+		failAPI(apiErr)
+		return
+	}
+
 	if err := deploy.UpdateImageURL(ctx, cluster, applicationCR, imageURL); err != nil {
 		failErr(err)
 		return
 	}
 
+	if err := application.ImageDigestSet(ctx, cluster, req.App, digest); err != nil {
+		failErr(err)
+		return
+	}
+
 	desiredRoutes, found, err := unstructured.NestedStringSlice(applicationCR.Object, "spec", "routes")
 	if err != nil {
 		failErr(err)
@@ -236,6 +351,13 @@ func runAsyncDeployment(ctx context.Context, deploymentID string, req models.Asy
 		return
 	}
 
+	// The dependencies may have gone down while staging. Wait for them again, the
+	// deployment itself does not.
+	if err := application.WaitForDependencies(ctx, cluster, req.App, dependencies); err != nil {
+		failErr(err)
+		return
+	}
+
 	deployResult, apiErr := deploy.DeployApp(ctx, cluster, req.App, username, stageID)
 	if apiErr != nil {
 		failAPI(apiErr)
@@ -252,6 +374,11 @@ func runAsyncDeployment(ctx context.Context, deploymentID string, req models.Asy
 		return
 	}
 
+	if err := application.GitPollSet(ctx, cluster, req.App, gitPollInterval(req.Origin), username); err != nil {
+		failErr(err)
+		return
+	}
+
 	update(func(s *models.AsyncDeployStatus) {
 		s.Status = "succeeded"
 		s.Routes = deployResult.Routes
@@ -267,6 +394,7 @@ func stageForAsyncDeploy(
 	appRef models.AppRef,
 	blobUID string,
 	builderImage string,
+	clearCache bool,
 	username string,
 ) (*models.StageResponse, apierror.APIErrors) {
 	log := requestctx.Logger(ctx).With("component", "async-stage")
@@ -291,20 +419,29 @@ func stageForAsyncDeploy(
 
 	// determine builder image (request overrides)
 	stageReq := models.StageRequest{
-		App:         appRef,
-		BlobUID:     blobUID,
+		App:          appRef,
+		BlobUID:      blobUID,
 		BuilderImage: builderImage,
+		ClearCache:   clearCache,
+	}
+
+	s3ConnectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
+		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return nil, apierror.InternalError(err, "failed to fetch the S3 connection details")
+	}
+
+	// Validate incoming blob id before attempting to stage (reuse existing helper)
+	if apiErr := validateBlob(ctx, blobUID, appRef, s3ConnectionDetails); apiErr != nil {
+		return nil, apiErr
 	}
 
-	builder, builderErr := getBuilderImage(stageReq, app)
+	buildMode, builder, builderErr := stagingBuilder(ctx, s3ConnectionDetails, stageReq, app, blobUID)
 	if builderErr != nil {
 		return nil, builderErr
 	}
 	if builder == "" {
-		builder = viper.GetString("default-builder-image")
-		if builder == "" {
-			return nil, apierror.NewBadRequestError("no builder image specified and no default configured")
-		}
+		return nil, apierror.NewBadRequestError("no builder image specified and no default configured")
 	}
 
 	config, err := DetermineStagingScripts(ctx, cluster, helmchart.Namespace(), builder)
@@ -312,18 +449,7 @@ func stageForAsyncDeploy(
 		return nil, apierror.InternalError(err, "failed to retrieve staging configuration")
 	}
 
-	log.Infow("staging app", "scripts", config.Name, "builder", builder)
-
-	s3ConnectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
-		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
-	if err != nil {
-		return nil, apierror.InternalError(err, "failed to fetch the S3 connection details")
-	}
-
-	// Validate incoming blob id before attempting to stage (reuse existing helper)
-	if apiErr := validateBlob(ctx, blobUID, appRef, s3ConnectionDetails); apiErr != nil {
-		return nil, apiErr
-	}
+	log.Infow("staging app", "scripts", config.Name, "mode", buildMode, "builder", builder)
 
 	uid, err := randstr.Hex16()
 	if err != nil {
@@ -374,6 +500,7 @@ func stageForAsyncDeploy(
 
 	params := stageParam{
 		AppRef:              appRef,
+		BuildMode:           buildMode,
 		BuilderImage:        builder,
 		DownloadImage:       config.DownloadImage,
 		UnpackImage:         config.UnpackImage,
@@ -394,33 +521,11 @@ func stageForAsyncDeploy(
 		HelmValues:          config.HelmValues,
 	}
 
-	if !params.HelmValues.Storage.Cache.EmptyDir {
-		if err := ensurePVC(ctx, cluster, params.HelmValues.Storage.Cache, appRef.MakeCachePVCName()); err != nil {
-			return nil, apierror.InternalError(err, "failed to ensure a PersistentVolumeClaim for the application cache")
-		}
-	}
-	if !params.HelmValues.Storage.SourceBlobs.EmptyDir {
-		if err := ensurePVC(ctx, cluster, params.HelmValues.Storage.SourceBlobs, appRef.MakeSourceBlobsPVCName()); err != nil {
-			return nil, apierror.InternalError(err, "failed to ensure a PersistentVolumeClaim for the application source blobs")
-		}
-	}
-
-	job, jobenv := newJobRun(params)
-
-	if err := cluster.CreateSecret(ctx, helmchart.Namespace(), *jobenv); err != nil {
-		return nil, apierror.InternalError(errors.Wrap(err, "failed to create job env secret"))
-	}
-	if err := cluster.CreateJob(ctx, helmchart.Namespace(), job); err != nil {
-		return nil, apierror.InternalError(errors.Wrap(err, "failed to create staging job"))
-	}
-	if err := updateApp(ctx, cluster, app, params); err != nil {
-		return nil, apierror.InternalError(err, "updating application CR with staging information")
+	stageResponse, apiErr := executeStage(ctx, cluster, app, stageReq, params)
+	if apiErr != nil {
+		return nil, apiErr
 	}
 
-	imageURL := params.ImageURL(params.RegistryURL)
-	return &models.StageResponse{
-		Stage:    models.NewStage(uid),
-		ImageURL: imageURL,
-	}, nil
+	return &stageResponse, nil
 }
 
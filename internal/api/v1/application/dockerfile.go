@@ -0,0 +1,143 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/s3manager"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/mholt/archives"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// dockerfileName is the file selecting the dockerfile build mode, when found at the
+	// top of the application sources.
+	dockerfileName = "Dockerfile"
+
+	// blobDockerfileKey is the key of the blob meta data recording that the sources
+	// contain a Dockerfile.
+	blobDockerfileKey = "dockerfile"
+)
+
+var errDockerfileFound = errors.New("dockerfile found")
+
+// archiveHasDockerfile returns true if the archive of application sources contains a
+// Dockerfile at its top level. Archives of unknown format have none.
+func archiveHasDockerfile(ctx context.Context, archive io.Reader) (bool, error) {
+	format, stream, err := archives.Identify(ctx, "", archive)
+	if err != nil {
+		if errors.Is(err, archives.NoMatch) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	extractor, ok := format.(archives.Extractor)
+	if !ok {
+		return false, nil
+	}
+
+	err = extractor.Extract(ctx, stream, func(ctx context.Context, info archives.FileInfo) error {
+		if !info.IsDir() && isTopLevelDockerfile(info.NameInArchive) {
+			return errDockerfileFound
+		}
+		return nil
+	})
+	if errors.Is(err, errDockerfileFound) {
+		return true, nil
+	}
+
+	return false, err
+}
+
+// dirHasDockerfile returns true if the directory of application sources contains a
+// Dockerfile at its top level.
+func dirHasDockerfile(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, dockerfileName))
+	return err == nil && !info.IsDir()
+}
+
+func isTopLevelDockerfile(name string) bool {
+	return path.Clean(strings.TrimPrefix(name, "/")) == dockerfileName
+}
+
+// blobHasDockerfile returns true if the meta data of the blob records a Dockerfile in the
+// sources.
+func blobHasDockerfile(ctx context.Context, s3ConnectionDetails s3manager.ConnectionDetails, blobUID string) (bool, error) {
+	manager, err := s3manager.New(s3ConnectionDetails)
+	if err != nil {
+		return false, err
+	}
+
+	blobMeta, err := manager.Meta(ctx, blobUID)
+	if err != nil {
+		return false, err
+	}
+
+	// S3 and SeaweedFS return user metadata keys in lowercase (per S3/HTTP behavior)
+	value, ok := blobMeta[blobDockerfileKey]
+	if !ok {
+		value = blobMeta["Dockerfile"]
+	}
+
+	return value == "true", nil
+}
+
+// stagingBuilder determines the build mode and builder image for staging the blob. An
+// explicitly requested builder image always selects a buildpack build. Otherwise sources
+// with a Dockerfile are built by the dockerfile builder image, and all others by the
+// builder image used before, or the default builder image.
+func stagingBuilder(
+	ctx context.Context,
+	s3ConnectionDetails s3manager.ConnectionDetails,
+	req models.StageRequest,
+	app *unstructured.Unstructured,
+	blobUID string,
+) (string, string, apierror.APIErrors) {
+	if req.BuilderImage != "" {
+		return models.BuildModeBuildpack, req.BuilderImage, nil
+	}
+
+	dockerfile, err := blobHasDockerfile(ctx, s3ConnectionDetails, blobUID)
+	if err != nil {
+		return "", "", apierror.InternalError(err, "querying blob id meta-data")
+	}
+	if dockerfile {
+		return models.BuildModeDockerfile, viper.GetString("dockerfile-builder-image"), nil
+	}
+
+	builderImage, builderErr := getBuilderImage(req, app)
+	if builderErr != nil {
+		return "", "", builderErr
+	}
+
+	// A previous dockerfile build leaves its builder image behind. It is no buildpack builder.
+	if application.BuildMode(app) == models.BuildModeDockerfile {
+		builderImage = ""
+	}
+	if builderImage == "" {
+		builderImage = viper.GetString("default-builder-image")
+	}
+
+	return models.BuildModeBuildpack, builderImage, nil
+}
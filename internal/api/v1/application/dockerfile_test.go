@@ -0,0 +1,97 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestArchiveHasDockerfile(t *testing.T) {
+	for _, tc := range []struct {
+		files    map[string][]byte
+		expected bool
+	}{
+		{map[string][]byte{"Dockerfile": []byte("FROM scratch"), "main.go": []byte("package main")}, true},
+		{map[string][]byte{"./Dockerfile": []byte("FROM scratch")}, true},
+		{map[string][]byte{"build/Dockerfile": []byte("FROM scratch"), "main.go": []byte("package main")}, false},
+		{map[string][]byte{"main.go": []byte("package main")}, false},
+	} {
+		archive, err := createTar(tc.files)
+		if err != nil {
+			t.Fatalf("creating tar: %v", err)
+		}
+
+		found, err := archiveHasDockerfile(context.Background(), archive)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if found != tc.expected {
+			t.Fatalf("files %v: expected %v, got %v", tc.files, tc.expected, found)
+		}
+	}
+}
+
+func TestNewJobRunDockerfile(t *testing.T) {
+	params := stageParam{
+		AppRef:       models.NewAppRef("app", "workspace"),
+		BuildMode:    models.BuildModeDockerfile,
+		BuilderImage: "kaniko",
+		Stage:        models.NewStage("stage"),
+	}
+
+	job, _ := newJobRun(params)
+
+	containers := job.Spec.Template.Spec.Containers
+	if len(containers) != 1 || containers[0].Name != "dockerfile" || containers[0].Image != "kaniko" {
+		t.Fatalf("expected the dockerfile build container, got %+v", containers)
+	}
+	if *containers[0].SecurityContext.RunAsUser != 0 {
+		t.Fatalf("expected the dockerfile build to run as root")
+	}
+	if len(job.Spec.Template.Spec.InitContainers) != 2 {
+		t.Fatalf("expected the download and unpack init containers")
+	}
+
+	params.BuildMode = models.BuildModeBuildpack
+	job, _ = newJobRun(params)
+	if name := job.Spec.Template.Spec.Containers[0].Name; name != "buildpack" {
+		t.Fatalf("expected the buildpack build container, got %s", name)
+	}
+}
+
+func TestDockerfileRegistryArgs(t *testing.T) {
+	for _, tc := range []struct {
+		params   stageParam
+		expected []string
+	}{
+		{stageParam{RegistryURL: "registry.example.com/apps"}, nil},
+		{stageParam{RegistryURL: "http://registry.example.com:5000/apps"},
+			[]string{"--insecure-registry=registry.example.com:5000"}},
+		{stageParam{RegistryURL: "127.0.0.1:30500/apps"},
+			[]string{"--insecure-registry=127.0.0.1:30500"}},
+		{stageParam{RegistryURL: "registry.example.com/apps", RegistryCASecret: "ca", RegistryCAHash: "abcd1234.0"},
+			[]string{"--registry-certificate=registry.example.com=/etc/ssl/certs/abcd1234.0"}},
+		{stageParam{RegistryURL: "registry.epinio.svc.cluster.local:5000/apps"},
+			[]string{"--skip-tls-verify-registry=registry.epinio.svc.cluster.local:5000"}},
+		{stageParam{RegistryURL: "registry.epinio.svc.cluster.local:5000/apps", RegistryCASecret: "ca", RegistryCAHash: "abcd1234.0"},
+			[]string{"--registry-certificate=registry.epinio.svc.cluster.local:5000=/etc/ssl/certs/abcd1234.0"}},
+	} {
+		args := dockerfileRegistryArgs(tc.params)
+		if !slices.Equal(args, tc.expected) {
+			t.Fatalf("registry %s: expected %v, got %v", tc.params.RegistryURL, tc.expected, args)
+		}
+	}
+}
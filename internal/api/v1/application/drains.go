@@ -0,0 +1,141 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/logdrain"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// LogDrainList handles the API endpoint GET /namespaces/:namespace/applications/:app/drains
+// It returns the log drains of the application, and of its namespace, with their health.
+func LogDrainList(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+	exists, err := application.Exists(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	drains, err := application.LogDrains(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	namespaceDrains, err := logdrain.NamespaceDrains(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.LogDrainsResponse(logdrain.Redact(append(drains, namespaceDrains...))))
+	return nil
+}
+
+// LogDrainAdd handles the API endpoint POST /namespaces/:namespace/applications/:app/drains
+// It adds a log drain to the application.
+func LogDrainAdd(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	var addRequest models.LogDrainAddRequest
+	if err := c.BindJSON(&addRequest); err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	drain := models.LogDrain{Name: addRequest.Name, URL: addRequest.URL}
+	if err := logdrain.Validate(drain); err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+	exists, err := application.Exists(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	log.Infow("adding log drain", "namespace", namespace, "app", appName, "drain", drain.Name, "url", drain.RedactedURL())
+
+	if err := application.LogDrainAdd(ctx, cluster, appRef, drain); err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.Created(c)
+	return nil
+}
+
+// LogDrainRemove handles the API endpoint DELETE /namespaces/:namespace/applications/:app/drains/:drain
+// It removes the named log drain from the application.
+func LogDrainRemove(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+	drainName := c.Param("drain")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+	exists, err := application.Exists(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	log.Infow("removing log drain", "namespace", namespace, "app", appName, "drain", drainName)
+
+	if err := application.LogDrainRemove(ctx, cluster, appRef, drainName); err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
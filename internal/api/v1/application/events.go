@@ -0,0 +1,145 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// eventsPollInterval is the period of the checks for new events of a followed timeline.
+const eventsPollInterval = 5 * time.Second
+
+// Events handles the API endpoint GET /namespaces/:namespace/applications/:app/events
+// It returns the events timeline of the application, oldest first.
+func Events(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+	exists, err := application.Exists(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	events, err := application.Events(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.AppEventsResponse{Events: events})
+	return nil
+}
+
+// EventsWebsocket handles the websocket API endpoint of the same path as Events. It sends
+// the timeline of the application, one event per message, and then the new events as they
+// appear, until the client closes the connection.
+func EventsWebsocket(c *gin.Context) {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+	exists, err := application.Exists(ctx, cluster, appRef)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+	if !exists {
+		response.Error(c, apierror.AppIsNotKnown(appName))
+		return
+	}
+
+	upgrader := newUpgrader()
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+	defer func() {
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		_ = conn.Close()
+	}()
+
+	// The client sends nothing. Reading detects its closing of the connection.
+	followCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	seen := map[string]bool{}
+	ticker := time.NewTicker(eventsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		events, err := application.Events(followCtx, cluster, appRef)
+		if err != nil && followCtx.Err() == nil {
+			log.Errorw("failed to read app events", "error", err)
+		}
+
+		for _, event := range events {
+			key := application.EventKey(event)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Errorw("failed to encode app event", "error", err)
+				continue
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				log.Debugw("app events websocket write failed", "error", err)
+				return
+			}
+		}
+
+		select {
+		case <-followCtx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
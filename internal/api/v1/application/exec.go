@@ -17,6 +17,7 @@ import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/proxy"
 	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/metrics"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/gin-gonic/gin"
 	v1 "k8s.io/api/core/v1"
@@ -129,5 +130,7 @@ func Exec(c *gin.Context) apierror.APIErrors {
 			Command:   command,
 		}, scheme.ParameterCodec).URL()
 
+	defer metrics.StreamOpened(metrics.StreamExec)()
+
 	return proxy.RunProxy(ctx, c.Writer, c.Request, attachURL)
 }
@@ -125,6 +125,16 @@ func ExportToRegistry(c *gin.Context) apierror.APIErrors {
 
 	applyDefaults(&req, namespace, appName, theApp.StageID)
 
+	// Check for the build metadata before doing any work, if it is to be attached.
+	var buildMeta *models.BuildMetadata
+	if req.SBOM {
+		var apierr apierror.APIErrors
+		buildMeta, apierr = appBuildMetadata(ctx, cluster, theApp)
+		if apierr != nil {
+			return apierr
+		}
+	}
+
 	// //////////////////////////////////////////////////////////////////////////////
 	/// Helper information
 	//
@@ -297,6 +307,17 @@ func ExportToRegistry(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	// build metadata and SBOM ...
+
+	if buildMeta != nil {
+		log.Infow("OCI export attach build metadata", "image", imageRemoteFile)
+
+		err = attachBuildMetadata(ctx, buildMeta, imageRemoteFile, destination, certFile)
+		if err != nil {
+			return apierror.InternalError(err, "attaching the build metadata")
+		}
+	}
+
 	// //////////////////////////////////////////////////////////////////////////////
 	response.OK(c)
 	return nil
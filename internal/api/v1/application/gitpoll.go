@@ -0,0 +1,220 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/internal/background"
+	gitbridge "github.com/epinio/epinio/internal/bridge/git"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const (
+	// gitPollScanInterval is the period of the scans for applications due a check.
+	gitPollScanInterval = 30 * time.Second
+	// gitPollCheckTimeout limits the time spent on checking a single application.
+	gitPollCheckTimeout = time.Minute
+)
+
+// GitPoller periodically checks the git origins of the applications having a poll
+// interval, and restages and deploys an application when the head of its tracked branch
+// moved. It is the alternative to the git webhook for repositories which cannot reach
+// Epinio.
+type GitPoller struct {
+	*background.Worker
+}
+
+// NewGitPoller returns a new, not yet started, poller.
+func NewGitPoller() *GitPoller {
+	p := &GitPoller{}
+	p.Worker = background.Periodic(gitPollScanInterval, p.scan)
+	return p
+}
+
+// scan checks all applications whose next check is due.
+func (p *GitPoller) scan(ctx context.Context) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		helpers.Logger.Errorw("git poll: cluster access", "error", err)
+		return
+	}
+
+	polls, err := application.GitPolls(ctx, cluster)
+	if err != nil {
+		helpers.Logger.Errorw("git poll: listing polled applications", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for appRef, status := range polls {
+		if ctx.Err() != nil {
+			return
+		}
+		if !application.GitPollDue(status, now) {
+			continue
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, gitPollCheckTimeout)
+		commit, checkErr := checkGitPoll(checkCtx, cluster, appRef, status)
+		cancel()
+
+		if checkErr != nil {
+			helpers.Logger.Infow("git poll: check failed", "app", appRef.Name, "namespace", appRef.Namespace,
+				"error", checkErr)
+		}
+
+		err := application.GitPollRecord(ctx, cluster, appRef, commit, checkErr)
+		if err != nil {
+			helpers.Logger.Errorw("git poll: recording check", "app", appRef.Name, "namespace", appRef.Namespace,
+				"error", err)
+		}
+	}
+}
+
+// checkGitPoll looks for the head of the branch tracked by the application, and starts a
+// deployment when it differs from the deployed revision and from the commit found by the
+// last check. It returns the head commit found.
+func checkGitPoll(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, status *models.GitPollStatus) (string, error) {
+	app, err := application.Lookup(ctx, cluster, appRef.Namespace, appRef.Name)
+	if err != nil {
+		return "", err
+	}
+	if app == nil {
+		return "", errors.New("application not found")
+	}
+	if app.Origin.Kind != models.OriginGit || app.Origin.Git == nil {
+		return "", errors.New("application origin is not git")
+	}
+	if app.Origin.Git.Branch == "" {
+		return "", errors.New("application does not track a branch")
+	}
+
+	user, err := auth.NewAuthService(cluster).GetUserByUsername(ctx, status.Username)
+	if err != nil {
+		return "", fmt.Errorf("user '%s' of the polling: %w", status.Username, err)
+	}
+
+	gitConfig, err := gitPollConfiguration(cluster, user, *app.Origin.Git)
+	if err != nil {
+		return "", err
+	}
+
+	head, err := gitBranchHead(ctx, app.Origin.Git.URL, app.Origin.Git.Branch, gitConfig)
+	if err != nil {
+		return "", err
+	}
+
+	if head == app.Origin.Git.Revision || head == status.LastCommit {
+		return head, nil
+	}
+
+	// Do not pile up on a running staging. The commit is not recorded, to be picked up
+	// by the next check.
+	if app.Status == models.ApplicationStaging {
+		return "", nil
+	}
+
+	deployment, err := startGitDeployment(ctx, user, appRef, app.Origin)
+	if err != nil {
+		return "", err
+	}
+
+	helpers.Logger.Infow("git poll: deploying new commit", "app", appRef.Name, "namespace", appRef.Namespace,
+		"branch", app.Origin.Git.Branch, "commit", head, "deployment", deployment.ID)
+
+	return head, nil
+}
+
+// gitPollConfiguration returns the git configuration to use for checking the origin. This
+// is the configuration selected by the origin, if any, else the most specific
+// configuration matching the repository url. A matching configuration the user is not
+// permitted to use, or which is not bound to the host of the repository, is ignored.
+func gitPollConfiguration(cluster *kubernetes.Cluster, user auth.User, origin models.GitRef) (*gitbridge.Configuration, error) {
+	if origin.Gitconfig != "" {
+		gitConfig, apiErr := resolveGitconfig(cluster, user, origin.Gitconfig)
+		if apiErr != nil {
+			return nil, apiErr.Errors()[0]
+		}
+		if !gitConfig.AllowsHost(origin.URL) {
+			return nil, fmt.Errorf("gitconfig [%s] is not allowed for the host of url [%s]",
+				origin.Gitconfig, origin.URL)
+		}
+		return gitConfig, nil
+	}
+
+	manager, err := gitbridge.NewManager(cluster.Kubectl.CoreV1().Secrets(helmchart.Namespace()))
+	if err != nil {
+		return nil, err
+	}
+
+	gitConfig, err := manager.FindConfiguration(origin.URL)
+	if err != nil {
+		return nil, err
+	}
+	if gitConfig == nil || !auth.CanUseGitconfig(user, *gitConfig) || !gitConfig.AllowsHost(origin.URL) {
+		return nil, nil
+	}
+
+	return gitConfig, nil
+}
+
+// gitBranchHead returns the commit at the head of the branch of the remote repository,
+// without cloning it, i.e. like `git ls-remote`.
+func gitBranchHead(ctx context.Context, url, branch string, gitConfig *gitbridge.Configuration) (string, error) {
+	// Reuse the credential handling of the clone.
+	cloneOptions := loadCloneOptions(git.CloneOptions{URL: url}, gitConfig)
+
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{url},
+	})
+
+	refs, err := remote.ListContext(ctx, &git.ListOptions{
+		Auth:            cloneOptions.Auth,
+		InsecureSkipTLS: cloneOptions.InsecureSkipTLS,
+		CABundle:        cloneOptions.CABundle,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	name := plumbing.NewBranchReferenceName(branch)
+	for _, ref := range refs {
+		if ref.Name() == name {
+			return ref.Hash().String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("branch '%s' not found", branch)
+}
+
+// gitPollInterval returns the poll interval of the origin. It is empty for origins which
+// are not git.
+func gitPollInterval(origin models.ApplicationOrigin) string {
+	if origin.Kind != models.OriginGit || origin.Git == nil {
+		return ""
+	}
+	return origin.Git.PollInterval
+}
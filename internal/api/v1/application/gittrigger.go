@@ -0,0 +1,410 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/randstr"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/metrics"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GitWebhookPath is the path of the git webhook endpoint, relative to the API root.
+const GitWebhookPath = "/webhooks/git/:namespace/:app"
+
+// maxGitWebhookPayload is the largest webhook payload accepted, in bytes.
+const maxGitWebhookPayload = 5 * 1024 * 1024
+
+// Headers of the supported webhook flavors. GitHub signs the payload with the secret.
+// GitLab sends the secret as is. Plain git hooks are expected to sign the payload like
+// GitHub does, using the epinio header.
+const (
+	githubEventHeader     = "X-GitHub-Event"
+	githubDeliveryHeader  = "X-GitHub-Delivery"
+	githubSignatureHeader = "X-Hub-Signature-256"
+	gitlabEventHeader     = "X-Gitlab-Event"
+	gitlabDeliveryHeader  = "X-Gitlab-Event-UUID"
+	gitlabTokenHeader     = "X-Gitlab-Token"
+	gitDeliveryHeader     = "X-Epinio-Delivery"
+	gitSignatureHeader    = "X-Epinio-Signature-256"
+)
+
+// gitPush is the information extracted from a webhook delivery.
+type gitPush struct {
+	provider models.GitProvider
+	event    string
+	delivery string
+	push     bool
+	branch   string
+	revision string
+}
+
+// GitTriggerShow handles the API endpoint GET /namespaces/:namespace/applications/:app/git-trigger
+// It returns the push-to-deploy configuration of the application, without the secret.
+func GitTriggerShow(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	appRef := models.NewAppRef(c.Param("app"), c.Param("namespace"))
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	apiErr := checkAppExists(c, cluster, appRef)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	trigger, err := application.GitTrigger(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if trigger == nil {
+		trigger = &models.GitTrigger{}
+	}
+
+	trigger.Secret = ""
+	if trigger.Enabled {
+		trigger.WebhookPath = gitWebhookPath(appRef)
+	}
+
+	response.OKReturn(c, trigger)
+	return nil
+}
+
+// GitTriggerEnable handles the API endpoint POST /namespaces/:namespace/applications/:app/git-trigger
+// It (re)generates the webhook secret of the application and returns it. Deployments
+// triggered by the webhook are made for the requesting user.
+func GitTriggerEnable(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	appRef := models.NewAppRef(c.Param("app"), c.Param("namespace"))
+	username := requestctx.User(ctx).Username
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	apiErr := checkAppExists(c, cluster, appRef)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	secret, err := application.GitTriggerEnable(ctx, cluster, appRef, username)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.GitTrigger{
+		Enabled:     true,
+		Secret:      secret,
+		Username:    username,
+		WebhookPath: gitWebhookPath(appRef),
+	})
+	return nil
+}
+
+// GitTriggerDisable handles the API endpoint DELETE /namespaces/:namespace/applications/:app/git-trigger
+// It removes the webhook secret of the application, and the recorded deliveries.
+func GitTriggerDisable(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	appRef := models.NewAppRef(c.Param("app"), c.Param("namespace"))
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	apiErr := checkAppExists(c, cluster, appRef)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	err = application.GitTriggerDisable(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// GitWebhook handles the unauthenticated API endpoint POST /webhooks/git/:namespace/:app
+// It verifies the delivery against the webhook secret of the application, and starts an
+// asynchronous deployment of the application when the delivery is a push to the branch
+// tracked by its git origin. Verified deliveries are recorded with the application, once:
+// replays of a recorded delivery are ignored. Rejected deliveries are only counted.
+func GitWebhook(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx).With("component", "git-webhook")
+	appRef := models.NewAppRef(c.Param("app"), c.Param("namespace"))
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Unknown applications and applications without trigger are not distinguished, to not
+	// leak the existence of applications to unauthenticated callers.
+	trigger, err := application.GitTrigger(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if trigger == nil {
+		return apierror.NewNotFoundError("git trigger", appRef.Name)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxGitWebhookPayload+1))
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error()).WithDetails("failed to read the webhook payload")
+	}
+	if len(body) > maxGitWebhookPayload {
+		return apierror.NewAPIError("webhook payload too large", http.StatusRequestEntityTooLarge)
+	}
+
+	// Unverified deliveries are only counted. Nothing the caller sent is stored.
+	push, err := parseGitPush(c.Request.Header, body, trigger.Secret)
+	if err != nil {
+		status, reason := http.StatusBadRequest, metrics.GitWebhookBadPayload
+		if errors.Is(err, errBadGitSignature) {
+			status, reason = http.StatusUnauthorized, metrics.GitWebhookBadSignature
+		}
+		metrics.GitWebhookRejected(reason)
+		log.Infow("git webhook delivery rejected", "app", appRef.Name, "namespace", appRef.Namespace,
+			"reason", reason)
+		return apierror.NewAPIError(err.Error(), status)
+	}
+
+	delivery := models.GitDelivery{
+		ID:         push.delivery,
+		Provider:   push.provider,
+		Event:      push.event,
+		Branch:     push.branch,
+		Revision:   push.revision,
+		Result:     models.GitDeliveryReceived,
+		ReceivedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if delivery.ID == "" {
+		delivery.ID, err = randstr.Hex16()
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Claiming the delivery before acting on it ensures that replays of it, including
+	// concurrent ones, do not deploy again.
+	claimed, err := application.GitTriggerClaimDelivery(ctx, cluster, appRef, delivery)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !claimed {
+		log.Infow("git webhook delivery replayed", "app", appRef.Name, "namespace", appRef.Namespace,
+			"delivery", delivery.ID)
+		delivery.Result = models.GitDeliveryIgnored
+		delivery.Message = "delivery was received before"
+		c.JSON(http.StatusOK, delivery)
+		return nil
+	}
+
+	delivery.Result, delivery.Message, delivery.DeploymentID = triggerGitDeployment(c, cluster, appRef, trigger, push)
+
+	log.Infow("git webhook delivery", "app", appRef.Name, "namespace", appRef.Namespace,
+		"delivery", delivery.ID, "result", delivery.Result, "message", delivery.Message)
+
+	err = application.GitTriggerRecordDelivery(ctx, cluster, appRef, delivery)
+	if err != nil {
+		log.Errorw("failed to record git webhook delivery", "error", err)
+	}
+
+	status := http.StatusOK
+	if delivery.Result == models.GitDeliveryDeployed {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, delivery)
+	return nil
+}
+
+// triggerGitDeployment starts the deployment of the application for a verified delivery,
+// if the delivery is a push to the tracked branch. It returns the result of the delivery,
+// an explanatory message, and the id of the started deployment.
+func triggerGitDeployment(c *gin.Context, cluster *kubernetes.Cluster, appRef models.AppRef, trigger *models.GitTrigger, push gitPush) (string, string, string) {
+	ctx := c.Request.Context()
+
+	if !push.push {
+		return models.GitDeliveryIgnored, fmt.Sprintf("event '%s' is not a push", push.event), ""
+	}
+	if push.branch == "" {
+		return models.GitDeliveryIgnored, "push is not to a branch", ""
+	}
+	if strings.Trim(push.revision, "0") == "" {
+		return models.GitDeliveryIgnored, fmt.Sprintf("branch '%s' was deleted", push.branch), ""
+	}
+
+	app, err := application.Lookup(ctx, cluster, appRef.Namespace, appRef.Name)
+	if err != nil {
+		return models.GitDeliveryFailed, err.Error(), ""
+	}
+	if app == nil {
+		return models.GitDeliveryFailed, "application not found", ""
+	}
+	if app.Origin.Kind != models.OriginGit || app.Origin.Git == nil {
+		return models.GitDeliveryIgnored, "application origin is not git", ""
+	}
+	if app.Origin.Git.Branch == "" {
+		return models.GitDeliveryIgnored, "application does not track a branch", ""
+	}
+	if app.Origin.Git.Branch != push.branch {
+		return models.GitDeliveryIgnored,
+			fmt.Sprintf("push to branch '%s', application tracks '%s'", push.branch, app.Origin.Git.Branch), ""
+	}
+
+	user, err := auth.NewAuthService(cluster).GetUserByUsername(ctx, trigger.Username)
+	if err != nil {
+		return models.GitDeliveryFailed,
+			fmt.Sprintf("user '%s' of the trigger: %s", trigger.Username, err.Error()), ""
+	}
+
+	// Deploy the head of the branch, which is at or after the pushed revision.
+	status, err := startGitDeployment(ctx, user, appRef, app.Origin)
+	if err != nil {
+		return models.GitDeliveryFailed, err.Error(), ""
+	}
+
+	return models.GitDeliveryDeployed, "", status.ID
+}
+
+// startGitDeployment starts the deployment of the head of the branch tracked by the git
+// origin of the application, for the given user.
+func startGitDeployment(ctx context.Context, user auth.User, appRef models.AppRef, origin models.ApplicationOrigin) (models.AsyncDeployStatus, error) {
+	gitRef := *origin.Git
+	gitRef.Revision = gitRef.Branch
+	origin.Git = &gitRef
+
+	return startAsyncDeployment(ctx, user, models.AsyncDeployRequest{
+		App:    appRef,
+		Origin: origin,
+	})
+}
+
+var errBadGitSignature = errors.New("webhook signature does not match")
+
+// parseGitPush determines the flavor of the webhook delivery from its headers, verifies
+// it against the secret, and extracts the pushed branch and revision. The returned push
+// carries the delivery information even when the verification failed.
+func parseGitPush(header http.Header, body []byte, secret string) (gitPush, error) {
+	var push gitPush
+
+	switch {
+	case header.Get(githubEventHeader) != "":
+		push.provider = models.ProviderGithub
+		push.event = header.Get(githubEventHeader)
+		push.delivery = header.Get(githubDeliveryHeader)
+		push.push = push.event == "push"
+
+		if !validGitSignature(header.Get(githubSignatureHeader), body, secret) {
+			return push, errBadGitSignature
+		}
+	case header.Get(gitlabEventHeader) != "":
+		push.provider = models.ProviderGitlab
+		push.event = header.Get(gitlabEventHeader)
+		push.delivery = header.Get(gitlabDeliveryHeader)
+		push.push = push.event == "Push Hook"
+
+		token := header.Get(gitlabTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			return push, errBadGitSignature
+		}
+	default:
+		push.provider = models.ProviderGit
+		push.event = "push"
+		push.delivery = header.Get(gitDeliveryHeader)
+		push.push = true
+
+		if !validGitSignature(header.Get(gitSignatureHeader), body, secret) {
+			return push, errBadGitSignature
+		}
+	}
+
+	if !push.push {
+		return push, nil
+	}
+
+	// All flavors carry the ref and the new revision under the same keys.
+	payload := struct {
+		Ref   string `json:"ref"`
+		After string `json:"after"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return push, fmt.Errorf("bad push payload: %s", err.Error())
+	}
+
+	push.branch = strings.TrimPrefix(payload.Ref, "refs/heads/")
+	if push.branch == payload.Ref {
+		// Tags and other refs
+		push.branch = ""
+	}
+	push.revision = payload.After
+
+	return push, nil
+}
+
+// validGitSignature checks that the signature, in the form `sha256=HEX`, is the HMAC-SHA256
+// of the body, keyed with the secret.
+func validGitSignature(signature string, body []byte, secret string) bool {
+	digest, found := strings.CutPrefix(signature, "sha256=")
+	if !found {
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(expected, mac.Sum(nil))
+}
+
+func gitWebhookPath(appRef models.AppRef) string {
+	return strings.NewReplacer(":namespace", appRef.Namespace, ":app", appRef.Name).Replace(GitWebhookPath)
+}
+
+func checkAppExists(c *gin.Context, cluster *kubernetes.Cluster, appRef models.AppRef) apierror.APIErrors {
+	exists, err := application.Exists(c.Request.Context(), cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.AppIsNotKnown(appRef.Name)
+	}
+	return nil
+}
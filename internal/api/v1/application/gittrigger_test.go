@@ -0,0 +1,130 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("parseGitPush", func() {
+	const secret = "s3cr3t"
+	body := []byte(`{"ref":"refs/heads/main","after":"0123456789abcdef0123456789abcdef01234567"}`)
+
+	sign := func(body []byte, key string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	It("accepts a signed github push", func() {
+		header := http.Header{}
+		header.Set("X-GitHub-Event", "push")
+		header.Set("X-GitHub-Delivery", "d-1")
+		header.Set("X-Hub-Signature-256", sign(body, secret))
+
+		push, err := parseGitPush(header, body, secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(push).To(Equal(gitPush{
+			provider: models.ProviderGithub,
+			event:    "push",
+			delivery: "d-1",
+			push:     true,
+			branch:   "main",
+			revision: "0123456789abcdef0123456789abcdef01234567",
+		}))
+	})
+
+	It("rejects a github push signed with another secret", func() {
+		header := http.Header{}
+		header.Set("X-GitHub-Event", "push")
+		header.Set("X-Hub-Signature-256", sign(body, "other"))
+
+		push, err := parseGitPush(header, body, secret)
+		Expect(err).To(MatchError(errBadGitSignature))
+		Expect(push.provider).To(Equal(models.ProviderGithub))
+		Expect(push.branch).To(BeEmpty())
+	})
+
+	It("does not parse the payload of other github events", func() {
+		header := http.Header{}
+		header.Set("X-GitHub-Event", "ping")
+		header.Set("X-Hub-Signature-256", sign([]byte(`{"zen":"x"}`), secret))
+
+		push, err := parseGitPush(header, []byte(`{"zen":"x"}`), secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(push.push).To(BeFalse())
+		Expect(push.event).To(Equal("ping"))
+	})
+
+	It("accepts a gitlab push with the proper token", func() {
+		header := http.Header{}
+		header.Set("X-Gitlab-Event", "Push Hook")
+		header.Set("X-Gitlab-Token", secret)
+
+		push, err := parseGitPush(header, body, secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(push.provider).To(Equal(models.ProviderGitlab))
+		Expect(push.push).To(BeTrue())
+		Expect(push.branch).To(Equal("main"))
+	})
+
+	It("rejects a gitlab push with a bad token", func() {
+		header := http.Header{}
+		header.Set("X-Gitlab-Event", "Push Hook")
+		header.Set("X-Gitlab-Token", "guess")
+
+		_, err := parseGitPush(header, body, secret)
+		Expect(err).To(MatchError(errBadGitSignature))
+	})
+
+	It("accepts a signed plain git push", func() {
+		header := http.Header{}
+		header.Set("X-Epinio-Signature-256", sign(body, secret))
+
+		push, err := parseGitPush(header, body, secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(push.provider).To(Equal(models.ProviderGit))
+		Expect(push.branch).To(Equal("main"))
+	})
+
+	It("rejects an unsigned plain git push", func() {
+		_, err := parseGitPush(http.Header{}, body, secret)
+		Expect(err).To(MatchError(errBadGitSignature))
+	})
+
+	It("leaves the branch empty for tag pushes", func() {
+		tag := []byte(`{"ref":"refs/tags/v1.0","after":"0123456789abcdef0123456789abcdef01234567"}`)
+		header := http.Header{}
+		header.Set("X-Epinio-Signature-256", sign(tag, secret))
+
+		push, err := parseGitPush(header, tag, secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(push.branch).To(BeEmpty())
+	})
+
+	It("fails for a bad payload", func() {
+		bad := []byte(`{`)
+		header := http.Header{}
+		header.Set("X-Epinio-Signature-256", sign(bad, secret))
+
+		_, err := parseGitPush(header, bad, secret)
+		Expect(err).To(MatchError(ContainSubstring("bad push payload")))
+	})
+})
@@ -0,0 +1,61 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/registry"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// imageReachable checks that a prebuilt image deployed by the application can be pulled,
+// using the registry credentials of the application, if any. Images staged by epinio are
+// not checked, as they are known to be in the epinio registry. The result is the digest of
+// the image, empty for staged images.
+func imageReachable(ctx context.Context, cluster *kubernetes.Cluster,
+	applicationCR *unstructured.Unstructured, stageID, imageURL string) (string, apierror.APIErrors) {
+
+	if stageID != "" {
+		return "", nil
+	}
+
+	digest, err := resolveImageDigest(ctx, cluster, applicationCR, imageURL)
+	if err != nil {
+		return "", apierror.NewBadRequestErrorf("image '%s' not reachable", imageURL).
+			WithDetails(err.Error())
+	}
+
+	requestctx.Logger(ctx).Infow("image resolved", "image", imageURL, "digest", digest)
+	return digest, nil
+}
+
+// resolveImageDigest returns the digest the image reference currently resolves to, asking
+// the registry with the registry credentials of the application, if any.
+func resolveImageDigest(ctx context.Context, cluster *kubernetes.Cluster,
+	applicationCR *unstructured.Unstructured, imageURL string) (string, error) {
+
+	credentials := registry.RegistryCredentials{}
+	if name := application.RegistryCredential(applicationCR); name != "" {
+		var err error
+		credentials, err = application.RegistryCredentialAuth(ctx, cluster, applicationCR.GetNamespace(), name)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return registry.ImageDigest(ctx, imageURL, credentials, nil)
+}
@@ -0,0 +1,145 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/background"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+const (
+	// imageWatchScanInterval is the period of the scans for applications due a check.
+	imageWatchScanInterval = 30 * time.Second
+	// imageWatchCheckTimeout limits the time spent on checking a single application.
+	imageWatchCheckTimeout = time.Minute
+)
+
+// ImageWatcher periodically checks the tags of the container images of the applications
+// having an image watch, and redeploys the application, or only notifies about it, when the
+// tag points to a new digest.
+type ImageWatcher struct {
+	*background.Worker
+}
+
+// NewImageWatcher returns a new, not yet started, watcher.
+func NewImageWatcher() *ImageWatcher {
+	w := &ImageWatcher{}
+	w.Worker = background.Periodic(imageWatchScanInterval, w.scan)
+	return w
+}
+
+// scan checks all applications whose next check is due.
+func (w *ImageWatcher) scan(ctx context.Context) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		helpers.Logger.Errorw("image watch: cluster access", "error", err)
+		return
+	}
+
+	watches, err := application.ImageWatches(ctx, cluster)
+	if err != nil {
+		helpers.Logger.Errorw("image watch: listing watched applications", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for appRef, status := range watches {
+		if ctx.Err() != nil {
+			return
+		}
+		if !application.ImageWatchDue(status, now) {
+			continue
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, imageWatchCheckTimeout)
+		digest, checkErr := checkImageWatch(checkCtx, cluster, appRef, status)
+		cancel()
+
+		if checkErr != nil {
+			helpers.Logger.Infow("image watch: check failed", "app", appRef.Name, "namespace", appRef.Namespace,
+				"error", checkErr)
+		}
+
+		err := application.ImageWatchRecord(ctx, cluster, appRef, digest, checkErr)
+		if err != nil {
+			helpers.Logger.Errorw("image watch: recording check", "app", appRef.Name, "namespace", appRef.Namespace,
+				"error", err)
+		}
+	}
+}
+
+// checkImageWatch resolves the tag of the image of the application, and reacts when the
+// digest found differs from the deployed digest and from the digest found by the last
+// check. It returns the digest found.
+func checkImageWatch(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, status *models.ImageWatchStatus) (string, error) {
+	app, err := application.Lookup(ctx, cluster, appRef.Namespace, appRef.Name)
+	if err != nil {
+		return "", err
+	}
+	if app == nil {
+		return "", errors.New("application not found")
+	}
+	if app.Origin.Kind != models.OriginContainer || app.Origin.Container == "" {
+		return "", errors.New("application origin is not a container image")
+	}
+
+	applicationCR, err := application.Get(ctx, cluster, appRef)
+	if err != nil {
+		return "", err
+	}
+
+	imageURL := app.Origin.Container
+	digest, err := resolveImageDigest(ctx, cluster, applicationCR, imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	deployed := application.ImageDigest(applicationCR)
+	if digest == deployed || digest == status.LatestDigest {
+		return digest, nil
+	}
+
+	if status.Mode != models.ImageWatchRedeploy {
+		helpers.Logger.Infow("image watch: new digest", "app", appRef.Name, "namespace", appRef.Namespace,
+			"image", imageURL, "digest", digest)
+
+		return digest, application.ImageWatchEvent(ctx, cluster, applicationCR, imageURL, digest)
+	}
+
+	err = application.ImageDigestSet(ctx, cluster, appRef, digest)
+	if err != nil {
+		return "", err
+	}
+
+	_, apiErr := deploy.DeployApp(ctx, cluster, appRef, status.Username, "")
+	if apiErr != nil {
+		// Keep the pin to the running image, for the next check to retry.
+		if err := application.ImageDigestSet(ctx, cluster, appRef, deployed); err != nil {
+			helpers.Logger.Errorw("image watch: restoring digest", "app", appRef.Name,
+				"namespace", appRef.Namespace, "error", err)
+		}
+		return "", apiErr.Errors()[0]
+	}
+
+	helpers.Logger.Infow("image watch: deployed new digest", "app", appRef.Name, "namespace", appRef.Namespace,
+		"image", imageURL, "digest", digest)
+
+	return digest, nil
+}
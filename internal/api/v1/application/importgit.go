@@ -297,9 +297,21 @@ func cloneAndUpload(
 		return "", "", "", apierror.InternalError(s3Error, "creating an S3 manager")
 	}
 
-	blobUID, uploadError := s3Manager.Upload(ctx, tarball, map[string]string{
+	checksum, checksumError := fileChecksum(tarball)
+	if checksumError != nil {
+		return "", "", "", apierror.InternalError(
+			checksumError, "computing the checksum of the tarball",
+		)
+	}
+
+	blobMeta := map[string]string{
 		"app": appName, "namespace": namespace, "username": username,
-	})
+		blobChecksumKey: checksum,
+	}
+	if dirHasDockerfile(gitRepo) {
+		blobMeta[blobDockerfileKey] = "true"
+	}
+	blobUID, uploadError := s3Manager.Upload(ctx, tarball, blobMeta)
 	if uploadError != nil {
 		if s3manager.IsQuotaExceededError(uploadError) {
 			return "", "", "", apierror.NewQuotaExceededError(
@@ -313,6 +325,10 @@ func cloneAndUpload(
 	}
 
 	log.Infow("uploaded app", "namespace", namespace, "app", appName, "blobUID", blobUID)
+	if info, statError := os.Stat(tarball); statError == nil {
+		recordBlobUpload(ctx, cluster, namespace, info.Size())
+	}
+
 	return blobUID, branch, resolvedRevision, nil
 }
 
@@ -529,3 +545,16 @@ func containsRevision(repo *git.Repository, revision plumbing.Hash, commitMap ma
 	}
 	return found, nil
 }
+
+// fileChecksum returns the digest of the named file.
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return sourceChecksum(file)
+}
@@ -22,11 +22,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/helpers/kubernetes/tailer"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/logarchive"
+	"github.com/epinio/epinio/internal/metrics"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
@@ -44,10 +47,14 @@ var (
 type LogParameterUpdate struct {
 	Type   string `json:"type"`
 	Params struct {
-		Since     string `json:"since"`
-		SinceTime string `json:"since_time"`
-		Tail      int    `json:"tail"`
-		Follow    bool   `json:"follow"`
+		Since     string   `json:"since"`
+		SinceTime string   `json:"since_time"`
+		Tail      int      `json:"tail"`
+		Follow    bool     `json:"follow"`
+		Grep      string   `json:"grep"`
+		GrepRegex bool     `json:"grep_regex"`
+		Level     string   `json:"level"`
+		Fields    []string `json:"fields"`
 	} `json:"params"`
 }
 
@@ -91,7 +98,7 @@ func ParseLogParameters(
 	}
 
 	if sinceStr != "" {
-		if since, err := time.ParseDuration(sinceStr); err == nil {
+		if since, err := helpers.ParseDuration(sinceStr); err == nil {
 			if since < 0 {
 				return nil, fmt.Errorf(
 					"since parameter must be non-negative, got: %s",
@@ -296,6 +303,10 @@ func Logs(c *gin.Context) {
 	sinceTimeStr := c.Query("since_time")
 	includeContainersStr := c.Query("include_containers")
 	excludeContainersStr := c.Query("exclude_containers")
+	grep := c.Query("grep")
+	grepRegex := c.Query("grep_regex") == "true"
+	level := c.Query("level")
+	fields := c.QueryArray("field")
 
 	// Parse and validate log parameters
 	logParams, err := ParseLogParameters(tailStr, sinceStr, sinceTimeStr, includeContainersStr, excludeContainersStr)
@@ -308,6 +319,13 @@ func Logs(c *gin.Context) {
 	follow := followStr == "true"
 	logParams.Follow = follow
 
+	// Content filters, validated before upgrading to websocket as well
+	logParams.Filter, err = application.NewLogFilter(grep, grepRegex, level, fields)
+	if err != nil {
+		response.Error(c, apierror.NewBadRequestError(err.Error()))
+		return
+	}
+
 	// Validate container filter regex patterns before upgrading to websocket
 	// This allows us to return HTTP errors instead of silently failing
 	if err := validateContainerFilterPatterns(logParams); err != nil {
@@ -324,7 +342,11 @@ func Logs(c *gin.Context) {
 		"follow: ", logParams.Follow,
 		"follow_raw: ", followStr,
 		"include_containers: ", logParams.IncludeContainers,
-		"exclude_containers: ", logParams.ExcludeContainers)
+		"exclude_containers: ", logParams.ExcludeContainers,
+		"grep: ", grep,
+		"grep_regex: ", grepRegex,
+		"level: ", level,
+		"fields: ", fields)
 
 	log.Debugw("upgrade to web socket")
 
@@ -335,6 +357,8 @@ func Logs(c *gin.Context) {
 		return
 	}
 
+	defer metrics.StreamOpened(metrics.StreamLogs)()
+
 	log.Debugw("streaming mode", "follow", logParams.Follow)
 	log.Debugw("streaming begin")
 
@@ -462,6 +486,19 @@ func streamPodLogs(
 				// Use the follow parameter from the client
 				parsedParams.Follow = update.Params.Follow
 
+				parsedParams.Filter, parsedParamsError = application.NewLogFilter(
+					update.Params.Grep,
+					update.Params.GrepRegex,
+					update.Params.Level,
+					update.Params.Fields,
+				)
+				if parsedParamsError != nil {
+					log.Errorw("failed to parse updated log filters",
+						"error", parsedParamsError,
+					)
+					continue
+				}
+
 				logWg.Add(1)
 				go startLogStreaming(
 					&logWg,
@@ -610,10 +647,32 @@ func startLogStreaming(
 		"namespace", namespaceName,
 	)
 
+	// With a time range the archived lines come first. The live lines already archived
+	// are dropped afterwards.
+	liveChan := logChan
+	var forwardWg sync.WaitGroup
+	if logarchive.Enabled() && (logParams.Since != nil || logParams.SinceTime != nil) {
+		newest := sendArchivedLogs(ctx, logChan, cluster, appName, stageID, namespaceName, logParams)
+		if len(newest) > 0 {
+			liveChan = make(chan tailer.ContainerLogLine)
+
+			forwardWg.Add(1)
+			go func() {
+				defer forwardWg.Done()
+				for line := range liveChan {
+					if !logarchive.LineTime(line).After(newest[line.PodName+"/"+line.ContainerName]) {
+						continue
+					}
+					logChan <- line
+				}
+			}()
+		}
+	}
+
 	var tailWg sync.WaitGroup
 	err := application.Logs(
 		ctx,
-		logChan,
+		liveChan,
 		&tailWg,
 		cluster,
 		appName,
@@ -627,6 +686,49 @@ func startLogStreaming(
 
 	log.Debugw("wait for backend completion")
 	tailWg.Wait()
+
+	if liveChan != logChan {
+		close(liveChan)
+		forwardWg.Wait()
+	}
+}
+
+// sendArchivedLogs writes the archived log lines selected by the log parameters to logChan.
+// It returns the time of the newest line written, per pod and container. Failing to read
+// the archive is logged, leaving only the live lines.
+func sendArchivedLogs(
+	ctx context.Context,
+	logChan chan tailer.ContainerLogLine,
+	cluster *kubernetes.Cluster,
+	appName,
+	stageID,
+	namespaceName string,
+	logParams *application.LogParameters,
+) map[string]time.Time {
+	log := requestctx.Logger(ctx)
+	newest := map[string]time.Time{}
+
+	store, err := logarchive.NewStore(ctx, cluster)
+	if err != nil {
+		log.Errorw("log archive not available", "error", err)
+		return newest
+	}
+
+	err = application.ArchivedLogs(ctx, store, appName, stageID, namespaceName, logParams,
+		func(line tailer.ContainerLogLine) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case logChan <- line:
+			}
+			newest[line.PodName+"/"+line.ContainerName] = logarchive.LineTime(line)
+			return nil
+		})
+	if err != nil && ctx.Err() == nil {
+		log.Errorw("reading the log archive failed", "error", err)
+	}
+
+	return newest
 }
 
 // https://pkg.go.dev/github.com/gorilla/websocket#hdr-Origin_Considerations
@@ -0,0 +1,79 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/usage"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultMetricsRange is the range of the metrics history returned when none is asked for.
+const defaultMetricsRange = 24 * time.Hour
+
+// Metrics handles the API endpoint GET /namespaces/:namespace/applications/:app/metrics
+// It returns the recorded CPU and memory usage of the application over the range given by
+// the `range` query parameter, e.g. `6h` or `7d`. Longer ranges have coarser steps.
+func Metrics(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	span, err := usage.ParseRange(c.Query("range"), defaultMetricsRange)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("bad range: %s", err.Error())
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	step, samples := usage.History.Range(time.Now(), namespace, appName, span)
+
+	response.OKReturn(c, models.AppMetricsResponse{
+		Namespace: namespace,
+		App:       appName,
+		Range:     span.String(),
+		Step:      step.String(),
+		Samples:   metricsSamples(samples),
+	})
+	return nil
+}
+
+// metricsSamples converts the samples of a history into their API form.
+func metricsSamples(samples []usage.Sample) []models.AppMetricsSample {
+	result := make([]models.AppMetricsSample, 0, len(samples))
+	for _, sample := range samples {
+		result = append(result, models.AppMetricsSample{
+			Time:        time.Unix(sample.Time, 0).UTC().Format(time.RFC3339),
+			MilliCPUs:   sample.AvgMilliCPUs(),
+			MemoryBytes: sample.AvgMemory(),
+			Instances:   sample.Instances,
+		})
+	}
+	return result
+}
@@ -50,8 +50,8 @@ import (
 
 const imageExportVolume = "/image-export/"
 
-// validPartNames lists part names accepted by GetPart (manifest, values, chart, image, archive).
-var validPartNames = []string{"manifest", "values", "chart", "image", "archive"}
+// validPartNames lists part names accepted by GetPart (manifest, values, chart, image, archive, sbom).
+var validPartNames = []string{"manifest", "values", "chart", "image", "archive", "sbom"}
 
 func isValidPartName(part string) bool {
 	for _, p := range validPartNames {
@@ -66,7 +66,7 @@ func isValidPartName(part string) bool {
 // CONSIDER ? Templated, and name given to server through EV ?
 
 // GetPart handles the API endpoint GET /namespaces/:namespace/applications/:app/part/:part
-// It determines the contents of the requested part (values, chart, image) and returns as
+// It determines the contents of the requested part (values, chart, image, sbom) and returns as
 // the response of the handler.
 func GetPart(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
@@ -75,7 +75,7 @@ func GetPart(c *gin.Context) apierror.APIErrors {
 	partName := c.Param("part")
 
 	if !isValidPartName(partName) {
-		return apierror.NewBadRequestErrorf("unknown '%s' part, expected chart, manifest, image, values, or archive", partName)
+		return apierror.NewBadRequestErrorf("unknown '%s' part, expected chart, manifest, image, values, archive, or sbom", partName)
 	}
 
 	cluster, err := kubernetes.GetCluster(ctx)
@@ -96,6 +96,11 @@ func GetPart(c *gin.Context) apierror.APIErrors {
 		return fetchAppManifest(c, app)
 	}
 
+	// The build metadata belongs to the stage, not the workload.
+	if partName == "sbom" {
+		return fetchAppSBOM(c, ctx, cluster, app)
+	}
+
 	// While the app exists it has no workload, and therefore no chart/image/values to
 	// export. Manifest however is fine, see above for its handler.
 
@@ -15,7 +15,7 @@ import "testing"
 
 // expectedPartNames is the canonical set of part names for GET .../part/:part.
 // Keep in sync with validPartNames in part.go.
-var expectedPartNames = []string{"manifest", "values", "chart", "image", "archive"}
+var expectedPartNames = []string{"manifest", "values", "chart", "image", "archive", "sbom"}
 
 func TestValidPartNamesContract(t *testing.T) {
 	t.Run("has expected length", func(t *testing.T) {
@@ -63,6 +63,12 @@ func TestIsValidPartName(t *testing.T) {
 		}
 	})
 
+	t.Run("sbom is valid", func(t *testing.T) {
+		if !isValidPartName("sbom") {
+			t.Error("expected 'sbom' to be a valid part name")
+		}
+	})
+
 	t.Run("all valid part names accepted", func(t *testing.T) {
 		for _, part := range validPartNames {
 			if !isValidPartName(part) {
@@ -18,6 +18,7 @@ import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/proxy"
 	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/metrics"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -100,6 +101,7 @@ func PortForward(c *gin.Context) apierror.APIErrors {
 	defer func() {
 		_ = wconn.Close()
 	}()
+	defer metrics.StreamOpened(metrics.StreamPortForward)()
 
 	target := fmt.Sprintf("%s:%d", pod.Status.PodIP, remotePort)
 	tcpProxy, err := proxy.NewTCPProxy(c, wconn.UnderlyingConn(), target)
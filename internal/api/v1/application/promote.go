@@ -0,0 +1,423 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/domain"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/internal/services"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Promote handles the API endpoint POST /namespaces/:namespace/applications/:app/promote
+// It deploys the staged image of the application into the target namespace, without
+// staging it again. The configuration of the application is carried over to the target
+// application, which is created if missing. Configurations and services bound to the
+// application have to exist in the target namespace. The promotion is recorded in the
+// lineage of the target application.
+func Promote(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // validation sequence
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+	user := requestctx.User(ctx)
+	log := requestctx.Logger(ctx)
+
+	var req models.AppPromoteRequest
+	err := c.BindJSON(&req)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	if req.Target == "" {
+		return apierror.NewBadRequestError("target namespace missing")
+	}
+	if req.Target == namespace {
+		return apierror.NewBadRequestError("target namespace is the namespace of the application")
+	}
+	if req.Instances != nil && *req.Instances < 0 {
+		return apierror.NewBadRequestError("instances param should be integer equal or greater than zero")
+	}
+
+	// The middleware authorized the user for the source namespace only. The target is
+	// checked here, against the same action.
+	if !user.IsAdmin() {
+		params := map[string]string{"namespace": req.Target, "app": appName}
+		if !slices.Contains(user.Namespaces, req.Target) ||
+			!user.IsAllowed(c.Request.Method, c.FullPath(), params) {
+			return apierror.NewAPIError("user unauthorized for namespace "+req.Target, http.StatusForbidden)
+		}
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Validate source and target
+
+	source, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if source == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+	if source.StageID == "" || source.ImageURL == "" {
+		return apierror.NewBadRequestErrorf("application '%s' has no staged image to promote", appName)
+	}
+
+	sourceCR, err := application.Get(ctx, cluster, source.Meta)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	exists, err := namespaces.Exists(ctx, cluster, req.Target)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.NamespaceIsNotKnown(req.Target)
+	}
+
+	targetRef := models.NewAppRef(appName, req.Target)
+	target, err := application.Lookup(ctx, cluster, req.Target, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if target != nil {
+		staging, err := application.IsCurrentlyStaging(ctx, cluster, req.Target, appName)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+		if staging {
+			return apierror.NewBadRequestErrorf("application '%s' is staging in namespace '%s'", appName, req.Target)
+		}
+
+		if target.Workload != nil && target.Configuration.AppChart != source.Configuration.AppChart {
+			return apierror.NewBadRequestError("unable to change app chart of active application")
+		}
+	}
+
+	configurationNames, apierr := promotedConfigurations(ctx, cluster, source, req.Target)
+	if apierr != nil {
+		return apierr
+	}
+
+	// Namespace-specific parts: routes and instances. Without overrides an existing
+	// target keeps its own, and a new target gets the defaults, resp. the instances of
+	// the source.
+
+	routes := req.Routes
+	if len(routes) == 0 {
+		if target != nil {
+			routes = target.Configuration.Routes
+		} else {
+			route, err := domain.AppDefaultRoute(ctx, appName, req.Target)
+			if err != nil {
+				return apierror.InternalError(err)
+			}
+			routes = []string{route}
+		}
+	}
+
+	apierr = validateRoutes(ctx, cluster, appName, req.Target, routes)
+	if apierr != nil {
+		return apierr
+	}
+
+	desired := DefaultInstances
+	if req.Instances != nil {
+		desired = *req.Instances
+	} else if target != nil && target.Configuration.Instances != nil {
+		desired = *target.Configuration.Instances
+	} else if source.Configuration.Instances != nil {
+		desired = *source.Configuration.Instances
+	}
+
+	if target == nil {
+		err = application.CheckCreateQuota(ctx, cluster, req.Target, desired)
+		if err != nil {
+			if apiErr, ok := err.(apierror.APIError); ok {
+				return apiErr
+			}
+			return apierror.InternalError(err)
+		}
+	}
+
+	err = application.ValidateResources(ctx, cluster, targetRef, source.Configuration.Resources, desired)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	err = application.ValidateDependencies(ctx, cluster, targetRef, source.Configuration.DependsOn)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	// The image has to pass the scan policy of the target namespace.
+	apierr = imageScanGate(ctx, cluster, source.Meta, source.ImageURL, req.Target, false)
+	if apierr != nil {
+		return apierr
+	}
+
+	// Arguments found OK, now we can modify the system state
+
+	log.Infow("promoting app", "app", appName, "from", namespace, "to", req.Target, "stage", source.StageID)
+
+	if target == nil {
+		err = application.Create(ctx, cluster, targetRef, user.Username, routes,
+			source.Configuration.AppChart, source.Configuration.Settings)
+		if err != nil {
+			if apiErr, ok := err.(apierror.APIError); ok {
+				return apiErr
+			}
+			return apierror.InternalError(err)
+		}
+
+		err = application.ScalingSetWithEventOnCreate(ctx, cluster, targetRef, desired, user.Username)
+	} else {
+		apierr = promoteIntoExisting(ctx, cluster, source, target, routes)
+		if apierr != nil {
+			return apierr
+		}
+
+		err = application.ScalingSetWithEvent(ctx, cluster, targetRef, desired, user.Username)
+	}
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	apierr = promoteConfiguration(ctx, cluster, source, targetRef, configurationNames, req.Environment)
+	if apierr != nil {
+		return apierr
+	}
+
+	lineage := application.LineageExtend(source.Lineage, models.AppPromotion{
+		Namespace:  namespace,
+		App:        appName,
+		StageID:    source.StageID,
+		ImageURL:   source.ImageURL,
+		PromotedAt: time.Now().UTC().Format(time.RFC3339),
+		PromotedBy: user.Username,
+	})
+
+	err = application.PromotionRecord(ctx, cluster, targetRef, sourceCR, lineage)
+	if err != nil {
+		return apierror.InternalError(err, "saving the promotion")
+	}
+
+	// Git polling stays with the source, the target only follows promotions.
+	origin := source.Origin
+	if origin.Git != nil {
+		git := *origin.Git
+		git.PollInterval = ""
+		origin.Git = &git
+	}
+
+	err = application.SetOrigin(ctx, cluster, targetRef, origin)
+	if err != nil {
+		return apierror.InternalError(err, "saving the app origin")
+	}
+
+	deployResult, apierr := deploy.DeployApp(ctx, cluster, targetRef, user.Username, "")
+	if apierr != nil {
+		return apierr
+	}
+
+	response.OKReturn(c, models.AppPromoteResponse{
+		Routes:   deployResult.Routes,
+		Warnings: deployResult.Warnings,
+		Lineage:  lineage,
+	})
+	return nil
+}
+
+// promotedConfigurations checks that the configurations and services bound to the source
+// application exist in the target namespace, and returns the names of the configurations
+// to bind to the target application. Configurations created by a service are replaced by
+// the configurations of the same service in the target namespace.
+func promotedConfigurations(ctx context.Context, cluster *kubernetes.Cluster, source *models.App, target string) ([]string, apierror.APIErrors) {
+	var theIssues []apierror.APIError
+	names := []string{}
+
+	for _, configurationName := range source.Configuration.Configurations {
+		sourceConfiguration, err := configurations.Lookup(ctx, cluster, source.Meta.Namespace, configurationName)
+		if err != nil && err.Error() != "configuration not found" {
+			return nil, apierror.InternalError(err)
+		}
+		if sourceConfiguration != nil && sourceConfiguration.Origin != "" {
+			// Service configuration, handled with the services below.
+			continue
+		}
+
+		_, err = configurations.Lookup(ctx, cluster, target, configurationName)
+		if err != nil {
+			if err.Error() == "configuration not found" {
+				theIssues = append(theIssues, apierror.ConfigurationIsNotKnown(configurationName).
+					WithDetailsf("configuration is missing in namespace '%s'", target))
+				continue
+			}
+			return nil, apierror.InternalError(err)
+		}
+
+		names = append(names, configurationName)
+	}
+
+	serviceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	for _, serviceName := range source.Configuration.Services {
+		service, err := serviceClient.Get(ctx, target, serviceName)
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+		if service == nil {
+			theIssues = append(theIssues, apierror.ServiceIsNotKnown(serviceName).
+				WithDetailsf("service is missing in namespace '%s'", target))
+			continue
+		}
+		if service.Status != models.ServiceStatusDeployed {
+			theIssues = append(theIssues, apierror.NewBadRequestErrorf("service '%s' is not deployed in namespace '%s'", serviceName, target))
+			continue
+		}
+
+		secrets, err := configurations.LabelServiceSecrets(ctx, cluster, service)
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+		for _, secret := range secrets {
+			names = append(names, secret.Name)
+		}
+	}
+
+	if len(theIssues) > 0 {
+		return nil, apierror.NewMultiError(theIssues)
+	}
+
+	return names, nil
+}
+
+// promoteIntoExisting brings the chart, settings, and routes of the existing target
+// application in line with the promotion.
+func promoteIntoExisting(ctx context.Context, cluster *kubernetes.Cluster, source, target *models.App, routes []string) apierror.APIErrors {
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if target.Configuration.AppChart != source.Configuration.AppChart {
+		err := updateAppChart(ctx, cluster, client, target.Meta.Namespace, target.Meta.Name, source.Configuration.AppChart)
+		if err != nil {
+			if apiErr, ok := err.(apierror.APIError); ok {
+				return apiErr
+			}
+			return apierror.InternalError(err)
+		}
+	}
+
+	err = updateChartValueSettings(ctx, client, target.Meta.Namespace, target.Meta.Name, source.Configuration.Settings)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = updateRoutes(ctx, client, target.Meta.Namespace, target.Meta.Name, routes)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	return nil
+}
+
+// promoteConfiguration replaces the configuration of the target application with the one of
+// the source application. The environment overrides are applied on top of the source
+// environment.
+func promoteConfiguration(ctx context.Context, cluster *kubernetes.Cluster, source *models.App,
+	targetRef models.AppRef, configurationNames []string, overrides models.EnvVariableMap) apierror.APIErrors {
+
+	err := application.BoundConfigurationsSet(ctx, cluster, targetRef, configurationNames, true)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	serviceNames := source.Configuration.Services
+	if serviceNames == nil {
+		serviceNames = []string{}
+	}
+	err = application.BoundServicesSet(ctx, cluster, targetRef, serviceNames, true)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	environment := models.EnvVariableMap{}
+	for name, value := range source.Configuration.Environment {
+		environment[name] = value
+	}
+	for name, value := range overrides {
+		environment[name] = value
+	}
+
+	err = application.EnvironmentSet(ctx, cluster, targetRef, environment, true)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.ResourcesSet(ctx, cluster, targetRef, source.Configuration.Resources)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.HealthcheckSet(ctx, cluster, targetRef, source.Configuration.Healthcheck)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.DependenciesSet(ctx, cluster, targetRef, source.Configuration.DependsOn)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.ContainersSet(ctx, cluster, targetRef,
+		source.Configuration.Sidecars, source.Configuration.InitContainers)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,455 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/internal/registry"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// scanContainerName is the name of the scanner container in the scan job.
+	scanContainerName = "trivy"
+
+	// scanTimeout is the time a scan job is given to complete. It includes the download
+	// of the vulnerability database by the scanner.
+	scanTimeout = 10 * time.Minute
+)
+
+// trivyReport is the part of the JSON report of trivy used by epinio.
+type trivyReport struct {
+	Results []struct {
+		Target          string `json:"Target"`
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Severity         string `json:"Severity"`
+			Title            string `json:"Title"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// Scan handles the API endpoint GET /namespaces/:namespace/applications/:app/scan
+// It returns the image scan report of the last staging of the application, evaluated
+// against the scan policy in effect for the namespace.
+func Scan(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	if app.StageID == "" {
+		return apierror.NewBadRequestErrorf("application '%s' was not staged", appName)
+	}
+
+	report, err := application.ScanReport(ctx, cluster, app.Meta, app.StageID)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if report == nil {
+		return apierror.NewNotFoundError("scan report", app.StageID)
+	}
+
+	threshold, err := scanThreshold(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	application.ScanEvaluate(report, threshold)
+
+	response.OKReturn(c, report)
+	return nil
+}
+
+// imageScanGate blocks the deployment of the image if its scan report has findings reaching
+// the threshold of the scan policy in effect for the policy namespace. This is the namespace
+// of the application, except for promotions, where the image of the source application is
+// deployed into the target namespace. Nothing is done if no scanner is configured.
+//
+// Only images in the registry of epinio are staged by epinio, and gated. Their staging run is
+// the tag of the image, whatever the request claims. Images without a report are scanned
+// when scan is set, i.e. for asynchronous deployments. Otherwise their deployment fails, as a
+// scan takes too long for a request.
+func imageScanGate(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, imageURL, policyNamespace string, scan bool) apierror.APIErrors {
+	log := requestctx.Logger(ctx)
+
+	if viper.GetString("image-scanner") == "" {
+		return nil
+	}
+
+	connectionDetails, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
+	if err != nil {
+		return apierror.InternalError(err, "getting registry connection details")
+	}
+	publicURL, err := connectionDetails.PublicRegistryURL()
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	privateURL, err := connectionDetails.PrivateRegistryURL()
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	stageID, staged := stagedImageID(imageURL, publicURL, privateURL)
+	if !staged {
+		return nil
+	}
+	if stageID == "" {
+		return apierror.NewBadRequestErrorf("image '%s' of the epinio registry is not tagged with its staging", imageURL)
+	}
+
+	report, err := application.ScanReport(ctx, cluster, appRef, stageID)
+	if err != nil {
+		return apierror.InternalError(err, "reading the image scan report")
+	}
+
+	if report == nil {
+		if !scan {
+			return apierror.NewAPIError("image not scanned", http.StatusConflict).
+				WithDetailsf("image '%s' has no scan report. Deploy asynchronously to scan it", imageURL)
+		}
+
+		log.Infow("scanning image", "app", appRef.Name, "namespace", appRef.Namespace, "stage", stageID, "image", imageURL)
+
+		report, err = scanImage(ctx, cluster, appRef, stageID, imageURL)
+		if err != nil {
+			return apierror.InternalError(err, "scanning the image")
+		}
+
+		err = application.ScanReportRecord(ctx, cluster, appRef, *report)
+		if err != nil {
+			return apierror.InternalError(err, "saving the image scan report")
+		}
+	}
+
+	threshold, err := scanThreshold(ctx, cluster, policyNamespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	application.ScanEvaluate(report, threshold)
+	if report.Blocked {
+		log.Infow("image scan blocks deployment", "app", appRef.Name, "namespace", policyNamespace, "reason", report.Reason)
+		return apierror.ImageScanBlocked(report.Reason)
+	}
+
+	return nil
+}
+
+// stagedImageID returns the id of the staging run which built the image, i.e. its tag, if
+// the image is in one of the given registries of epinio. The flag is false for images of
+// other registries. The id is empty for images of epinio without tag.
+func stagedImageID(imageURL string, registryURLs ...string) (string, bool) {
+	for _, registryURL := range registryURLs {
+		if registryURL == "" || !strings.HasPrefix(imageURL, registryURL+"/") {
+			continue
+		}
+
+		name := imageURL[strings.LastIndex(imageURL, "/")+1:]
+		if strings.Contains(name, "@") {
+			return "", true
+		}
+		if _, tag, found := strings.Cut(name, ":"); found {
+			return tag, true
+		}
+		return "", true
+	}
+
+	return "", false
+}
+
+// recordImageScan scans the image built by the successful staging job, unless already done,
+// so that synchronous deployments find its report. Failures are logged, and otherwise
+// ignored. Asynchronous deployments scan the image again.
+func recordImageScan(ctx context.Context, cluster *kubernetes.Cluster, job batchv1.Job) {
+	if viper.GetString("image-scanner") == "" {
+		return
+	}
+
+	appRef := models.NewAppRef(job.Labels["app.kubernetes.io/name"], job.Labels["app.kubernetes.io/part-of"])
+	stageID := job.Labels[models.EpinioStageIDLabel]
+	if appRef.Name == "" || appRef.Namespace == "" || stageID == "" {
+		return
+	}
+	log := helpers.Logger.With("app", appRef.Name, "namespace", appRef.Namespace, "stage", stageID)
+
+	imageURL := ""
+	if builder := builderContainer(job); builder != nil {
+		for _, ev := range builder.Env {
+			if ev.Name == "APPIMAGE" {
+				imageURL = ev.Value
+			}
+		}
+	}
+	if imageURL == "" {
+		return
+	}
+
+	report, err := application.ScanReport(ctx, cluster, appRef, stageID)
+	if err != nil {
+		log.Infow("image scan: reading", "error", err)
+		return
+	}
+	if report != nil {
+		return
+	}
+
+	report, err = scanImage(ctx, cluster, appRef, stageID, imageURL)
+	if err != nil {
+		log.Infow("image scan: scanning", "error", err)
+		return
+	}
+
+	err = application.ScanReportRecord(ctx, cluster, appRef, *report)
+	if err != nil {
+		log.Infow("image scan: recording", "error", err)
+	}
+}
+
+// scanThreshold returns the lowest severity of image scan findings blocking deployments in
+// the namespace. The policy of the namespace takes precedence over the global policy.
+func scanThreshold(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (string, error) {
+	threshold, err := namespaces.GetScanThreshold(ctx, cluster, namespace)
+	if err != nil {
+		return "", err
+	}
+	if threshold == "" {
+		threshold = viper.GetString("scan-block-severity")
+	}
+
+	return threshold, nil
+}
+
+// scanImage runs the scanner against the image, as a job, and returns its findings. The job
+// is deleted when it succeeded, and kept for inspection otherwise.
+func scanImage(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, stageID, imageURL string) (*models.ScanReport, error) {
+	log := requestctx.Logger(ctx)
+
+	// The scanner runs inside the cluster, and has to use the internal registry url.
+	connectionDetails, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting registry connection details")
+	}
+	scanURL, err := connectionDetails.ReplaceWithInternalRegistry(imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	job := newScanJob(appRef, stageID, viper.GetString("image-scanner"), scanURL, scanURL != imageURL)
+
+	err = cluster.CreateJob(ctx, helmchart.Namespace(), job)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create scan job %s", job.Name)
+	}
+
+	err = cluster.WaitForJobDone(ctx, helmchart.Namespace(), job.Name, scanTimeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error waiting for completion of scan job %s", job.Name)
+	}
+
+	failed, err := cluster.IsJobFailed(ctx, job.Name, helmchart.Namespace())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error checking status of scan job %s", job.Name)
+	}
+	if failed {
+		return nil, fmt.Errorf("scan job %s failed", job.Name)
+	}
+
+	output, err := jobLogs(ctx, cluster, job.Name, scanContainerName)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infow("delete completed scan job", "job", job.Name)
+	err = cluster.DeleteJob(ctx, helmchart.Namespace(), job.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error deleting scan job %s", job.Name)
+	}
+
+	report, err := parseTrivyReport(output)
+	if err != nil {
+		return nil, err
+	}
+
+	report.StageID = stageID
+	report.Image = imageURL
+	report.Scanner = viper.GetString("image-scanner")
+	report.ScannedAt = time.Now().UTC().Format(time.RFC3339)
+
+	return report, nil
+}
+
+// newScanJob returns the job scanning the image with trivy. The registry credentials of
+// epinio are provided to the scanner as docker configuration. The internal registry uses a
+// certificate the scanner does not know, so verification is disabled for it.
+func newScanJob(appRef models.AppRef, stageID, scanner, imageURL string, internal bool) *batchv1.Job {
+	// A failed job is kept for inspection. The timestamp keeps a retry from clashing with it.
+	nano := fmt.Sprintf("%d", time.Now().UnixNano())
+	jobName := names.GenerateResourceName("scan", appRef.Namespace, appRef.Name, stageID, nano)
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":       names.Truncate(jobName, 63),
+		"app.kubernetes.io/part-of":    helmchart.Namespace(),
+		"app.kubernetes.io/managed-by": "epinio",
+		"app.kubernetes.io/component":  "scan",
+		models.EpinioStageIDLabel:      stageID,
+	}
+
+	env := []corev1.EnvVar{{
+		Name:  "DOCKER_CONFIG",
+		Value: "/docker-config",
+	}}
+	if internal {
+		env = append(env, corev1.EnvVar{Name: "TRIVY_INSECURE", Value: "true"})
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   jobName,
+			Labels: labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  scanContainerName,
+						Image: scanner,
+						Args:  []string{"image", "--format", "json", "--quiet", imageURL},
+						Env:   env,
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "registry-creds",
+							MountPath: "/docker-config",
+							ReadOnly:  true,
+						}},
+					}},
+					RestartPolicy: corev1.RestartPolicyNever,
+					Volumes: []corev1.Volume{{
+						Name: "registry-creds",
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{
+								SecretName: registry.CredentialsSecretName,
+								Items: []corev1.KeyToPath{{
+									Key:  ".dockerconfigjson",
+									Path: "config.json",
+								}},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// jobLogs returns the output of the named container of the pod run by the job.
+func jobLogs(ctx context.Context, cluster *kubernetes.Cluster, jobName, container string) ([]byte, error) {
+	pods, err := cluster.ListPods(ctx, helmchart.Namespace(), fmt.Sprintf("job-name=%s", jobName))
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no pod found for job %s", jobName)
+	}
+
+	return cluster.Kubectl.CoreV1().Pods(helmchart.Namespace()).GetLogs(
+		pods.Items[0].Name,
+		&corev1.PodLogOptions{Container: container},
+	).DoRaw(ctx)
+}
+
+// parseTrivyReport converts the JSON report printed by trivy into a scan report. Log lines
+// printed before the report are skipped. The findings are sorted by decreasing severity.
+func parseTrivyReport(output []byte) (*models.ScanReport, error) {
+	start := bytes.IndexByte(output, '{')
+	if start < 0 {
+		return nil, errors.New("scanner printed no report")
+	}
+
+	trivy := trivyReport{}
+	if err := json.Unmarshal(output[start:], &trivy); err != nil {
+		return nil, errors.Wrap(err, "bad scanner report")
+	}
+
+	report := &models.ScanReport{
+		Summary:  map[string]int{},
+		Findings: []models.ScanFinding{},
+	}
+	for _, result := range trivy.Results {
+		for _, v := range result.Vulnerabilities {
+			severity := models.SeverityUnknown
+			if rank, ok := models.SeverityRank(v.Severity); ok {
+				severity = models.Severities[rank]
+			}
+
+			report.Summary[severity]++
+			report.Findings = append(report.Findings, models.ScanFinding{
+				ID:               v.VulnerabilityID,
+				Package:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Severity:         severity,
+				Title:            v.Title,
+			})
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		ri, _ := models.SeverityRank(report.Findings[i].Severity)
+		rj, _ := models.SeverityRank(report.Findings[j].Severity)
+		return ri > rj
+	})
+
+	return report, nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestParseTrivyReport(t *testing.T) {
+	output := []byte(`2026-01-01T00:00:00Z	INFO	Vulnerability scanning is enabled
+{
+  "SchemaVersion": 2,
+  "Results": [
+    {
+      "Target": "app (debian 12)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-1", "PkgName": "libc", "InstalledVersion": "1.0", "Severity": "LOW"},
+        {"VulnerabilityID": "CVE-2", "PkgName": "openssl", "InstalledVersion": "3.0", "FixedVersion": "3.1", "Severity": "CRITICAL", "Title": "bad"}
+      ]
+    },
+    {
+      "Target": "node-pkg"
+    },
+    {
+      "Target": "go-binary",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-3", "PkgName": "x/net", "Severity": "whatever"}
+      ]
+    }
+  ]
+}
+`)
+
+	report, err := parseTrivyReport(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Findings) != 3 {
+		t.Fatalf("expected 3 findings, got %d", len(report.Findings))
+	}
+	first := report.Findings[0]
+	if first.ID != "CVE-2" || first.Severity != models.SeverityCritical || first.FixedVersion != "3.1" {
+		t.Errorf("expected the critical finding first, got %+v", first)
+	}
+	if report.Findings[2].Severity != models.SeverityUnknown {
+		t.Errorf("expected an unknown severity last, got %q", report.Findings[2].Severity)
+	}
+
+	want := map[string]int{
+		models.SeverityLow:      1,
+		models.SeverityCritical: 1,
+		models.SeverityUnknown:  1,
+	}
+	for severity, count := range want {
+		if report.Summary[severity] != count {
+			t.Errorf("summary %s = %d, want %d", severity, report.Summary[severity], count)
+		}
+	}
+}
+
+func TestParseTrivyReportWithoutReport(t *testing.T) {
+	if _, err := parseTrivyReport([]byte("FATAL unable to pull image\n")); err == nil {
+		t.Error("expected an error for output without report")
+	}
+	if _, err := parseTrivyReport([]byte("{ broken")); err == nil {
+		t.Error("expected an error for a broken report")
+	}
+}
+
+func TestStagedImageID(t *testing.T) {
+	for _, tc := range []struct {
+		image  string
+		id     string
+		staged bool
+	}{
+		{"registry.example.com/apps/workspace-app:abcdef", "abcdef", true},
+		{"127.0.0.1:30500/apps/workspace-app:abcdef", "abcdef", true},
+		{"registry.example.com/apps/workspace-app@sha256:0123", "", true},
+		{"registry.example.com/apps/workspace-app", "", true},
+		{"registry.example.com/other/workspace-app:abcdef", "", false},
+		{"docker.io/library/nginx:latest", "", false},
+	} {
+		id, staged := stagedImageID(tc.image, "registry.example.com/apps", "127.0.0.1:30500/apps", "")
+		if id != tc.id || staged != tc.staged {
+			t.Fatalf("image %s: expected (%q, %v), got (%q, %v)", tc.image, tc.id, tc.staged, id, staged)
+		}
+	}
+}
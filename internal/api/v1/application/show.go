@@ -40,6 +40,11 @@ func Show(c *gin.Context) apierror.APIErrors {
 		return apierror.AppIsNotKnown(appName)
 	}
 
+	app.BuildCache, err = application.BuildCache(ctx, cluster, app.Meta)
+	if err != nil {
+		return apierror.InternalError(err, "reading the build cache")
+	}
+
 	response.OKReturn(c, app)
 	return nil
 }
@@ -22,7 +22,6 @@ import (
 	"github.com/epinio/epinio/internal/registry"
 	"github.com/epinio/epinio/internal/s3manager"
 	"github.com/gin-gonic/gin"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -217,13 +216,45 @@ func sourceInfo(
 		)
 	}
 
+	// The patch may add a Dockerfile, or leave the one in the existing sources
+	dockerfile, dockerfileError := archiveHasDockerfile(ctx, patchedBlob)
+	if dockerfileError != nil {
+		return "", nil, s3manager.ConnectionDetails{}, apierror.InternalError(
+			dockerfileError,
+			"looking for a Dockerfile in the patched sources",
+		)
+	}
+	size, seekError := patchedBlob.Seek(0, io.SeekEnd)
+	if seekError == nil {
+		_, seekError = patchedBlob.Seek(0, io.SeekStart)
+	}
+	if seekError != nil {
+		return "", nil, s3manager.ConnectionDetails{}, apierror.InternalError(
+			seekError,
+			"resetting the patched sources after looking for a Dockerfile",
+		)
+	}
+	checksum, checksumError := sourceChecksum(patchedBlob)
+	if checksumError != nil {
+		return "", nil, s3manager.ConnectionDetails{}, apierror.InternalError(
+			checksumError,
+			"computing the checksum of the patched sources",
+		)
+	}
+
+	blobMeta := map[string]string{
+		"app": name, "namespace": namespace, "username": username,
+		blobChecksumKey: checksum,
+	}
+	if dockerfile {
+		blobMeta[blobDockerfileKey] = "true"
+	}
+
 	newBlobUID, uploadBlobError := manager.UploadStream(
 		ctx,
 		patchedBlob,
-		-1,
-		map[string]string{
-			"app": name, "namespace": namespace, "username": username,
-		},
+		size,
+		blobMeta,
 	)
 	if uploadBlobError != nil {
 		return "", nil, s3manager.ConnectionDetails{}, apierror.InternalError(
@@ -241,6 +272,7 @@ func sourceInfo(
 		"blobUID",
 		newBlobUID,
 	)
+	recordBlobUpload(ctx, cluster, namespace, size)
 
 	return newBlobUID, app, connectionDetails, nil
 }
@@ -259,13 +291,10 @@ func buildAndPatch(
 		BlobUID: newBlobUID,
 	}
 
-	builderImage, builderError := getBuilderImage(req, app)
+	buildMode, builderImage, builderError := stagingBuilder(ctx, connectionDetails, req, app, newBlobUID)
 	if builderError != nil {
 		return models.StageResponse{}, stageParam{}, builderError
 	}
-	if builderImage == "" {
-		builderImage = viper.GetString("default-builder-image")
-	}
 
 	config, determineStagingError := DetermineStagingScripts(
 		ctx,
@@ -342,6 +371,7 @@ func buildAndPatch(
 
 	params := stageParam{
 		AppRef:              appRef,
+		BuildMode:           buildMode,
 		BuilderImage:        builderImage,
 		BlobUID:             newBlobUID,
 		DownloadImage:       config.DownloadImage,
@@ -642,7 +672,7 @@ func extractTar(archive io.Reader) (map[string][]byte, error) {
 	return files, nil
 }
 
-func createTar(files map[string][]byte) (io.Reader, error) {
+func createTar(files map[string][]byte) (io.ReadSeeker, error) {
 	var buffer bytes.Buffer
 	tarWriter := tar.NewWriter(&buffer)
 
@@ -673,7 +703,7 @@ func createTar(files map[string][]byte) (io.Reader, error) {
 	return bytes.NewReader(buffer.Bytes()), nil
 }
 
-func applySourcePatch(base io.Reader, patch io.Reader) (io.Reader, error) {
+func applySourcePatch(base io.Reader, patch io.Reader) (io.ReadSeeker, error) {
 	baseFiles, baseFilesError := extractTar(base)
 	if baseFilesError != nil {
 		return nil, baseFilesError
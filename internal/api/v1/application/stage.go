@@ -25,6 +25,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v3"
 	batchv1 "k8s.io/api/batch/v1"
@@ -48,9 +49,11 @@ import (
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/duration"
 	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/metrics"
 	"github.com/epinio/epinio/internal/names"
 	"github.com/epinio/epinio/internal/registry"
 	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/epinio/epinio/internal/tracing"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 )
@@ -58,13 +61,14 @@ import (
 type stageParam struct {
 	models.AppRef
 	BlobUID             string
+	BuildMode           string
 	BuilderImage        string
 	DownloadImage       string
 	UnpackImage         string
 	Environment         models.EnvVariableList
 	Owner               metav1.OwnerReference
 	RegistryURL         string
-	S3ConnectionDetails s3manager.ConnectionDetails
+	S3ConnectionDetails s3manager.ConnectionDetails `json:"-"` // Not persisted with queued stagings
 	Stage               models.StageRef
 	Username            string
 	PreviousStageID     string
@@ -75,6 +79,7 @@ type stageParam struct {
 	GroupID             int64
 	Scripts             string
 	HelmValues          HelmValuesMap
+	TraceEnv            map[string]string // Trace context of the request starting the staging
 }
 
 type HelmValuesMap struct {
@@ -111,13 +116,19 @@ func (app *stageParam) ImageURL(registryURL string) string {
 // "source" workspace.
 // The same PVC stores the application's build cache (on a separate directory).
 func ensurePVC(ctx context.Context, cluster *kubernetes.Cluster, config StagingStorageValues, pvcName string) error {
-	_, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+	pvc, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
 		Get(ctx, pvcName, metav1.GetOptions{})
 	if err != nil && !apierrors.IsNotFound(err) { // Unknown error, irrelevant to non-existence
 		return err
 	}
-	if err == nil { // pvc already exists
-		return nil
+	if err == nil {
+		if pvc.DeletionTimestamp == nil { // pvc already exists
+			return nil
+		}
+		// A cleared or pruned pvc is still going away. Wait for it, then recreate.
+		if err := application.WaitForPVCDeletion(ctx, cluster, pvcName); err != nil {
+			return err
+		}
 	}
 
 	// Insert a default of last resort. See also note below.
@@ -204,15 +215,26 @@ func Stage(c *gin.Context) apierror.APIErrors {
 		return apierror.NewBadRequestError("staging job for image ID still running")
 	}
 
-	// get builder image from either request, application, or default as final fallback
+	s3ConnectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
+		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return apierror.InternalError(err, "failed to fetch the S3 connection details")
+	}
+
+	blobUID, blobErr := resolveBlobUID(
+		ctx, cluster, s3ConnectionDetails, req, app, username,
+	)
+	if blobErr != nil {
+		return blobErr
+	}
+
+	// get build mode and builder image from either request, sources, application, or
+	// default as final fallback
 
-	builderImage, builderErr := getBuilderImage(req, app)
+	buildMode, builderImage, builderErr := stagingBuilder(ctx, s3ConnectionDetails, req, app, blobUID)
 	if builderErr != nil {
 		return builderErr
 	}
-	if builderImage == "" {
-		builderImage = viper.GetString("default-builder-image")
-	}
 
 	// Find staging script spec based on the builder image and what images are supported by each spec
 	// This also resolves a `base` reference, if present.
@@ -223,6 +245,7 @@ func Stage(c *gin.Context) apierror.APIErrors {
 	}
 
 	log.Infow("staging app", "scripts", config.Name)
+	log.Infow("staging app", "mode", buildMode)
 	log.Infow("staging app", "builder", builderImage)
 	log.Infow("staging app", "download", config.DownloadImage)
 	log.Infow("staging app", "unpack", config.UnpackImage)
@@ -232,19 +255,6 @@ func Stage(c *gin.Context) apierror.APIErrors {
 	log.Infow("staging app", "Staging Values", config.HelmValues)
 	log.Infow("staging app", "namespace", namespace, "app", req)
 
-	s3ConnectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
-		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
-	if err != nil {
-		return apierror.InternalError(err, "failed to fetch the S3 connection details")
-	}
-
-	blobUID, blobErr := resolveBlobUID(
-		ctx, cluster, s3ConnectionDetails, req, app, username,
-	)
-	if blobErr != nil {
-		return blobErr
-	}
-
 	// Create uid identifying the staging job to be
 
 	uid, err := randstr.Hex16()
@@ -296,6 +306,7 @@ func Stage(c *gin.Context) apierror.APIErrors {
 
 	params := stageParam{
 		AppRef:              req.App,
+		BuildMode:           buildMode,
 		BuilderImage:        builderImage,
 		BlobUID:             blobUID,
 		DownloadImage:       config.DownloadImage,
@@ -326,6 +337,8 @@ func Stage(c *gin.Context) apierror.APIErrors {
 }
 
 // executeStage runs the staging job for the given params. It is called by both Stage and SourcePatch.
+// The job is created right away when the staging limits allow it. Otherwise the staging run is
+// queued, and the response reports its position in the queue.
 func executeStage(
 	ctx context.Context,
 	cluster *kubernetes.Cluster,
@@ -335,9 +348,61 @@ func executeStage(
 ) (models.StageResponse, apierror.APIErrors) {
 	log := requestctx.Logger(ctx)
 
+	// Captured now, as a queued staging run is started outside of the request.
+	params.TraceEnv = tracing.Env(ctx)
+
+	payload, err := json.Marshal(queuedStage{Request: req, Params: params})
+	if err != nil {
+		return models.StageResponse{}, apierror.InternalError(err, "encoding the staging for the queue")
+	}
+
+	var startErr apierror.APIErrors
+	start := func(ctx context.Context) error {
+		startErr = startStage(ctx, cluster, app, req, params)
+		if startErr != nil {
+			return startErr.Errors()[0]
+		}
+		return nil
+	}
+
+	position, _ := application.Staging.Submit(ctx, stagingLimits(), models.StagingQueueEntry{
+		ID:       params.Stage.ID,
+		App:      params.AppRef,
+		Username: params.Username,
+	}, payload, start)
+	if position == 0 && startErr != nil {
+		return models.StageResponse{}, startErr
+	}
+
+	imageURL := params.ImageURL(params.RegistryURL)
+	log.Infow(
+		"staged app",
+		"namespace", helmchart.Namespace(),
+		"app", params.AppRef,
+		"uid", params.Stage.ID,
+		"image", imageURL,
+		"queue", position,
+	)
+
+	return models.StageResponse{
+		Stage:         params.Stage,
+		ImageURL:      imageURL,
+		QueuePosition: position,
+	}, nil
+}
+
+// startStage creates the staging job for the given params, and records the staging run in
+// the application.
+func startStage(
+	ctx context.Context,
+	cluster *kubernetes.Cluster,
+	app *unstructured.Unstructured,
+	req models.StageRequest,
+	params stageParam,
+) apierror.APIErrors {
 	pvcError := ensureStagingPVCs(ctx, cluster, req, params.HelmValues)
 	if pvcError != nil {
-		return models.StageResponse{}, pvcError
+		return pvcError
 	}
 
 	job, jobenv := newJobRun(params)
@@ -345,7 +410,7 @@ func executeStage(
 	// Note: The secret is deleted with the job in function `Unstage()`.
 	createSecretError := cluster.CreateSecret(ctx, helmchart.Namespace(), *jobenv)
 	if createSecretError != nil {
-		return models.StageResponse{}, apierror.InternalError(
+		return apierror.InternalError(
 			createSecretError,
 			fmt.Sprintf("failed to create job env: %#v", jobenv),
 		)
@@ -353,7 +418,7 @@ func executeStage(
 
 	createJobError := cluster.CreateJob(ctx, helmchart.Namespace(), job)
 	if createJobError != nil {
-		return models.StageResponse{}, apierror.InternalError(
+		return apierror.InternalError(
 			createJobError,
 			fmt.Sprintf("failed to create job run: %#v", job),
 		)
@@ -361,29 +426,22 @@ func executeStage(
 
 	updateAppError := updateApp(ctx, cluster, app, params)
 	if updateAppError != nil {
-		return models.StageResponse{}, apierror.InternalError(
+		return apierror.InternalError(
 			updateAppError,
 			"updating application CR with staging information",
 		)
 	}
 
-	imageURL := params.ImageURL(params.RegistryURL)
-	log.Infow(
-		"staged app",
-		"namespace", helmchart.Namespace(),
-		"app", params.AppRef,
-		"uid", params.Stage.ID,
-		"image", imageURL,
-	)
-
-	return models.StageResponse{
-		Stage:    params.Stage,
-		ImageURL: imageURL,
-	}, nil
+	return nil
 }
 
-// stageJobs returns the jobs responsible for the staging run of the provided stageID.
+// stageJobs returns the jobs responsible for the staging run of the provided stageID. A
+// queued staging run is waited for, until its jobs are created.
 func stageJobs(ctx context.Context, cluster *kubernetes.Cluster, namespace, stageID string) ([]batchv1.Job, apierror.APIErrors) {
+	if err := application.Staging.Wait(ctx, stageID, nil); err != nil {
+		return nil, apierror.InternalError(err, "starting the queued staging")
+	}
+
 	selector := fmt.Sprintf("app.kubernetes.io/component=staging,app.kubernetes.io/part-of=%s,epinio.io/stage-id=%s",
 		namespace, stageID)
 
@@ -406,18 +464,78 @@ func waitForStagingCompletion(ctx context.Context, cluster *kubernetes.Cluster,
 			return false, err
 		}
 
-		failed, err := cluster.IsJobFailed(ctx, job.Name, helmchart.Namespace())
+		finished, err := cluster.Kubectl.BatchV1().Jobs(helmchart.Namespace()).Get(ctx, job.Name, metav1.GetOptions{})
 		if err != nil {
 			return false, err
 		}
-		if failed {
+		observeStaging(*finished)
+		if _, success := jobDoneState([]batchv1.Job{*finished}); !success {
 			return false, nil
 		}
+
+		recordBuildCacheUsage(ctx, cluster, job)
+		recordBuildMetadata(ctx, cluster, job)
+		recordImageScan(ctx, cluster, job)
 	}
 
 	return true, nil
 }
 
+// observeStaging records the outcome and duration of the finished staging job in the
+// metrics, and as span of the trace which started the job. Jobs still running are ignored.
+func observeStaging(job batchv1.Job) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		var outcome string
+		switch condition.Type {
+		case batchv1.JobComplete:
+			outcome = metrics.OutcomeSucceeded
+		case batchv1.JobFailed:
+			outcome = metrics.OutcomeFailed
+		default:
+			continue
+		}
+
+		elapsed := condition.LastTransitionTime.Sub(job.CreationTimestamp.Time)
+		if !metrics.ObserveStaging(string(job.UID), outcome, elapsed) {
+			return
+		}
+
+		traceStaging(job, outcome, condition.LastTransitionTime.Time)
+		return
+	}
+}
+
+// traceStaging records the run of the finished staging job as a span, when the job carries
+// the trace context of the request which started it.
+func traceStaging(job batchv1.Job, outcome string, finished time.Time) {
+	env := map[string]string{}
+	for _, container := range job.Spec.Template.Spec.Containers {
+		for _, ev := range container.Env {
+			if ev.Name == tracing.EnvTraceParent || ev.Name == tracing.EnvTraceState {
+				env[ev.Name] = ev.Value
+			}
+		}
+	}
+	if len(env) == 0 {
+		return
+	}
+
+	var err error
+	if outcome == metrics.OutcomeFailed {
+		err = errors.New("staging job failed")
+	}
+
+	ctx := tracing.FromEnv(context.Background(), env)
+	tracing.Record(ctx, "staging job", job.CreationTimestamp.Time, finished, err,
+		attribute.String("k8s.job.name", job.Name),
+		attribute.String("epinio.stage_id", job.Labels[models.EpinioStageIDLabel]),
+	)
+}
+
 // jobDoneState checks current job conditions and reports if all jobs are done and whether they succeeded.
 func jobDoneState(jobs []batchv1.Job) (done bool, success bool) {
 	if len(jobs) == 0 {
@@ -549,7 +667,15 @@ func StagedWebsocket(c *gin.Context) {
 
 	// If the job already completed before the websocket upgrade, send the final state immediately.
 	if done, success := jobDoneState(jobs); done {
+		for _, job := range jobs {
+			observeStaging(job)
+		}
 		if success {
+			for _, job := range jobs {
+				recordBuildCacheUsage(ctx, cluster, job)
+				recordBuildMetadata(ctx, cluster, job)
+				recordImageScan(ctx, cluster, job)
+			}
 			_ = sendUpdate(models.StageStatusSucceeded, "", true)
 		} else {
 			_ = sendUpdate(models.StageStatusFailed, fmt.Sprintf("stage-id = %s failed to complete", stageID), true)
@@ -678,7 +804,8 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 	unpackScript := fmt.Sprintf(`source /stage-support/%s`, helmchart.EpinioStageUnpack)
 
 	// runtime: app.BuilderImage
-	buildpackScript := fmt.Sprintf(`source /stage-support/%s`, helmchart.EpinioStageBuild)
+	// The cache usage is reported through the termination message, whatever the outcome.
+	buildpackScript := fmt.Sprintf(`%s; source /stage-support/%s`, cacheUsageTrap, helmchart.EpinioStageBuild)
 
 	// build configuration
 	// - shared between all the phases, even if each phase uses only part of the set
@@ -851,6 +978,26 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 		},
 	}
 
+	buildContainer := corev1.Container{
+		Name:    "buildpack",
+		Image:   app.BuilderImage,
+		Command: []string{"/bin/bash"},
+		Args: []string{
+			"-c",
+			buildpackScript,
+		},
+		Env:          stageEnv,
+		VolumeMounts: volumeMounts,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:  ptr.To[int64](app.UserID),
+			RunAsGroup: ptr.To[int64](app.GroupID),
+		},
+		Resources: app.HelmValues.Resources,
+	}
+	if app.BuildMode == models.BuildModeDockerfile {
+		buildContainer = newDockerfileContainer(app, stageEnv, volumeMounts)
+	}
+
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: jobName,
@@ -890,29 +1037,12 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 				Spec: corev1.PodSpec{
 					ServiceAccountName: app.HelmValues.ServiceAccountName,
 					InitContainers:     initContainers,
-					Containers: []corev1.Container{
-						{
-							Name:    "buildpack",
-							Image:   app.BuilderImage,
-							Command: []string{"/bin/bash"},
-							Args: []string{
-								"-c",
-								buildpackScript,
-							},
-							Env:          stageEnv,
-							VolumeMounts: volumeMounts,
-							SecurityContext: &corev1.SecurityContext{
-								RunAsUser:  ptr.To[int64](app.UserID),
-								RunAsGroup: ptr.To[int64](app.GroupID),
-							},
-							Resources: app.HelmValues.Resources,
-						},
-					},
-					RestartPolicy: corev1.RestartPolicyNever,
-					Volumes:       volumes,
-					Tolerations:   app.HelmValues.Tolerations,
-					NodeSelector:  app.HelmValues.NodeSelector,
-					Affinity:      &app.HelmValues.Affinity,
+					Containers:         []corev1.Container{buildContainer},
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Volumes:            volumes,
+					Tolerations:        app.HelmValues.Tolerations,
+					NodeSelector:       app.HelmValues.NodeSelector,
+					Affinity:           &app.HelmValues.Affinity,
 				},
 			},
 		},
@@ -921,6 +1051,57 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 	return job, jobenv
 }
 
+// newDockerfileContainer returns the container building the application image from the
+// Dockerfile of the sources. It uses the sources unpacked by the init containers, and keeps
+// the cached base images in the cache volume. The builder runs without a docker daemon, as
+// root, and pushes to the registry using the same credentials and certificates as the
+// buildpack build.
+func newDockerfileContainer(app stageParam, stageEnv []corev1.EnvVar, volumeMounts []corev1.VolumeMount) corev1.Container {
+	args := []string{
+		"--context=dir:///workspace/source/app",
+		"--dockerfile=/workspace/source/app/" + dockerfileName,
+		"--destination=$(APPIMAGE)",
+		"--cache=true",
+		"--cache-dir=/workspace/cache/dockerfile",
+	}
+
+	return corev1.Container{
+		Name:         "dockerfile",
+		Image:        app.BuilderImage,
+		Args:         append(args, dockerfileRegistryArgs(app)...),
+		Env:          appendEnvVar(slices.Clone(stageEnv), "DOCKER_CONFIG", "/home/cnb/.docker"),
+		VolumeMounts: volumeMounts,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:  ptr.To[int64](0),
+			RunAsGroup: ptr.To[int64](0),
+		},
+		Resources: app.HelmValues.Resources,
+	}
+}
+
+// dockerfileRegistryArgs returns the arguments telling the dockerfile builder how to reach
+// the registry of epinio. The builder does not look into the system certificates the
+// registry certificate is mounted to, it is given the certificate directly. A registry
+// reached over plain http, or a registry internal to the cluster without certificate to
+// trust, is accessed insecurely. Only the registry of epinio is, not the registries the
+// base images are pulled from.
+func dockerfileRegistryArgs(app stageParam) []string {
+	scheme, host := registry.Endpoint(app.RegistryURL)
+	if host == "" {
+		return nil
+	}
+
+	switch {
+	case scheme == "http":
+		return []string{"--insecure-registry=" + host}
+	case app.RegistryCASecret != "" && app.RegistryCAHash != "":
+		return []string{fmt.Sprintf("--registry-certificate=%s=%s", host, registryCertPath(app))}
+	case strings.Contains(host, ".svc.cluster.local"):
+		return []string{"--skip-tls-verify-registry=" + host}
+	}
+	return nil
+}
+
 func assembleStageEnv(app, previous stageParam) []corev1.EnvVar {
 	stageEnv := []corev1.EnvVar{}
 
@@ -938,6 +1119,13 @@ func assembleStageEnv(app, previous stageParam) []corev1.EnvVar {
 	stageEnv = appendEnvVar(stageEnv, "USERID", strconv.FormatInt(app.UserID, 10))
 	stageEnv = appendEnvVar(stageEnv, "GROUPID", strconv.FormatInt(app.GroupID, 10))
 
+	// Let the staging scripts continue the trace of the request starting them.
+	for _, name := range []string{tracing.EnvTraceParent, tracing.EnvTraceState} {
+		if value, ok := app.TraceEnv[name]; ok {
+			stageEnv = appendEnvVar(stageEnv, name, value)
+		}
+	}
+
 	return stageEnv
 }
 
@@ -1172,6 +1360,9 @@ func ensureStagingPVCs(
 	helmValues HelmValuesMap,
 ) apierror.APIErrors {
 	if !helmValues.Storage.Cache.EmptyDir {
+		if apiErr := prepareBuildCache(ctx, cluster, req); apiErr != nil {
+			return apiErr
+		}
 		cacheErr := ensurePVC(
 			ctx, cluster,
 			helmValues.Storage.Cache,
@@ -1241,8 +1432,15 @@ func updateApp(ctx context.Context, cluster *kubernetes.Cluster, app *unstructur
 		"blobuid":      params.BlobUID,
 	}
 
+	// The build mode is not part of the application resource spec.
+	metadataPatch := map[string]any{
+		"annotations": map[string]any{
+			models.EpinioBuildModeAnnotation: params.BuildMode,
+		},
+	}
+
 	// Merge patch avoids resourceVersion update conflicts for these spec fields.
-	patchBody, err := json.Marshal(map[string]any{"spec": specPatch})
+	patchBody, err := json.Marshal(map[string]any{"spec": specPatch, "metadata": metadataPatch})
 	if err != nil {
 		return err
 	}
@@ -1294,7 +1492,7 @@ func mountRegistryCerts(app stageParam, volumes []corev1.Volume, volumeMounts []
 
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      "registry-certs",
-			MountPath: fmt.Sprintf("/etc/ssl/certs/%s", app.RegistryCAHash),
+			MountPath: registryCertPath(app),
 			SubPath:   app.RegistryCACertKey,
 			ReadOnly:  true,
 		})
@@ -1303,6 +1501,12 @@ func mountRegistryCerts(app stageParam, volumes []corev1.Volume, volumeMounts []
 	return volumes, volumeMounts
 }
 
+// registryCertPath returns the path the registry certificate is mounted at, in the system
+// certificates of the staging containers.
+func registryCertPath(app stageParam) string {
+	return fmt.Sprintf("/etc/ssl/certs/%s", app.RegistryCAHash)
+}
+
 func appendEnvVar(envs []corev1.EnvVar, name, value string) []corev1.EnvVar {
 	return append(envs, corev1.EnvVar{Name: name, Value: value})
 }
@@ -1,10 +1,19 @@
 package application
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/epinio/epinio/internal/metrics"
+	"github.com/epinio/epinio/internal/tracing"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestJobDoneStateSuccess(t *testing.T) {
@@ -45,3 +54,78 @@ func TestJobDoneStatePending(t *testing.T) {
 		t.Fatalf("expected done=false, success=false got done=%v success=%v", done, success)
 	}
 }
+
+func TestNewJobRunTraceEnv(t *testing.T) {
+	params := stageParam{
+		AppRef:       models.NewAppRef("app", "workspace"),
+		BuilderImage: "builder",
+		Stage:        models.NewStage("stage"),
+	}
+
+	job, _ := newJobRun(params)
+	for _, ev := range job.Spec.Template.Spec.Containers[0].Env {
+		if ev.Name == tracing.EnvTraceParent {
+			t.Fatalf("expected no trace context without trace, got %s", ev.Value)
+		}
+	}
+
+	traceparent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	params.TraceEnv = map[string]string{tracing.EnvTraceParent: traceparent}
+
+	job, _ = newJobRun(params)
+	found := ""
+	for _, ev := range job.Spec.Template.Spec.Containers[0].Env {
+		if ev.Name == tracing.EnvTraceParent {
+			found = ev.Value
+		}
+	}
+	if found != traceparent {
+		t.Fatalf("expected the trace context in the job environment, got %q", found)
+	}
+}
+
+func TestTraceStaging(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	shutdown := tracing.Install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer func() {
+		_ = shutdown(context.Background())
+	}()
+
+	created := time.Now().Add(-time.Minute)
+	finished := time.Now()
+	job := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "stage-job",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Env: []corev1.EnvVar{{
+							Name:  tracing.EnvTraceParent,
+							Value: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+						}},
+					}},
+				},
+			},
+		},
+	}
+
+	traceStaging(job, metrics.OutcomeFailed, finished)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "staging job" || span.Parent.TraceID().String() != "0af7651916cd43dd8448eb211c80319c" {
+		t.Fatalf("expected the staging job span in the trace of the job, got %s in %s", span.Name, span.Parent.TraceID())
+	}
+	if !span.StartTime.Equal(created) || !span.EndTime.Equal(finished) {
+		t.Fatalf("expected the span to cover the run of the job, got %s to %s", span.StartTime, span.EndTime)
+	}
+	if span.Status.Code != codes.Error {
+		t.Fatalf("expected the failed job to be marked as error, got %s", span.Status.Code)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/background"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/s3manager"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// stagingDispatchInterval is the period of the checks for free staging slots. Staging jobs
+// finishing are not signaled.
+const stagingDispatchInterval = 5 * time.Second
+
+// StagingDispatcher periodically starts the queued staging runs, as the staging jobs
+// occupying the slots complete.
+type StagingDispatcher struct {
+	*background.Worker
+}
+
+// NewStagingDispatcher returns a new, not yet started, dispatcher.
+func NewStagingDispatcher() *StagingDispatcher {
+	return &StagingDispatcher{
+		Worker: background.Periodic(stagingDispatchInterval, func(ctx context.Context) {
+			application.Staging.Dispatch(ctx, stagingLimits())
+		}),
+	}
+}
+
+// queuedStage is the payload of a queued staging run, from which it is started again after a
+// restart of the server.
+type queuedStage struct {
+	Request models.StageRequest `json:"request"`
+	Params  stageParam          `json:"params"`
+}
+
+// RestoreStagingQueue restores the staging runs queued before the restart of the server. It
+// is called before the dispatcher is started.
+func RestoreStagingQueue(ctx context.Context) error {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	return application.Staging.Restore(ctx, application.NewStagingSecretStore(cluster),
+		func(ctx context.Context, queued application.QueuedStaging) (application.StagingStart, error) {
+			return restoreStage(ctx, cluster, queued)
+		})
+}
+
+// restoreStage returns the function starting the persisted staging run, or nil if the run
+// was started before the restart.
+func restoreStage(ctx context.Context, cluster *kubernetes.Cluster, queued application.QueuedStaging) (application.StagingStart, error) {
+	var stage queuedStage
+	if err := json.Unmarshal(queued.Payload, &stage); err != nil {
+		return nil, errors.Wrap(err, "bad queued staging")
+	}
+
+	selector := fmt.Sprintf("app.kubernetes.io/component=staging,%s=%s", models.EpinioStageIDLabel, queued.Entry.ID)
+	jobList, err := cluster.ListJobs(ctx, helmchart.Namespace(), selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobList.Items) > 0 {
+		return nil, nil
+	}
+
+	app, err := application.Get(ctx, cluster, stage.Params.AppRef)
+	if err != nil {
+		return nil, err
+	}
+
+	stage.Params.S3ConnectionDetails, err = s3manager.GetConnectionDetails(ctx, cluster,
+		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		if err := startStage(ctx, cluster, app, stage.Request, stage.Params); err != nil {
+			return err.Errors()[0]
+		}
+		return nil
+	}, nil
+}
+
+// stagingLimits returns the configured limits on concurrent staging runs.
+func stagingLimits() application.StagingLimits {
+	return application.StagingLimits{
+		Global:    viper.GetInt("staging-concurrency"),
+		Namespace: viper.GetInt("staging-namespace-concurrency"),
+	}
+}
+
+// StagingQueueShow handles the API endpoint GET /staging/queue
+// It returns the queued staging runs, in order, and the staging limits.
+func StagingQueueShow(c *gin.Context) apierror.APIErrors {
+	response.OKReturn(c, stagingQueue())
+	return nil
+}
+
+// StagingQueueMove handles the API endpoint POST /staging/queue
+// It moves a queued staging run to a new position in the queue.
+func StagingQueueMove(c *gin.Context) apierror.APIErrors {
+	req := models.StagingQueueMoveRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		return apierror.NewBadRequestError(err.Error()).WithDetails("failed to unmarshal staging queue move request")
+	}
+
+	if application.Staging.Position(req.ID) == 0 {
+		return apierror.NewNotFoundError("queued staging", req.ID)
+	}
+	if err := application.Staging.Move(req.ID, req.Position); err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	response.OKReturn(c, stagingQueue())
+	return nil
+}
+
+// stagingQueue returns the queued staging runs, with the configured staging limits.
+func stagingQueue() models.StagingQueue {
+	limits := stagingLimits()
+
+	queue := application.Staging.List()
+	queue.Limit = limits.Global
+	queue.NamespaceLimit = limits.Namespace
+
+	return queue
+}
@@ -88,6 +88,13 @@ func Update(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplifica
 		len(updateRequest.Settings) == 0 &&
 		updateRequest.Configurations == nil &&
 		updateRequest.Routes == nil &&
+		updateRequest.Resources == nil &&
+		updateRequest.Healthcheck == nil &&
+		updateRequest.DependsOn == nil &&
+		updateRequest.Sidecars == nil &&
+		updateRequest.InitContainers == nil &&
+		updateRequest.RegistryCredential == nil &&
+		updateRequest.ImageWatch == nil &&
 		updateRequest.AppChart == "" {
 
 		log.Infow("updating app -- no changes")
@@ -131,6 +138,65 @@ func Update(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplifica
 		}
 	}
 
+	if updateRequest.Resources != nil {
+		desired := DefaultInstances
+		if updateRequest.Instances != nil {
+			desired = *updateRequest.Instances
+		} else if app.Configuration.Instances != nil {
+			desired = *app.Configuration.Instances
+		}
+
+		err := application.ValidateResources(ctx, cluster, appRef, updateRequest.Resources, desired)
+		if err != nil {
+			if apiErr, ok := err.(apierror.APIError); ok {
+				return apiErr
+			}
+			return apierror.InternalError(err)
+		}
+	}
+
+	err = application.ValidateHealthcheck(updateRequest.Healthcheck)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	err = application.ValidateDependencies(ctx, cluster, appRef, updateRequest.DependsOn)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	err = application.ValidateContainers(appName, updateRequest.Sidecars, updateRequest.InitContainers)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	if updateRequest.RegistryCredential != nil {
+		err = application.ValidateRegistryCredential(ctx, cluster, namespace, *updateRequest.RegistryCredential)
+		if err != nil {
+			if apiErr, ok := err.(apierror.APIError); ok {
+				return apiErr
+			}
+			return apierror.InternalError(err)
+		}
+	}
+
+	err = application.ValidateImageWatch(updateRequest.ImageWatch)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
 	// Save all changes to the relevant parts of the app resources (CRD, secrets, and the like).
 
 	// update appChart
@@ -151,6 +217,9 @@ func Update(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplifica
 
 		err := application.ScalingSetWithEvent(ctx, cluster, appRef, desired, username)
 		if err != nil {
+			if apiErr, ok := err.(apierror.APIError); ok {
+				return apiErr
+			}
 			return apierror.InternalError(err)
 		}
 	}
@@ -189,6 +258,66 @@ func Update(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplifica
 		}
 	}
 
+	// update resources
+	if updateRequest.Resources != nil {
+		log.Infow("updating app", "resources", updateRequest.Resources)
+
+		err := application.ResourcesSet(ctx, cluster, appRef, updateRequest.Resources)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// update health check
+	if updateRequest.Healthcheck != nil {
+		log.Infow("updating app", "healthcheck", updateRequest.Healthcheck)
+
+		err := application.HealthcheckSet(ctx, cluster, appRef, updateRequest.Healthcheck)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// update dependencies
+	if updateRequest.DependsOn != nil {
+		log.Infow("updating app", "dependsOn", updateRequest.DependsOn)
+
+		err := application.DependenciesSet(ctx, cluster, appRef, updateRequest.DependsOn)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// update sidecars and init containers
+	if updateRequest.Sidecars != nil || updateRequest.InitContainers != nil {
+		log.Infow("updating app", "sidecars", updateRequest.Sidecars, "initContainers", updateRequest.InitContainers)
+
+		err := application.ContainersSet(ctx, cluster, appRef, updateRequest.Sidecars, updateRequest.InitContainers)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// update registry credentials
+	if updateRequest.RegistryCredential != nil {
+		log.Infow("updating app", "registryCredential", *updateRequest.RegistryCredential)
+
+		err := application.RegistryCredentialSet(ctx, cluster, appRef, *updateRequest.RegistryCredential)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// update the watch of the image tag
+	if updateRequest.ImageWatch != nil {
+		log.Infow("updating app", "imageWatch", *updateRequest.ImageWatch)
+
+		err := application.ImageWatchSet(ctx, cluster, appRef, updateRequest.ImageWatch, username)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
 	// update settings only if chart values have been set, otherwise just leave it as it is.
 	if len(updateRequest.Settings) > 0 {
 		log.Infow("updating app", "settings", updateRequest.Settings)
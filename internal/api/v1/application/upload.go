@@ -12,6 +12,7 @@
 package application
 
 import (
+	"context"
 	"io"
 	"mime/multipart"
 	"os"
@@ -20,6 +21,7 @@ import (
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/epinio/epinio/internal/s3manager"
 	"github.com/gin-gonic/gin"
 	"github.com/h2non/filetype"
@@ -76,6 +78,20 @@ func Upload(c *gin.Context) apierror.APIErrors {
 		return apierror.NewBadRequestErrorf("archive type not supported [%s]", contentType)
 	}
 
+	// Remember a Dockerfile in the sources, for the selection of the build mode when staging
+	dockerfile, err := archiveHasDockerfile(ctx, file)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error()).WithDetails("can't read archive")
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return apierror.InternalError(err, "resetting file cursor after looking for a Dockerfile")
+	}
+	checksum, err := sourceChecksum(file)
+	if err != nil {
+		return apierror.InternalError(err, "computing the checksum of the archive")
+	}
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err, "failed to get access to a kube client")
@@ -90,10 +106,20 @@ func Upload(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err, "creating an S3 manager")
 	}
 
+	apierr := checkBlobStorageQuota(ctx, cluster, manager, namespace, fileheader.Size)
+	if apierr != nil {
+		return apierr
+	}
+
 	username := requestctx.User(ctx).Username
-	blobUID, err := manager.UploadStream(ctx, file, fileheader.Size, map[string]string{
+	blobMeta := map[string]string{
 		"app": name, "namespace": namespace, "username": username,
-	})
+		blobChecksumKey: checksum,
+	}
+	if dockerfile {
+		blobMeta[blobDockerfileKey] = "true"
+	}
+	blobUID, err := manager.UploadStream(ctx, file, fileheader.Size, blobMeta)
 	if err != nil {
 		// Check if the error is due to quota exhaustion
 		if s3manager.IsQuotaExceededError(err) {
@@ -104,6 +130,7 @@ func Upload(c *gin.Context) apierror.APIErrors {
 	}
 
 	log.Infow("uploaded app", "namespace", namespace, "app", name, "blobUID", blobUID)
+	recordBlobUpload(ctx, cluster, namespace, fileheader.Size)
 
 	/*Delete the temporary file created by the multipart form if upload is
 		successful. If it fails the net/http package doesn't store the multipart file
@@ -131,6 +158,41 @@ func Upload(c *gin.Context) apierror.APIErrors {
 	return nil
 }
 
+// checkBlobStorageQuota rejects an upload of the given size when it would go beyond the blob
+// storage quota of the namespace.
+func checkBlobStorageQuota(ctx context.Context, cluster *kubernetes.Cluster, manager *s3manager.Manager, namespace string, size int64) apierror.APIErrors {
+	quota, err := namespaces.GetQuota(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err, "failed to get namespace quota")
+	}
+	if quota.BlobStorage == "" {
+		return nil
+	}
+
+	used, err := namespaces.BlobStorageUsage(ctx, cluster, manager, namespace)
+	if err != nil {
+		return apierror.InternalError(err, "failed to determine blob storage usage")
+	}
+
+	err = namespaces.CheckQuantity(namespace, models.QuotaBlobStorage, quota.BlobStorage, false, used, size)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	return nil
+}
+
+// recordBlobUpload adds the size of an uploaded blob to the blob storage usage recorded for
+// the namespace. A failure is only logged, the upload itself succeeded.
+func recordBlobUpload(ctx context.Context, cluster *kubernetes.Cluster, namespace string, size int64) {
+	if err := namespaces.AddBlobUsage(ctx, cluster, namespace, size); err != nil {
+		requestctx.Logger(ctx).Errorw("failed to record blob storage usage", "namespace", namespace, "error", err)
+	}
+}
+
 func GetFileContentType(file multipart.File) (string, error) {
 	// to sniff the content type only the first 512 bytes are used.
 	buf := make([]byte, 512)
@@ -16,6 +16,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
@@ -153,6 +154,19 @@ func deployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 		Domains:        domains,
 		Start:          start,
 		Settings:       appObj.Configuration.Settings,
+		Resources:      appObj.Configuration.Resources,
+		Healthcheck:    appObj.Configuration.Healthcheck,
+		Sidecars:       appObj.Configuration.Sidecars,
+		InitContainers: appObj.Configuration.InitContainers,
+	}
+
+	// Pod level pull secrets replace those of the service account. Keep the epinio
+	// registry credentials next to those of the application.
+	if name := appObj.Configuration.RegistryCredential; name != "" {
+		deployParams.PullSecrets = []string{
+			registry.CredentialsSecretName,
+			registry.PullCredentialSecretName(name),
+		}
 	}
 
 	log.Infow("deploying app", "namespace", app.Namespace, "app", app.Name)
@@ -162,8 +176,36 @@ func deployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 		return nil, apierror.InternalError(err, "preparing ImageURL registry for use by Kubernetes", imageURL)
 	}
 
+	// Container images are deployed by the digest their tag resolved to, so that all
+	// replicas run the same image, even when the tag moves.
+	if appObj.Origin.Kind == models.OriginContainer {
+		applicationCR, err := application.Get(ctx, cluster, app)
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+		deployParams.ImageURL = registry.PinImage(deployParams.ImageURL, application.ImageDigest(applicationCR))
+	}
+
+	// The dependencies of the application have to be ready. Callers able to wait for them,
+	// i.e. the asynchronous deployments, do so before calling.
+	err = application.CheckDependencies(ctx, cluster, app, appObj.Configuration.DependsOn)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return nil, apiErr
+		}
+		return nil, apierror.InternalError(err)
+	}
+
+	// Remember the pods of the deployment, to report failing health checks even if the
+	// failed deployment is rolled back.
+	tracker := application.TrackProbeFailures(ctx, cluster, app)
+
 	err = helm.Deploy(deployParams)
+	failures := tracker.Stop(ctx)
 	if err != nil {
+		if len(failures) > 0 {
+			err = errors.Wrapf(err, "health check failed: %s", strings.Join(failures, "; "))
+		}
 		return nil, apierror.InternalError(err)
 	}
 
@@ -103,6 +103,8 @@ type AppMatchResponse struct {
 
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/part/{Part} application AppPart
 // Return parts of the named `App` in the `Namespace`.
+// The `sbom` part is the build metadata of the current stage, with the SBOM documents
+// produced by the buildpack lifecycle, as JSON.
 // responses:
 //   200: AppPartResponse
 
@@ -151,6 +153,18 @@ type AppLogsParam struct {
 	// Comma-separated container names/patterns to exclude
 	// in: query
 	ExcludeContainers string `json:"exclude_containers"`
+	// Text the log messages have to contain
+	// in: query
+	Grep string `json:"grep"`
+	// Use grep as a regular expression (true/false)
+	// in: query
+	GrepRegex string `json:"grep_regex"`
+	// Minimum level of structured (JSON/logfmt) log lines (e.g. "warn")
+	// in: query
+	Level string `json:"level"`
+	// Field matches on structured log lines (key=value), repeatable
+	// in: query
+	Field []string `json:"field"`
 }
 
 // swagger:response AppLogsResponse
@@ -221,6 +235,18 @@ type StagingLogsParam struct {
 	// Comma-separated container names/patterns to exclude
 	// in: query
 	ExcludeContainers string `json:"exclude_containers"`
+	// Text the log messages have to contain
+	// in: query
+	Grep string `json:"grep"`
+	// Use grep as a regular expression (true/false)
+	// in: query
+	GrepRegex string `json:"grep_regex"`
+	// Minimum level of structured (JSON/logfmt) log lines (e.g. "warn")
+	// in: query
+	Level string `json:"level"`
+	// Field matches on structured log lines (key=value), repeatable
+	// in: query
+	Field []string `json:"field"`
 }
 
 // swagger:response StagingLogsResponse
@@ -331,6 +357,104 @@ type AppRestartResponse struct {
 	Body models.Response
 }
 
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/cache/clear application AppCacheClear
+// Clear the build cache of the named `App` in the `Namespace`.
+// responses:
+//   200: AppCacheClearResponse
+
+// swagger:parameters AppCacheClear
+type AppCacheClearParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppCacheClearResponse
+type AppCacheClearResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/scan application AppScan
+// Return the image scan report of the last staging of the named `App` in the `Namespace`.
+// responses:
+//   200: AppScanResponse
+
+// swagger:parameters AppScan
+type AppScanParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppScanResponse
+type AppScanResponse struct {
+	// in: body
+	Body models.ScanReport
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/metrics application AppMetrics
+// Return the recorded CPU and memory usage of the named `App` in the `Namespace` over the
+// `range`, 24 hours by default.
+// responses:
+//   200: AppMetricsResponse
+
+// swagger:parameters AppMetrics
+type AppMetricsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: query
+	Range string `json:"range"`
+}
+
+// swagger:response AppMetricsResponse
+type AppMetricsResponse struct {
+	// in: body
+	Body models.AppMetricsResponse
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/events application AppEvents
+// Return the events timeline of the named `App` in the `Namespace`, oldest first. It merges
+// the kubernetes events of the app's pods, ingresses and staging jobs, the scaling events, the
+// helm release transitions, and the changes made through the API.
+// responses:
+//   200: AppEventsResponse
+
+// swagger:parameters AppEvents
+type AppEventsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppEventsResponse
+type AppEventsResponse struct {
+	// in: body
+	Body models.AppEventsResponse
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/events websocket AppEventsWs
+// Stream the events timeline of the named `App` in the `Namespace` over a websocket, one
+// event per message, followed by the new events as they appear.
+// responses:
+//   200: AppEventsWsResponse
+
+// swagger:parameters AppEventsWs
+type AppEventsWsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppEventsWsResponse
+type AppEventsWsResponse struct{}
+
 // swagger:route POST /namespaces/{Namespace}/applications/{App}/import-git application AppImportGit
 // Store the named `App` from a Git repo in the `Namespace`.
 // responses:
@@ -431,6 +555,27 @@ type AppDeployResponse struct {
 	Body models.DeployResponse
 }
 
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/promote application AppPromote
+// Deploy the staged image of the named `App` in the `Namespace` into the target namespace of the request, with the configuration of the `App`.
+// responses:
+//   200: AppPromoteResponse
+
+// swagger:parameters AppPromote
+type AppPromoteParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: body
+	Body models.AppPromoteRequest
+}
+
+// swagger:response AppPromoteResponse
+type AppPromoteResponse struct {
+	// in: body
+	Body models.AppPromoteResponse
+}
+
 // swagger:route PATCH /namespaces/{Namespace}/applications/{App} application AppUpdate
 // Patch the named `App` in the `Namespace`.
 // responses:
@@ -511,3 +656,176 @@ type AppExportResponse struct {
 	// in: body
 	Body models.Response
 }
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/git-trigger application AppGitTriggerShow
+// Return the push-to-deploy configuration of the named `App` in the `Namespace`, and its recent webhook deliveries.
+// responses:
+//   200: AppGitTriggerResponse
+
+// swagger:parameters AppGitTriggerShow
+type AppGitTriggerShowParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/git-trigger application AppGitTriggerEnable
+// Enable push-to-deploy for the named `App` in the `Namespace`. Returns a new webhook secret.
+// responses:
+//   200: AppGitTriggerResponse
+
+// swagger:parameters AppGitTriggerEnable
+type AppGitTriggerEnableParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppGitTriggerResponse
+type AppGitTriggerResponse struct {
+	// in: body
+	Body models.GitTrigger
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/applications/{App}/git-trigger application AppGitTriggerDisable
+// Disable push-to-deploy for the named `App` in the `Namespace`.
+// responses:
+//   200: AppGitTriggerDisableResponse
+
+// swagger:parameters AppGitTriggerDisable
+type AppGitTriggerDisableParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppGitTriggerDisableResponse
+type AppGitTriggerDisableResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/drains application AppLogDrainList
+// Return the log drains of the named `App` in the `Namespace`, and of the `Namespace`, with their health.
+// responses:
+//   200: LogDrainsResponse
+
+// swagger:parameters AppLogDrainList
+type AppLogDrainListParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response LogDrainsResponse
+type LogDrainsResponse struct {
+	// in: body
+	Body models.LogDrainsResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/drains application AppLogDrainAdd
+// Add a log drain to the named `App` in the `Namespace`.
+// responses:
+//   201: AppLogDrainAddResponse
+
+// swagger:parameters AppLogDrainAdd
+type AppLogDrainAddParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: body
+	Body models.LogDrainAddRequest
+}
+
+// swagger:response AppLogDrainAddResponse
+type AppLogDrainAddResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/applications/{App}/drains/{Drain} application AppLogDrainRemove
+// Remove the named log `Drain` from the named `App` in the `Namespace`.
+// responses:
+//   200: AppLogDrainRemoveResponse
+
+// swagger:parameters AppLogDrainRemove
+type AppLogDrainRemoveParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: path
+	Drain string
+}
+
+// swagger:response AppLogDrainRemoveResponse
+type AppLogDrainRemoveResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/alerts application AppAlertList
+// Return the alerts of the named `App` in the `Namespace`, with their state.
+// responses:
+//   200: AppAlertsResponse
+
+// swagger:parameters AppAlertList
+type AppAlertListParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppAlertsResponse
+type AppAlertsResponse struct {
+	// in: body
+	Body models.AppAlertsResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/alerts application AppAlertAdd
+// Add an alert to the named `App` in the `Namespace`.
+// responses:
+//   201: AppAlertAddResponse
+
+// swagger:parameters AppAlertAdd
+type AppAlertAddParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: body
+	Body models.AppAlertAddRequest
+}
+
+// swagger:response AppAlertAddResponse
+type AppAlertAddResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/applications/{App}/alerts/{Alert} application AppAlertRemove
+// Remove the named `Alert` from the named `App` in the `Namespace`.
+// responses:
+//   200: AppAlertRemoveResponse
+
+// swagger:parameters AppAlertRemove
+type AppAlertRemoveParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: path
+	Alert string
+}
+
+// swagger:response AppAlertRemoveResponse
+type AppAlertRemoveResponse struct {
+	// in: body
+	Body models.Response
+}
@@ -88,6 +88,145 @@ type NamespaceShowResponse struct {
 	Body models.Namespace
 }
 
+// swagger:route GET /namespaces/{Namespace}/quota namespace NamespaceQuotaShow
+// Return the quota of the named `Namespace`, and its current usage.
+// responses:
+//   200: NamespaceQuotaResponse
+
+// swagger:parameters NamespaceQuotaShow
+type NamespaceQuotaShowParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:route PATCH /namespaces/{Namespace}/quota namespace NamespaceQuotaUpdate
+// Change the quota of the named `Namespace`. Restricted to admins.
+// responses:
+//   200: NamespaceQuotaResponse
+
+// swagger:parameters NamespaceQuotaUpdate
+type NamespaceQuotaUpdateParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.NamespaceQuotaUpdateRequest
+}
+
+// swagger:response NamespaceQuotaResponse
+type NamespaceQuotaResponse struct {
+	// in: body
+	Body models.NamespaceQuotaResponse
+}
+
+// swagger:route GET /namespaces/{Namespace}/scanpolicy namespace NamespaceScanPolicyShow
+// Return the image scan policy of the named `Namespace`.
+// responses:
+//   200: NamespaceScanPolicyResponse
+
+// swagger:parameters NamespaceScanPolicyShow
+type NamespaceScanPolicyShowParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:route PATCH /namespaces/{Namespace}/scanpolicy namespace NamespaceScanPolicyUpdate
+// Change the image scan policy of the named `Namespace`. Restricted to admins.
+// responses:
+//   200: NamespaceScanPolicyResponse
+
+// swagger:parameters NamespaceScanPolicyUpdate
+type NamespaceScanPolicyUpdateParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.NamespaceScanPolicyUpdateRequest
+}
+
+// swagger:response NamespaceScanPolicyResponse
+type NamespaceScanPolicyResponse struct {
+	// in: body
+	Body models.NamespaceScanPolicy
+}
+
+// swagger:route GET /namespaces/{Namespace}/logretention namespace NamespaceLogRetentionShow
+// Return the log retention of the named `Namespace`.
+// responses:
+//   200: NamespaceLogRetentionResponse
+
+// swagger:parameters NamespaceLogRetentionShow
+type NamespaceLogRetentionShowParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:route PATCH /namespaces/{Namespace}/logretention namespace NamespaceLogRetentionUpdate
+// Change the log retention of the named `Namespace`. Restricted to admins.
+// responses:
+//   200: NamespaceLogRetentionResponse
+
+// swagger:parameters NamespaceLogRetentionUpdate
+type NamespaceLogRetentionUpdateParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.NamespaceLogRetentionUpdateRequest
+}
+
+// swagger:response NamespaceLogRetentionResponse
+type NamespaceLogRetentionResponse struct {
+	// in: body
+	Body models.NamespaceLogRetention
+}
+
+// swagger:route GET /namespaces/{Namespace}/drains namespace NamespaceLogDrainList
+// Return the log drains of the named `Namespace`, with their health.
+// responses:
+//   200: LogDrainsResponse
+
+// swagger:parameters NamespaceLogDrainList
+type NamespaceLogDrainListParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:route POST /namespaces/{Namespace}/drains namespace NamespaceLogDrainAdd
+// Add a log drain for all applications of the named `Namespace`. Restricted to admins.
+// responses:
+//   201: NamespaceLogDrainAddResponse
+
+// swagger:parameters NamespaceLogDrainAdd
+type NamespaceLogDrainAddParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.LogDrainAddRequest
+}
+
+// swagger:response NamespaceLogDrainAddResponse
+type NamespaceLogDrainAddResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/drains/{Drain} namespace NamespaceLogDrainRemove
+// Remove the named log `Drain` from the named `Namespace`. Restricted to admins.
+// responses:
+//   200: NamespaceLogDrainRemoveResponse
+
+// swagger:parameters NamespaceLogDrainRemove
+type NamespaceLogDrainRemoveParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Drain string
+}
+
+// swagger:response NamespaceLogDrainRemoveResponse
+type NamespaceLogDrainRemoveResponse struct {
+	// in: body
+	Body models.Response
+}
+
 // swagger:route GET /namespacematches/{Pattern} namespace NamespaceMatch
 // Return list of names for all controlled namespaces whose name matches the prefix `Pattern`.
 // responses:
@@ -114,3 +253,28 @@ type NamespaceMatchResponse struct {
 type NamespaceMatch0Param struct{}
 
 // response: See NamespaceMatch.
+
+// swagger:route GET /namespaces/{Namespace}/support-bundle namespace NamespaceSupportBundle
+// Return a support bundle for the applications of the `Namespace`, as a tar.gz archive.
+// It contains the manifests, helm values, pod descriptions, events, recent staging logs
+// and ingresses of the applications, with environment variable values redacted.
+// responses:
+//   200: NamespaceSupportBundleResponse
+
+// swagger:parameters NamespaceSupportBundle
+type NamespaceSupportBundleParam struct {
+	// in: path
+	Namespace string
+	// Names of the applications to include. Default is all applications of the namespace.
+	// in: query
+	Applications []string `json:"applications[]"`
+	// Limit the staging logs to the last N lines
+	// in: query
+	Tail string `json:"tail"`
+}
+
+// swagger:response NamespaceSupportBundleResponse
+type NamespaceSupportBundleResponse struct {
+	// in: body
+	Body []byte
+}
@@ -0,0 +1,71 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs
+
+import "github.com/epinio/epinio/pkg/api/core/v1/models"
+
+//go:generate swagger generate spec
+
+// swagger:route GET /namespaces/{Namespace}/registrycredentials registrycredential RegistryCredentials
+// Return list of the registry credentials in the `Namespace`.
+// responses:
+//   200: RegistryCredentialsResponse
+
+// swagger:parameters RegistryCredentials
+type RegistryCredentialsParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:response RegistryCredentialsResponse
+type RegistryCredentialsResponse struct {
+	// in: body
+	Body models.RegistryCredentialList
+}
+
+// swagger:route POST /namespaces/{Namespace}/registrycredentials registrycredential RegistryCredentialCreate
+// Create the posted new registry credentials in the `Namespace`.
+// responses:
+//   200: RegistryCredentialCreateResponse
+
+// swagger:parameters RegistryCredentialCreate
+type RegistryCredentialCreateParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.RegistryCredentialCreateRequest
+}
+
+// swagger:response RegistryCredentialCreateResponse
+type RegistryCredentialCreateResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/registrycredentials/{RegistryCredential} registrycredential RegistryCredentialDelete
+// Delete the named `RegistryCredential` in the `Namespace`.
+// responses:
+//   200: RegistryCredentialDeleteResponse
+
+// swagger:parameters RegistryCredentialDelete
+type RegistryCredentialDeleteParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	RegistryCredential string
+}
+
+// swagger:response RegistryCredentialDeleteResponse
+type RegistryCredentialDeleteResponse struct {
+	// in: body
+	Body models.Response
+}
@@ -25,3 +25,25 @@ type NodeReportResponse struct {
 	// in: body
 	Body models.ReportResponse
 }
+
+// Usage Report
+
+// swagger:route GET /report/usage report UsageReport
+// Return the CPU-hours and GB-hours used by the applications of each namespace over the
+// `range`, 30 days by default. Use `format=csv` for a CSV report.
+// responses:
+//   200: UsageReportResponse
+
+// swagger:parameters UsageReport
+type UsageReportParam struct {
+	// in: query
+	Range string `json:"range"`
+	// in: query
+	Format string `json:"format"`
+}
+
+// swagger:response UsageReportResponse
+type UsageReportResponse struct {
+	// in: body
+	Body models.UsageReportResponse
+}
@@ -0,0 +1,38 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs
+
+import "github.com/epinio/epinio/pkg/api/core/v1/models"
+
+// Staging Queue
+
+// swagger:route GET /staging/queue staging StagingQueue
+// Return the stagings waiting for a free slot, in order, and the staging limits.
+// responses:
+//   200: StagingQueueResponse
+
+// swagger:route POST /staging/queue staging StagingQueueMove
+// Move a queued staging to a new position in the queue.
+// responses:
+//   200: StagingQueueResponse
+
+// swagger:parameters StagingQueueMove
+type StagingQueueMoveParam struct {
+	// in: body
+	Move models.StagingQueueMoveRequest
+}
+
+// swagger:response StagingQueueResponse
+type StagingQueueResponse struct {
+	// in: body
+	Body models.StagingQueue
+}
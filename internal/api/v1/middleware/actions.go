@@ -0,0 +1,64 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/tracing"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// actionEventTimeout limits the recording of an action event.
+const actionEventTimeout = 10 * time.Second
+
+// AppActions returns a gin middleware recording the successful changes of applications made
+// through the API as events of the application, for its timeline. Changes are requests other
+// than GET on the routes of a single application. The events are named after the route, see
+// v1.Routes, and recorded in the background, without delaying the response.
+func AppActions() gin.HandlerFunc {
+	names := RouteNames()
+
+	return func(c *gin.Context) {
+		c.Next()
+
+		appName := c.Param("app")
+		if appName == "" || c.Request.Method == http.MethodGet || c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+
+		ctx := c.Request.Context()
+		appRef := models.NewAppRef(appName, c.Param("namespace"))
+		action := routeName(names, c)
+		username := requestctx.User(ctx).Username
+		logger := requestctx.Logger(ctx)
+
+		go func() {
+			ctx, cancel := context.WithTimeout(tracing.Detach(ctx), actionEventTimeout)
+			defer cancel()
+
+			cluster, err := kubernetes.GetCluster(ctx)
+			if err == nil {
+				err = application.EmitActionEvent(ctx, cluster, appRef, action, username)
+			}
+			if err != nil {
+				logger.Infow("failed to record app action", "error", err, "action", action, "app", appRef.Name, "namespace", appRef.Namespace)
+			}
+		}()
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"time"
+
+	v1 "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/internal/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics returns a gin middleware recording the count and latency of requests. Requests
+// are labeled by the name of their route, see v1.Routes and v1.WsRoutes, or by the path
+// pattern for the few routes registered without a name.
+func Metrics() gin.HandlerFunc {
+	names := RouteNames()
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		metrics.ObserveRequest(routeName(names, c), c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// routeName returns the name of the route matched by the request, or its path pattern if
+// the route has no name.
+func routeName(names map[string]string, c *gin.Context) string {
+	route := c.FullPath()
+	if name, found := names[c.Request.Method+" "+route]; found {
+		return name
+	}
+	return route
+}
+
+// RouteNames returns the names of the API routes, keyed by method and full path pattern.
+func RouteNames() map[string]string {
+	names := map[string]string{
+		v1.GitWebhook.Method + " " + v1.Root + v1.GitWebhook.Path: "GitWebhook",
+	}
+	for name, route := range v1.Routes {
+		names[route.Method+" "+v1.Root+route.Path] = name
+	}
+	for name, route := range v1.WsRoutes {
+		names[route.Method+" "+v1.WsRoot+route.Path] = name
+	}
+	return names
+}
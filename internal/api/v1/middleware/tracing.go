@@ -0,0 +1,54 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/epinio/epinio/internal/tracing"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Tracing returns a gin middleware starting a server span for each request, as child of
+// the trace context sent by the client, if any. The span is named after the route of the
+// request, and placed into the request context for the handlers to extend.
+func Tracing() gin.HandlerFunc {
+	names := RouteNames()
+
+	return func(c *gin.Context) {
+		ctx := tracing.Extract(c.Request.Context(), c.Request.Header)
+
+		route := routeName(names, c)
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx, span := tracing.StartServer(ctx, route,
+			attribute.String("http.request.method", c.Request.Method),
+			attribute.String("http.route", c.FullPath()),
+			attribute.String("url.path", c.Request.URL.Path),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
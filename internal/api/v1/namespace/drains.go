@@ -0,0 +1,124 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"net/http"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/logdrain"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogDrainList handles the API endpoint GET /namespaces/:namespace/drains
+// It returns the log drains of the specified namespace, with their health
+func LogDrainList(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	drains, err := logdrain.NamespaceDrains(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.LogDrainsResponse(logdrain.Redact(drains)))
+	return nil
+}
+
+// LogDrainAdd handles the API endpoint POST /namespaces/:namespace/drains
+// It adds a log drain for all applications of the specified namespace. Only admins are
+// allowed to do so.
+func LogDrainAdd(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+
+	user := requestctx.User(ctx)
+	if !user.IsAdmin() {
+		return apierror.NewAPIError("user unauthorized, only admins can change namespace log drains", http.StatusForbidden)
+	}
+
+	var addRequest models.LogDrainAddRequest
+	err := c.BindJSON(&addRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	drain := models.LogDrain{Name: addRequest.Name, URL: addRequest.URL}
+	if err := logdrain.Validate(drain); err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	log.Infow("adding namespace log drain", "namespace", namespace, "drain", drain.Name, "url", drain.RedactedURL())
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = logdrain.NamespaceDrainAdd(ctx, cluster, namespace, drain)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.Created(c)
+	return nil
+}
+
+// LogDrainRemove handles the API endpoint DELETE /namespaces/:namespace/drains/:drain
+// It removes the named log drain from the specified namespace. Only admins are allowed to
+// do so.
+func LogDrainRemove(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+	drainName := c.Param("drain")
+
+	user := requestctx.User(ctx)
+	if !user.IsAdmin() {
+		return apierror.NewAPIError("user unauthorized, only admins can change namespace log drains", http.StatusForbidden)
+	}
+
+	log.Infow("removing namespace log drain", "namespace", namespace, "drain", drainName)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = logdrain.NamespaceDrainRemove(ctx, cluster, namespace, drainName)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/epinio/epinio/internal/services"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaShow handles the API endpoint GET /namespaces/:namespace/quota
+// It returns the quota of the specified namespace, together with the current usage
+func QuotaShow(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	quota, err := namespaces.GetQuota(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	usage, err := quotaUsage(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.NamespaceQuotaResponse{
+		Namespace: namespace,
+		Quota:     quota,
+		Usage:     usage,
+	})
+	return nil
+}
+
+// QuotaUpdate handles the API endpoint PATCH /namespaces/:namespace/quota
+// It changes the quota of the specified namespace. Only admins are allowed to do so.
+func QuotaUpdate(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+
+	user := requestctx.User(ctx)
+	if !user.IsAdmin() {
+		return apierror.NewAPIError("user unauthorized, only admins can change namespace quotas", http.StatusForbidden)
+	}
+
+	var updateRequest models.NamespaceQuotaUpdateRequest
+	err := c.BindJSON(&updateRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	log.Infow("updating namespace quota", "namespace", namespace, "request", updateRequest)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	quota, err := namespaces.UpdateQuota(ctx, cluster, namespace, updateRequest)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	usage, err := quotaUsage(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.NamespaceQuotaResponse{
+		Namespace: namespace,
+		Quota:     quota,
+		Usage:     usage,
+	})
+	return nil
+}
+
+// quotaUsage collects the resource usage of the namespace from applications, services, and
+// the blob store. A failure to reach the blob store is logged and leaves the blob storage
+// usage at zero.
+func quotaUsage(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (models.NamespaceQuotaUsage, error) {
+	log := requestctx.Logger(ctx)
+
+	usage, err := application.QuotaUsage(ctx, cluster, namespace)
+	if err != nil {
+		return usage, err
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return usage, err
+	}
+
+	usage.Services, err = kubeServiceClient.CountInNamespace(ctx, namespace)
+	if err != nil {
+		return usage, err
+	}
+
+	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		log.Infow("blob storage usage not available", "namespace", namespace, "error", err)
+		return usage, nil
+	}
+	manager, err := s3manager.New(connectionDetails)
+	if err != nil {
+		log.Infow("blob storage usage not available", "namespace", namespace, "error", err)
+		return usage, nil
+	}
+
+	usage.BlobStorageBytes, err = namespaces.BlobStorageUsage(ctx, cluster, manager, namespace)
+	if err != nil {
+		log.Infow("blob storage usage not available", "namespace", namespace, "error", err)
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,97 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"net/http"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// ScanPolicyShow handles the API endpoint GET /namespaces/:namespace/scanpolicy
+// It returns the image scan policy of the specified namespace, and the threshold in effect
+func ScanPolicyShow(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	threshold, err := namespaces.GetScanThreshold(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, scanPolicy(namespace, threshold))
+	return nil
+}
+
+// ScanPolicyUpdate handles the API endpoint PATCH /namespaces/:namespace/scanpolicy
+// It changes the image scan policy of the specified namespace. Only admins are allowed to do so.
+func ScanPolicyUpdate(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+
+	user := requestctx.User(ctx)
+	if !user.IsAdmin() {
+		return apierror.NewAPIError("user unauthorized, only admins can change namespace scan policies", http.StatusForbidden)
+	}
+
+	var updateRequest models.NamespaceScanPolicyUpdateRequest
+	err := c.BindJSON(&updateRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	log.Infow("updating namespace scan policy", "namespace", namespace, "request", updateRequest)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	threshold, err := namespaces.SetScanThreshold(ctx, cluster, namespace, updateRequest.Threshold)
+	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, scanPolicy(namespace, threshold))
+	return nil
+}
+
+// scanPolicy returns the scan policy of the namespace, with the global policy applied.
+func scanPolicy(namespace, threshold string) models.NamespaceScanPolicy {
+	effective := threshold
+	if effective == "" {
+		effective = viper.GetString("scan-block-severity")
+	}
+
+	return models.NamespaceScanPolicy{
+		Namespace: namespace,
+		Threshold: threshold,
+		Effective: effective,
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrycredential
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/gin-gonic/gin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Create handles the API endpoint /namespaces/:namespace/registrycredentials (POST).
+// It creates the registry credentials with the specified name in the namespace.
+func Create(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	var request models.RegistryCredentialCreateRequest
+	err := c.BindJSON(&request)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	if request.Name == "" {
+		return apierror.NewBadRequestError("name of registry credential to create not found")
+	}
+	errorMsgs := validation.IsDNS1123Label(request.Name)
+	if len(errorMsgs) > 0 {
+		return apierror.NewBadRequestErrorf("Registry credentials' name must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name', or '123-abc').")
+	}
+	if request.URL == "" {
+		return apierror.NewBadRequestError("registry url of registry credential not found")
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	secret, err := registry.NewPullCredentialSecret(namespace, request.Name,
+		request.URL, request.Username, request.Password)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = cluster.CreateSecret(ctx, namespace, secret)
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return apierror.NewConflictError("registry credential", request.Name)
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.Created(c)
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrycredential
+
+import (
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/gin-gonic/gin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+)
+
+// Delete handles the API endpoint /namespaces/:namespace/registrycredentials/:registrycredential (DELETE).
+// It destroys the registry credentials specified by their name. Credentials still used by
+// applications of the namespace are kept.
+func Delete(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	name := c.Param("registrycredential")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	users, err := application.RegistryCredentialUsers(ctx, cluster, namespace, name)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if len(users) > 0 {
+		return apierror.NewBadRequestError("registry credential used by applications").
+			WithDetails(strings.Join(users, ","))
+	}
+
+	err = cluster.DeleteSecret(ctx, namespace, registry.PullCredentialSecretName(name))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return apierror.NewNotFoundError("registry credential", name)
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
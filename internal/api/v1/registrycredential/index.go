@@ -0,0 +1,56 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registrycredential contains the API handlers for the registry credentials of a
+// namespace. The credentials give applications deployed from prebuilt images access to
+// private registries.
+package registrycredential
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Index handles the API endpoint /namespaces/:namespace/registrycredentials (GET)
+// It returns a list of all registry credentials of the namespace. Passwords are not returned.
+func Index(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	credentials, err := registry.PullCredentials(cluster.Kubectl.CoreV1().Secrets(namespace))
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	result := models.RegistryCredentialList{}
+	for _, credential := range credentials {
+		result = append(result, models.RegistryCredential{
+			Name:      credential.Name,
+			Namespace: credential.Namespace,
+			URL:       credential.URL,
+			Username:  credential.Username,
+			CreatedAt: credential.CreatedAt,
+		})
+	}
+
+	response.OKReturn(c, result)
+	return nil
+}
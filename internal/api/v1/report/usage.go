@@ -0,0 +1,112 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/usage"
+	"github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultUsageRange is the range of the usage report when none is asked for.
+const defaultUsageRange = 30 * 24 * time.Hour
+
+// Usage returns the chargeback report of the CPU-hours and GB-hours used by the
+// applications of each namespace, over the range given by the `range` query parameter,
+// 30 days by default. It is JSON by default. To receive CSV, use ?format=csv.
+func Usage(c *gin.Context) errors.APIErrors {
+	span, err := usage.ParseRange(c.Query("range"), defaultUsageRange)
+	if err != nil {
+		return errors.NewBadRequestErrorf("bad range: %s", err.Error())
+	}
+
+	now := time.Now().UTC()
+	report := buildUsageReport(now, span, usage.History.Usage(now, span))
+
+	if strings.EqualFold(c.Query("format"), "csv") {
+		body, err := renderUsageCSV(report)
+		if err != nil {
+			return errors.InternalError(err)
+		}
+		c.Data(200, "text/csv; charset=utf-8", body)
+		return nil
+	}
+
+	response.OKReturn(c, report)
+	return nil
+}
+
+func buildUsageReport(now time.Time, span time.Duration, usages map[string]map[string]usage.Usage) models.UsageReportResponse {
+	report := models.UsageReportResponse{
+		GeneratedAt: now.Format(time.RFC3339),
+		Range:       span.String(),
+		Namespaces:  []models.NamespaceUsage{},
+	}
+
+	for namespace, apps := range usages {
+		namespaceUsage := models.NamespaceUsage{
+			Namespace:    namespace,
+			Applications: []models.AppUsage{},
+		}
+		for app, appUsage := range apps {
+			namespaceUsage.CPUHours += appUsage.CPUHours
+			namespaceUsage.GBHours += appUsage.GBHours
+			namespaceUsage.Applications = append(namespaceUsage.Applications, models.AppUsage{
+				Name:     app,
+				CPUHours: appUsage.CPUHours,
+				GBHours:  appUsage.GBHours,
+			})
+		}
+		sort.Slice(namespaceUsage.Applications, func(i, j int) bool {
+			return namespaceUsage.Applications[i].Name < namespaceUsage.Applications[j].Name
+		})
+		report.Namespaces = append(report.Namespaces, namespaceUsage)
+	}
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		return report.Namespaces[i].Namespace < report.Namespaces[j].Namespace
+	})
+
+	return report
+}
+
+// renderUsageCSV renders the report with a row per application, for spreadsheets and
+// billing tools.
+func renderUsageCSV(report models.UsageReportResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	rows := [][]string{{"namespace", "application", "cpu_hours", "gb_hours"}}
+	for _, namespace := range report.Namespaces {
+		for _, app := range namespace.Applications {
+			rows = append(rows, []string{
+				namespace.Namespace,
+				app.Name,
+				strconv.FormatFloat(app.CPUHours, 'f', 3, 64),
+				strconv.FormatFloat(app.GBHours, 'f', 3, 64),
+			})
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,50 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/epinio/epinio/internal/usage"
+)
+
+func TestBuildUsageReport(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	report := buildUsageReport(now, 24*time.Hour, map[string]map[string]usage.Usage{
+		"workspace": {
+			"web":    {CPUHours: 2, GBHours: 4},
+			"api":    {CPUHours: 1, GBHours: 0.5},
+			"worker": {CPUHours: 0.25, GBHours: 1},
+		},
+		"billing": {
+			"cron": {CPUHours: 0.5, GBHours: 0.5},
+		},
+	})
+
+	if report.Range != "24h0m0s" {
+		t.Fatalf("expected range 24h0m0s, got %s", report.Range)
+	}
+	if len(report.Namespaces) != 2 || report.Namespaces[0].Namespace != "billing" {
+		t.Fatalf("expected namespaces sorted by name, got %+v", report.Namespaces)
+	}
+
+	workspace := report.Namespaces[1]
+	if workspace.CPUHours != 3.25 || workspace.GBHours != 5.5 {
+		t.Fatalf("expected totals 3.25 CPU-hours and 5.5 GB-hours, got %v and %v", workspace.CPUHours, workspace.GBHours)
+	}
+	if len(workspace.Applications) != 3 || workspace.Applications[0].Name != "api" {
+		t.Fatalf("expected applications sorted by name, got %+v", workspace.Applications)
+	}
+
+	body, err := renderUsageCSV(report)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected a header and 4 rows, got %q", lines)
+	}
+	if lines[1] != "billing,cron,0.500,0.500" {
+		t.Fatalf("unexpected first row %q", lines[1])
+	}
+}
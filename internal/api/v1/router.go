@@ -31,6 +31,7 @@ import (
 	"github.com/epinio/epinio/internal/api/v1/gitconfig"
 	"github.com/epinio/epinio/internal/api/v1/gitproxy"
 	"github.com/epinio/epinio/internal/api/v1/namespace"
+	"github.com/epinio/epinio/internal/api/v1/registrycredential"
 	"github.com/epinio/epinio/internal/api/v1/report"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/api/v1/service"
@@ -101,12 +102,15 @@ func put(path string, h gin.HandlerFunc) routes.Route {
 var AdminRoutes map[string]struct{} = map[string]struct{}{
 	"/api/v1/support-bundle": {},
 	"/api/v1/report/nodes":   {},
+	"/api/v1/report/usage":   {},
+	"/api/v1/staging/queue":  {},
 }
 
 var Routes = routes.NamedRoutes{
 	"AuthToken": get("/authtoken", errorHandler(AuthToken)),
 
-	"NodeReport": get("/report/nodes", errorHandler(report.Nodes)),
+	"NodeReport":  get("/report/nodes", errorHandler(report.Nodes)),
+	"UsageReport": get("/report/usage", errorHandler(report.Usage)), // See report/usage.go
 
 	// app controller files see application/*.go
 
@@ -133,6 +137,26 @@ var Routes = routes.NamedRoutes{
 	"AppSource":       get("/namespaces/:namespace/applications/:app/source", errorHandler(application.GetSource)),
 	"AppValidateCV":   get("/namespaces/:namespace/applications/:app/validate-cv", errorHandler(application.ValidateChartValues)),
 	"AppExport":       post("/namespaces/:namespace/applications/:app/export", errorHandler(application.ExportToRegistry)),
+	"AppCacheClear":   post("/namespaces/:namespace/applications/:app/cache/clear", errorHandler(application.CacheClear)), // See cache.go
+	"AppScan":         get("/namespaces/:namespace/applications/:app/scan", errorHandler(application.Scan)),               // See scan.go
+	"AppMetrics":      get("/namespaces/:namespace/applications/:app/metrics", errorHandler(application.Metrics)),         // See metrics.go
+	"AppEvents":       get("/namespaces/:namespace/applications/:app/events", errorHandler(application.Events)),           // See events.go
+	"AppPromote":      post("/namespaces/:namespace/applications/:app/promote", errorHandler(application.Promote)),
+
+	// See gittrigger.go
+	"AppGitTriggerShow":    get("/namespaces/:namespace/applications/:app/git-trigger", errorHandler(application.GitTriggerShow)),
+	"AppGitTriggerEnable":  post("/namespaces/:namespace/applications/:app/git-trigger", errorHandler(application.GitTriggerEnable)),
+	"AppGitTriggerDisable": delete("/namespaces/:namespace/applications/:app/git-trigger", errorHandler(application.GitTriggerDisable)),
+
+	// See drains.go
+	"AppLogDrainList":   get("/namespaces/:namespace/applications/:app/drains", errorHandler(application.LogDrainList)),
+	"AppLogDrainAdd":    post("/namespaces/:namespace/applications/:app/drains", errorHandler(application.LogDrainAdd)),
+	"AppLogDrainRemove": delete("/namespaces/:namespace/applications/:app/drains/:drain", errorHandler(application.LogDrainRemove)),
+
+	// See alerts.go
+	"AppAlertList":   get("/namespaces/:namespace/applications/:app/alerts", errorHandler(application.AlertList)),
+	"AppAlertAdd":    post("/namespaces/:namespace/applications/:app/alerts", errorHandler(application.AlertAdd)),
+	"AppAlertRemove": delete("/namespaces/:namespace/applications/:app/alerts/:alert", errorHandler(application.AlertRemove)),
 
 	"AppMatch":  get("/namespaces/:namespace/appsmatches/:pattern", errorHandler(application.Match)),
 	"AppMatch0": get("/namespaces/:namespace/appsmatches", errorHandler(application.Match)),
@@ -160,6 +184,21 @@ var Routes = routes.NamedRoutes{
 	"NamespaceDelete":      delete("/namespaces/:namespace", errorHandler(namespace.Delete)),
 	"NamespaceBatchDelete": delete("/namespaces", errorHandler(namespace.Delete)),
 	"NamespaceShow":        get("/namespaces/:namespace", errorHandler(namespace.Show)),
+	"NamespaceQuotaShow":   get("/namespaces/:namespace/quota", errorHandler(namespace.QuotaShow)),
+	"NamespaceQuotaUpdate": patch("/namespaces/:namespace/quota", errorHandler(namespace.QuotaUpdate)),
+
+	// See scanpolicy.go
+	"NamespaceScanPolicyShow":   get("/namespaces/:namespace/scanpolicy", errorHandler(namespace.ScanPolicyShow)),
+	"NamespaceScanPolicyUpdate": patch("/namespaces/:namespace/scanpolicy", errorHandler(namespace.ScanPolicyUpdate)),
+
+	// See logretention.go
+	"NamespaceLogRetentionShow":   get("/namespaces/:namespace/logretention", errorHandler(namespace.LogRetentionShow)),
+	"NamespaceLogRetentionUpdate": patch("/namespaces/:namespace/logretention", errorHandler(namespace.LogRetentionUpdate)),
+
+	// See drains.go
+	"NamespaceLogDrainList":   get("/namespaces/:namespace/drains", errorHandler(namespace.LogDrainList)),
+	"NamespaceLogDrainAdd":    post("/namespaces/:namespace/drains", errorHandler(namespace.LogDrainAdd)),
+	"NamespaceLogDrainRemove": delete("/namespaces/:namespace/drains/:drain", errorHandler(namespace.LogDrainRemove)),
 
 	// Note, the second registration catches calls with an empty pattern!
 	"NamespacesMatch":  get("/namespacematches/:pattern", errorHandler(namespace.Match)),
@@ -254,16 +293,32 @@ var Routes = routes.NamedRoutes{
 	"ExportregistriesMatch":  get("/exportregistrymatches/:pattern", errorHandler(exportregistry.Match)),
 	"ExportregistriesMatch0": get("/exportregistrymatches", errorHandler(exportregistry.Match)),
 
+	// Registry credentials of a namespace (auth for private image registries) - List, create, and delete.
+	"RegistryCredentials":      get("/namespaces/:namespace/registrycredentials", errorHandler(registrycredential.Index)),
+	"RegistryCredentialCreate": post("/namespaces/:namespace/registrycredentials", errorHandler(registrycredential.Create)),
+	"RegistryCredentialDelete": delete("/namespaces/:namespace/registrycredentials/:registrycredential", errorHandler(registrycredential.Delete)),
+
 	"GitProxy": post("/gitproxy", errorHandler(gitproxy.ProxyHandler)),
 
 	// Support bundle
-	"SupportBundle": get("/support-bundle", errorHandler(supportbundle.Bundle)),
+	"SupportBundle":          get("/support-bundle", errorHandler(supportbundle.Bundle)),
+	"NamespaceSupportBundle": get("/namespaces/:namespace/support-bundle", errorHandler(supportbundle.NamespaceBundle)),
+
+	// Staging queue, see application/stagingqueue.go
+	"StagingQueue":     get("/staging/queue", errorHandler(application.StagingQueueShow)),
+	"StagingQueueMove": post("/staging/queue", errorHandler(application.StagingQueueMove)),
 }
 
+// GitWebhook is the endpoint receiving git push webhooks. It is registered by the server
+// outside of the authenticated routes, as deliveries are verified against the secret of
+// the application instead.
+var GitWebhook = post(application.GitWebhookPath, errorHandler(application.GitWebhook))
+
 var WsRoutes = routes.NamedRoutes{
 	"AppExec":            get("/namespaces/:namespace/applications/:app/exec", errorHandler(application.Exec)),
 	"AppPortForward":     get("/namespaces/:namespace/applications/:app/portforward", errorHandler(application.PortForward)),
 	"AppLogs":            get("/namespaces/:namespace/applications/:app/logs", application.Logs),
+	"AppEventsWs":        get("/namespaces/:namespace/applications/:app/events", application.EventsWebsocket),
 	"ServicePortForward": get("/namespaces/:namespace/services/:service/portforward", errorHandler(service.PortForward)),
 	"StagingLogs":        get("/namespaces/:namespace/staging/:stage_id/logs", application.Logs),
 	"StagingCompleteWs":  get("/namespaces/:namespace/staging/:stage_id/complete", application.StagedWebsocket),
@@ -92,6 +92,9 @@ func Create(c *gin.Context) apierror.APIErrors {
 			return WhenFullyDeployed(ctx, cluster, namespace, createRequest.Name)
 		})
 	if err != nil {
+		if apiErr, ok := err.(apierror.APIError); ok {
+			return apiErr
+		}
 		return apierror.InternalError(err)
 	}
 
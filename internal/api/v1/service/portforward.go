@@ -17,6 +17,7 @@ import (
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/metrics"
 	"github.com/epinio/epinio/internal/api/v1/proxy"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -57,6 +58,7 @@ func PortForward(c *gin.Context) apierror.APIErrors {
 			logger.Errorw("failed to close connection", "error", err)
 		}
 	}()
+	defer metrics.StreamOpened(metrics.StreamPortForward)()
 
 	conn := wconn.UnderlyingConn()
 
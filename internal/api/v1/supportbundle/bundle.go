@@ -27,6 +27,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
 )
 
 const (
@@ -140,6 +141,12 @@ func Bundle(c *gin.Context) apierror.APIErrors {
 		return apierror.NewAPIError("no logs could be collected from any Epinio components", http.StatusInternalServerError)
 	}
 
+	return sendArchive(ctx, c, log, collector, "epinio-support-bundle")
+}
+
+// sendArchive creates the archive of the collected files and streams it to the client, as
+// a download named after the prefix and the current time.
+func sendArchive(ctx context.Context, c *gin.Context, log *zap.SugaredLogger, collector *Collector, prefix string) apierror.APIErrors {
 	// Create tar archive
 	log.Infow("creating support bundle archive")
 	archivePath, err := collector.CreateArchive(ctx)
@@ -170,7 +177,7 @@ func Bundle(c *gin.Context) apierror.APIErrors {
 	}
 
 	// Generate filename with timestamp
-	filename := fmt.Sprintf("epinio-support-bundle-%s.tar.gz", time.Now().Format("20060102-150405"))
+	filename := fmt.Sprintf("%s-%s.tar.gz", prefix, time.Now().Format("20060102-150405"))
 
 	// Open the file for streaming
 	file, err := os.Open(archivePath) // nolint:gosec // archivePath from os.MkdirTemp in same function
@@ -0,0 +1,369 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/mask"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/helm"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NamespaceBundle handles the download of a support bundle scoped to the applications of a
+// single namespace. Unlike Bundle it is available to the developers of the namespace. For
+// each selected application (all, by default) it contains the manifest, the helm values,
+// the pod descriptions, the events, the logs of the recent stagings and the ingresses. All
+// environment variable values are redacted.
+func NamespaceBundle(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	log := requestctx.Logger(ctx).With("component", "support-bundle", "namespace", namespace)
+
+	log.Infow("starting namespace support bundle collection")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	tailLines := DefaultTailLines
+	if tailLinesStr := c.Query("tail"); tailLinesStr != "" {
+		if parsed, err := parseTailLines(tailLinesStr); err == nil {
+			tailLines = parsed
+		} else {
+			log.Infow("invalid tail parameter, using default", "input", tailLinesStr, "error", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, BundleCollectionTimeout)
+	defer cancel()
+
+	apps, apiErr := selectApps(ctx, cluster, namespace, c.QueryArray("applications[]"))
+	if apiErr != nil {
+		return apiErr
+	}
+	if len(apps) == 0 {
+		return apierror.NewBadRequestErrorf("namespace %s has no applications", namespace)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "epinio-support-bundle-*")
+	if err != nil {
+		return apierror.InternalError(errors.Wrap(err, "failed to create temporary directory"))
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			log.Errorw("failed to cleanup temporary directory", "error", err)
+		}
+	}()
+
+	collector := NewCollector(cluster, tmpDir, tailLines, log, viper.GetString("namespace"))
+
+	for i := range apps {
+		log.Infow("collecting application", "app", apps[i].Meta.Name)
+		if err := collector.CollectApplication(ctx, &apps[i]); err != nil {
+			log.Errorw("failed to collect application", "error", err, "app", apps[i].Meta.Name)
+		}
+	}
+
+	return sendArchive(ctx, c, log, collector, fmt.Sprintf("epinio-support-bundle-%s", namespace))
+}
+
+// selectApps returns the named applications of the namespace, or all of them when no names
+// are given.
+func selectApps(ctx context.Context, cluster *kubernetes.Cluster, namespace string, names []string) (models.AppList, apierror.APIErrors) {
+	if len(names) == 0 {
+		apps, err := application.List(ctx, cluster, namespace)
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+		return apps, nil
+	}
+
+	apps := models.AppList{}
+	for _, name := range names {
+		app, err := application.Lookup(ctx, cluster, namespace, name)
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+		if app == nil {
+			return nil, apierror.AppIsNotKnown(name)
+		}
+		apps = append(apps, *app)
+	}
+	return apps, nil
+}
+
+// CollectApplication collects the diagnostics of a single application into its own
+// directory of the bundle. Failures to collect a piece are logged, and do not stop the
+// collection of the others.
+func (c *Collector) CollectApplication(ctx context.Context, app *models.App) error {
+	appRef := app.Meta
+	dirName := filepath.Join("applications", appRef.Name)
+
+	if err := os.MkdirAll(filepath.Join(c.bundleDir, dirName), 0755); err != nil { // nolint:gosec // dirName from the app name
+		return errors.Wrap(err, "failed to create application directory")
+	}
+
+	redacted := redactApp(*app)
+
+	if err := c.writeJSON(dirName, "app.json", redacted); err != nil {
+		c.logger.Errorw("failed to write application details", "error", err, "app", appRef.Name)
+	}
+
+	manifest, err := yaml.Marshal(models.ApplicationManifest{
+		Name:          appRef.Name,
+		Namespace:     appRef.Namespace,
+		Configuration: redacted.Configuration,
+		Origin:        redacted.Origin,
+		Staging:       redacted.Staging,
+	})
+	if err == nil {
+		err = c.writeFile(dirName, "manifest.yaml", manifest)
+	}
+	if err != nil {
+		c.logger.Errorw("failed to write application manifest", "error", err, "app", appRef.Name)
+	}
+
+	if err := c.writeAppValues(dirName, appRef); err != nil {
+		c.logger.Errorw("failed to write application values", "error", err, "app", appRef.Name)
+	}
+
+	selector := metav1.ListOptions{LabelSelector: "app.kubernetes.io/name=" + appRef.Name}
+
+	pods, err := c.cluster.Kubectl.CoreV1().Pods(appRef.Namespace).List(ctx, selector)
+	if err == nil {
+		for i := range pods.Items {
+			redactPod(&pods.Items[i])
+		}
+		err = c.writeJSON(dirName, "pods.json", pods.Items)
+	}
+	if err != nil {
+		c.logger.Errorw("failed to write application pods", "error", err, "app", appRef.Name)
+	}
+
+	ingresses, err := c.cluster.Kubectl.NetworkingV1().Ingresses(appRef.Namespace).List(ctx, selector)
+	if err == nil {
+		for i := range ingresses.Items {
+			ingresses.Items[i].ManagedFields = nil
+		}
+		err = c.writeJSON(dirName, "ingresses.json", ingresses.Items)
+	}
+	if err != nil {
+		c.logger.Errorw("failed to write application ingresses", "error", err, "app", appRef.Name)
+	}
+
+	events, err := application.Events(ctx, c.cluster, appRef)
+	if err == nil {
+		err = c.writeFile(dirName, "events.log", []byte(formatEvents(events)))
+	}
+	if err != nil {
+		c.logger.Errorw("failed to write application events", "error", err, "app", appRef.Name)
+	}
+
+	if err := c.collectAppStagingLogs(ctx, filepath.Join(dirName, "staging"), appRef); err != nil {
+		c.logger.Errorw("failed to collect application staging logs", "error", err, "app", appRef.Name)
+	}
+
+	return nil
+}
+
+// writeAppValues writes the helm values of the application's release, with the values of
+// all environment variables redacted.
+func (c *Collector) writeAppValues(dirName string, appRef models.AppRef) error {
+	raw, err := helm.Values(c.cluster, appRef)
+	if err != nil {
+		return err
+	}
+
+	var values interface{}
+	if err := yaml.Unmarshal(raw, &values); err != nil {
+		return errors.Wrap(err, "failed to parse values")
+	}
+
+	redacted, err := yaml.Marshal(redactValues(values))
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize values")
+	}
+
+	return c.writeFile(dirName, "values.yaml", redacted)
+}
+
+// collectAppStagingLogs collects the logs of the recent staging jobs of the application.
+func (c *Collector) collectAppStagingLogs(ctx context.Context, dirName string, appRef models.AppRef) error {
+	selector := labels.SelectorFromSet(labels.Set{
+		"app.kubernetes.io/component": "staging",
+		"app.kubernetes.io/name":      appRef.Name,
+		"app.kubernetes.io/part-of":   appRef.Namespace,
+	})
+
+	jobs, err := c.cluster.ListJobs(ctx, c.namespace, selector.String())
+	if err != nil {
+		return errors.Wrap(err, "failed to list staging jobs")
+	}
+
+	cutoffTime := time.Now().Add(-RecentStagingJobsWindow)
+	for _, job := range jobs.Items {
+		if !job.CreationTimestamp.After(cutoffTime) {
+			continue
+		}
+
+		jobSelector := labels.SelectorFromSet(labels.Set{"job-name": job.Name})
+		if err := c.collectPodLogs(ctx, filepath.Join(dirName, job.Name), c.namespace, jobSelector, true); err != nil {
+			c.logger.Infow("no logs for staging job", "error", err, "job", job.Name, "app", appRef.Name)
+		}
+	}
+
+	return nil
+}
+
+// writeFile writes a file into a directory of the bundle.
+func (c *Collector) writeFile(dirName, name string, data []byte) error {
+	return os.WriteFile(filepath.Join(c.bundleDir, dirName, name), data, 0600) // nolint:gosec // path under bundleDir
+}
+
+// writeJSON writes the value as indented JSON into a directory of the bundle.
+func (c *Collector) writeJSON(dirName, name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return c.writeFile(dirName, name, data)
+}
+
+// formatEvents renders the events timeline of an application, one event per line.
+func formatEvents(events []models.AppEvent) string {
+	var b strings.Builder
+	for _, event := range events {
+		fmt.Fprintf(&b, "%s %s %s %s %s %s\n",
+			event.Time, event.Source, event.Type, event.Reason, event.Object, event.Message)
+	}
+	return b.String()
+}
+
+// redactApp returns a copy of the application with the values of all environment
+// variables and chart settings, and the user info of the git origin masked.
+func redactApp(app models.App) models.App {
+	app.Configuration.Environment = mask.MaskMap(app.Configuration.Environment)
+	app.Configuration.Settings = mask.MaskMap(app.Configuration.Settings)
+	if grouped := app.Configuration.EnvironmentGrouped; grouped != nil {
+		app.Configuration.EnvironmentGrouped = &models.EnvVariableGroupedResponse{
+			User:    mask.MaskMap(grouped.User),
+			Service: mask.MaskMap(grouped.Service),
+		}
+	}
+	app.Configuration.Sidecars = redactContainers(app.Configuration.Sidecars)
+	app.Configuration.InitContainers = redactContainers(app.Configuration.InitContainers)
+	if app.Origin.Git != nil {
+		git := *app.Origin.Git
+		git.URL = redactURL(git.URL)
+		app.Origin.Git = &git
+	}
+	return app
+}
+
+// redactURL masks the user name and password of the url, if any. Urls which do not parse,
+// e.g. scp-like git urls, are returned as is.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	// Assembled by hand, as url escapes the masks.
+	userinfo := mask.MaskValue(u.User.Username())
+	if password, ok := u.User.Password(); ok {
+		userinfo += ":" + mask.MaskValue(password)
+	}
+	u.User = nil
+	return strings.Replace(u.String(), "//", "//"+userinfo+"@", 1)
+}
+
+// redactContainers returns a copy of the containers with their environment masked.
+func redactContainers(containers []models.AppContainer) []models.AppContainer {
+	if containers == nil {
+		return nil
+	}
+	result := make([]models.AppContainer, len(containers))
+	for i, container := range containers {
+		container.Env = mask.MaskMap(container.Env)
+		result[i] = container
+	}
+	return result
+}
+
+// redactPod masks the literal environment variable values of all containers of the pod,
+// and drops the managed fields.
+func redactPod(pod *corev1.Pod) {
+	pod.ManagedFields = nil
+	for _, containers := range [][]corev1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for i := range containers {
+			for j := range containers[i].Env {
+				containers[i].Env[j].Value = mask.MaskValue(containers[i].Env[j].Value)
+			}
+		}
+	}
+}
+
+// redactValues masks the environment variable values found in helm values, i.e. the
+// `value` of all entries of `env` lists, at any depth. The values are modified in place.
+func redactValues(values interface{}) interface{} {
+	switch v := values.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if key == "env" {
+				redactEnvList(value)
+				continue
+			}
+			redactValues(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			redactValues(value)
+		}
+	}
+	return values
+}
+
+// redactEnvList masks the values of a list of name/value environment variables.
+func redactEnvList(env interface{}) {
+	list, ok := env.([]interface{})
+	if !ok {
+		return
+	}
+	for _, entry := range list {
+		variable, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := variable["value"].(string); ok {
+			variable["value"] = mask.MaskValue(value)
+		}
+	}
+}
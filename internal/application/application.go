@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,10 +29,12 @@ import (
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/alerts"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/duration"
 	"github.com/epinio/epinio/internal/helm"
 	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/logdrain"
 	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/epinio/epinio/internal/registry"
 	"github.com/epinio/epinio/internal/s3manager"
@@ -145,6 +148,7 @@ func ValidateCV(
 // Create generates a new kube app resource in the namespace of the namespace.
 // Note that this is the passive resource holding the app's configuration.
 // It is not the active workload.
+// Callers check the quota of the namespace first, see CheckCreateQuota.
 func Create(
 	ctx context.Context,
 	cluster *kubernetes.Cluster,
@@ -238,7 +242,7 @@ func IsCurrentlyStaging(
 		return false, err
 	}
 	status := staging[EncodeConfigurationKey(appName, namespace)]
-	return status == models.ApplicationStagingActive, nil
+	return status == models.ApplicationStagingActive || status == models.ApplicationStagingQueued, nil
 }
 
 // StagingStatuses returns a map of applications and their staging statuses
@@ -294,37 +298,44 @@ func stagingStatus(
 		return nil, err
 	}
 
-	completed := func(condition apibatchv1.JobCondition) bool {
-		return condition.Status == v1.ConditionTrue && condition.Type == apibatchv1.JobComplete
-	}
-
-	failed := func(condition apibatchv1.JobCondition) bool {
-		return condition.Status == v1.ConditionTrue && condition.Type == apibatchv1.JobFailed
-	}
-
-	jobStaging := func(job apibatchv1.Job) models.ApplicationStagingStatus {
-		for _, condition := range job.Status.Conditions {
-			if failed(condition) {
-				return models.ApplicationStagingFailed
-			}
-			if completed(condition) {
-				// Terminal, not staging
-				return models.ApplicationStagingDone
-			}
-		}
-		// No terminal condition found on the job, it is actively staging
-		return models.ApplicationStagingActive
-	}
-
 	for _, job := range jobList.Items {
 		appName := job.GetLabels()["app.kubernetes.io/name"]
 		namespace := job.GetLabels()["app.kubernetes.io/part-of"]
-		stagingJobsMap[EncodeConfigurationKey(appName, namespace)] = jobStaging(job)
+		stagingJobsMap[EncodeConfigurationKey(appName, namespace)] = jobStagingStatus(job)
+	}
+
+	// Staging runs waiting for a free slot have no job yet.
+	for _, entry := range Staging.List().Entries {
+		if namespace != "" && entry.App.Namespace != namespace {
+			continue
+		}
+		if len(appNames) > 0 && !slices.Contains(appNames, entry.App.Name) {
+			continue
+		}
+		stagingJobsMap[EncodeConfigurationKey(entry.App.Name, entry.App.Namespace)] = models.ApplicationStagingQueued
 	}
 
 	return stagingJobsMap, nil
 }
 
+// jobStagingStatus returns the staging status of the application for the staging job.
+func jobStagingStatus(job apibatchv1.Job) models.ApplicationStagingStatus {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		if condition.Type == apibatchv1.JobFailed {
+			return models.ApplicationStagingFailed
+		}
+		if condition.Type == apibatchv1.JobComplete {
+			// Terminal, not staging
+			return models.ApplicationStagingDone
+		}
+	}
+	// No terminal condition found on the job, it is actively staging
+	return models.ApplicationStagingActive
+}
+
 func updateAppDataMapWithStagingJobStatus(
 	appDataMap map[ConfigurationKey]AppData,
 	stagingJobsMap map[ConfigurationKey]models.ApplicationStagingStatus,
@@ -390,13 +401,21 @@ func ListAppRefs(
 }
 
 type AppData struct {
-	scaling  *v1.Secret
-	bound    *v1.Secret
-	env      *v1.Secret
-	services *v1.Secret
-	routes   []string
-	pods     []v1.Pod
-	staging  models.ApplicationStagingStatus
+	scaling      *v1.Secret
+	bound        *v1.Secret
+	env          *v1.Secret
+	services     *v1.Secret
+	resources    *v1.Secret
+	healthcheck  *v1.Secret
+	dependencies *v1.Secret
+	containers   *v1.Secret
+	gitpoll      *v1.Secret
+	imagewatch   *v1.Secret
+	drains       *v1.Secret
+	alerts       *v1.Secret
+	routes       []string
+	pods         []v1.Pod
+	staging      models.ApplicationStagingStatus
 }
 
 // loadEnrichmentData fetches the auxiliary data needed to build full App structs.
@@ -759,6 +778,13 @@ func Delete(
 				log.Errorw("Failed to get image URL from application, skipping image deletion", "error", err, "app", appRef.Name)
 			} else if imageURL == "" {
 				log.Infow("No image URL found in application, skipping image deletion", "app", appRef.Name)
+			} else if shared, err := imageShared(ctx, cluster, appRef, imageURL); err != nil {
+				log.Errorw("Failed to check for other users of the image, skipping image deletion", "error", err, "app", appRef.Name)
+				imageURL = ""
+			} else if shared {
+				// Promotions deploy the same image into other namespaces.
+				log.Infow("Image is used by other applications, skipping image deletion", "app", appRef.Name, "image", imageURL)
+				imageURL = ""
 			}
 		}
 	}
@@ -827,12 +853,53 @@ func Delete(
 	return result, nil
 }
 
+// imageShared returns true if the image is used by applications other than the referenced
+// one, i.e. was promoted to or from it.
+func imageShared(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, imageURL string) (bool, error) {
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return false, err
+	}
+
+	list, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	for _, app := range list.Items {
+		if app.GetNamespace() == appRef.Namespace && app.GetName() == appRef.Name {
+			continue
+		}
+		if url, _ := ImageURL(&app); url == imageURL {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // deleteContainerImage deletes the container image from the registry
 func deleteContainerImage(
 	ctx context.Context,
 	cluster *kubernetes.Cluster,
 	imageURL string,
 ) error {
+	matchingCreds, tlsConfig, err := RegistryAccess(ctx, cluster, imageURL)
+	if err != nil {
+		return err
+	}
+
+	// Delete the image
+	return registry.DeleteImage(ctx, imageURL, matchingCreds, tlsConfig)
+}
+
+// RegistryAccess returns the credentials and the TLS configuration for accessing the Epinio
+// registry holding the image.
+func RegistryAccess(
+	ctx context.Context,
+	cluster *kubernetes.Cluster,
+	imageURL string,
+) (registry.RegistryCredentials, *tls.Config, error) {
 	// Get registry connection details
 	connectionDetails, err := registry.GetConnectionDetails(
 		ctx,
@@ -841,11 +908,11 @@ func deleteContainerImage(
 		registry.CredentialsSecretName,
 	)
 	if err != nil {
-		return errors.Wrap(err, "getting registry connection details")
+		return registry.RegistryCredentials{}, nil, errors.Wrap(err, "getting registry connection details")
 	}
 
 	if len(connectionDetails.RegistryCredentials) == 0 {
-		return errors.New("no registry credentials found")
+		return registry.RegistryCredentials{}, nil, errors.New("no registry credentials found")
 	}
 
 	// Use the first set of credentials (typically there's only one)
@@ -854,7 +921,7 @@ func deleteContainerImage(
 	// Extract registry URL from image URL to match credentials
 	imageRegistryURL, _, err := registry.ExtractImageParts(imageURL)
 	if err != nil {
-		return errors.Wrap(err, "extracting registry URL from image")
+		return registry.RegistryCredentials{}, nil, errors.Wrap(err, "extracting registry URL from image")
 	}
 
 	// Find matching credentials for the image's registry
@@ -965,8 +1032,7 @@ func deleteContainerImage(
 		}
 	}
 
-	// Delete the image
-	return registry.DeleteImage(ctx, imageURL, matchingCreds, tlsConfig)
+	return matchingCreds, tlsConfig, nil
 }
 
 // deleteCacheStagePVC removes the kube PVC resource which was used to hold the application
@@ -1083,6 +1149,19 @@ func BuilderURL(app *unstructured.Unstructured) (string, error) {
 	return builderURL, nil
 }
 
+// BuildMode returns the build mode of the last staging of the application. Applications
+// staged before the introduction of build modes were staged with buildpacks. The result is
+// empty for applications which were never staged.
+func BuildMode(app *unstructured.Unstructured) string {
+	if mode := app.GetAnnotations()[models.EpinioBuildModeAnnotation]; mode != "" {
+		return mode
+	}
+	if builderURL, _ := BuilderURL(app); builderURL != "" {
+		return models.BuildModeBuildpack
+	}
+	return ""
+}
+
 // ErrBlobCleanupIncomplete is returned when blob cleanup could not complete
 // due to storage quota issues. The unstaging operation succeeded for jobs and
 // secrets, but some blobs remain in S3 storage. This is a non-fatal warning
@@ -1173,15 +1252,53 @@ func Unstage(
 		}
 	}
 
-	// Cleanup s3 objects
+	// Cleanup s3 objects, and release their space from the blob storage usage of the
+	// namespace. The sizes are taken before the objects are gone.
+	sizes := staleBlobSizes(ctx, s3m, jobs.Items, currentJob)
+
 	result.FailedBlobCleanups, err = CleanupS3Blobs(ctx, log, s3m, jobs.Items, currentJob, appRef)
 	if err != nil {
 		return nil, err
 	}
 
+	for _, blobUID := range result.FailedBlobCleanups {
+		delete(sizes, blobUID)
+	}
+	var freed int64
+	for _, size := range sizes {
+		freed += size
+	}
+	if err := namespaces.AddBlobUsage(ctx, cluster, appRef.Namespace, -freed); err != nil {
+		log.Error(err, "failed to record blob storage usage", "namespace", appRef.Namespace)
+	}
+
 	return result, nil
 }
 
+// staleBlobSizes returns the sizes of the blobs CleanupS3Blobs is going to delete, by blob
+// UID. Blobs whose size cannot be determined, i.e. already gone, are left out.
+func staleBlobSizes(ctx context.Context, s3m *s3manager.Manager, jobs []apibatchv1.Job, currentJob *apibatchv1.Job) map[string]int64 {
+	sizes := map[string]int64{}
+
+	for _, job := range jobs {
+		blobUID := job.Labels[models.EpinioStageBlobUIDLabel]
+		if currentJob != nil && blobUID == currentJob.Labels[models.EpinioStageBlobUIDLabel] {
+			continue
+		}
+		if _, ok := sizes[blobUID]; ok || blobUID == "" {
+			continue
+		}
+
+		size, err := s3m.Size(ctx, blobUID)
+		if err != nil {
+			continue
+		}
+		sizes[blobUID] = size
+	}
+
+	return sizes
+}
+
 // CleanupS3Blobs deletes blobs from S3 storage for the given jobs, skipping the current job's blob.
 // Returns a list of blob UIDs that failed to delete due to quota errors (non-fatal).
 // Returns an error for other types of failures (fatal).
@@ -1236,8 +1353,9 @@ type LogParameters struct {
 	Since             *time.Duration
 	SinceTime         *time.Time
 	Follow            bool
-	IncludeContainers []string // List of container names/patterns to include (regex patterns)
-	ExcludeContainers []string // List of container names/patterns to exclude (regex patterns)
+	IncludeContainers []string   // List of container names/patterns to include (regex patterns)
+	ExcludeContainers []string   // List of container names/patterns to exclude (regex patterns)
+	Filter            *LogFilter // Selection of the log lines by content, if any
 }
 
 // buildContainerIncludePattern builds the regex pattern for including containers.
@@ -1462,6 +1580,10 @@ func Logs(
 		}
 	}
 
+	if logParams != nil && logParams.Filter != nil {
+		config.Match = logParams.Filter.Matches
+	}
+
 	// Use follow from logParams if provided, otherwise default to false
 	follow := false
 	if logParams != nil {
@@ -1486,7 +1608,7 @@ func makeAuxiliaryMap(secrets []v1.Secret) map[ConfigurationKey]AppData {
 		as per their area (*). Key the maps by namespace and name of their
 		controlling application for quick access in the	aggregation step.
 
-		(*) Label "epinio.io/area": "environment"|"scaling"|"configuration"
+		(*) Label "epinio.io/area": "environment"|"scaling"|"configuration"|"service"|"resources"|"healthcheck"|"dependencies"|"containers"|"gitpoll"|"imagewatch"|"drains"|"alerts"
 	*/
 
 	result := map[ConfigurationKey]AppData{}
@@ -1519,6 +1641,22 @@ func makeAuxiliaryMap(secrets []v1.Secret) map[ConfigurationKey]AppData {
 			data.env = &secretToAssign
 		case "service":
 			data.services = &secretToAssign
+		case "resources":
+			data.resources = &secretToAssign
+		case "healthcheck":
+			data.healthcheck = &secretToAssign
+		case "dependencies":
+			data.dependencies = &secretToAssign
+		case "containers":
+			data.containers = &secretToAssign
+		case "gitpoll":
+			data.gitpoll = &secretToAssign
+		case "imagewatch":
+			data.imagewatch = &secretToAssign
+		case logdrain.AreaLabel:
+			data.drains = &secretToAssign
+		case alerts.AreaLabel:
+			data.alerts = &secretToAssign
 		default:
 			// ignore secret
 		}
@@ -1576,6 +1714,59 @@ func aggregate(ctx context.Context,
 	if aux.services != nil {
 		services = BoundServiceNamesFromSecret(aux.services)
 	}
+	var resources *models.AppResources
+	if aux.resources != nil {
+		resources = ResourcesFromSecret(aux.resources)
+	}
+	var healthcheck *models.AppHealthcheck
+	if aux.healthcheck != nil {
+		healthcheck, err = HealthcheckFromSecret(aux.healthcheck)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding health check")
+		}
+	}
+	var dependencies []models.AppDependency
+	if aux.dependencies != nil {
+		dependencies, err = DependenciesFromSecret(aux.dependencies)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding dependencies")
+		}
+	}
+	var sidecars, initContainers []models.AppContainer
+	if aux.containers != nil {
+		sidecars, initContainers, err = ContainersFromSecret(aux.containers)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding containers")
+		}
+	}
+	var gitPoll *models.GitPollStatus
+	if aux.gitpoll != nil {
+		gitPoll, err = GitPollFromSecret(aux.gitpoll)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding git polling")
+		}
+	}
+	var imageWatch *models.ImageWatchStatus
+	if aux.imagewatch != nil {
+		imageWatch, err = ImageWatchFromSecret(aux.imagewatch)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding image watch")
+		}
+	}
+	var drains []models.LogDrainStatus
+	if aux.drains != nil {
+		drains, err = logdrain.FromSecret(aux.drains, models.LogDrainScopeApp)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding log drains")
+		}
+	}
+	var appAlerts []models.AppAlertStatus
+	if aux.alerts != nil {
+		appAlerts, err = alerts.FromSecret(aux.alerts)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding alerts")
+		}
+	}
 
 	// II. Unpack the core application resource
 
@@ -1628,10 +1819,30 @@ func aggregate(ctx context.Context,
 	app.Configuration.Routes = desiredRoutes
 	app.Configuration.AppChart = chartName
 	app.Configuration.Settings = settings
+	app.Configuration.Resources = resources
+	app.Configuration.Healthcheck = healthcheck
+	app.Configuration.DependsOn = dependencies
+	app.Configuration.Sidecars = sidecars
+	app.Configuration.InitContainers = initContainers
+	app.Configuration.RegistryCredential = RegistryCredential(&appCR)
 	app.Origin = origin
+	app.GitPoll = gitPoll
+	if gitPoll != nil && app.Origin.Git != nil {
+		app.Origin.Git.PollInterval = gitPoll.Interval
+	}
+	app.ImageWatch = imageWatch
+	if imageWatch != nil {
+		app.Configuration.ImageWatch = &models.AppImageWatch{
+			Interval: imageWatch.Interval,
+			Mode:     imageWatch.Mode,
+		}
+	}
+	app.LogDrains = logdrain.Redact(drains)
+	app.Alerts = alerts.Redact(appAlerts)
 	app.StageID = stageID
 	app.ImageURL = imageURL
 	app.Staging.Builder = builderURL
+	app.Staging.Mode = BuildMode(&appCR)
 
 	// IV. Assemble the deployment structure for active applications.
 
@@ -1652,21 +1863,39 @@ func aggregate(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	if app.Workload != nil {
+		app.Workload.ImageDigest = ImageDigest(&appCR)
+	}
 
 	// set app status and done ...
 
 	app.StagingStatus = aux.staging
 
-	if aux.staging == models.ApplicationStagingActive {
+	if aux.staging == models.ApplicationStagingQueued {
+		app.QueuePosition = Staging.AppPosition(app.Meta)
+	}
+	if aux.staging == models.ApplicationStagingActive || aux.staging == models.ApplicationStagingQueued {
 		app.Status = models.ApplicationStaging
 		return app, nil
 	}
 
+	if waitingOn := WaitingOn(&appCR); waitingOn != "" {
+		app.Status = models.ApplicationWaiting
+		app.StatusMessage = "waiting on " + waitingOn
+		return app, nil
+	}
+
 	if app.Workload == nil {
 		app.Status = models.ApplicationCreated
 		return app, nil
 	}
 
+	if failure := workloadFailure(app.Workload); failure != "" {
+		app.Status = models.ApplicationError
+		app.StatusMessage = failure
+		return app, nil
+	}
+
 	app.Status = models.ApplicationRunning
 	return app, nil
 }
@@ -1759,6 +1988,70 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 		return err
 	}
 
+	resources, err := Resources(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding resources")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	healthcheck, err := Healthcheck(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding health check")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	dependencies, err := Dependencies(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding dependencies")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	sidecars, initContainers, err := Containers(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding containers")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	gitPoll, err := GitPoll(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding git polling")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	imageWatch, err := ImageWatch(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding image watch")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	drains, err := LogDrains(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding log drains")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	appAlerts, err := Alerts(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding alerts")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
 	chartName, err := AppChart(applicationCR)
 	if err != nil {
 		err = errors.Wrap(err, "finding app chart")
@@ -1799,6 +2092,14 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 		return err
 	}
 
+	lineage, err := Lineage(applicationCR)
+	if err != nil {
+		err = errors.Wrap(err, "finding the promotion lineage")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
 	app.Meta.CreatedAt = applicationCR.GetCreationTimestamp()
 
 	app.Configuration.Instances = &instances
@@ -1809,10 +2110,31 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 	app.Configuration.Routes = desiredRoutes
 	app.Configuration.AppChart = chartName
 	app.Configuration.Settings = settings
+	app.Configuration.Resources = resources
+	app.Configuration.Healthcheck = healthcheck
+	app.Configuration.DependsOn = dependencies
+	app.Configuration.Sidecars = sidecars
+	app.Configuration.InitContainers = initContainers
+	app.Configuration.RegistryCredential = RegistryCredential(applicationCR)
 	app.Origin = origin
+	app.GitPoll = gitPoll
+	if gitPoll != nil && app.Origin.Git != nil {
+		app.Origin.Git.PollInterval = gitPoll.Interval
+	}
+	app.ImageWatch = imageWatch
+	if imageWatch != nil {
+		app.Configuration.ImageWatch = &models.AppImageWatch{
+			Interval: imageWatch.Interval,
+			Mode:     imageWatch.Mode,
+		}
+	}
+	app.LogDrains = logdrain.Redact(drains)
+	app.Alerts = alerts.Redact(appAlerts)
 	app.StageID = stageID
 	app.ImageURL = imageURL
 	app.Staging.Builder = builderURL
+	app.Staging.Mode = BuildMode(applicationCR)
+	app.Lineage = lineage
 
 	// Check if app is active, and if yes, fill the associated parts.  May have to
 	// straighten the workload structure a bit further.
@@ -1824,6 +2146,9 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 		app.Status = models.ApplicationError
 		return err
 	}
+	if app.Workload != nil {
+		app.Workload.ImageDigest = ImageDigest(applicationCR)
+	}
 
 	staging, err := stagingStatus(ctx, cluster, app.Meta.Namespace, []string{app.Meta.Name})
 	if err != nil {
@@ -1835,16 +2160,31 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 
 	app.StagingStatus = staging[EncodeConfigurationKey(app.Meta.Name, app.Meta.Namespace)]
 
-	if app.StagingStatus == models.ApplicationStagingActive {
+	if app.StagingStatus == models.ApplicationStagingQueued {
+		app.QueuePosition = Staging.AppPosition(app.Meta)
+	}
+	if app.StagingStatus == models.ApplicationStagingActive || app.StagingStatus == models.ApplicationStagingQueued {
 		app.Status = models.ApplicationStaging
 		return nil
 	}
 
+	if waitingOn := WaitingOn(applicationCR); waitingOn != "" {
+		app.Status = models.ApplicationWaiting
+		app.StatusMessage = "waiting on " + waitingOn
+		return nil
+	}
+
 	if app.Workload == nil {
 		app.Status = models.ApplicationCreated
 		return nil
 	}
 
+	if failure := workloadFailure(app.Workload); failure != "" {
+		app.Status = models.ApplicationError
+		app.StatusMessage = failure
+		return nil
+	}
+
 	app.Status = models.ApplicationRunning
 	return nil
 }
@@ -0,0 +1,125 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// maxBuildMetadata is the number of staging runs whose build metadata is kept per
+// application.
+const maxBuildMetadata = 10
+
+// BuildMetadata returns the build metadata recorded for the identified staging run of the
+// application. The result is nil if nothing was recorded.
+func BuildMetadata(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, stageID string) (*models.BuildMetadata, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeBuildMetadataSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return BuildMetadataFromSecret(secret, stageID)
+}
+
+// BuildMetadataFromSecret is the core of BuildMetadata, extracting the metadata of the
+// staging run from the secret containing it. The result is nil if nothing was recorded.
+func BuildMetadataFromSecret(secret *v1.Secret, stageID string) (*models.BuildMetadata, error) {
+	data, ok := secret.Data[stageID]
+	if !ok {
+		return nil, nil
+	}
+
+	meta := models.BuildMetadata{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, errors.Wrap(err, "bad build metadata")
+	}
+
+	return &meta, nil
+}
+
+// BuildMetadataRecord saves the build metadata of a staging run of the application, keyed
+// by the stage id. The metadata of the oldest runs beyond the limit is dropped.
+func BuildMetadataRecord(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, meta models.BuildMetadata) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef, appRef.MakeBuildMetadataSecretName(), "build")
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[meta.StageID] = data
+		pruneStageEntries(secret.Data, maxBuildMetadata, buildMetadataTime)
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// buildMetadataTime returns the creation time of the encoded build metadata, or the empty
+// string for bad data.
+func buildMetadataTime(data []byte) string {
+	meta := models.BuildMetadata{}
+	_ = json.Unmarshal(data, &meta)
+	return meta.CreatedAt
+}
+
+// pruneStageEntries removes the entries of the oldest staging runs until at most `limit`
+// entries are left. The age of an entry is determined by the timestamp returned by
+// `timeOf`. Entries without a proper timestamp are considered oldest.
+func pruneStageEntries(data map[string][]byte, limit int, timeOf func([]byte) string) {
+	if len(data) <= limit {
+		return
+	}
+
+	type entry struct {
+		key     string
+		created string
+	}
+
+	entries := []entry{}
+	for key, value := range data {
+		entries = append(entries, entry{key: key, created: timeOf(value)})
+	}
+
+	// RFC3339 timestamps in UTC sort lexicographically
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].created != entries[j].created {
+			return entries[i].created < entries[j].created
+		}
+		return entries[i].key < entries[j].key
+	})
+
+	for _, e := range entries[:len(entries)-limit] {
+		delete(data, e.key)
+	}
+}
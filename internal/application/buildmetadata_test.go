@@ -0,0 +1,88 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("BuildMetadata", func() {
+	entry := func(stageID, created string) []byte {
+		data, err := json.Marshal(models.BuildMetadata{StageID: stageID, CreatedAt: created})
+		Expect(err).ToNot(HaveOccurred())
+		return data
+	}
+
+	Describe("BuildMetadataFromSecret", func() {
+		It("returns the metadata of the stage", func() {
+			secret := &v1.Secret{Data: map[string][]byte{
+				"s1": entry("s1", "2026-01-01T00:00:00Z"),
+			}}
+
+			meta, err := BuildMetadataFromSecret(secret, "s1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(meta).ToNot(BeNil())
+			Expect(meta.StageID).To(Equal("s1"))
+		})
+
+		It("returns nothing for an unknown stage", func() {
+			secret := &v1.Secret{Data: map[string][]byte{
+				"s1": entry("s1", "2026-01-01T00:00:00Z"),
+			}}
+
+			meta, err := BuildMetadataFromSecret(secret, "s2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(meta).To(BeNil())
+		})
+
+		It("fails for bad metadata", func() {
+			secret := &v1.Secret{Data: map[string][]byte{"s1": []byte("{")}}
+
+			_, err := BuildMetadataFromSecret(secret, "s1")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("pruneStageEntries", func() {
+		It("keeps everything within the limit", func() {
+			data := map[string][]byte{
+				"s1": entry("s1", "2026-01-01T00:00:00Z"),
+				"s2": entry("s2", "2026-01-02T00:00:00Z"),
+			}
+
+			pruneStageEntries(data, 2, buildMetadataTime)
+			Expect(data).To(HaveLen(2))
+		})
+
+		It("drops the oldest stages beyond the limit", func() {
+			data := map[string][]byte{}
+			for day := 1; day <= 5; day++ {
+				id := fmt.Sprintf("s%d", day)
+				data[id] = entry(id, fmt.Sprintf("2026-01-0%dT00:00:00Z", day))
+			}
+			data["bad"] = []byte("{")
+
+			pruneStageEntries(data, 3, buildMetadataTime)
+			Expect(data).To(HaveLen(3))
+			Expect(data).To(HaveKey("s3"))
+			Expect(data).To(HaveKey("s4"))
+			Expect(data).To(HaveKey("s5"))
+		})
+	})
+})
@@ -0,0 +1,134 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// cacheDeletionTimeout limits the wait for the removal of a deleted build cache volume.
+const cacheDeletionTimeout = 2 * time.Minute
+
+// BuildCache returns the build cache of the application, i.e. the size of its volume and
+// the last recorded usage. The result is nil if the application has no cache volume.
+func BuildCache(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.AppBuildCache, error) {
+	pvc, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+		Get(ctx, appRef.MakeCachePVCName(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return BuildCacheFromPVC(pvc)
+}
+
+// BuildCacheFromPVC is the core of BuildCache, extracting the information from the cache
+// volume claim.
+func BuildCacheFromPVC(pvc *corev1.PersistentVolumeClaim) (*models.AppBuildCache, error) {
+	cache := &models.AppBuildCache{
+		MeasuredAt: pvc.Annotations[models.EpinioCacheMeasuredAnnotation],
+	}
+
+	// Prefer the actual capacity of a bound volume over the requested size.
+	size, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+	if !ok {
+		size = pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	}
+	cache.Size = size.String()
+
+	if usage := pvc.Annotations[models.EpinioCacheUsageAnnotation]; usage != "" {
+		bytes, err := strconv.ParseInt(usage, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad cache usage '%s'", usage)
+		}
+		cache.UsageBytes = bytes
+	}
+
+	return cache, nil
+}
+
+// BuildCacheExceeds returns true if the recorded usage of the cache is above the limit. A
+// cache without recorded usage never exceeds it.
+func BuildCacheExceeds(cache *models.AppBuildCache, limit resource.Quantity) bool {
+	if cache == nil || cache.MeasuredAt == "" {
+		return false
+	}
+	return cache.UsageBytes > limit.Value()
+}
+
+// BuildCacheRecordUsage records the usage of the build cache of the application, as
+// measured at the end of a staging run.
+func BuildCacheRecordUsage(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, usage int64) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				models.EpinioCacheUsageAnnotation:    strconv.FormatInt(usage, 10),
+				models.EpinioCacheMeasuredAnnotation: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+		Patch(ctx, appRef.MakeCachePVCName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		// Cache was cleared while staging
+		return nil
+	}
+	return err
+}
+
+// BuildCacheClear removes the build cache volume of the application, and waits for it to be
+// gone. The next staging starts with an empty cache.
+func BuildCacheClear(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) error {
+	err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+		Delete(ctx, appRef.MakeCachePVCName(), metav1.DeleteOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return WaitForPVCDeletion(ctx, cluster, appRef.MakeCachePVCName())
+}
+
+// WaitForPVCDeletion waits for the named staging volume claim to be gone.
+func WaitForPVCDeletion(ctx context.Context, cluster *kubernetes.Cluster, pvcName string) error {
+	return wait.PollUntilContextTimeout(ctx, time.Second, cacheDeletionTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			_, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+				Get(ctx, pvcName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+}
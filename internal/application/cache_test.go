@@ -0,0 +1,91 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("BuildCache", func() {
+	pvc := func(annotations map[string]string) *v1.PersistentVolumeClaim {
+		return &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec: v1.PersistentVolumeClaimSpec{
+				Resources: v1.VolumeResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			},
+		}
+	}
+
+	Describe("BuildCacheFromPVC", func() {
+		It("decodes the recorded usage", func() {
+			cache, err := application.BuildCacheFromPVC(pvc(map[string]string{
+				models.EpinioCacheUsageAnnotation:    "2048",
+				models.EpinioCacheMeasuredAnnotation: "2026-01-01T00:00:00Z",
+			}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cache).To(Equal(&models.AppBuildCache{
+				Size:       "1Gi",
+				UsageBytes: 2048,
+				MeasuredAt: "2026-01-01T00:00:00Z",
+			}))
+		})
+
+		It("prefers the capacity of the bound volume", func() {
+			claim := pvc(nil)
+			claim.Status.Capacity = v1.ResourceList{v1.ResourceStorage: resource.MustParse("2Gi")}
+
+			cache, err := application.BuildCacheFromPVC(claim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cache.Size).To(Equal("2Gi"))
+			Expect(cache.MeasuredAt).To(BeEmpty())
+		})
+
+		It("rejects a bad usage", func() {
+			_, err := application.BuildCacheFromPVC(pvc(map[string]string{
+				models.EpinioCacheUsageAnnotation: "lots",
+			}))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("BuildCacheExceeds", func() {
+		limit := resource.MustParse("1Ki")
+
+		It("is true for a usage above the limit", func() {
+			Expect(application.BuildCacheExceeds(&models.AppBuildCache{
+				UsageBytes: 1025,
+				MeasuredAt: "2026-01-01T00:00:00Z",
+			}, limit)).To(BeTrue())
+		})
+
+		It("is false for a usage at the limit", func() {
+			Expect(application.BuildCacheExceeds(&models.AppBuildCache{
+				UsageBytes: 1024,
+				MeasuredAt: "2026-01-01T00:00:00Z",
+			}, limit)).To(BeFalse())
+		})
+
+		It("is false without a measurement, or cache", func() {
+			Expect(application.BuildCacheExceeds(&models.AppBuildCache{UsageBytes: 4096}, limit)).To(BeFalse())
+			Expect(application.BuildCacheExceeds(nil, limit)).To(BeFalse())
+		})
+	})
+})
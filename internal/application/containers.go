@@ -0,0 +1,153 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+)
+
+// Keys of the containers secret. The values are the JSON encoded container lists.
+const (
+	sidecarsKey       = "sidecars"
+	initContainersKey = "initContainers"
+)
+
+// Containers returns the sidecars and init containers declared for the application.
+func Containers(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) ([]models.AppContainer, []models.AppContainer, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeContainersSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	return ContainersFromSecret(secret)
+}
+
+// ContainersFromSecret is the core of Containers, extracting the sidecars and init
+// containers from the secret containing them.
+func ContainersFromSecret(secret *v1.Secret) ([]models.AppContainer, []models.AppContainer, error) {
+	var sidecars, initContainers []models.AppContainer
+
+	if data, ok := secret.Data[sidecarsKey]; ok {
+		if err := json.Unmarshal(data, &sidecars); err != nil {
+			return nil, nil, errors.Wrap(err, "bad sidecars")
+		}
+	}
+	if data, ok := secret.Data[initContainersKey]; ok {
+		if err := json.Unmarshal(data, &initContainers); err != nil {
+			return nil, nil, errors.Wrap(err, "bad init containers")
+		}
+	}
+
+	return sidecars, initContainers, nil
+}
+
+// ContainersSet replaces the sidecars and/or init containers of the named application. A
+// nil list leaves the current containers of that kind untouched, an empty list removes them.
+func ContainersSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, sidecars, initContainers []models.AppContainer) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef, appRef.MakeContainersSecretName(), "containers")
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+
+		for key, containers := range map[string][]models.AppContainer{
+			sidecarsKey:       sidecars,
+			initContainersKey: initContainers,
+		} {
+			if containers == nil {
+				continue
+			}
+			if len(containers) == 0 {
+				delete(secret.Data, key)
+				continue
+			}
+			data, err := json.Marshal(containers)
+			if err != nil {
+				return err
+			}
+			secret.Data[key] = data
+		}
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// ValidateContainers checks the sidecars and init containers of the named application for
+// missing images, bad and duplicate names, and bad volume mounts. The name of the application
+// container, which is the application name, is reserved. It returns a bad request API error
+// for the first issue found.
+func ValidateContainers(appName string, sidecars, initContainers []models.AppContainer) error {
+	names := map[string]bool{}
+
+	for _, group := range []struct {
+		kind       string
+		containers []models.AppContainer
+	}{
+		{"sidecar", sidecars},
+		{"init container", initContainers},
+	} {
+		for _, container := range group.containers {
+			if issues := validation.IsDNS1123Label(container.Name); len(issues) > 0 {
+				return apierror.NewBadRequestErrorf("bad %s name '%s'", group.kind, container.Name).
+					WithDetails(strings.Join(issues, ", "))
+			}
+			if container.Name == appName {
+				return apierror.NewBadRequestErrorf("%s name '%s' is reserved for the application container",
+					group.kind, container.Name)
+			}
+			if names[container.Name] {
+				return apierror.NewBadRequestErrorf("%s name '%s' is used more than once", group.kind, container.Name)
+			}
+			names[container.Name] = true
+
+			if container.Image == "" {
+				return apierror.NewBadRequestErrorf("%s '%s' has no image", group.kind, container.Name)
+			}
+
+			for _, volume := range container.Volumes {
+				if issues := validation.IsDNS1123Label(volume.Name); len(issues) > 0 {
+					return apierror.NewBadRequestErrorf("bad volume name '%s' in %s '%s'",
+						volume.Name, group.kind, container.Name).
+						WithDetails(strings.Join(issues, ", "))
+				}
+				if !path.IsAbs(volume.Path) {
+					return apierror.NewBadRequestErrorf("volume '%s' in %s '%s' needs an absolute path",
+						volume.Name, group.kind, container.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
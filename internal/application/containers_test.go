@@ -0,0 +1,88 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Containers", func() {
+	Describe("ContainersFromSecret", func() {
+		It("decodes the stored containers", func() {
+			sidecars, initContainers, err := application.ContainersFromSecret(&v1.Secret{
+				Data: map[string][]byte{
+					"sidecars": []byte(`[{"name":"proxy","image":"envoy","volumes":[{"name":"shared","path":"/shared"}]}]`),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(initContainers).To(BeNil())
+			Expect(sidecars).To(Equal([]models.AppContainer{{
+				Name:    "proxy",
+				Image:   "envoy",
+				Volumes: []models.AppVolumeMount{{Name: "shared", Path: "/shared"}},
+			}}))
+		})
+
+		It("fails for bad data", func() {
+			_, _, err := application.ContainersFromSecret(&v1.Secret{
+				Data: map[string][]byte{
+					"initContainers": []byte(`{`),
+				},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ValidateContainers", func() {
+		It("accepts proper containers", func() {
+			Expect(application.ValidateContainers("myapp",
+				[]models.AppContainer{{Name: "proxy", Image: "envoy"}},
+				[]models.AppContainer{{Name: "migrate", Image: "app-migrations",
+					Volumes: []models.AppVolumeMount{{Name: "data", Path: "/data"}}}},
+			)).To(Succeed())
+		})
+
+		It("rejects bad and duplicate names", func() {
+			err := application.ValidateContainers("myapp", []models.AppContainer{{Name: "Proxy", Image: "envoy"}}, nil)
+			Expect(err).To(MatchError(ContainSubstring("bad sidecar name 'Proxy'")))
+
+			err = application.ValidateContainers("myapp",
+				[]models.AppContainer{{Name: "x", Image: "a"}},
+				[]models.AppContainer{{Name: "x", Image: "b"}})
+			Expect(err).To(MatchError(ContainSubstring("init container name 'x' is used more than once")))
+		})
+
+		It("rejects the name of the application container", func() {
+			err := application.ValidateContainers("myapp", []models.AppContainer{{Name: "myapp", Image: "envoy"}}, nil)
+			Expect(err).To(MatchError(ContainSubstring("sidecar name 'myapp' is reserved")))
+
+			err = application.ValidateContainers("myapp", nil, []models.AppContainer{{Name: "myapp", Image: "app-migrations"}})
+			Expect(err).To(MatchError(ContainSubstring("init container name 'myapp' is reserved")))
+		})
+
+		It("rejects a missing image", func() {
+			err := application.ValidateContainers("myapp", nil, []models.AppContainer{{Name: "migrate"}})
+			Expect(err).To(MatchError(ContainSubstring("has no image")))
+		})
+
+		It("rejects a relative volume path", func() {
+			err := application.ValidateContainers("myapp", []models.AppContainer{{Name: "proxy", Image: "envoy",
+				Volumes: []models.AppVolumeMount{{Name: "data", Path: "data"}}}}, nil)
+			Expect(err).To(MatchError(ContainSubstring("needs an absolute path")))
+		})
+	})
+})
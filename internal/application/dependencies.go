@@ -0,0 +1,280 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/duration"
+	"github.com/epinio/epinio/internal/services"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// dependsOnKey is the key of the dependencies secret. The value is the list of
+// dependencies in textual form, one per line.
+const dependsOnKey = "dependsOn"
+
+// Dependencies returns the dependencies declared for the application.
+func Dependencies(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) ([]models.AppDependency, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeDependenciesSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return DependenciesFromSecret(secret)
+}
+
+// DependenciesFromSecret is the core of Dependencies, extracting the dependencies from the
+// secret containing them.
+func DependenciesFromSecret(secret *v1.Secret) ([]models.AppDependency, error) {
+	var result []models.AppDependency
+
+	for _, line := range strings.Split(string(secret.Data[dependsOnKey]), "\n") {
+		if line == "" {
+			continue
+		}
+		dependency, err := models.ParseAppDependency(line)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dependency)
+	}
+
+	return result, nil
+}
+
+// DependenciesSet replaces the dependencies of the named application.
+func DependenciesSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, dependencies []models.AppDependency) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef, appRef.MakeDependenciesSecretName(), "dependencies")
+		if err != nil {
+			return err
+		}
+
+		lines := []string{}
+		for _, dependency := range dependencies {
+			lines = append(lines, dependency.String())
+		}
+
+		secret.Data = map[string][]byte{}
+		if len(lines) > 0 {
+			secret.Data[dependsOnKey] = []byte(strings.Join(lines, "\n"))
+		}
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// ValidateDependencies checks that every dependency references exactly one app or service,
+// that the application does not depend on itself, and that the dependencies between the
+// applications of the namespace do not form a cycle. The dependencies do not have to exist
+// yet. It returns a bad request API error for the first issue found.
+func ValidateDependencies(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, dependencies []models.AppDependency) error {
+	for _, dependency := range dependencies {
+		if (dependency.App == "") == (dependency.Service == "") {
+			return apierror.NewBadRequestError("a dependency has to reference exactly one app or service")
+		}
+		if dependency.App == appRef.Name {
+			return apierror.NewBadRequestErrorf("application '%s' cannot depend on itself", appRef.Name)
+		}
+	}
+
+	// Walk the app dependencies, looking for a path back to the application.
+
+	visited := map[string]bool{}
+	pending := []string{}
+	for _, dependency := range dependencies {
+		if dependency.App != "" {
+			pending = append(pending, dependency.App)
+		}
+	}
+
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
+
+		next, err := Dependencies(ctx, cluster, models.NewAppRef(name, appRef.Namespace))
+		if err != nil {
+			return err
+		}
+		for _, dependency := range next {
+			if dependency.App == "" {
+				continue
+			}
+			if dependency.App == appRef.Name {
+				return apierror.NewBadRequestErrorf("dependency cycle: application '%s' depends on '%s'",
+					name, appRef.Name)
+			}
+			pending = append(pending, dependency.App)
+		}
+	}
+
+	return nil
+}
+
+// WaitForDependencies waits until all dependencies of the application are ready, i.e.
+// applications have all their desired instances ready, and services are deployed. While
+// waiting the dependency waited on is recorded in the application resource, for display
+// in the application status. It gives up after `duration.ToAppBuilt()`.
+func WaitForDependencies(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, dependencies []models.AppDependency) error {
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	serviceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, dependency := range dependencies {
+		ready, err := dependencyReady(ctx, cluster, serviceClient, appRef.Namespace, dependency)
+		if err != nil {
+			return err
+		}
+		if ready {
+			continue
+		}
+
+		helpers.Logger.Infow("waiting on dependency", "app", appRef.Name, "namespace", appRef.Namespace,
+			"dependency", dependency.String())
+
+		err = setWaitingOn(ctx, cluster, appRef, dependency.String())
+		if err != nil {
+			return err
+		}
+
+		err = wait.PollUntilContextTimeout(ctx, 2*time.Second, duration.ToAppBuilt(), true,
+			func(ctx context.Context) (bool, error) {
+				return dependencyReady(ctx, cluster, serviceClient, appRef.Namespace, dependency)
+			})
+		if err != nil {
+			if clearErr := setWaitingOn(ctx, cluster, appRef, ""); clearErr != nil {
+				helpers.Logger.Infow("failed to clear waiting state", "app", appRef.Name, "error", clearErr)
+			}
+			return errors.Wrapf(err, "waiting on %s", dependency.String())
+		}
+	}
+
+	return setWaitingOn(ctx, cluster, appRef, "")
+}
+
+// CheckDependencies returns a conflict error listing the dependencies of the application
+// which are not ready. Unlike WaitForDependencies it does not wait, for use by synchronous
+// requests, which must not hang until the dependencies come up.
+func CheckDependencies(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, dependencies []models.AppDependency) error {
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	serviceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return err
+	}
+
+	pending := []string{}
+	for _, dependency := range dependencies {
+		ready, err := dependencyReady(ctx, cluster, serviceClient, appRef.Namespace, dependency)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			pending = append(pending, dependency.String())
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	return apierror.NewAPIError("dependencies not ready", http.StatusConflict).
+		WithDetailsf("application %s waits on %s. Deploy asynchronously to wait for them",
+			appRef.Name, strings.Join(pending, ", "))
+}
+
+// WaitingOn returns the dependency the application currently waits on, if any.
+func WaitingOn(app *unstructured.Unstructured) string {
+	return app.GetAnnotations()[models.EpinioWaitingOnAnnotation]
+}
+
+func dependencyReady(ctx context.Context, cluster *kubernetes.Cluster, serviceClient *services.ServiceClient, namespace string, dependency models.AppDependency) (bool, error) {
+	if dependency.Service != "" {
+		service, err := serviceClient.Get(ctx, namespace, dependency.Service)
+		if err != nil {
+			return false, err
+		}
+		return service != nil && service.Status == models.ServiceStatusDeployed, nil
+	}
+
+	app, err := Lookup(ctx, cluster, namespace, dependency.App)
+	if err != nil {
+		return false, err
+	}
+	if app == nil || app.Workload == nil {
+		return false, nil
+	}
+
+	return app.Workload.DesiredReplicas > 0 &&
+		app.Workload.ReadyReplicas >= app.Workload.DesiredReplicas, nil
+}
+
+// setWaitingOn records the dependency waited on in the application resource. An empty
+// dependency removes the record.
+func setWaitingOn(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, dependency string) error {
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return err
+	}
+
+	var value interface{}
+	if dependency != "" {
+		value = dependency
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				models.EpinioWaitingOnAnnotation: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Namespace(appRef.Namespace).Patch(ctx, appRef.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,151 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// previousLogLines is the number of log lines of the terminated container of a replica
+// shown in its diagnostics.
+const previousLogLines = 20
+
+// imagePullReasons are the waiting reasons of a container whose image cannot be pulled.
+var imagePullReasons = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// podDiagnostics returns the diagnostics of a replica of the application, based on the
+// status of the pod and of the named app container. The result is nil for a replica
+// without problems, now or before.
+func podDiagnostics(pod corev1.Pod, container string) *models.PodDiagnostics {
+	diagnostics := &models.PodDiagnostics{}
+
+	if pod.Status.Phase == corev1.PodPending {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+				diagnostics.PendingReason = joinReason(condition.Reason, condition.Message)
+			}
+		}
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != container {
+			continue
+		}
+
+		if cs.State.Waiting != nil && imagePullReasons[cs.State.Waiting.Reason] {
+			diagnostics.ImagePullError = joinReason(cs.State.Waiting.Reason, cs.State.Waiting.Message)
+		}
+
+		terminated := cs.State.Terminated
+		if terminated == nil {
+			terminated = cs.LastTerminationState.Terminated
+		}
+		if terminated != nil {
+			diagnostics.LastTermination = &models.ContainerTermination{
+				Reason:   terminated.Reason,
+				ExitCode: terminated.ExitCode,
+				Signal:   terminated.Signal,
+				Message:  strings.TrimSpace(terminated.Message),
+			}
+			if !terminated.FinishedAt.IsZero() {
+				diagnostics.LastTermination.FinishedAt = terminated.FinishedAt.Format(time.RFC3339)
+			}
+			diagnostics.OOMKilled = terminated.Reason == "OOMKilled"
+		}
+	}
+
+	if diagnostics.LastTermination == nil && diagnostics.ImagePullError == "" && diagnostics.PendingReason == "" {
+		return nil
+	}
+	return diagnostics
+}
+
+// joinReason combines the reason and message of a kubernetes status.
+func joinReason(reason, message string) string {
+	return strings.TrimSuffix(strings.TrimSpace(reason+": "+message), ":")
+}
+
+// populatePreviousLogs adds the last log lines of the terminated container to the
+// diagnostics of the restarted replicas. Errors are logged and ignored, as the information
+// is not essential.
+func (a *Workload) populatePreviousLogs(ctx context.Context, podInfos map[string]*models.PodInfo) {
+	for name, podInfo := range podInfos {
+		if podInfo.Restarts == 0 || podInfo.Diagnostics == nil || podInfo.Diagnostics.LastTermination == nil {
+			continue
+		}
+
+		tail := int64(previousLogLines)
+		raw, err := a.cluster.Kubectl.CoreV1().Pods(a.app.Namespace).GetLogs(name, &corev1.PodLogOptions{
+			Container: a.name,
+			Previous:  true,
+			TailLines: &tail,
+		}).DoRaw(ctx)
+		if err != nil {
+			helpers.Logger.Infow("previous logs not available", "app", a.app.Name, "pod", name, "error", err)
+			continue
+		}
+
+		podInfo.Diagnostics.PreviousLogs = logLines(raw)
+	}
+}
+
+// logLines splits raw logs into lines, dropping a trailing empty line.
+func logLines(raw []byte) []string {
+	result := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		result = append(result, scanner.Text())
+	}
+	return result
+}
+
+// workloadFailure returns a human-readable explanation of a workload without ready
+// replicas, based on the diagnostics of its replicas. The result is empty when there is
+// nothing to explain.
+func workloadFailure(workload *models.AppDeployment) string {
+	if workload == nil || workload.ReadyReplicas > 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(workload.Replicas))
+	for name, podInfo := range workload.Replicas {
+		if !podInfo.Ready && podInfo.Diagnostics != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+
+	message := fmt.Sprintf("no instance is ready, %s %s", names[0], workload.Replicas[names[0]].Diagnostics.Summary())
+	if len(names) > 1 {
+		message = fmt.Sprintf("%s (and %d more failing)", message, len(names)-1)
+	}
+	return message
+}
@@ -0,0 +1,144 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Diagnostics", func() {
+	Describe("podDiagnostics", func() {
+		It("returns nothing for a healthy replica", func() {
+			pod := v1.Pod{Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name:  "app",
+					Ready: true,
+					State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				}},
+			}}
+			Expect(podDiagnostics(pod, "app")).To(BeNil())
+		})
+
+		It("detects a container killed for running out of memory", func() {
+			pod := v1.Pod{Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name:  "app",
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+						Reason:   "OOMKilled",
+						ExitCode: 137,
+					}},
+				}},
+			}}
+
+			diagnostics := podDiagnostics(pod, "app")
+			Expect(diagnostics).ToNot(BeNil())
+			Expect(diagnostics.OOMKilled).To(BeTrue())
+			Expect(diagnostics.LastTermination.ExitCode).To(Equal(int32(137)))
+			Expect(diagnostics.Summary()).To(Equal("was killed for running out of memory (exit code 137)"))
+		})
+
+		It("ignores the other containers of the pod", func() {
+			pod := v1.Pod{Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name: "sidecar",
+					State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+						Reason:   "Error",
+						ExitCode: 1,
+					}},
+				}},
+			}}
+			Expect(podDiagnostics(pod, "app")).To(BeNil())
+		})
+
+		It("reports image pull errors", func() {
+			pod := v1.Pod{Status: v1.PodStatus{
+				Phase: v1.PodPending,
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name: "app",
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: "Back-off pulling image \"nope\"",
+					}},
+				}},
+			}}
+
+			diagnostics := podDiagnostics(pod, "app")
+			Expect(diagnostics).ToNot(BeNil())
+			Expect(diagnostics.ImagePullError).To(Equal("ImagePullBackOff: Back-off pulling image \"nope\""))
+			Expect(diagnostics.Summary()).To(HavePrefix("cannot pull its image"))
+		})
+
+		It("reports why a pending replica is not scheduled", func() {
+			pod := v1.Pod{Status: v1.PodStatus{
+				Phase: v1.PodPending,
+				Conditions: []v1.PodCondition{{
+					Type:    v1.PodScheduled,
+					Status:  v1.ConditionFalse,
+					Reason:  "Unschedulable",
+					Message: "0/1 nodes are available: 1 Insufficient memory.",
+				}},
+			}}
+
+			diagnostics := podDiagnostics(pod, "app")
+			Expect(diagnostics).ToNot(BeNil())
+			Expect(diagnostics.PendingReason).To(Equal("Unschedulable: 0/1 nodes are available: 1 Insufficient memory."))
+			Expect(diagnostics.Summary()).To(HavePrefix("cannot be scheduled"))
+		})
+	})
+
+	Describe("workloadFailure", func() {
+		failing := &models.PodDiagnostics{
+			LastTermination: &models.ContainerTermination{Reason: "Error", ExitCode: 1},
+		}
+
+		It("is empty while some replica is ready", func() {
+			Expect(workloadFailure(&models.AppDeployment{
+				ReadyReplicas: 1,
+				Replicas: map[string]*models.PodInfo{
+					"a": {Ready: true},
+					"b": {Diagnostics: failing},
+				},
+			})).To(BeEmpty())
+		})
+
+		It("is empty without diagnostics", func() {
+			Expect(workloadFailure(nil)).To(BeEmpty())
+			Expect(workloadFailure(&models.AppDeployment{
+				Replicas: map[string]*models.PodInfo{"a": {}},
+			})).To(BeEmpty())
+		})
+
+		It("explains the first failing replica", func() {
+			Expect(workloadFailure(&models.AppDeployment{
+				Replicas: map[string]*models.PodInfo{
+					"b": {Diagnostics: failing},
+					"a": {Diagnostics: failing},
+				},
+			})).To(Equal("no instance is ready, a terminated with Error (exit code 1) (and 1 more failing)"))
+		})
+	})
+
+	Describe("logLines", func() {
+		It("splits the logs into lines", func() {
+			Expect(logLines([]byte("one\ntwo\n"))).To(Equal([]string{"one", "two"}))
+			Expect(logLines(nil)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,338 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// eventComponent is the source component of the kubernetes events emitted by epinio.
+	eventComponent = "epinio-api"
+
+	// releaseHistoryMax is the number of helm release revisions shown in the timeline.
+	releaseHistoryMax = 20
+)
+
+// Events returns the timeline of the application, oldest first. It merges the kubernetes
+// events of the application, its pods and ingresses, and of its staging jobs, the events
+// emitted by epinio for scaling and API actions, and the transitions of its helm release.
+// Only the failure to read the events of the application's namespace is an error. The other
+// sources are best-effort.
+func Events(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) ([]models.AppEvent, error) {
+	objects := appObjects(ctx, cluster, appRef)
+
+	namespaceEvents, err := cluster.Kubectl.CoreV1().Events(appRef.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.AppEvent{}
+	for _, event := range namespaceEvents.Items {
+		if objects.has(event.InvolvedObject) {
+			result = append(result, kubeEvent(event))
+		}
+	}
+
+	jobs, err := cluster.Kubectl.BatchV1().Jobs(helmchart.Namespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("app.kubernetes.io/component=staging,app.kubernetes.io/name=%s,app.kubernetes.io/part-of=%s",
+			appRef.Name, appRef.Namespace),
+	})
+	if err != nil {
+		helpers.Logger.Infow("app events: listing staging jobs", "error", err, "app", appRef.Name, "namespace", appRef.Namespace)
+	} else if len(jobs.Items) > 0 {
+		stagingObjects := &eventObjects{names: map[string]bool{}}
+		for _, job := range jobs.Items {
+			result = append(result, stagingJobEvents(job)...)
+			stagingObjects.names["Job/"+job.Name] = true
+			stagingObjects.prefixes = append(stagingObjects.prefixes, job.Name+"-")
+		}
+
+		systemEvents, err := cluster.Kubectl.CoreV1().Events(helmchart.Namespace()).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			helpers.Logger.Infow("app events: listing staging events", "error", err, "app", appRef.Name, "namespace", appRef.Namespace)
+		} else {
+			for _, event := range systemEvents.Items {
+				if stagingObjects.has(event.InvolvedObject) {
+					result = append(result, kubeEvent(event))
+				}
+			}
+		}
+	}
+
+	releases, err := helm.History(cluster, appRef, releaseHistoryMax)
+	if err != nil {
+		// Includes the case of a not yet deployed application, without release.
+		helpers.Logger.Infow("app events: reading release history", "error", err, "app", appRef.Name, "namespace", appRef.Namespace)
+	}
+	result = append(result, releaseEvents(releases)...)
+
+	SortEvents(result)
+	return result, nil
+}
+
+// EmitActionEvent records an API action on the application as a kubernetes event of the
+// application. It also works for an application which was just deleted.
+func EmitActionEvent(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, action, username string) error {
+	if username == "" {
+		username = "unknown"
+	}
+	return emitAppEvent(ctx, cluster, appRef, "action", action, fmt.Sprintf("%s by %s", action, username))
+}
+
+// emitAppEvent creates a normal kubernetes event for the application, from epinio. The kind
+// is part of the generated name of the event.
+func emitAppEvent(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, kind, reason, message string) error {
+	involved := v1.ObjectReference{
+		APIVersion: "application.epinio.io/v1",
+		Kind:       "App",
+		Name:       appRef.Name,
+		Namespace:  appRef.Namespace,
+	}
+	if appCR, err := Get(ctx, cluster, appRef); err == nil {
+		involved.UID = appCR.GetUID()
+	}
+
+	now := metav1.NewTime(time.Now())
+	event := &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", appRef.Name, kind),
+			Namespace:    appRef.Namespace,
+		},
+		InvolvedObject: involved,
+		Reason:         reason,
+		Message:        message,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Type:           v1.EventTypeNormal,
+		Source: v1.EventSource{
+			Component: eventComponent,
+		},
+	}
+
+	_, err := cluster.Kubectl.CoreV1().Events(appRef.Namespace).Create(ctx, event, metav1.CreateOptions{})
+	return err
+}
+
+// SortEvents orders the events by time, keeping the order of simultaneous events.
+func SortEvents(events []models.AppEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time < events[j].Time
+	})
+}
+
+// EventKey returns a key identifying the event, for the detection of new events. A
+// repetition of a kubernetes event has a new key.
+func EventKey(event models.AppEvent) string {
+	return strings.Join([]string{
+		event.Time, event.Source, event.Object, event.Reason, fmt.Sprint(event.Count), event.Message,
+	}, "|")
+}
+
+// eventObjects are the kubernetes resources belonging to an application, by kind and
+// name, plus the name prefixes of the pods and replica sets created for them.
+type eventObjects struct {
+	names    map[string]bool
+	prefixes []string
+}
+
+func (o *eventObjects) has(object v1.ObjectReference) bool {
+	if o.names[object.Kind+"/"+object.Name] {
+		return true
+	}
+	if object.Kind != "Pod" && object.Kind != "ReplicaSet" {
+		return false
+	}
+	for _, prefix := range o.prefixes {
+		if strings.HasPrefix(object.Name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// appObjects returns the resources of the application whose events are part of its
+// timeline. Pods and replica sets are also matched by the name of their deployment, to
+// catch the events of the already removed ones.
+func appObjects(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) *eventObjects {
+	objects := &eventObjects{
+		names: map[string]bool{"App/" + appRef.Name: true},
+	}
+	selector := metav1.ListOptions{LabelSelector: "app.kubernetes.io/name=" + appRef.Name}
+
+	if pods, err := cluster.Kubectl.CoreV1().Pods(appRef.Namespace).List(ctx, selector); err == nil {
+		for _, pod := range pods.Items {
+			objects.names["Pod/"+pod.Name] = true
+		}
+	}
+	if deployments, err := cluster.Kubectl.AppsV1().Deployments(appRef.Namespace).List(ctx, selector); err == nil {
+		for _, deployment := range deployments.Items {
+			objects.names["Deployment/"+deployment.Name] = true
+			objects.prefixes = append(objects.prefixes, deployment.Name+"-")
+		}
+	}
+	if ingresses, err := ingressListForApp(ctx, cluster, appRef); err == nil {
+		for _, ingress := range ingresses.Items {
+			objects.names["Ingress/"+ingress.Name] = true
+		}
+	}
+
+	return objects
+}
+
+// kubeEvent converts a kubernetes event into a timeline event. The events emitted by epinio
+// itself are attributed to it.
+func kubeEvent(event v1.Event) models.AppEvent {
+	source := models.AppEventSourceKubernetes
+	if event.Source.Component == eventComponent {
+		source = models.AppEventSourceEpinio
+	}
+
+	when := event.LastTimestamp.Time
+	if when.IsZero() {
+		when = event.EventTime.Time
+	}
+	if when.IsZero() {
+		when = event.CreationTimestamp.Time
+	}
+
+	return models.AppEvent{
+		Time:    eventTime(when),
+		Source:  source,
+		Type:    event.Type,
+		Reason:  event.Reason,
+		Object:  event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+		Message: event.Message,
+		Count:   event.Count,
+	}
+}
+
+// stagingJobEvents returns the start, and the end, if any, of a staging job.
+func stagingJobEvents(job batchv1.Job) []models.AppEvent {
+	object := "Job/" + job.Name
+	stageID := job.Labels[models.EpinioStageIDLabel]
+
+	startMessage := fmt.Sprintf("staging %s started", stageID)
+	if user := job.Annotations[models.EpinioCreatedByAnnotation]; user != "" {
+		startMessage = fmt.Sprintf("%s by %s", startMessage, user)
+	}
+
+	result := []models.AppEvent{{
+		Time:    eventTime(job.CreationTimestamp.Time),
+		Source:  models.AppEventSourceStaging,
+		Type:    v1.EventTypeNormal,
+		Reason:  "StagingStarted",
+		Object:  object,
+		Message: startMessage,
+	}}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			result = append(result, models.AppEvent{
+				Time:    eventTime(condition.LastTransitionTime.Time),
+				Source:  models.AppEventSourceStaging,
+				Type:    v1.EventTypeNormal,
+				Reason:  "StagingSucceeded",
+				Object:  object,
+				Message: fmt.Sprintf("staging %s succeeded", stageID),
+			})
+		case batchv1.JobFailed:
+			message := fmt.Sprintf("staging %s failed", stageID)
+			if condition.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, condition.Message)
+			}
+			result = append(result, models.AppEvent{
+				Time:    eventTime(condition.LastTransitionTime.Time),
+				Source:  models.AppEventSourceStaging,
+				Type:    v1.EventTypeWarning,
+				Reason:  "StagingFailed",
+				Object:  object,
+				Message: message,
+			})
+		}
+	}
+
+	return result
+}
+
+// releaseEvents returns the deployment of each revision of a helm release, with its
+// current status.
+func releaseEvents(releases []*helmrelease.Release) []models.AppEvent {
+	result := []models.AppEvent{}
+	for _, release := range releases {
+		if release == nil || release.Info == nil {
+			continue
+		}
+
+		eventType := v1.EventTypeNormal
+		if release.Info.Status == helmrelease.StatusFailed {
+			eventType = v1.EventTypeWarning
+		}
+
+		when := release.Info.LastDeployed.Time
+		if when.IsZero() {
+			when = release.Info.FirstDeployed.Time
+		}
+
+		message := fmt.Sprintf("revision %d %s", release.Version, release.Info.Status)
+		if release.Info.Description != "" {
+			message = fmt.Sprintf("%s: %s", message, release.Info.Description)
+		}
+
+		result = append(result, models.AppEvent{
+			Time:    eventTime(when),
+			Source:  models.AppEventSourceHelm,
+			Type:    eventType,
+			Reason:  "Release" + camelCase(release.Info.Status.String()),
+			Object:  "Release/" + release.Name,
+			Message: message,
+		})
+	}
+	return result
+}
+
+// camelCase converts a dashed helm status, e.g. `pending-upgrade`, into the CamelCase of
+// event reasons.
+func camelCase(status string) string {
+	var result strings.Builder
+	for _, part := range strings.Split(status, "-") {
+		if part != "" {
+			result.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return result.String()
+}
+
+// eventTime formats the time of an event so that the formatted times sort like the times.
+func eventTime(when time.Time) string {
+	return when.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+}
@@ -0,0 +1,177 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Events", func() {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	Describe("kubeEvent", func() {
+		It("attributes the events emitted by epinio to it", func() {
+			event := kubeEvent(v1.Event{
+				InvolvedObject: v1.ObjectReference{Kind: "App", Name: "web"},
+				Reason:         "ScaleUp",
+				Message:        "scaled from 1 to 3 by admin",
+				Type:           v1.EventTypeNormal,
+				LastTimestamp:  metav1.NewTime(start),
+				Count:          1,
+				Source:         v1.EventSource{Component: "epinio-api"},
+			})
+			Expect(event).To(Equal(models.AppEvent{
+				Time:    "2026-03-01T10:00:00.000Z",
+				Source:  models.AppEventSourceEpinio,
+				Type:    v1.EventTypeNormal,
+				Reason:  "ScaleUp",
+				Object:  "App/web",
+				Message: "scaled from 1 to 3 by admin",
+				Count:   1,
+			}))
+		})
+
+		It("falls back to the event time", func() {
+			event := kubeEvent(v1.Event{
+				InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "web-abc"},
+				Reason:         "BackOff",
+				Type:           v1.EventTypeWarning,
+				EventTime:      metav1.NewMicroTime(start),
+				Source:         v1.EventSource{Component: "kubelet"},
+			})
+			Expect(event.Source).To(Equal(models.AppEventSourceKubernetes))
+			Expect(event.Time).To(Equal("2026-03-01T10:00:00.000Z"))
+		})
+	})
+
+	Describe("eventObjects", func() {
+		objects := &eventObjects{
+			names:    map[string]bool{"App/web": true, "Ingress/web-route": true},
+			prefixes: []string{"rweb-"},
+		}
+
+		It("matches the resources of the application", func() {
+			Expect(objects.has(v1.ObjectReference{Kind: "App", Name: "web"})).To(BeTrue())
+			Expect(objects.has(v1.ObjectReference{Kind: "Ingress", Name: "web-route"})).To(BeTrue())
+			Expect(objects.has(v1.ObjectReference{Kind: "Pod", Name: "rweb-5f7c-x2x"})).To(BeTrue())
+			Expect(objects.has(v1.ObjectReference{Kind: "ReplicaSet", Name: "rweb-5f7c"})).To(BeTrue())
+		})
+
+		It("ignores other resources", func() {
+			Expect(objects.has(v1.ObjectReference{Kind: "App", Name: "api"})).To(BeFalse())
+			Expect(objects.has(v1.ObjectReference{Kind: "Secret", Name: "rweb-creds"})).To(BeFalse())
+			Expect(objects.has(v1.ObjectReference{Kind: "Pod", Name: "rapi-5f7c-x2x"})).To(BeFalse())
+		})
+	})
+
+	Describe("stagingJobEvents", func() {
+		It("returns the start and the failure of a staging", func() {
+			job := batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "stage-web-s1",
+					CreationTimestamp: metav1.NewTime(start),
+					Labels:            map[string]string{models.EpinioStageIDLabel: "s1"},
+					Annotations:       map[string]string{models.EpinioCreatedByAnnotation: "admin"},
+				},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{{
+						Type:               batchv1.JobFailed,
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(start.Add(time.Minute)),
+						Message:            "Job has reached the specified backoff limit",
+					}},
+				},
+			}
+
+			events := stagingJobEvents(job)
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Reason).To(Equal("StagingStarted"))
+			Expect(events[0].Message).To(Equal("staging s1 started by admin"))
+			Expect(events[1].Reason).To(Equal("StagingFailed"))
+			Expect(events[1].Type).To(Equal(v1.EventTypeWarning))
+			Expect(events[1].Message).To(Equal("staging s1 failed: Job has reached the specified backoff limit"))
+		})
+
+		It("returns only the start of a running staging", func() {
+			job := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "stage-web-s2", CreationTimestamp: metav1.NewTime(start)}}
+			Expect(stagingJobEvents(job)).To(HaveLen(1))
+		})
+	})
+
+	Describe("releaseEvents", func() {
+		It("returns the revisions of the release", func() {
+			events := releaseEvents([]*helmrelease.Release{
+				{
+					Name:    "rweb",
+					Version: 2,
+					Info: &helmrelease.Info{
+						Status:       helmrelease.StatusPendingUpgrade,
+						LastDeployed: helmtime.Time{Time: start},
+						Description:  "Preparing upgrade",
+					},
+				},
+				{
+					Name:    "rweb",
+					Version: 1,
+					Info: &helmrelease.Info{
+						Status:       helmrelease.StatusFailed,
+						LastDeployed: helmtime.Time{Time: start.Add(-time.Hour)},
+					},
+				},
+				nil,
+			})
+
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Reason).To(Equal("ReleasePendingUpgrade"))
+			Expect(events[0].Message).To(Equal("revision 2 pending-upgrade: Preparing upgrade"))
+			Expect(events[1].Reason).To(Equal("ReleaseFailed"))
+			Expect(events[1].Type).To(Equal(v1.EventTypeWarning))
+		})
+	})
+
+	Describe("SortEvents", func() {
+		It("orders the events by time, keeping simultaneous events in order", func() {
+			events := []models.AppEvent{
+				{Time: eventTime(start.Add(time.Minute)), Reason: "c"},
+				{Time: eventTime(start), Reason: "a"},
+				{Time: eventTime(start.Add(time.Minute)), Reason: "d"},
+				{Time: eventTime(start.Add(500 * time.Millisecond)), Reason: "b"},
+			}
+			SortEvents(events)
+
+			reasons := []string{}
+			for _, event := range events {
+				reasons = append(reasons, event.Reason)
+			}
+			Expect(reasons).To(Equal([]string{"a", "b", "c", "d"}))
+		})
+	})
+
+	Describe("EventKey", func() {
+		It("changes with the repetition of an event", func() {
+			event := models.AppEvent{Time: eventTime(start), Reason: "BackOff", Count: 1}
+			repeated := event
+			repeated.Count = 2
+			Expect(EventKey(event)).ToNot(Equal(EventKey(repeated)))
+		})
+	})
+})
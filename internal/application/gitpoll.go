@@ -0,0 +1,257 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// Keys of the git poll secret
+const (
+	gitPollIntervalKey    = "interval"
+	gitPollUsernameKey    = "username"
+	gitPollLastCheckedKey = "lastChecked"
+	gitPollLastCommitKey  = "lastCommit"
+	gitPollLastErrorKey   = "lastError"
+	gitPollFailuresKey    = "failures"
+	gitPollNextCheckKey   = "nextCheck"
+)
+
+const (
+	// MinGitPollInterval is the smallest poll interval accepted.
+	MinGitPollInterval = time.Minute
+	// maxGitPollBackoff limits the delay between checks after repeated failures. Intervals
+	// larger than this are not extended.
+	maxGitPollBackoff = time.Hour
+)
+
+// GitPoll returns the polling configuration and status of the application. The result is
+// nil if the application is not polled.
+func GitPoll(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.GitPollStatus, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeGitPollSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return GitPollFromSecret(secret)
+}
+
+// GitPollFromSecret is the core of GitPoll, extracting the configuration and status from
+// the secret containing them. The result is nil if the application is not polled.
+func GitPollFromSecret(secret *v1.Secret) (*models.GitPollStatus, error) {
+	interval := string(secret.Data[gitPollIntervalKey])
+	if interval == "" {
+		return nil, nil
+	}
+
+	status := &models.GitPollStatus{
+		Interval:    interval,
+		Username:    string(secret.Data[gitPollUsernameKey]),
+		LastChecked: string(secret.Data[gitPollLastCheckedKey]),
+		LastCommit:  string(secret.Data[gitPollLastCommitKey]),
+		LastError:   string(secret.Data[gitPollLastErrorKey]),
+		NextCheck:   string(secret.Data[gitPollNextCheckKey]),
+	}
+
+	if failures := string(secret.Data[gitPollFailuresKey]); failures != "" {
+		i, err := strconv.ParseInt(failures, 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad git poll failures '%s'", failures)
+		}
+		status.Failures = int32(i)
+	}
+
+	return status, nil
+}
+
+// GitPollSet configures the polling of the named application, for the named user. An empty
+// interval stops the polling. The next check is scheduled one interval from now, as the
+// application was just deployed.
+func GitPollSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, interval, username string) error {
+	if interval == "" {
+		err := cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Delete(ctx,
+			appRef.MakeGitPollSecretName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	period, err := time.ParseDuration(interval)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef, appRef.MakeGitPollSecretName(), "gitpoll")
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[gitPollIntervalKey] = []byte(interval)
+		secret.Data[gitPollUsernameKey] = []byte(username)
+		secret.Data[gitPollNextCheckKey] = []byte(GitPollNextCheck(period, 0, time.Now()).Format(time.RFC3339))
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// GitPollRecord records the result of a check of the named application, and schedules the
+// next check. A failed check is retried with exponential backoff. A successful check
+// records the commit found at the head of the tracked branch.
+func GitPollRecord(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, commit string, checkErr error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeGitPollSecretName())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// Polling was stopped during the check
+				return nil
+			}
+			return err
+		}
+
+		status, err := GitPollFromSecret(secret)
+		if err != nil {
+			return err
+		}
+		if status == nil {
+			return nil
+		}
+
+		period, err := time.ParseDuration(status.Interval)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		secret.Data[gitPollLastCheckedKey] = []byte(now.UTC().Format(time.RFC3339))
+
+		if checkErr != nil {
+			status.Failures++
+			secret.Data[gitPollLastErrorKey] = []byte(checkErr.Error())
+			secret.Data[gitPollFailuresKey] = []byte(strconv.Itoa(int(status.Failures)))
+		} else {
+			status.Failures = 0
+			delete(secret.Data, gitPollLastErrorKey)
+			delete(secret.Data, gitPollFailuresKey)
+			if commit != "" {
+				secret.Data[gitPollLastCommitKey] = []byte(commit)
+			}
+		}
+
+		next := GitPollNextCheck(period, status.Failures, now)
+		secret.Data[gitPollNextCheckKey] = []byte(next.UTC().Format(time.RFC3339))
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// GitPolls returns the polling configuration and status of all polled applications.
+func GitPolls(ctx context.Context, cluster *kubernetes.Cluster) (map[models.AppRef]*models.GitPollStatus, error) {
+	secrets, err := cluster.Kubectl.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=gitpoll", EpinioApplicationAreaLabel),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[models.AppRef]*models.GitPollStatus{}
+	for idx := range secrets.Items {
+		secret := &secrets.Items[idx]
+		appName := secret.GetLabels()["app.kubernetes.io/name"]
+		if appName == "" {
+			continue
+		}
+		status, err := GitPollFromSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+		if status == nil {
+			continue
+		}
+		result[models.NewAppRef(appName, secret.Namespace)] = status
+	}
+
+	return result, nil
+}
+
+// GitPollDue returns true if the next check of the application is due.
+func GitPollDue(status *models.GitPollStatus, now time.Time) bool {
+	if status.NextCheck == "" {
+		return true
+	}
+	next, err := time.Parse(time.RFC3339, status.NextCheck)
+	if err != nil {
+		return true
+	}
+	return !now.Before(next)
+}
+
+// GitPollNextCheck returns the time of the next check after `now`, for the interval and
+// number of consecutive failures. Failures double the delay, up to an hour. A jitter of up
+// to a tenth of the delay spreads the checks of applications with the same interval.
+func GitPollNextCheck(interval time.Duration, failures int32, now time.Time) time.Time {
+	delay := interval
+	for i := int32(0); i < failures && delay < maxGitPollBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxGitPollBackoff && interval < maxGitPollBackoff {
+		delay = maxGitPollBackoff
+	}
+
+	jitter := time.Duration(rand.Int64N(int64(delay/10) + 1))
+
+	return now.Add(delay + jitter)
+}
+
+// ValidateGitPollInterval checks that the interval is a proper duration, and not below the
+// minimum. It returns a bad request API error for any issue. The empty interval is valid,
+// it disables polling.
+func ValidateGitPollInterval(interval string) error {
+	if interval == "" {
+		return nil
+	}
+
+	period, err := time.ParseDuration(interval)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("bad git poll interval '%s'", interval).WithDetails(err.Error())
+	}
+	if period < MinGitPollInterval {
+		return apierror.NewBadRequestErrorf("git poll interval '%s' is below the minimum of %s",
+			interval, MinGitPollInterval)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("GitPoll", func() {
+	Describe("GitPollFromSecret", func() {
+		It("decodes the stored status", func() {
+			status, err := application.GitPollFromSecret(&v1.Secret{
+				Data: map[string][]byte{
+					"interval":   []byte("5m"),
+					"username":   []byte("admin"),
+					"lastCommit": []byte("abc123"),
+					"lastError":  []byte("timeout"),
+					"failures":   []byte("2"),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(Equal(&models.GitPollStatus{
+				Interval:   "5m",
+				Username:   "admin",
+				LastCommit: "abc123",
+				LastError:  "timeout",
+				Failures:   2,
+			}))
+		})
+
+		It("returns nothing without an interval", func() {
+			status, err := application.GitPollFromSecret(&v1.Secret{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(BeNil())
+		})
+	})
+
+	Describe("GitPollNextCheck", func() {
+		now := time.Now()
+
+		It("adds the interval and some jitter", func() {
+			next := application.GitPollNextCheck(5*time.Minute, 0, now)
+			Expect(next).To(BeTemporally(">=", now.Add(5*time.Minute)))
+			Expect(next).To(BeTemporally("<=", now.Add(5*time.Minute+30*time.Second)))
+		})
+
+		It("backs off on failures, up to an hour", func() {
+			next := application.GitPollNextCheck(5*time.Minute, 2, now)
+			Expect(next).To(BeTemporally(">=", now.Add(20*time.Minute)))
+			Expect(next).To(BeTemporally("<=", now.Add(22*time.Minute)))
+
+			next = application.GitPollNextCheck(5*time.Minute, 10, now)
+			Expect(next).To(BeTemporally(">=", now.Add(time.Hour)))
+			Expect(next).To(BeTemporally("<=", now.Add(66*time.Minute)))
+		})
+	})
+
+	Describe("GitPollDue", func() {
+		It("compares the next check to the time", func() {
+			now := time.Now()
+			status := &models.GitPollStatus{NextCheck: now.Add(time.Minute).Format(time.RFC3339)}
+			Expect(application.GitPollDue(status, now)).To(BeFalse())
+			Expect(application.GitPollDue(status, now.Add(2*time.Minute))).To(BeTrue())
+			Expect(application.GitPollDue(&models.GitPollStatus{}, now)).To(BeTrue())
+		})
+	})
+
+	Describe("ValidateGitPollInterval", func() {
+		It("accepts proper and empty intervals", func() {
+			Expect(application.ValidateGitPollInterval("5m")).To(Succeed())
+			Expect(application.ValidateGitPollInterval("")).To(Succeed())
+		})
+
+		It("rejects bad and short intervals", func() {
+			Expect(application.ValidateGitPollInterval("often")).ToNot(Succeed())
+			Expect(application.ValidateGitPollInterval("10s")).ToNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,213 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// Keys of the git trigger secret
+const (
+	gitTriggerSecretKey     = "secret"
+	gitTriggerUsernameKey   = "username"
+	gitTriggerDeliveriesKey = "deliveries"
+)
+
+// maxGitDeliveries is the number of webhook deliveries remembered per application.
+const maxGitDeliveries = 20
+
+// GitTrigger returns the push-to-deploy configuration of the application, including the
+// webhook secret. The result is nil if the trigger is not enabled.
+func GitTrigger(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.GitTrigger, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeGitTriggerSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return GitTriggerFromSecret(secret)
+}
+
+// GitTriggerFromSecret is the core of GitTrigger, extracting the configuration from the
+// secret containing it. The result is nil if the trigger is not enabled.
+func GitTriggerFromSecret(secret *v1.Secret) (*models.GitTrigger, error) {
+	webhookSecret := string(secret.Data[gitTriggerSecretKey])
+	if webhookSecret == "" {
+		return nil, nil
+	}
+
+	trigger := &models.GitTrigger{
+		Enabled:  true,
+		Secret:   webhookSecret,
+		Username: string(secret.Data[gitTriggerUsernameKey]),
+	}
+
+	if data, ok := secret.Data[gitTriggerDeliveriesKey]; ok {
+		if err := json.Unmarshal(data, &trigger.Deliveries); err != nil {
+			return nil, errors.Wrap(err, "bad git deliveries")
+		}
+	}
+
+	return trigger, nil
+}
+
+// GitTriggerEnable generates a new webhook secret for the named application and returns
+// it. Deployments triggered by webhooks are made for the named user. Enabling an already
+// enabled trigger rotates the secret, and keeps the recorded deliveries.
+func GitTriggerEnable(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, username string) (string, error) {
+	randBytes := make([]byte, 32)
+	if _, err := rand.Read(randBytes); err != nil {
+		return "", err
+	}
+	webhookSecret := hex.EncodeToString(randBytes)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef, appRef.MakeGitTriggerSecretName(), "gittrigger")
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[gitTriggerSecretKey] = []byte(webhookSecret)
+		secret.Data[gitTriggerUsernameKey] = []byte(username)
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return webhookSecret, nil
+}
+
+// GitTriggerDisable removes the webhook secret and the recorded deliveries of the named
+// application.
+func GitTriggerDisable(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) error {
+	err := cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Delete(ctx,
+		appRef.MakeGitTriggerSecretName(), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// GitTriggerClaimDelivery records the verified delivery as received, unless a delivery with
+// the same provider and id was recorded before. The result is false for such replays, which
+// are not to be acted upon again. Nothing is recorded, and the result is false, when the
+// trigger is not enabled.
+func GitTriggerClaimDelivery(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, delivery models.GitDelivery) (bool, error) {
+	claimed := false
+	err := updateGitDeliveries(ctx, cluster, appRef, func(deliveries []models.GitDelivery) ([]models.GitDelivery, bool) {
+		if findGitDelivery(deliveries, delivery) >= 0 {
+			claimed = false
+			return deliveries, false
+		}
+		claimed = true
+		return recordGitDelivery(deliveries, delivery), true
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
+
+// GitTriggerRecordDelivery records the outcome of the verified delivery with the recent
+// deliveries of the named application, replacing the entry made when claiming it, and
+// dropping the oldest ones beyond the limit. Nothing is recorded when the trigger is not
+// enabled.
+func GitTriggerRecordDelivery(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, delivery models.GitDelivery) error {
+	return updateGitDeliveries(ctx, cluster, appRef, func(deliveries []models.GitDelivery) ([]models.GitDelivery, bool) {
+		return recordGitDelivery(deliveries, delivery), true
+	})
+}
+
+// updateGitDeliveries applies the modifier to the recorded deliveries of the named
+// application, and saves the result if the modifier asks for it. Nothing is done when the
+// trigger is not enabled.
+func updateGitDeliveries(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef,
+	modify func([]models.GitDelivery) ([]models.GitDelivery, bool)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeGitTriggerSecretName())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		trigger, err := GitTriggerFromSecret(secret)
+		if err != nil {
+			return err
+		}
+		if trigger == nil {
+			return nil
+		}
+
+		deliveries, changed := modify(trigger.Deliveries)
+		if !changed {
+			return nil
+		}
+
+		data, err := json.Marshal(deliveries)
+		if err != nil {
+			return err
+		}
+		secret.Data[gitTriggerDeliveriesKey] = data
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// recordGitDelivery replaces the entry of the delivery in the list, or adds it as the most
+// recent one, dropping the oldest entries beyond the limit.
+func recordGitDelivery(deliveries []models.GitDelivery, delivery models.GitDelivery) []models.GitDelivery {
+	if index := findGitDelivery(deliveries, delivery); index >= 0 {
+		result := append([]models.GitDelivery{}, deliveries...)
+		result[index] = delivery
+		return result
+	}
+
+	result := append([]models.GitDelivery{delivery}, deliveries...)
+	if len(result) > maxGitDeliveries {
+		result = result[:maxGitDeliveries]
+	}
+	return result
+}
+
+// findGitDelivery returns the index of the entry for the delivery in the list, or -1.
+func findGitDelivery(deliveries []models.GitDelivery, delivery models.GitDelivery) int {
+	for index, d := range deliveries {
+		if d.ID == delivery.ID && d.Provider == delivery.Provider {
+			return index
+		}
+	}
+	return -1
+}
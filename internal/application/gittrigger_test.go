@@ -0,0 +1,133 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("GitTriggerFromSecret", func() {
+	It("returns nothing for a trigger without secret", func() {
+		trigger, err := application.GitTriggerFromSecret(&v1.Secret{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(trigger).To(BeNil())
+	})
+
+	It("decodes the trigger and its deliveries", func() {
+		trigger, err := application.GitTriggerFromSecret(&v1.Secret{
+			Data: map[string][]byte{
+				"secret":     []byte("s3cr3t"),
+				"username":   []byte("admin"),
+				"deliveries": []byte(`[{"id":"d-1","result":"ignored","receivedAt":"2023-01-01T00:00:00Z"}]`),
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(trigger).To(Equal(&models.GitTrigger{
+			Enabled:  true,
+			Secret:   "s3cr3t",
+			Username: "admin",
+			Deliveries: []models.GitDelivery{{
+				ID:         "d-1",
+				Result:     models.GitDeliveryIgnored,
+				ReceivedAt: "2023-01-01T00:00:00Z",
+			}},
+		}))
+	})
+
+	It("fails for bad deliveries", func() {
+		_, err := application.GitTriggerFromSecret(&v1.Secret{
+			Data: map[string][]byte{
+				"secret":     []byte("s3cr3t"),
+				"deliveries": []byte(`[`),
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("GitTriggerClaimDelivery", func() {
+	var (
+		ctx     context.Context
+		cluster *kubernetes.Cluster
+		appRef  models.AppRef
+	)
+
+	delivery := func(id, result string) models.GitDelivery {
+		return models.GitDelivery{ID: id, Provider: models.ProviderGithub, Result: result}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		appRef = models.NewAppRef("app", "workspace")
+		cluster = &kubernetes.Cluster{
+			Kubectl: k8sfake.NewSimpleClientset(&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      appRef.MakeGitTriggerSecretName(),
+					Namespace: appRef.Namespace,
+				},
+				Data: map[string][]byte{"secret": []byte("s3cr3t")},
+			}),
+		}
+	})
+
+	deliveries := func() []models.GitDelivery {
+		trigger, err := application.GitTrigger(ctx, cluster, appRef)
+		Expect(err).ToNot(HaveOccurred())
+		return trigger.Deliveries
+	}
+
+	It("claims a delivery once", func() {
+		claimed, err := application.GitTriggerClaimDelivery(ctx, cluster, appRef, delivery("d-1", models.GitDeliveryReceived))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(claimed).To(BeTrue())
+
+		claimed, err = application.GitTriggerClaimDelivery(ctx, cluster, appRef, delivery("d-1", models.GitDeliveryReceived))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(claimed).To(BeFalse())
+
+		Expect(deliveries()).To(Equal([]models.GitDelivery{delivery("d-1", models.GitDeliveryReceived)}))
+	})
+
+	It("records the outcome of a claimed delivery in place", func() {
+		for _, id := range []string{"d-1", "d-2"} {
+			claimed, err := application.GitTriggerClaimDelivery(ctx, cluster, appRef, delivery(id, models.GitDeliveryReceived))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		}
+
+		err := application.GitTriggerRecordDelivery(ctx, cluster, appRef, delivery("d-1", models.GitDeliveryDeployed))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(deliveries()).To(Equal([]models.GitDelivery{
+			delivery("d-2", models.GitDeliveryReceived),
+			delivery("d-1", models.GitDeliveryDeployed),
+		}))
+	})
+
+	It("does not claim deliveries without trigger", func() {
+		Expect(application.GitTriggerDisable(ctx, cluster, appRef)).To(Succeed())
+
+		claimed, err := application.GitTriggerClaimDelivery(ctx, cluster, appRef, delivery("d-1", models.GitDeliveryReceived))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(claimed).To(BeFalse())
+	})
+})
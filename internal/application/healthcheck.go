@@ -0,0 +1,290 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// Keys of the health check secret
+const (
+	healthcheckTypeKey             = "type"
+	healthcheckPathKey             = "path"
+	healthcheckPortKey             = "port"
+	healthcheckTimeoutKey          = "timeout"
+	healthcheckPeriodKey           = "period"
+	healthcheckSuccessThresholdKey = "success-threshold"
+	healthcheckFailureThresholdKey = "failure-threshold"
+	healthcheckStartupGraceKey     = "startup-grace"
+)
+
+// probeFailureReason is the reason of the events kubernetes emits for failing probes
+const probeFailureReason = "Unhealthy"
+
+// Healthcheck returns the health check configuration set by a user for the application.
+// The result is nil if nothing was set.
+func Healthcheck(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.AppHealthcheck, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeHealthcheckSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return HealthcheckFromSecret(secret)
+}
+
+// HealthcheckFromSecret is the core of Healthcheck, extracting the configuration from the
+// secret containing it. The result is nil if nothing was set.
+func HealthcheckFromSecret(secret *v1.Secret) (*models.AppHealthcheck, error) {
+	kind := string(secret.Data[healthcheckTypeKey])
+	if kind == "" {
+		return nil, nil
+	}
+
+	healthcheck := &models.AppHealthcheck{
+		Type: kind,
+		Path: string(secret.Data[healthcheckPathKey]),
+	}
+
+	for key, field := range map[string]*int32{
+		healthcheckPortKey:             &healthcheck.Port,
+		healthcheckTimeoutKey:          &healthcheck.TimeoutSeconds,
+		healthcheckPeriodKey:           &healthcheck.PeriodSeconds,
+		healthcheckSuccessThresholdKey: &healthcheck.SuccessThreshold,
+		healthcheckFailureThresholdKey: &healthcheck.FailureThreshold,
+		healthcheckStartupGraceKey:     &healthcheck.StartupGraceSeconds,
+	} {
+		value := string(secret.Data[key])
+		if value == "" {
+			continue
+		}
+		i, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad health check %s '%s'", key, value)
+		}
+		*field = int32(i)
+	}
+
+	return healthcheck, nil
+}
+
+// HealthcheckSet replaces the health check configuration of the named application.  A nil
+// argument, or one without a type, removes the configuration.
+func HealthcheckSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, healthcheck *models.AppHealthcheck) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef, appRef.MakeHealthcheckSecretName(), "healthcheck")
+		if err != nil {
+			return err
+		}
+
+		secret.Data = map[string][]byte{}
+		if healthcheck != nil && healthcheck.Type != "" {
+			secret.Data[healthcheckTypeKey] = []byte(healthcheck.Type)
+			if healthcheck.Path != "" {
+				secret.Data[healthcheckPathKey] = []byte(healthcheck.Path)
+			}
+			for key, value := range map[string]int32{
+				healthcheckPortKey:             healthcheck.Port,
+				healthcheckTimeoutKey:          healthcheck.TimeoutSeconds,
+				healthcheckPeriodKey:           healthcheck.PeriodSeconds,
+				healthcheckSuccessThresholdKey: healthcheck.SuccessThreshold,
+				healthcheckFailureThresholdKey: healthcheck.FailureThreshold,
+				healthcheckStartupGraceKey:     healthcheck.StartupGraceSeconds,
+			} {
+				if value != 0 {
+					secret.Data[key] = []byte(strconv.Itoa(int(value)))
+				}
+			}
+		}
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// ValidateHealthcheck checks the health check configuration for an unknown type, settings
+// not applicable to the type, and out of range values. It returns a bad request API error
+// for the first issue found. A configuration without type and settings is valid, it
+// removes the health check.
+func ValidateHealthcheck(healthcheck *models.AppHealthcheck) error {
+	if healthcheck == nil || *healthcheck == (models.AppHealthcheck{}) {
+		return nil
+	}
+
+	switch healthcheck.Type {
+	case models.HealthcheckHTTP:
+		if healthcheck.Path != "" && !strings.HasPrefix(healthcheck.Path, "/") {
+			return apierror.NewBadRequestErrorf("health check path '%s' must start with '/'", healthcheck.Path)
+		}
+	case models.HealthcheckTCP, models.HealthcheckProcess:
+		if healthcheck.Path != "" {
+			return apierror.NewBadRequestErrorf("health check path is not supported for type '%s'", healthcheck.Type)
+		}
+	default:
+		return apierror.NewBadRequestErrorf("unknown health check type '%s'", healthcheck.Type).
+			WithDetailsf("known types: %s, %s, %s",
+				models.HealthcheckHTTP, models.HealthcheckTCP, models.HealthcheckProcess)
+	}
+
+	if healthcheck.Port < 0 || healthcheck.Port > 65535 {
+		return apierror.NewBadRequestErrorf("health check port %d is out of range", healthcheck.Port)
+	}
+
+	for name, value := range map[string]int32{
+		"timeout":           healthcheck.TimeoutSeconds,
+		"period":            healthcheck.PeriodSeconds,
+		"success threshold": healthcheck.SuccessThreshold,
+		"failure threshold": healthcheck.FailureThreshold,
+		"start-up grace":    healthcheck.StartupGraceSeconds,
+	} {
+		if value < 0 {
+			return apierror.NewBadRequestErrorf("health check %s must not be negative", name)
+		}
+	}
+
+	return nil
+}
+
+// probeFailures returns a map from pod names to the message of the last failing probe
+// reported for the pod, for all pods in the namespace.
+func probeFailures(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (map[string]string, error) {
+	events, err := cluster.Kubectl.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=Pod,reason=%s", probeFailureReason),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]string{}
+	last := map[string]time.Time{}
+	for _, event := range events.Items {
+		pod := event.InvolvedObject.Name
+		when := event.LastTimestamp.Time
+		if when.IsZero() {
+			when = event.EventTime.Time
+		}
+		if previous, ok := last[pod]; ok && previous.After(when) {
+			continue
+		}
+		last[pod] = when
+		result[pod] = strings.TrimSpace(event.Message)
+	}
+
+	return result, nil
+}
+
+// ProbeFailureTracker remembers the pods of an application while it is deployed. This
+// enables the reporting of failing health checks even after a failed deployment was rolled
+// back and its pods are gone.
+type ProbeFailureTracker struct {
+	cluster *kubernetes.Cluster
+	app     models.AppRef
+	mutex   sync.Mutex
+	pods    map[string]struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// TrackProbeFailures starts tracking the pods of the referenced application. The caller
+// has to invoke `Stop` when the deployment is done.
+func TrackProbeFailures(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) *ProbeFailureTracker {
+	tracker := &ProbeFailureTracker{
+		cluster: cluster,
+		app:     appRef,
+		pods:    map[string]struct{}{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(tracker.done)
+
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			tracker.collect(ctx)
+
+			select {
+			case <-tracker.stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return tracker
+}
+
+// Stop ends the tracking and returns the last probe failure message of every tracked pod,
+// prefixed with the name of the pod. The result is sorted by pod name.
+func (t *ProbeFailureTracker) Stop(ctx context.Context) []string {
+	close(t.stop)
+	<-t.done
+
+	// Catch pods which came up since the last poll.
+	t.collect(ctx)
+
+	failures, err := probeFailures(ctx, t.cluster, t.app.Namespace)
+	if err != nil {
+		helpers.Logger.Infow("failed to get probe failures", "app", t.app.Name, "namespace", t.app.Namespace, "error", err)
+		return nil
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	result := []string{}
+	for pod := range t.pods {
+		if message, ok := failures[pod]; ok {
+			result = append(result, fmt.Sprintf("%s: %s", pod, message))
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func (t *ProbeFailureTracker) collect(ctx context.Context) {
+	pods, err := NewWorkload(t.cluster, t.app, 0).Pods(ctx)
+	if err != nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, pod := range pods {
+		t.pods[pod.Name] = struct{}{}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Healthcheck", func() {
+	Describe("HealthcheckFromSecret", func() {
+		It("returns nil for a secret without type", func() {
+			healthcheck, err := application.HealthcheckFromSecret(&v1.Secret{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(healthcheck).To(BeNil())
+		})
+
+		It("decodes the stored configuration", func() {
+			healthcheck, err := application.HealthcheckFromSecret(&v1.Secret{
+				Data: map[string][]byte{
+					"type":              []byte("http"),
+					"path":              []byte("/healthz"),
+					"port":              []byte("9090"),
+					"failure-threshold": []byte("5"),
+					"startup-grace":     []byte("30"),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(healthcheck).To(Equal(&models.AppHealthcheck{
+				Type:                models.HealthcheckHTTP,
+				Path:                "/healthz",
+				Port:                9090,
+				FailureThreshold:    5,
+				StartupGraceSeconds: 30,
+			}))
+		})
+
+		It("fails for bad numbers", func() {
+			_, err := application.HealthcheckFromSecret(&v1.Secret{
+				Data: map[string][]byte{
+					"type":    []byte("tcp"),
+					"timeout": []byte("soon"),
+				},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ValidateHealthcheck", func() {
+		It("accepts a missing or empty configuration", func() {
+			Expect(application.ValidateHealthcheck(nil)).To(Succeed())
+			Expect(application.ValidateHealthcheck(&models.AppHealthcheck{})).To(Succeed())
+		})
+
+		It("accepts the known types", func() {
+			for _, kind := range []string{models.HealthcheckHTTP, models.HealthcheckTCP, models.HealthcheckProcess} {
+				Expect(application.ValidateHealthcheck(&models.AppHealthcheck{Type: kind})).To(Succeed())
+			}
+		})
+
+		It("rejects an unknown type", func() {
+			err := application.ValidateHealthcheck(&models.AppHealthcheck{Type: "exec"})
+			Expect(err).To(MatchError(ContainSubstring("unknown health check type 'exec'")))
+		})
+
+		It("rejects a path for a tcp check", func() {
+			err := application.ValidateHealthcheck(&models.AppHealthcheck{Type: models.HealthcheckTCP, Path: "/"})
+			Expect(err).To(MatchError(ContainSubstring("path is not supported")))
+		})
+
+		It("rejects a relative path", func() {
+			err := application.ValidateHealthcheck(&models.AppHealthcheck{Type: models.HealthcheckHTTP, Path: "healthz"})
+			Expect(err).To(MatchError(ContainSubstring("must start with '/'")))
+		})
+
+		It("rejects a bad port and negative values", func() {
+			err := application.ValidateHealthcheck(&models.AppHealthcheck{Type: models.HealthcheckTCP, Port: 70000})
+			Expect(err).To(MatchError(ContainSubstring("out of range")))
+
+			err = application.ValidateHealthcheck(&models.AppHealthcheck{Type: models.HealthcheckTCP, PeriodSeconds: -1})
+			Expect(err).To(MatchError(ContainSubstring("period must not be negative")))
+		})
+	})
+})
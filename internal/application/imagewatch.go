@@ -0,0 +1,315 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// Keys of the image watch secret
+const (
+	imageWatchIntervalKey     = "interval"
+	imageWatchModeKey         = "mode"
+	imageWatchUsernameKey     = "username"
+	imageWatchLastCheckedKey  = "lastChecked"
+	imageWatchLatestDigestKey = "latestDigest"
+	imageWatchLastErrorKey    = "lastError"
+	imageWatchFailuresKey     = "failures"
+	imageWatchNextCheckKey    = "nextCheck"
+)
+
+// MinImageWatchInterval is the smallest image watch interval accepted.
+const MinImageWatchInterval = time.Minute
+
+// ImageDigest returns the digest the container image of the application is pinned to. The
+// result is empty if the image is not pinned.
+func ImageDigest(app *unstructured.Unstructured) string {
+	return app.GetAnnotations()[models.EpinioImageDigestAnnotation]
+}
+
+// ImageDigestSet records the digest the container image of the named application is
+// pinned to. An empty digest removes the pin.
+func ImageDigestSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, digest string) error {
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return err
+	}
+
+	var value interface{}
+	if digest != "" {
+		value = digest
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				models.EpinioImageDigestAnnotation: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Namespace(appRef.Namespace).Patch(ctx, appRef.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// ImageWatch returns the image watch configuration and status of the application. The
+// result is nil if the image of the application is not watched.
+func ImageWatch(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.ImageWatchStatus, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeImageWatchSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ImageWatchFromSecret(secret)
+}
+
+// ImageWatchFromSecret is the core of ImageWatch, extracting the configuration and status
+// from the secret containing them. The result is nil if the image is not watched.
+func ImageWatchFromSecret(secret *v1.Secret) (*models.ImageWatchStatus, error) {
+	interval := string(secret.Data[imageWatchIntervalKey])
+	if interval == "" {
+		return nil, nil
+	}
+
+	status := &models.ImageWatchStatus{
+		Interval:     interval,
+		Mode:         string(secret.Data[imageWatchModeKey]),
+		Username:     string(secret.Data[imageWatchUsernameKey]),
+		LastChecked:  string(secret.Data[imageWatchLastCheckedKey]),
+		LatestDigest: string(secret.Data[imageWatchLatestDigestKey]),
+		LastError:    string(secret.Data[imageWatchLastErrorKey]),
+		NextCheck:    string(secret.Data[imageWatchNextCheckKey]),
+	}
+	if status.Mode == "" {
+		status.Mode = models.ImageWatchNotify
+	}
+
+	if failures := string(secret.Data[imageWatchFailuresKey]); failures != "" {
+		i, err := strconv.ParseInt(failures, 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad image watch failures '%s'", failures)
+		}
+		status.Failures = int32(i)
+	}
+
+	return status, nil
+}
+
+// ImageWatchSet configures the image watch of the named application, for the named user. A
+// nil watch, or an empty interval, stops the watch. The next check is scheduled one interval
+// from now, as the application was just deployed.
+func ImageWatchSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, watch *models.AppImageWatch, username string) error {
+	if watch == nil || watch.Interval == "" {
+		err := cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Delete(ctx,
+			appRef.MakeImageWatchSecretName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	period, err := time.ParseDuration(watch.Interval)
+	if err != nil {
+		return err
+	}
+
+	mode := watch.Mode
+	if mode == "" {
+		mode = models.ImageWatchNotify
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef, appRef.MakeImageWatchSecretName(), "imagewatch")
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[imageWatchIntervalKey] = []byte(watch.Interval)
+		secret.Data[imageWatchModeKey] = []byte(mode)
+		secret.Data[imageWatchUsernameKey] = []byte(username)
+		secret.Data[imageWatchNextCheckKey] = []byte(GitPollNextCheck(period, 0, time.Now()).Format(time.RFC3339))
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// ImageWatchRecord records the result of a check of the named application, and schedules
+// the next check. A failed check is retried with exponential backoff, as for git polling. A
+// successful check records the digest the watched tag points to.
+func ImageWatchRecord(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, digest string, checkErr error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeImageWatchSecretName())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// Watch was stopped during the check
+				return nil
+			}
+			return err
+		}
+
+		status, err := ImageWatchFromSecret(secret)
+		if err != nil {
+			return err
+		}
+		if status == nil {
+			return nil
+		}
+
+		period, err := time.ParseDuration(status.Interval)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		secret.Data[imageWatchLastCheckedKey] = []byte(now.UTC().Format(time.RFC3339))
+
+		if checkErr != nil {
+			status.Failures++
+			secret.Data[imageWatchLastErrorKey] = []byte(checkErr.Error())
+			secret.Data[imageWatchFailuresKey] = []byte(strconv.Itoa(int(status.Failures)))
+		} else {
+			status.Failures = 0
+			delete(secret.Data, imageWatchLastErrorKey)
+			delete(secret.Data, imageWatchFailuresKey)
+			if digest != "" {
+				secret.Data[imageWatchLatestDigestKey] = []byte(digest)
+			}
+		}
+
+		next := GitPollNextCheck(period, status.Failures, now)
+		secret.Data[imageWatchNextCheckKey] = []byte(next.UTC().Format(time.RFC3339))
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// ImageWatches returns the image watch configuration and status of all watched applications.
+func ImageWatches(ctx context.Context, cluster *kubernetes.Cluster) (map[models.AppRef]*models.ImageWatchStatus, error) {
+	secrets, err := cluster.Kubectl.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=imagewatch", EpinioApplicationAreaLabel),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[models.AppRef]*models.ImageWatchStatus{}
+	for idx := range secrets.Items {
+		secret := &secrets.Items[idx]
+		appName := secret.GetLabels()["app.kubernetes.io/name"]
+		if appName == "" {
+			continue
+		}
+		status, err := ImageWatchFromSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+		if status == nil {
+			continue
+		}
+		result[models.NewAppRef(appName, secret.Namespace)] = status
+	}
+
+	return result, nil
+}
+
+// ImageWatchDue returns true if the next check of the application is due.
+func ImageWatchDue(status *models.ImageWatchStatus, now time.Time) bool {
+	return GitPollDue(&models.GitPollStatus{NextCheck: status.NextCheck}, now)
+}
+
+// ImageWatchEvent records a kubernetes event for the application, announcing that the tag
+// of its image points to a new digest. This is the reaction of an image watch in notify
+// mode.
+func ImageWatchEvent(ctx context.Context, cluster *kubernetes.Cluster, appCR *unstructured.Unstructured, imageURL, digest string) error {
+	now := metav1.NewTime(time.Now())
+	event := &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-image-", appCR.GetName()),
+			Namespace:    appCR.GetNamespace(),
+		},
+		InvolvedObject: v1.ObjectReference{
+			APIVersion: "application.epinio.io/v1",
+			Kind:       "App",
+			Name:       appCR.GetName(),
+			Namespace:  appCR.GetNamespace(),
+			UID:        appCR.GetUID(),
+		},
+		Reason:         "ImageUpdated",
+		Message:        fmt.Sprintf("image %s now points to %s, deployed is %s", imageURL, digest, ImageDigest(appCR)),
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Type:           v1.EventTypeNormal,
+		Source: v1.EventSource{
+			Component: "epinio-api",
+		},
+	}
+
+	_, err := cluster.Kubectl.CoreV1().Events(appCR.GetNamespace()).Create(ctx, event, metav1.CreateOptions{})
+	return err
+}
+
+// ValidateImageWatch checks that the interval of the watch is a proper duration, not below
+// the minimum, and that the mode is known. It returns a bad request API error for any
+// issue. A nil watch, or an empty interval, is valid, it disables the watch.
+func ValidateImageWatch(watch *models.AppImageWatch) error {
+	if watch == nil || watch.Interval == "" {
+		return nil
+	}
+
+	period, err := time.ParseDuration(watch.Interval)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("bad image watch interval '%s'", watch.Interval).WithDetails(err.Error())
+	}
+	if period < MinImageWatchInterval {
+		return apierror.NewBadRequestErrorf("image watch interval '%s' is below the minimum of %s",
+			watch.Interval, MinImageWatchInterval)
+	}
+
+	switch watch.Mode {
+	case "", models.ImageWatchRedeploy, models.ImageWatchNotify:
+	default:
+		return apierror.NewBadRequestErrorf("bad image watch mode '%s', expected one of %s, %s",
+			watch.Mode, models.ImageWatchRedeploy, models.ImageWatchNotify)
+	}
+
+	return nil
+}
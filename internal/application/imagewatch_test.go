@@ -0,0 +1,100 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("ImageWatch", func() {
+	Describe("ImageWatchFromSecret", func() {
+		It("decodes the stored status", func() {
+			status, err := application.ImageWatchFromSecret(&v1.Secret{
+				Data: map[string][]byte{
+					"interval":     []byte("10m"),
+					"mode":         []byte("redeploy"),
+					"username":     []byte("admin"),
+					"latestDigest": []byte("sha256:abc"),
+					"failures":     []byte("1"),
+					"lastError":    []byte("unauthorized"),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(Equal(&models.ImageWatchStatus{
+				Interval:     "10m",
+				Mode:         models.ImageWatchRedeploy,
+				Username:     "admin",
+				LatestDigest: "sha256:abc",
+				LastError:    "unauthorized",
+				Failures:     1,
+			}))
+		})
+
+		It("defaults to notification", func() {
+			status, err := application.ImageWatchFromSecret(&v1.Secret{
+				Data: map[string][]byte{"interval": []byte("10m")},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Mode).To(Equal(models.ImageWatchNotify))
+		})
+
+		It("returns nothing without an interval", func() {
+			status, err := application.ImageWatchFromSecret(&v1.Secret{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(BeNil())
+		})
+	})
+
+	Describe("ImageWatchDue", func() {
+		It("compares the next check to the time", func() {
+			now := time.Now()
+			status := &models.ImageWatchStatus{NextCheck: now.Add(time.Minute).Format(time.RFC3339)}
+			Expect(application.ImageWatchDue(status, now)).To(BeFalse())
+			Expect(application.ImageWatchDue(status, now.Add(2*time.Minute))).To(BeTrue())
+		})
+	})
+
+	Describe("ImageDigest", func() {
+		It("reads the digest annotation", func() {
+			app := &unstructured.Unstructured{}
+			Expect(application.ImageDigest(app)).To(BeEmpty())
+
+			app.SetAnnotations(map[string]string{models.EpinioImageDigestAnnotation: "sha256:abc"})
+			Expect(application.ImageDigest(app)).To(Equal("sha256:abc"))
+		})
+	})
+
+	Describe("ValidateImageWatch", func() {
+		It("accepts proper and empty watches", func() {
+			Expect(application.ValidateImageWatch(nil)).To(Succeed())
+			Expect(application.ValidateImageWatch(&models.AppImageWatch{})).To(Succeed())
+			Expect(application.ValidateImageWatch(&models.AppImageWatch{Interval: "5m"})).To(Succeed())
+			Expect(application.ValidateImageWatch(&models.AppImageWatch{
+				Interval: "5m", Mode: models.ImageWatchRedeploy})).To(Succeed())
+		})
+
+		It("rejects bad intervals and modes", func() {
+			Expect(application.ValidateImageWatch(&models.AppImageWatch{Interval: "often"})).ToNot(Succeed())
+			Expect(application.ValidateImageWatch(&models.AppImageWatch{Interval: "10s"})).ToNot(Succeed())
+			Expect(application.ValidateImageWatch(&models.AppImageWatch{
+				Interval: "5m", Mode: "restart"})).ToNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,70 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/logarchive"
+)
+
+// ArchivedLogs passes the archived log lines of the application, or staging, selected by
+// the time range, container and content filters, and tail of the log parameters, to emit,
+// in time order. The archive is streamed, see logarchive.Read.
+func ArchivedLogs(ctx context.Context, store logarchive.Store, app, stageID, namespace string, logParams *LogParameters, emit func(tailer.ContainerLogLine) error) error {
+	since := time.Time{}
+	if logParams.SinceTime != nil {
+		since = *logParams.SinceTime
+	} else if logParams.Since != nil {
+		since = time.Now().Add(-*logParams.Since)
+	}
+
+	kind, name := logarchive.KindApp, app
+	if stageID != "" {
+		kind, name = logarchive.KindStaging, stageID
+	}
+
+	includePattern, hasUserIncludeFilter, err := buildContainerIncludePattern(logParams)
+	if err != nil {
+		return err
+	}
+	include, err := regexp.Compile(includePattern)
+	if err != nil {
+		return fmt.Errorf("invalid include_containers pattern: %w", err)
+	}
+	exclude, err := buildContainerExcludePattern(logParams, hasUserIncludeFilter)
+	if err != nil {
+		return err
+	}
+
+	options := logarchive.ReadOptions{
+		Since: since,
+		Match: func(line tailer.ContainerLogLine) bool {
+			if !include.MatchString(line.ContainerName) {
+				return false
+			}
+			if exclude != nil && exclude.MatchString(line.ContainerName) {
+				return false
+			}
+			return logParams.Filter == nil || logParams.Filter.Matches(line.Message)
+		},
+	}
+	if logParams.Tail != nil {
+		options.Tail = *logParams.Tail
+	}
+
+	return logarchive.Read(ctx, store, namespace, kind, name, options, emit)
+}
@@ -0,0 +1,75 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/logdrain"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// LogDrains returns the log drains of the application, with their status.
+func LogDrains(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) ([]models.LogDrainStatus, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeLogDrainsSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return []models.LogDrainStatus{}, nil
+		}
+		return nil, err
+	}
+
+	return logdrain.FromSecret(secret, models.LogDrainScopeApp)
+}
+
+// LogDrainAdd adds the drain to the application. The drain is expected to be validated
+// already.
+func LogDrainAdd(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, drain models.LogDrain) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef, appRef.MakeLogDrainsSecretName(), logdrain.AreaLabel)
+		if err != nil {
+			return err
+		}
+
+		if err := logdrain.AddToSecret(secret, drain); err != nil {
+			return err
+		}
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// LogDrainRemove removes the named drain from the application.
+func LogDrainRemove(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, name string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeLogDrainsSecretName())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return apierror.NewNotFoundError("log drain", name)
+			}
+			return err
+		}
+
+		if err := logdrain.RemoveFromSecret(secret, name); err != nil {
+			return err
+		}
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,234 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// logLevelKeys are the keys holding the level of a structured log line, in order of
+// preference.
+var logLevelKeys = []string{"level", "lvl", "severity", "loglevel"}
+
+// logLevelRanks orders the known log levels by severity.
+var logLevelRanks = map[string]int{
+	"trace":    0,
+	"debug":    1,
+	"info":     2,
+	"notice":   2,
+	"warn":     3,
+	"warning":  3,
+	"error":    4,
+	"err":      4,
+	"critical": 5,
+	"fatal":    5,
+	"panic":    5,
+}
+
+// LogFilter selects log lines by their content. All the specified conditions have to hold
+// for a line to pass.
+type LogFilter struct {
+	Grep   *regexp.Regexp    // Match on the raw message
+	Level  string            // Minimum level of structured lines
+	Fields map[string]string // Field values of structured lines. Nested JSON keys are joined by dots
+}
+
+// NewLogFilter returns the filter for the grep text, level and `key=value` field matches.
+// The grep text is a substring match, or a regular expression when grepRegex is set. The
+// result is nil when nothing is filtered.
+func NewLogFilter(grep string, grepRegex bool, level string, fields []string) (*LogFilter, error) {
+	if grep == "" && level == "" && len(fields) == 0 {
+		return nil, nil
+	}
+
+	filter := &LogFilter{}
+
+	if grep != "" {
+		pattern := regexp.QuoteMeta(grep)
+		if grepRegex {
+			pattern = grep
+		}
+		rex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grep pattern: %w", err)
+		}
+		filter.Grep = rex
+	}
+
+	if level != "" {
+		level = strings.ToLower(level)
+		if _, ok := logLevelRanks[level]; !ok {
+			return nil, fmt.Errorf("unknown log level '%s'", level)
+		}
+		filter.Level = level
+	}
+
+	if len(fields) > 0 {
+		filter.Fields = map[string]string{}
+		for _, field := range fields {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok || key == "" {
+				return nil, fmt.Errorf("bad field match '%s', expected key=value", field)
+			}
+			filter.Fields[key] = value
+		}
+	}
+
+	return filter, nil
+}
+
+// Matches returns true if the log line passes the filter. Level and field conditions only
+// pass structured lines, i.e. JSON objects or logfmt.
+func (f *LogFilter) Matches(message string) bool {
+	if f.Grep != nil && !f.Grep.MatchString(message) {
+		return false
+	}
+
+	if f.Level == "" && len(f.Fields) == 0 {
+		return true
+	}
+
+	fields, ok := structuredLogFields(message)
+	if !ok {
+		return false
+	}
+
+	if f.Level != "" && !logLevelAtLeast(fields, f.Level) {
+		return false
+	}
+
+	for key, value := range f.Fields {
+		if actual, found := fields[key]; !found || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// logLevelAtLeast returns true if the level of the structured line is at least as severe as
+// the minimum. Unknown levels only match themselves.
+func logLevelAtLeast(fields map[string]string, minimum string) bool {
+	for _, key := range logLevelKeys {
+		for name, value := range fields {
+			if !strings.EqualFold(name, key) {
+				continue
+			}
+			level := strings.ToLower(value)
+			rank, known := logLevelRanks[level]
+			if !known {
+				return level == minimum
+			}
+			return rank >= logLevelRanks[minimum]
+		}
+	}
+	return false
+}
+
+// structuredLogFields returns the fields of a JSON object or logfmt log line. Nested JSON
+// objects are flattened, with their keys joined by dots.
+func structuredLogFields(message string) (map[string]string, bool) {
+	message = strings.TrimSpace(message)
+
+	if strings.HasPrefix(message, "{") {
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(message), &object); err != nil {
+			return nil, false
+		}
+		fields := map[string]string{}
+		flattenLogFields(fields, "", object)
+		return fields, true
+	}
+
+	return logfmtFields(message)
+}
+
+// flattenLogFields adds the values of the JSON object to the fields, under the prefixed keys.
+func flattenLogFields(fields map[string]string, prefix string, object map[string]interface{}) {
+	for key, value := range object {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenLogFields(fields, prefix+key+".", v)
+		case string:
+			fields[prefix+key] = v
+		case nil:
+			fields[prefix+key] = ""
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			fields[prefix+key] = string(data)
+		}
+	}
+}
+
+// logfmtFields returns the fields of a logfmt line, i.e. space separated `key=value` pairs
+// with optionally quoted values. Lines without any pair are not logfmt.
+func logfmtFields(message string) (map[string]string, bool) {
+	fields := map[string]string{}
+
+	for rest := message; rest != ""; {
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" {
+			break
+		}
+
+		end := strings.IndexAny(rest, "= ")
+		if end <= 0 || rest[end] != '=' {
+			return nil, false
+		}
+		key := rest[:end]
+		rest = rest[end+1:]
+
+		var value string
+		if strings.HasPrefix(rest, "\"") {
+			closing := 1
+			for closing < len(rest) && rest[closing] != '"' {
+				if rest[closing] == '\\' {
+					closing++
+				}
+				closing++
+			}
+			if closing >= len(rest) {
+				return nil, false
+			}
+			unquoted, err := unquoteLogfmt(rest[:closing+1])
+			if err != nil {
+				return nil, false
+			}
+			value = unquoted
+			rest = rest[closing+1:]
+		} else {
+			end := strings.IndexByte(rest, ' ')
+			if end < 0 {
+				end = len(rest)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+
+		fields[key] = value
+	}
+
+	return fields, len(fields) > 0
+}
+
+// unquoteLogfmt returns the content of the quoted logfmt value.
+func unquoteLogfmt(quoted string) (string, error) {
+	var value string
+	err := json.Unmarshal([]byte(quoted), &value)
+	return value, err
+}
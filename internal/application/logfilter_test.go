@@ -0,0 +1,97 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LogFilter", func() {
+	Describe("NewLogFilter", func() {
+		It("returns nothing without conditions", func() {
+			filter, err := application.NewLogFilter("", false, "", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter).To(BeNil())
+		})
+
+		It("rejects bad patterns, levels and fields", func() {
+			_, err := application.NewLogFilter("(", true, "", nil)
+			Expect(err).To(HaveOccurred())
+			_, err = application.NewLogFilter("", false, "loud", nil)
+			Expect(err).To(HaveOccurred())
+			_, err = application.NewLogFilter("", false, "", []string{"status"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Matches", func() {
+		It("matches substrings", func() {
+			filter, err := application.NewLogFilter("timeout", false, "", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter.Matches("request timeout after 5s")).To(BeTrue())
+			Expect(filter.Matches("request done")).To(BeFalse())
+
+			filter, err = application.NewLogFilter("[ERROR]", false, "", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter.Matches("[ERROR] disk full")).To(BeTrue())
+			Expect(filter.Matches("ERROR disk full")).To(BeFalse())
+
+			filter, err = application.NewLogFilter("error (code", false, "", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter.Matches("error (code 7)")).To(BeTrue())
+		})
+
+		It("matches regular expressions when asked for", func() {
+			filter, err := application.NewLogFilter("a.b", true, "", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter.Matches("xa.by")).To(BeTrue())
+			Expect(filter.Matches("xacby")).To(BeTrue())
+			Expect(filter.Matches("xaby")).To(BeFalse())
+		})
+
+		It("filters JSON lines by minimum level", func() {
+			filter, err := application.NewLogFilter("", false, "WARN", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter.Matches(`{"level":"error","msg":"boom"}`)).To(BeTrue())
+			Expect(filter.Matches(`{"severity":"WARNING","msg":"hmm"}`)).To(BeTrue())
+			Expect(filter.Matches(`{"level":"info","msg":"ok"}`)).To(BeFalse())
+			Expect(filter.Matches(`plain error text`)).To(BeFalse())
+		})
+
+		It("filters logfmt lines by level", func() {
+			filter, err := application.NewLogFilter("", false, "error", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter.Matches(`time=now level=error msg="disk full"`)).To(BeTrue())
+			Expect(filter.Matches(`time=now level=debug msg="tick"`)).To(BeFalse())
+		})
+
+		It("matches fields of structured lines, nested JSON keys included", func() {
+			filter, err := application.NewLogFilter("", false, "", []string{"req.status=500", "method=GET"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter.Matches(`{"method":"GET","req":{"status":500}}`)).To(BeTrue())
+			Expect(filter.Matches(`{"method":"GET","req":{"status":200}}`)).To(BeFalse())
+			Expect(filter.Matches(`method=GET req.status=500 path="/a b"`)).To(BeTrue())
+			Expect(filter.Matches(`GET /a 500`)).To(BeFalse())
+		})
+
+		It("requires all conditions", func() {
+			filter, err := application.NewLogFilter("db", false, "error", []string{"component=api"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter.Matches(`{"level":"error","component":"api","msg":"db down"}`)).To(BeTrue())
+			Expect(filter.Matches(`{"level":"error","component":"web","msg":"db down"}`)).To(BeFalse())
+			Expect(filter.Matches(`{"level":"error","component":"api","msg":"cache down"}`)).To(BeFalse())
+		})
+	})
+})
@@ -185,6 +185,14 @@ func SetOrigin(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 }
 
 func buildBodyPatch(origin models.ApplicationOrigin) ([]byte, error) {
+	// The poll interval is not part of the application resource. It is kept in the git
+	// poll secret, see GitPollSet.
+	if origin.Git != nil && origin.Git.PollInterval != "" {
+		git := *origin.Git
+		git.PollInterval = ""
+		origin.Git = &git
+	}
+
 	operations := []PatchOperation{{
 		Op:    "replace",
 		Path:  "/spec/origin",
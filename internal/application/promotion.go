@@ -0,0 +1,116 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxLineage is the number of promotion steps kept in the lineage of an application.
+const maxLineage = 20
+
+// Lineage returns the promotion lineage of the application, oldest step first. The result
+// is empty for applications whose image was not promoted from another namespace.
+func Lineage(app *unstructured.Unstructured) ([]models.AppPromotion, error) {
+	data := app.GetAnnotations()[models.EpinioLineageAnnotation]
+	if data == "" {
+		return nil, nil
+	}
+
+	lineage := []models.AppPromotion{}
+	if err := json.Unmarshal([]byte(data), &lineage); err != nil {
+		return nil, errors.Wrap(err, "bad promotion lineage")
+	}
+
+	return lineage, nil
+}
+
+// LineageExtend returns the lineage of an image promoted by the step, given the lineage of
+// the source application. Only the newest steps up to the limit are kept.
+func LineageExtend(lineage []models.AppPromotion, step models.AppPromotion) []models.AppPromotion {
+	result := make([]models.AppPromotion, 0, len(lineage)+1)
+	result = append(result, lineage...)
+	result = append(result, step)
+
+	if len(result) > maxLineage {
+		result = result[len(result)-maxLineage:]
+	}
+
+	return result
+}
+
+// PromotionRecord saves the promoted image, stage, and lineage in the resource of the
+// target application. The build mode of the source is carried over, as the image was built
+// with it, as is the digest the image is pinned to. The source blob is cleared, as the
+// target has none.
+func PromotionRecord(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef,
+	source *unstructured.Unstructured, lineage []models.AppPromotion) error {
+
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return err
+	}
+
+	stageID, err := StageID(source)
+	if err != nil {
+		return err
+	}
+	imageURL, err := ImageURL(source)
+	if err != nil {
+		return err
+	}
+	builderURL, err := BuilderURL(source)
+	if err != nil {
+		return err
+	}
+
+	var digest interface{}
+	if d := ImageDigest(source); d != "" {
+		digest = d
+	}
+
+	lineageData, err := json.Marshal(lineage)
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"stageid":      stageID,
+			"imageurl":     imageURL,
+			"builderimage": builderURL,
+			"blobuid":      "",
+		},
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				models.EpinioBuildModeAnnotation:   BuildMode(source),
+				models.EpinioLineageAnnotation:     string(lineageData),
+				models.EpinioImageDigestAnnotation: digest,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Namespace(appRef.Namespace).Patch(ctx, appRef.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,77 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Promotion", func() {
+	Describe("Lineage", func() {
+		It("is empty for an application which was not promoted", func() {
+			lineage, err := Lineage(&unstructured.Unstructured{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lineage).To(BeEmpty())
+		})
+
+		It("decodes the recorded lineage", func() {
+			app := &unstructured.Unstructured{}
+			app.SetAnnotations(map[string]string{
+				models.EpinioLineageAnnotation: `[{"namespace":"dev","app":"a","stageId":"s1","imageURL":"i","promotedAt":"t"}]`,
+			})
+
+			lineage, err := Lineage(app)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lineage).To(HaveLen(1))
+			Expect(lineage[0].Namespace).To(Equal("dev"))
+			Expect(lineage[0].StageID).To(Equal("s1"))
+		})
+
+		It("fails for bad data", func() {
+			app := &unstructured.Unstructured{}
+			app.SetAnnotations(map[string]string{models.EpinioLineageAnnotation: "bogus"})
+
+			_, err := Lineage(app)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("LineageExtend", func() {
+		It("appends the step to the source lineage", func() {
+			source := []models.AppPromotion{{Namespace: "dev"}}
+
+			lineage := LineageExtend(source, models.AppPromotion{Namespace: "staging"})
+			Expect(lineage).To(HaveLen(2))
+			Expect(lineage[0].Namespace).To(Equal("dev"))
+			Expect(lineage[1].Namespace).To(Equal("staging"))
+			Expect(source).To(HaveLen(1))
+		})
+
+		It("keeps only the newest steps", func() {
+			source := []models.AppPromotion{}
+			for i := 0; i < maxLineage; i++ {
+				source = append(source, models.AppPromotion{Namespace: fmt.Sprintf("ns%d", i)})
+			}
+
+			lineage := LineageExtend(source, models.AppPromotion{Namespace: "last"})
+			Expect(lineage).To(HaveLen(maxLineage))
+			Expect(lineage[0].Namespace).To(Equal("ns1"))
+			Expect(lineage[maxLineage-1].Namespace).To(Equal("last"))
+		})
+	})
+})
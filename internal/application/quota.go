@@ -0,0 +1,242 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// QuotaUsage returns the application related resource consumption of the namespace, i.e.
+// number of apps, desired instances, and the cpu and memory used by the application pods.
+// The service and blob storage usage is not determined here.
+func QuotaUsage(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (models.NamespaceQuotaUsage, error) {
+	usage := models.NamespaceQuotaUsage{}
+
+	apps, err := ListAppRefs(ctx, cluster, namespace)
+	if err != nil {
+		return usage, err
+	}
+	usage.Apps = int32(len(apps))
+
+	instances, err := namespaceInstances(ctx, cluster, namespace)
+	if err != nil {
+		return usage, err
+	}
+	for _, desired := range instances {
+		usage.Instances += desired
+	}
+
+	podMetrics, err := GetPodMetrics(ctx, cluster, namespace, nil)
+	if err != nil {
+		// Missing metrics are not fatal. The usage simply reports them as not available.
+		helpers.Logger.Infow("failed to get pod metrics for quota usage", "namespace", namespace, "error", err)
+		return usage, nil
+	}
+
+	usage.MetricsOk = true
+	for _, metric := range podMetrics {
+		milliCPUs, memoryBytes := podUsage(metric)
+		usage.MilliCPUs += milliCPUs
+		usage.MemoryBytes += memoryBytes
+	}
+
+	return usage, nil
+}
+
+// CheckCreateQuota verifies that the namespace has room for a new application with the given
+// number of instances. It allows callers to reject a creation before any state is changed.
+func CheckCreateQuota(ctx context.Context, cluster *kubernetes.Cluster, namespace string, instances int32) error {
+	if err := checkAppsQuota(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	quota, err := namespaces.GetQuota(ctx, cluster, namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to get namespace quota")
+	}
+	if quota.Instances == nil {
+		return nil
+	}
+
+	current, err := namespaceInstances(ctx, cluster, namespace)
+	if err != nil {
+		return err
+	}
+
+	var total int32
+	for _, desired := range current {
+		total += desired
+	}
+
+	return namespaces.CheckCount(namespace, models.QuotaInstances, quota.Instances, total, instances)
+}
+
+// checkAppsQuota verifies that the namespace has room for one more application.
+func checkAppsQuota(ctx context.Context, cluster *kubernetes.Cluster, namespace string) error {
+	quota, err := namespaces.GetQuota(ctx, cluster, namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to get namespace quota")
+	}
+	if quota.Apps == nil {
+		return nil
+	}
+
+	apps, err := ListAppRefs(ctx, cluster, namespace)
+	if err != nil {
+		return err
+	}
+
+	return namespaces.CheckCount(namespace, models.QuotaApps, quota.Apps, int32(len(apps)), 1)
+}
+
+// checkScalingQuota verifies that changing the desired instances of the referenced
+// application to `instances` keeps the namespace within its instances quota. For cpu and
+// memory the additional usage is projected from the resources reserved for an instance, or,
+// if none are, from the average measured usage of the existing pods of the application.
+func checkScalingQuota(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, instances int32) error {
+	quota, err := namespaces.GetQuota(ctx, cluster, appRef.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to get namespace quota")
+	}
+	if quota.Instances == nil && quota.CPU == "" && quota.Memory == "" {
+		return nil
+	}
+
+	current, err := namespaceInstances(ctx, cluster, appRef.Namespace)
+	if err != nil {
+		return err
+	}
+
+	previous, ok := current[appRef.Name]
+	if !ok {
+		previous = 1
+	}
+	added := instances - previous
+	if added <= 0 {
+		// Scaling down, or no change, never exceeds a quota.
+		return nil
+	}
+
+	var total int32
+	for _, desired := range current {
+		total += desired
+	}
+
+	err = namespaces.CheckCount(appRef.Namespace, models.QuotaInstances, quota.Instances, total, added)
+	if err != nil {
+		return err
+	}
+
+	if quota.CPU == "" && quota.Memory == "" {
+		return nil
+	}
+
+	podMetrics, err := GetPodMetrics(ctx, cluster, appRef.Namespace, nil)
+	if err != nil {
+		// Without metrics the projection is not possible. Do not block the user.
+		helpers.Logger.Infow("failed to get pod metrics for quota check", "app", appRef.Name, "namespace", appRef.Namespace, "error", err)
+		return nil
+	}
+
+	var usedCPU, usedMemory, appCPU, appMemory, appPods int64
+	for _, metric := range podMetrics {
+		milliCPUs, memoryBytes := podUsage(metric)
+		usedCPU += milliCPUs
+		usedMemory += memoryBytes
+
+		if metric.GetLabels()["app.kubernetes.io/name"] == appRef.Name {
+			appCPU += milliCPUs
+			appMemory += memoryBytes
+			appPods++
+		}
+	}
+
+	// Per instance amounts. Reserved resources take precedence over measurements.
+
+	resources, err := Resources(ctx, cluster, appRef)
+	if err != nil {
+		return err
+	}
+	instanceCPU, instanceMemory := reservedResources(resources)
+	if appPods > 0 {
+		if instanceCPU == 0 {
+			instanceCPU = appCPU / appPods
+		}
+		if instanceMemory == 0 {
+			instanceMemory = appMemory / appPods
+		}
+	}
+
+	err = namespaces.CheckQuantity(appRef.Namespace, models.QuotaCPU, quota.CPU, true,
+		usedCPU, instanceCPU*int64(added))
+	if err != nil {
+		return err
+	}
+
+	return namespaces.CheckQuantity(appRef.Namespace, models.QuotaMemory, quota.Memory, false,
+		usedMemory, instanceMemory*int64(added))
+}
+
+// namespaceInstances returns a map from application names to desired number of instances,
+// for all applications in the namespace.
+func namespaceInstances(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (map[string]int32, error) {
+	apps, err := ListAppRefs(ctx, cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := cluster.Kubectl.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=scaling", EpinioApplicationAreaLabel),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]int32{}
+	for _, app := range apps {
+		// Default for applications without scaling data.
+		result[app.Name] = 1
+	}
+	for idx := range secrets.Items {
+		secret := &secrets.Items[idx]
+		appName := secret.GetLabels()["app.kubernetes.io/name"]
+		if _, ok := result[appName]; !ok {
+			continue
+		}
+		desired, err := ScalingFromSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+		result[appName] = desired
+	}
+
+	return result, nil
+}
+
+// podUsage returns the cpu (in millicores) and memory (in bytes) used by the containers of the pod.
+func podUsage(metric metricsv1beta1.PodMetrics) (int64, int64) {
+	var milliCPUs, memoryBytes int64
+	for _, container := range metric.Containers {
+		milliCPUs += container.Usage.Cpu().MilliValue()
+		memoryBytes += container.Usage.Memory().Value()
+	}
+	return milliCPUs, memoryBytes
+}
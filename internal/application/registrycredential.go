@@ -0,0 +1,114 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/registry"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RegistryCredential returns the name of the registry credentials used to pull the image of
+// the application. The result is empty if the application has none.
+func RegistryCredential(app *unstructured.Unstructured) string {
+	return app.GetAnnotations()[models.EpinioRegistryCredentialAnnotation]
+}
+
+// ValidateRegistryCredential checks that the named registry credentials exist in the
+// namespace. An empty name is valid, i.e. no credentials.
+func ValidateRegistryCredential(ctx context.Context, cluster *kubernetes.Cluster, namespace, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	_, err := cluster.GetSecret(ctx, namespace, registry.PullCredentialSecretName(name))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return apierror.NewBadRequestErrorf("registry credential '%s' does not exist", name)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RegistryCredentialSet sets the named registry credentials into the application resource.
+// An empty name removes them.
+func RegistryCredentialSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, name string) error {
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return err
+	}
+
+	var value interface{}
+	if name != "" {
+		value = name
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				models.EpinioRegistryCredentialAnnotation: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Namespace(appRef.Namespace).Patch(ctx, appRef.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// RegistryCredentialAuth returns the named registry credentials of the namespace, with
+// password, for access to the registry.
+func RegistryCredentialAuth(ctx context.Context, cluster *kubernetes.Cluster, namespace, name string) (registry.RegistryCredentials, error) {
+	secret, err := cluster.GetSecret(ctx, namespace, registry.PullCredentialSecretName(name))
+	if err != nil {
+		return registry.RegistryCredentials{}, errors.Wrapf(err, "loading registry credential '%s'", name)
+	}
+
+	return registry.PullCredentialAuth(*secret)
+}
+
+// RegistryCredentialUsers returns the names of the applications in the namespace which pull
+// their image with the named registry credentials.
+func RegistryCredentialUsers(ctx context.Context, cluster *kubernetes.Cluster, namespace, name string) ([]string, error) {
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	users := []string{}
+	for idx := range list.Items {
+		if RegistryCredential(&list.Items[idx]) == name {
+			users = append(users, list.Items[idx].GetName())
+		}
+	}
+
+	return users, nil
+}
@@ -0,0 +1,252 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// Keys of the resources secret
+const (
+	requestsCPUKey    = "requests.cpu"
+	requestsMemoryKey = "requests.memory"
+	limitsCPUKey      = "limits.cpu"
+	limitsMemoryKey   = "limits.memory"
+)
+
+// Resources returns the cpu and memory requests and limits set by a user for the
+// application. The result is nil if nothing was set.
+func Resources(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.AppResources, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeResourcesSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ResourcesFromSecret(secret), nil
+}
+
+// ResourcesFromSecret is the core of Resources, extracting the requests and limits from the
+// secret containing them. The result is nil if nothing was set.
+func ResourcesFromSecret(secret *v1.Secret) *models.AppResources {
+	resources := &models.AppResources{
+		Requests: models.ResourceValues{
+			CPU:    string(secret.Data[requestsCPUKey]),
+			Memory: string(secret.Data[requestsMemoryKey]),
+		},
+		Limits: models.ResourceValues{
+			CPU:    string(secret.Data[limitsCPUKey]),
+			Memory: string(secret.Data[limitsMemoryKey]),
+		},
+	}
+
+	if resources.IsEmpty() {
+		return nil
+	}
+	return resources
+}
+
+// ResourcesSet replaces the cpu and memory requests and limits of the named application.
+// A nil or empty argument removes all of them.
+func ResourcesSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, resources *models.AppResources) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef, appRef.MakeResourcesSecretName(), "resources")
+		if err != nil {
+			return err
+		}
+
+		secret.Data = map[string][]byte{}
+		if resources != nil {
+			for key, value := range map[string]string{
+				requestsCPUKey:    resources.Requests.CPU,
+				requestsMemoryKey: resources.Requests.Memory,
+				limitsCPUKey:      resources.Limits.CPU,
+				limitsMemoryKey:   resources.Limits.Memory,
+			} {
+				if value != "" {
+					secret.Data[key] = []byte(value)
+				}
+			}
+		}
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// ValidateResources checks that the requests and limits are proper quantities, that no
+// request is larger than its limit, and that the given number of instances of the referenced
+// application fits into the cpu and memory quota of its namespace, next to the resources
+// reserved by the other applications of the namespace. It returns a suitable API error for
+// any issue.
+func ValidateResources(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, resources *models.AppResources, instances int32) error {
+	if resources.IsEmpty() {
+		return nil
+	}
+
+	for _, check := range []struct {
+		kind           string
+		request, limit string
+	}{
+		{"cpu", resources.Requests.CPU, resources.Limits.CPU},
+		{"memory", resources.Requests.Memory, resources.Limits.Memory},
+	} {
+		request, err := parseResource(check.kind+" request", check.request)
+		if err != nil {
+			return err
+		}
+		limit, err := parseResource(check.kind+" limit", check.limit)
+		if err != nil {
+			return err
+		}
+		if request != nil && limit != nil && request.Cmp(*limit) > 0 {
+			return apierror.NewBadRequestErrorf("%s request '%s' is larger than the %s limit '%s'",
+				check.kind, check.request, check.kind, check.limit)
+		}
+	}
+
+	namespace := appRef.Namespace
+	quota, err := namespaces.GetQuota(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err, "failed to get namespace quota")
+	}
+	if quota.CPU == "" && quota.Memory == "" {
+		return nil
+	}
+
+	usedCPU, usedMemory, err := namespaceReservations(ctx, cluster, namespace, appRef.Name)
+	if err != nil {
+		return apierror.InternalError(err, "failed to get the resources reserved in the namespace")
+	}
+
+	milliCPUs, memoryBytes := reservedResources(resources)
+
+	if quota.CPU != "" {
+		limit, err := resource.ParseQuantity(quota.CPU)
+		if err == nil && usedCPU+milliCPUs*int64(instances) > limit.MilliValue() {
+			return apierror.NamespaceQuotaExceeded(namespace, models.QuotaCPU).
+				WithDetailsf("limit: %s, reserved by other applications: %s, requested: %d instances of %s",
+					quota.CPU, resource.NewMilliQuantity(usedCPU, resource.DecimalSI),
+					instances, resource.NewMilliQuantity(milliCPUs, resource.DecimalSI))
+		}
+	}
+
+	if quota.Memory != "" {
+		limit, err := resource.ParseQuantity(quota.Memory)
+		if err == nil && usedMemory+memoryBytes*int64(instances) > limit.Value() {
+			return apierror.NamespaceQuotaExceeded(namespace, models.QuotaMemory).
+				WithDetailsf("limit: %s, reserved by other applications: %s, requested: %d instances of %s",
+					quota.Memory, resource.NewQuantity(usedMemory, resource.BinarySI),
+					instances, resource.NewQuantity(memoryBytes, resource.BinarySI))
+		}
+	}
+
+	return nil
+}
+
+// namespaceReservations returns the cpu (in millicores) and memory (in bytes) reserved by
+// all instances of the applications in the namespace, except for the named one.
+func namespaceReservations(ctx context.Context, cluster *kubernetes.Cluster, namespace, exclude string) (int64, int64, error) {
+	instances, err := namespaceInstances(ctx, cluster, namespace)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	secrets, err := cluster.Kubectl.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=resources", EpinioApplicationAreaLabel),
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	milliCPUs, memoryBytes := sumReservations(instances, secrets.Items, exclude)
+	return milliCPUs, memoryBytes, nil
+}
+
+// sumReservations is the core of namespaceReservations, adding up the resources reserved
+// per instance, as found in the resources secrets, times the desired instances of each
+// application.
+func sumReservations(instances map[string]int32, secrets []v1.Secret, exclude string) (int64, int64) {
+	var milliCPUs, memoryBytes int64
+	for idx := range secrets {
+		secret := &secrets[idx]
+		appName := secret.GetLabels()["app.kubernetes.io/name"]
+		desired, ok := instances[appName]
+		if !ok || appName == exclude {
+			continue
+		}
+
+		cpu, memory := reservedResources(ResourcesFromSecret(secret))
+		milliCPUs += cpu * int64(desired)
+		memoryBytes += memory * int64(desired)
+	}
+
+	return milliCPUs, memoryBytes
+}
+
+// reservedResources returns the cpu (in millicores) and memory (in bytes) reserved for a
+// single instance. As with kubernetes a missing request defaults to the limit.
+func reservedResources(resources *models.AppResources) (int64, int64) {
+	if resources == nil {
+		return 0, 0
+	}
+
+	var milliCPUs, memoryBytes int64
+
+	cpu := resources.Requests.CPU
+	if cpu == "" {
+		cpu = resources.Limits.CPU
+	}
+	if quantity, err := resource.ParseQuantity(cpu); err == nil {
+		milliCPUs = quantity.MilliValue()
+	}
+
+	memory := resources.Requests.Memory
+	if memory == "" {
+		memory = resources.Limits.Memory
+	}
+	if quantity, err := resource.ParseQuantity(memory); err == nil {
+		memoryBytes = quantity.Value()
+	}
+
+	return milliCPUs, memoryBytes
+}
+
+func parseResource(kind, value string) (*resource.Quantity, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil, apierror.NewBadRequestErrorf("bad %s '%s'", kind, value).WithDetails(err.Error())
+	}
+	if quantity.Sign() <= 0 {
+		return nil, apierror.NewBadRequestErrorf("%s must be greater than zero", kind)
+	}
+
+	return &quantity, nil
+}
@@ -0,0 +1,54 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Resources", func() {
+	Describe("sumReservations", func() {
+		secret := func(app string, data map[string]string) v1.Secret {
+			secret := v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   app + "-resources",
+					Labels: map[string]string{"app.kubernetes.io/name": app},
+				},
+				Data: map[string][]byte{},
+			}
+			for key, value := range data {
+				secret.Data[key] = []byte(value)
+			}
+			return secret
+		}
+
+		It("adds the reservations of all instances of the other applications", func() {
+			instances := map[string]int32{"web": 3, "worker": 2, "self": 4}
+			secrets := []v1.Secret{
+				secret("web", map[string]string{requestsCPUKey: "250m", requestsMemoryKey: "128Mi"}),
+				// A missing request defaults to the limit
+				secret("worker", map[string]string{limitsCPUKey: "1", limitsMemoryKey: "1Gi"}),
+				// The application being validated is left out
+				secret("self", map[string]string{requestsCPUKey: "2", requestsMemoryKey: "2Gi"}),
+				// Leftovers of deleted applications are ignored
+				secret("gone", map[string]string{requestsCPUKey: "8", requestsMemoryKey: "8Gi"}),
+			}
+
+			milliCPUs, memoryBytes := sumReservations(instances, secrets, "self")
+			Expect(milliCPUs).To(Equal(int64(3*250 + 2*1000)))
+			Expect(memoryBytes).To(Equal(int64(3*128*1024*1024 + 2*1024*1024*1024)))
+		})
+	})
+})
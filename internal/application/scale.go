@@ -15,7 +15,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
@@ -65,7 +64,12 @@ func ScalingFromSecret(scaleSecret *v1.Secret) (int32, error) {
 
 // ScalingSet sets the desired number of instances for the named application.
 // When the function returns the number is saved.
+// An increase beyond the instances, cpu, or memory quota of the namespace is rejected.
 func ScalingSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, instances int32) error {
+	if err := checkScalingQuota(ctx, cluster, appRef, instances); err != nil {
+		return err
+	}
+
 	return scaleUpdate(ctx, cluster, appRef, func(scaleSecret *v1.Secret) {
 		scaleSecret.Data[instanceKey] = []byte(strconv.Itoa(int(instances)))
 	})
@@ -147,11 +151,6 @@ func scaleLoad(ctx context.Context, cluster *kubernetes.Cluster, appRef models.A
 }
 
 func emitScalingEvent(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, from, to int32, username string) error {
-	appCR, err := Get(ctx, cluster, appRef)
-	if err != nil {
-		return err
-	}
-
 	if username == "" {
 		username = "unknown"
 	}
@@ -164,30 +163,5 @@ func emitScalingEvent(ctx context.Context, cluster *kubernetes.Cluster, appRef m
 		reason = "ScaleUnchanged"
 	}
 
-	now := metav1.NewTime(time.Now())
-	event := &v1.Event{
-		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: fmt.Sprintf("%s-scaling-", appRef.Name),
-			Namespace:    appRef.Namespace,
-		},
-		InvolvedObject: v1.ObjectReference{
-			APIVersion: "application.epinio.io/v1",
-			Kind:       "App",
-			Name:       appRef.Name,
-			Namespace:  appRef.Namespace,
-			UID:        appCR.GetUID(),
-		},
-		Reason:         reason,
-		Message:        fmt.Sprintf("scaled from %d to %d by %s", from, to, username),
-		FirstTimestamp: now,
-		LastTimestamp:  now,
-		Count:          1,
-		Type:           v1.EventTypeNormal,
-		Source: v1.EventSource{
-			Component: "epinio-api",
-		},
-	}
-
-	_, err = cluster.Kubectl.CoreV1().Events(appRef.Namespace).Create(ctx, event, metav1.CreateOptions{})
-	return err
+	return emitAppEvent(ctx, cluster, appRef, "scaling", reason, fmt.Sprintf("scaled from %d to %d by %s", from, to, username))
 }
@@ -0,0 +1,131 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// maxScanReports is the number of staging runs whose image scan report is kept per
+// application.
+const maxScanReports = 10
+
+// ScanReport returns the image scan report recorded for the identified staging run of the
+// application. The result is nil if the image was not scanned.
+func ScanReport(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, stageID string) (*models.ScanReport, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeScanSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ScanReportFromSecret(secret, stageID)
+}
+
+// ScanReportFromSecret is the core of ScanReport, extracting the report of the staging run
+// from the secret containing it. The result is nil if the image was not scanned.
+func ScanReportFromSecret(secret *v1.Secret, stageID string) (*models.ScanReport, error) {
+	data, ok := secret.Data[stageID]
+	if !ok {
+		return nil, nil
+	}
+
+	report := models.ScanReport{}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, errors.Wrap(err, "bad scan report")
+	}
+
+	return &report, nil
+}
+
+// ScanReportRecord saves the image scan report of a staging run of the application, keyed
+// by the stage id. The reports of the oldest runs beyond the limit are dropped.
+func ScanReportRecord(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, report models.ScanReport) error {
+	// The policy evaluation is not part of the stored report.
+	report.Threshold = ""
+	report.Blocked = false
+	report.Reason = ""
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef, appRef.MakeScanSecretName(), "scan")
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[report.StageID] = data
+		pruneStageEntries(secret.Data, maxScanReports, scanReportTime)
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// scanReportTime returns the scan time of the encoded report, or the empty string for bad
+// data.
+func scanReportTime(data []byte) string {
+	report := models.ScanReport{}
+	_ = json.Unmarshal(data, &report)
+	return report.ScannedAt
+}
+
+// ScanEvaluate applies the threshold to the report, i.e. fills its policy fields. Findings
+// of the threshold severity or above block the deployment of the image. An empty threshold,
+// or `none`, blocks nothing.
+func ScanEvaluate(report *models.ScanReport, threshold string) {
+	report.Threshold = threshold
+	report.Blocked = false
+	report.Reason = ""
+
+	limit, ok := models.SeverityRank(threshold)
+	if !ok {
+		return
+	}
+
+	counts := []string{}
+	total := 0
+	for rank := len(models.Severities) - 1; rank >= limit; rank-- {
+		severity := models.Severities[rank]
+		if n := report.Summary[severity]; n > 0 {
+			total += n
+			counts = append(counts, fmt.Sprintf("%s: %d", severity, n))
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	report.Blocked = true
+	report.Reason = fmt.Sprintf("image scan found %d vulnerabilities of severity %s or above (%s)",
+		total, strings.ToUpper(threshold), strings.Join(counts, ", "))
+}
@@ -0,0 +1,83 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"encoding/json"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Scan", func() {
+	Describe("ScanReportFromSecret", func() {
+		It("returns the report of the stage, without policy evaluation", func() {
+			data, err := json.Marshal(models.ScanReport{StageID: "s1", Scanner: "trivy"})
+			Expect(err).ToNot(HaveOccurred())
+			secret := &v1.Secret{Data: map[string][]byte{"s1": data}}
+
+			report, err := ScanReportFromSecret(secret, "s1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(report).ToNot(BeNil())
+			Expect(report.Scanner).To(Equal("trivy"))
+			Expect(report.Blocked).To(BeFalse())
+		})
+
+		It("returns nothing for an unscanned stage", func() {
+			report, err := ScanReportFromSecret(&v1.Secret{}, "s1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(report).To(BeNil())
+		})
+	})
+
+	Describe("ScanEvaluate", func() {
+		var report *models.ScanReport
+
+		BeforeEach(func() {
+			report = &models.ScanReport{Summary: map[string]int{
+				models.SeverityLow:  3,
+				models.SeverityHigh: 2,
+			}}
+		})
+
+		It("blocks findings at or above the threshold", func() {
+			ScanEvaluate(report, "medium")
+			Expect(report.Blocked).To(BeTrue())
+			Expect(report.Threshold).To(Equal("medium"))
+			Expect(report.Reason).To(ContainSubstring("2 vulnerabilities of severity MEDIUM or above"))
+			Expect(report.Reason).To(ContainSubstring("HIGH: 2"))
+		})
+
+		It("counts all severities at or above the threshold", func() {
+			ScanEvaluate(report, models.SeverityLow)
+			Expect(report.Blocked).To(BeTrue())
+			Expect(report.Reason).To(ContainSubstring("5 vulnerabilities"))
+		})
+
+		It("passes findings below the threshold", func() {
+			ScanEvaluate(report, models.SeverityCritical)
+			Expect(report.Blocked).To(BeFalse())
+			Expect(report.Reason).To(BeEmpty())
+		})
+
+		It("passes everything without threshold", func() {
+			ScanEvaluate(report, models.ScanThresholdNone)
+			Expect(report.Blocked).To(BeFalse())
+
+			ScanEvaluate(report, "")
+			Expect(report.Blocked).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,712 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// dispatchedRetention is the time a dispatched staging run is remembered, for waiters
+// asking for the result of its start.
+const dispatchedRetention = time.Hour
+
+// StagingLimits are the limits on concurrent staging runs. Zero is no limit.
+type StagingLimits struct {
+	Global    int
+	Namespace int
+}
+
+// StagingStart starts a staging run, i.e. creates its job.
+type StagingStart func(ctx context.Context) error
+
+// QueuedStaging is the persisted form of a staging run not started yet. The payload holds
+// what the submitter of the run needs to start it after a restart of the server.
+type QueuedStaging struct {
+	Entry   models.StagingQueueEntry `json:"entry"`
+	Payload []byte                   `json:"payload,omitempty"`
+}
+
+// StagingStore persists the staging runs not started yet, in order. Load returns the runs to
+// restore, i.e. those persisted by this replica of the server before its restart, and those
+// of replicas which are gone. Save persists the runs of this replica.
+type StagingStore interface {
+	Load(ctx context.Context) ([]QueuedStaging, error)
+	Save(ctx context.Context, queued []QueuedStaging) error
+}
+
+// StagingRestore returns the function starting a persisted staging run. The result is nil
+// when the run was started before the restart.
+type StagingRestore func(ctx context.Context, queued QueuedStaging) (StagingStart, error)
+
+// StagingCounter returns the number of active staging runs, overall and per namespace.
+type StagingCounter func(ctx context.Context) (int, map[string]int, error)
+
+// StagingAdmission serializes the admission of staging runs across the replicas of the
+// server, between counting the active runs and the creation of the jobs of the admitted
+// ones. It returns the function ending the admission.
+type StagingAdmission func(ctx context.Context) (func(), error)
+
+type stagingEntry struct {
+	entry        models.StagingQueueEntry
+	payload      []byte
+	start        StagingStart
+	done         chan struct{} // closed after the start
+	err          error         // result of the start
+	dispatchedAt time.Time
+}
+
+// StagingQueue holds the staging runs waiting for a free slot, and dispatches them in
+// order as the limits allow. Runs blocked by the limit of their namespace do not block
+// the runs of other namespaces. With a store, the runs not started yet are persisted as
+// they change, to be restored after a restart.
+type StagingQueue struct {
+	mu         sync.Mutex
+	dispatchMu sync.Mutex // orders the dispatches
+	count      StagingCounter
+	admission  StagingAdmission
+	limits     StagingLimits
+	waiting    []*stagingEntry
+	starting   map[string]int // runs started, whose jobs may not be visible yet, per namespace
+	dispatched map[string]*stagingEntry
+	store      StagingStore
+	saveMu     sync.Mutex // orders the saves of the store
+}
+
+// Staging is the staging queue of the server. Each replica of the server holds the runs
+// submitted to it, and persists them for its restarts. The limits hold across the replicas,
+// as the active runs are counted from the staging jobs of the cluster, under a cluster-wide
+// admission.
+var Staging = NewStagingQueue(ActiveStagings, AdmitStaging)
+
+// NewStagingQueue returns a new, empty queue, using the counter to determine the active
+// staging runs. A nil admission admits without serialization, for a single server.
+func NewStagingQueue(count StagingCounter, admission StagingAdmission) *StagingQueue {
+	return &StagingQueue{
+		count:      count,
+		admission:  admission,
+		starting:   map[string]int{},
+		dispatched: map[string]*stagingEntry{},
+	}
+}
+
+// Restore sets the store of the queue, and queues the persisted staging runs in front of
+// those submitted since the start of the server. Runs which cannot be restored are dropped.
+func (q *StagingQueue) Restore(ctx context.Context, store StagingStore, restore StagingRestore) error {
+	queued, err := store.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	restored := []*stagingEntry{}
+	for _, s := range queued {
+		start, err := restore(ctx, s)
+		if err != nil {
+			helpers.Logger.Errorw("staging queue: dropping unrestorable staging", "app", s.Entry.App.Name,
+				"namespace", s.Entry.App.Namespace, "stage", s.Entry.ID, "error", err)
+			continue
+		}
+		if start == nil {
+			continue
+		}
+		restored = append(restored, &stagingEntry{
+			entry:   s.Entry,
+			payload: s.Payload,
+			start:   start,
+			done:    make(chan struct{}),
+		})
+	}
+
+	q.mu.Lock()
+	q.store = store
+	q.waiting = append(restored, q.waiting...)
+	q.mu.Unlock()
+
+	q.save(ctx)
+	return nil
+}
+
+// Submit queues the staging run, and dispatches whatever the limits allow. It returns
+// the position of the run in the queue, or zero if it was started right away. In the
+// latter case the error is the result of the start. The payload is persisted with the run
+// until it is started, for its restoration.
+func (q *StagingQueue) Submit(ctx context.Context, limits StagingLimits, entry models.StagingQueueEntry, payload []byte, start StagingStart) (int, error) {
+	e := &stagingEntry{
+		entry:   entry,
+		payload: payload,
+		start:   start,
+		done:    make(chan struct{}),
+	}
+	e.entry.QueuedAt = time.Now().UTC().Format(time.RFC3339)
+
+	q.mu.Lock()
+	q.waiting = append(q.waiting, e)
+	q.mu.Unlock()
+
+	q.save(ctx)
+
+	q.Dispatch(ctx, limits)
+
+	if position := q.Position(entry.ID); position > 0 {
+		return position, nil
+	}
+
+	<-e.done
+	return 0, e.err
+}
+
+// Dispatch starts the queued staging runs the limits allow. With limits, the runs are
+// admitted under the admission of the queue, held until their jobs exist, so that the
+// other replicas of the server count them.
+func (q *StagingQueue) Dispatch(ctx context.Context, limits StagingLimits) {
+	q.dispatchMu.Lock()
+	defer q.dispatchMu.Unlock()
+
+	q.mu.Lock()
+	pending := len(q.waiting) > 0
+	q.mu.Unlock()
+
+	if pending && q.admission != nil && (limits.Global > 0 || limits.Namespace > 0) {
+		release, err := q.admission(ctx)
+		if err != nil {
+			helpers.Logger.Errorw("staging queue: admission", "error", err)
+			return
+		}
+		defer release()
+	}
+
+	for _, e := range q.admit(ctx, limits) {
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// admit starts the queued staging runs the limits allow, and returns them.
+func (q *StagingQueue) admit(ctx context.Context, limits StagingLimits) []*stagingEntry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.limits = limits
+
+	for id, e := range q.dispatched {
+		if time.Since(e.dispatchedAt) > dispatchedRetention {
+			delete(q.dispatched, id)
+		}
+	}
+
+	if len(q.waiting) == 0 {
+		return nil
+	}
+
+	total := 0
+	counts := map[string]int{}
+	if limits.Global > 0 || limits.Namespace > 0 {
+		active, perNamespace, err := q.count(ctx)
+		if err != nil {
+			helpers.Logger.Errorw("staging queue: counting active stagings", "error", err)
+			return nil
+		}
+		total = active
+		for namespace, n := range perNamespace {
+			counts[namespace] = n
+		}
+		for namespace, n := range q.starting {
+			total += n
+			counts[namespace] += n
+		}
+	}
+
+	admitted := []*stagingEntry{}
+	remaining := []*stagingEntry{}
+	for _, e := range q.waiting {
+		namespace := e.entry.App.Namespace
+		if (limits.Global > 0 && total >= limits.Global) ||
+			(limits.Namespace > 0 && counts[namespace] >= limits.Namespace) {
+			remaining = append(remaining, e)
+			continue
+		}
+
+		total++
+		counts[namespace]++
+		q.starting[namespace]++
+		e.dispatchedAt = time.Now()
+		q.dispatched[e.entry.ID] = e
+		admitted = append(admitted, e)
+
+		go q.run(e)
+	}
+	q.waiting = remaining
+
+	return admitted
+}
+
+func (q *StagingQueue) run(e *stagingEntry) {
+	// Detached from the request which queued the run.
+	err := e.start(context.Background())
+	if err != nil {
+		helpers.Logger.Errorw("staging queue: starting staging", "app", e.entry.App.Name,
+			"namespace", e.entry.App.Namespace, "stage", e.entry.ID, "error", err)
+	}
+
+	q.mu.Lock()
+	e.err = err
+	namespace := e.entry.App.Namespace
+	q.starting[namespace]--
+	if q.starting[namespace] <= 0 {
+		delete(q.starting, namespace)
+	}
+	limits := q.limits
+	q.mu.Unlock()
+
+	close(e.done)
+	q.save(context.Background())
+
+	// A failed start leaves a free slot.
+	if err != nil {
+		q.Dispatch(context.Background(), limits)
+	}
+}
+
+// Wait waits until the identified staging run is started, and returns the result of the
+// start. The callback is invoked with the position of the run in the queue whenever it
+// changes. Runs unknown to the queue are considered started.
+func (q *StagingQueue) Wait(ctx context.Context, id string, progress func(position int)) error {
+	last := -1
+	for {
+		q.mu.Lock()
+		e, position := q.find(id)
+		if e == nil {
+			e = q.dispatched[id]
+		}
+		q.mu.Unlock()
+
+		if e == nil {
+			return nil
+		}
+		if position == 0 {
+			select {
+			case <-e.done:
+				return e.err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if position != last && progress != nil {
+			progress(position)
+		}
+		last = position
+
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Position returns the position of the identified staging run in the queue, or zero if it
+// is not queued.
+func (q *StagingQueue) Position(id string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	_, position := q.find(id)
+	return position
+}
+
+// AppPosition returns the position of the first queued staging run of the application, or
+// zero if it has none.
+func (q *StagingQueue) AppPosition(appRef models.AppRef) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for idx, e := range q.waiting {
+		if e.entry.App.Name == appRef.Name && e.entry.App.Namespace == appRef.Namespace {
+			return idx + 1
+		}
+	}
+	return 0
+}
+
+// List returns the queued staging runs, in order, and the limits last used for dispatch.
+func (q *StagingQueue) List() models.StagingQueue {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	result := models.StagingQueue{
+		Limit:          q.limits.Global,
+		NamespaceLimit: q.limits.Namespace,
+		Entries:        []models.StagingQueueEntry{},
+	}
+	for idx, e := range q.waiting {
+		entry := e.entry
+		entry.Position = idx + 1
+		result.Entries = append(result.Entries, entry)
+	}
+	return result
+}
+
+// Move moves the identified staging run to the given position in the queue. Positions
+// beyond the end of the queue move the run to the end.
+func (q *StagingQueue) Move(id string, position int) error {
+	if position < 1 {
+		return fmt.Errorf("bad queue position %d", position)
+	}
+
+	q.mu.Lock()
+	e, current := q.find(id)
+	if e == nil {
+		q.mu.Unlock()
+		return fmt.Errorf("staging '%s' is not queued", id)
+	}
+
+	q.waiting = append(q.waiting[:current-1], q.waiting[current:]...)
+	if position > len(q.waiting) {
+		position = len(q.waiting) + 1
+	}
+
+	q.waiting = append(q.waiting[:position-1], append([]*stagingEntry{e}, q.waiting[position-1:]...)...)
+	q.mu.Unlock()
+
+	q.save(context.Background())
+	return nil
+}
+
+// save persists the runs not started yet, if the queue has a store. These are the runs
+// being started, in the order of their dispatch, followed by the waiting runs.
+func (q *StagingQueue) save(ctx context.Context) {
+	q.saveMu.Lock()
+	defer q.saveMu.Unlock()
+
+	q.mu.Lock()
+	store := q.store
+	starting := []*stagingEntry{}
+	for _, e := range q.dispatched {
+		select {
+		case <-e.done:
+		default:
+			starting = append(starting, e)
+		}
+	}
+	sort.SliceStable(starting, func(i, j int) bool {
+		return starting[i].dispatchedAt.Before(starting[j].dispatchedAt)
+	})
+	queued := []QueuedStaging{}
+	for _, e := range append(starting, q.waiting...) {
+		queued = append(queued, QueuedStaging{Entry: e.entry, Payload: e.payload})
+	}
+	q.mu.Unlock()
+
+	if store == nil {
+		return
+	}
+	if err := store.Save(ctx, queued); err != nil {
+		helpers.Logger.Errorw("staging queue: saving the queue", "error", err)
+	}
+}
+
+// find returns the queued entry for the id, and its position. Callers hold the lock.
+func (q *StagingQueue) find(id string) (*stagingEntry, int) {
+	for idx, e := range q.waiting {
+		if e.entry.ID == id {
+			return e, idx + 1
+		}
+	}
+	return nil, 0
+}
+
+// ActiveStagings returns the number of active staging jobs, overall and per namespace.
+func ActiveStagings(ctx context.Context) (int, map[string]int, error) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	jobList, err := cluster.ListJobs(ctx, helmchart.Namespace(), "app.kubernetes.io/component=staging")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	total := 0
+	counts := map[string]int{}
+	for _, job := range jobList.Items {
+		if jobStagingStatus(job) != models.ApplicationStagingActive {
+			continue
+		}
+		total++
+		counts[job.GetLabels()["app.kubernetes.io/part-of"]]++
+	}
+
+	return total, counts, nil
+}
+
+// stagingAdmissionLease is the name of the lease in the epinio namespace held by the replica
+// of the server admitting staging runs.
+const stagingAdmissionLease = "epinio-staging-admission"
+
+const (
+	// stagingAdmissionDuration is the time after which an admission not ended is
+	// considered abandoned, i.e. by a replica which is gone.
+	stagingAdmissionDuration = time.Minute
+	// stagingAdmissionRetry is the period of the attempts to begin an admission.
+	stagingAdmissionRetry = 500 * time.Millisecond
+)
+
+// stagingReplica identifies this replica of the server, i.e. its pod.
+var stagingReplica = func() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "epinio-server"
+	}
+	return hostname
+}()
+
+// AdmitStaging is the StagingAdmission of the cluster. It holds a lease of the epinio
+// namespace until the admission ends, or stagingAdmissionDuration passed.
+func AdmitStaging(ctx context.Context) (func(), error) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	holder := stagingReplica + "_" + uuid.NewString()
+	leases := cluster.Kubectl.CoordinationV1().Leases(helmchart.Namespace())
+
+	for {
+		acquired, err := acquireStagingAdmission(ctx, leases, holder)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			break
+		}
+
+		select {
+		case <-time.After(stagingAdmissionRetry):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			lease, err := leases.Get(context.Background(), stagingAdmissionLease, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != holder {
+				return nil
+			}
+			lease.Spec.HolderIdentity = nil
+			_, err = leases.Update(context.Background(), lease, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			helpers.Logger.Errorw("staging queue: ending the admission", "error", err)
+		}
+	}
+
+	return release, nil
+}
+
+// acquireStagingAdmission takes the admission lease for the holder, if it is free, or
+// abandoned. Conflicts with other replicas leave the lease to them.
+func acquireStagingAdmission(ctx context.Context, leases coordinationv1client.LeaseInterface, holder string) (bool, error) {
+	now := metav1.NewMicroTime(time.Now())
+	seconds := int32(stagingAdmissionDuration.Seconds())
+
+	lease, err := leases.Get(ctx, stagingAdmissionLease, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = leases.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: stagingAdmissionLease},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &holder,
+				LeaseDurationSeconds: &seconds,
+				AcquireTime:          &now,
+			},
+		}, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	held := lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != ""
+	if held && lease.Spec.AcquireTime != nil &&
+		time.Since(lease.Spec.AcquireTime.Time) < stagingAdmissionDuration {
+		return false, nil
+	}
+
+	lease.Spec.HolderIdentity = &holder
+	lease.Spec.LeaseDurationSeconds = &seconds
+	lease.Spec.AcquireTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// stagingQueueSecretName is the name of the secret persisting the staging queue. A secret,
+// as the payloads of the runs contain the environment of the applications.
+const stagingQueueSecretName = "epinio-staging-queue"
+
+const stagingQueueKey = "queue"
+
+// StagingSecretStore persists the staging queues of the replicas of the server in a secret of
+// the epinio namespace. The runs are recorded with the replica, i.e. pod, owning them.
+type StagingSecretStore struct {
+	cluster *kubernetes.Cluster
+	owner   string
+}
+
+// ownedStaging is a persisted staging run, with the replica owning it. The runs persisted
+// without owner belong to no replica.
+type ownedStaging struct {
+	Owner string `json:"owner,omitempty"`
+	QueuedStaging
+}
+
+// NewStagingSecretStore returns a store persisting the staging queue of this replica in the
+// cluster.
+func NewStagingSecretStore(cluster *kubernetes.Cluster) *StagingSecretStore {
+	return &StagingSecretStore{cluster: cluster, owner: stagingReplica}
+}
+
+// Load takes over the runs of this replica, and of the replicas whose pods are gone, and
+// returns them. The result is empty if nothing was persisted.
+func (s *StagingSecretStore) Load(ctx context.Context) ([]QueuedStaging, error) {
+	gone := map[string]bool{}
+	var result []QueuedStaging
+
+	err := s.update(ctx, func(stored []ownedStaging) ([]ownedStaging, error) {
+		result = []QueuedStaging{}
+		for idx, o := range stored {
+			if o.Owner != s.owner && o.Owner != "" {
+				isGone, known := gone[o.Owner]
+				if !known {
+					_, err := s.cluster.Kubectl.CoreV1().Pods(helmchart.Namespace()).Get(ctx, o.Owner, metav1.GetOptions{})
+					if err != nil && !apierrors.IsNotFound(err) {
+						return nil, err
+					}
+					isGone = apierrors.IsNotFound(err)
+					gone[o.Owner] = isGone
+				}
+				if !isGone {
+					continue
+				}
+			}
+
+			stored[idx].Owner = s.owner
+			result = append(result, o.QueuedStaging)
+		}
+		return stored, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Save persists the runs of this replica, replacing those it persisted before.
+func (s *StagingSecretStore) Save(ctx context.Context, queued []QueuedStaging) error {
+	return s.update(ctx, func(stored []ownedStaging) ([]ownedStaging, error) {
+		result := []ownedStaging{}
+		for _, o := range stored {
+			if o.Owner != s.owner {
+				result = append(result, o)
+			}
+		}
+		for _, q := range queued {
+			result = append(result, ownedStaging{Owner: s.owner, QueuedStaging: q})
+		}
+		return result, nil
+	})
+}
+
+// update replaces the persisted runs of all replicas with the result of modify, retrying
+// on conflicts with the other replicas.
+func (s *StagingSecretStore) update(ctx context.Context, modify func([]ownedStaging) ([]ownedStaging, error)) error {
+	secrets := s.cluster.Kubectl.CoreV1().Secrets(helmchart.Namespace())
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := secrets.Get(ctx, stagingQueueSecretName, metav1.GetOptions{})
+		notFound := apierrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return err
+		}
+
+		stored := []ownedStaging{}
+		if !notFound {
+			if data, ok := secret.Data[stagingQueueKey]; ok {
+				if err := json.Unmarshal(data, &stored); err != nil {
+					return errors.Wrap(err, "bad staging queue")
+				}
+			}
+		}
+
+		stored, err = modify(stored)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+
+		if notFound {
+			_, err = secrets.Create(ctx, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: stagingQueueSecretName,
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "epinio",
+						"app.kubernetes.io/component":  "staging",
+					},
+				},
+				Data: map[string][]byte{stagingQueueKey: data},
+			}, metav1.CreateOptions{})
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[stagingQueueKey] = data
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
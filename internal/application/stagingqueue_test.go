@@ -0,0 +1,263 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StagingQueue", func() {
+	var (
+		mu      sync.Mutex
+		active  map[string]int
+		started []string
+		queue   *application.StagingQueue
+	)
+
+	counter := func(ctx context.Context) (int, map[string]int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		total := 0
+		counts := map[string]int{}
+		for namespace, n := range active {
+			total += n
+			counts[namespace] = n
+		}
+		return total, counts, nil
+	}
+
+	// start records the staging run as started, and as occupying a slot of its namespace.
+	start := func(id, namespace string) application.StagingStart {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			started = append(started, id)
+			active[namespace]++
+			return nil
+		}
+	}
+
+	entry := func(id, namespace string) models.StagingQueueEntry {
+		return models.StagingQueueEntry{ID: id, App: models.NewAppRef("app-"+id, namespace)}
+	}
+
+	submit := func(limits application.StagingLimits, id, namespace string) int {
+		position, err := queue.Submit(context.Background(), limits, entry(id, namespace), nil, start(id, namespace))
+		Expect(err).ToNot(HaveOccurred())
+		return position
+	}
+
+	BeforeEach(func() {
+		active = map[string]int{}
+		started = nil
+		queue = application.NewStagingQueue(counter, nil)
+	})
+
+	It("starts right away without limits", func() {
+		Expect(submit(application.StagingLimits{}, "a", "ns")).To(Equal(0))
+		Expect(submit(application.StagingLimits{}, "b", "ns")).To(Equal(0))
+		Expect(started).To(Equal([]string{"a", "b"}))
+	})
+
+	It("returns the error of a start made right away", func() {
+		_, err := queue.Submit(context.Background(), application.StagingLimits{}, entry("a", "ns"), nil,
+			func(ctx context.Context) error { return errors.New("boom") })
+		Expect(err).To(MatchError("boom"))
+	})
+
+	It("queues above the global limit, and starts in order as slots free up", func() {
+		limits := application.StagingLimits{Global: 1}
+
+		Expect(submit(limits, "a", "ns1")).To(Equal(0))
+		Expect(submit(limits, "b", "ns2")).To(Equal(1))
+		Expect(submit(limits, "c", "ns3")).To(Equal(2))
+		Expect(queue.AppPosition(models.NewAppRef("app-c", "ns3"))).To(Equal(2))
+
+		mu.Lock()
+		active = map[string]int{}
+		mu.Unlock()
+
+		queue.Dispatch(context.Background(), limits)
+		Expect(queue.Wait(context.Background(), "b", nil)).To(Succeed())
+		Expect(queue.Position("c")).To(Equal(1))
+		Expect(started).To(Equal([]string{"a", "b"}))
+	})
+
+	It("does not block other namespaces on a namespace limit", func() {
+		limits := application.StagingLimits{Namespace: 1}
+
+		Expect(submit(limits, "a", "ns1")).To(Equal(0))
+		Expect(submit(limits, "b", "ns1")).To(Equal(1))
+		Expect(submit(limits, "c", "ns2")).To(Equal(0))
+		Expect(started).To(Equal([]string{"a", "c"}))
+	})
+
+	It("moves queued stagings", func() {
+		limits := application.StagingLimits{Global: 1}
+
+		submit(limits, "a", "ns")
+		submit(limits, "b", "ns")
+		submit(limits, "c", "ns")
+		submit(limits, "d", "ns")
+
+		Expect(queue.Move("d", 1)).To(Succeed())
+		Expect(queue.Move("b", 10)).To(Succeed())
+
+		ids := []string{}
+		for _, e := range queue.List().Entries {
+			ids = append(ids, e.ID)
+		}
+		Expect(ids).To(Equal([]string{"d", "c", "b"}))
+		Expect(queue.List().Entries[0].Position).To(Equal(1))
+
+		Expect(queue.Move("a", 1)).To(HaveOccurred())
+		Expect(queue.Move("c", 0)).To(HaveOccurred())
+	})
+
+	It("admits the runs under the admission, only with limits", func() {
+		admissions, held := 0, false
+		queue = application.NewStagingQueue(counter, func(ctx context.Context) (func(), error) {
+			mu.Lock()
+			defer mu.Unlock()
+			Expect(held).To(BeFalse())
+			admissions++
+			held = true
+			return func() {
+				mu.Lock()
+				defer mu.Unlock()
+				held = false
+			}, nil
+		})
+
+		Expect(submit(application.StagingLimits{}, "a", "ns")).To(Equal(0))
+		Expect(admissions).To(Equal(0))
+
+		limits := application.StagingLimits{Global: 2}
+		Expect(submit(limits, "b", "ns")).To(Equal(0))
+		Expect(submit(limits, "c", "ns")).To(Equal(1))
+		Expect(admissions).To(Equal(2))
+		Expect(held).To(BeFalse())
+		Expect(started).To(Equal([]string{"a", "b"}))
+	})
+
+	It("keeps the runs queued when the admission fails", func() {
+		queue = application.NewStagingQueue(counter, func(ctx context.Context) (func(), error) {
+			return nil, errors.New("no lease")
+		})
+
+		Expect(submit(application.StagingLimits{Global: 1}, "a", "ns")).To(Equal(1))
+		Expect(started).To(BeEmpty())
+	})
+
+	Describe("persistence", func() {
+		var store *memoryStagingStore
+
+		restore := func(ctx context.Context, queued application.QueuedStaging) (application.StagingStart, error) {
+			switch string(queued.Payload) {
+			case "started":
+				return nil, nil
+			case "broken":
+				return nil, errors.New("broken")
+			}
+			return start(queued.Entry.ID, queued.Entry.App.Namespace), nil
+		}
+
+		ids := func(queued []application.QueuedStaging) []string {
+			result := []string{}
+			for _, q := range queued {
+				result = append(result, q.Entry.ID)
+			}
+			return result
+		}
+
+		BeforeEach(func() {
+			store = &memoryStagingStore{}
+			Expect(queue.Restore(context.Background(), store, restore)).To(Succeed())
+		})
+
+		It("persists the stagings not started yet", func() {
+			limits := application.StagingLimits{Global: 1}
+
+			submit(limits, "a", "ns")
+			submit(limits, "b", "ns")
+			submit(limits, "c", "ns")
+			Expect(ids(store.saved())).To(Equal([]string{"b", "c"}))
+
+			Expect(queue.Move("c", 1)).To(Succeed())
+			Expect(ids(store.saved())).To(Equal([]string{"c", "b"}))
+
+			mu.Lock()
+			active = map[string]int{}
+			mu.Unlock()
+
+			queue.Dispatch(context.Background(), limits)
+			Expect(queue.Wait(context.Background(), "c", nil)).To(Succeed())
+			Eventually(func() []string { return ids(store.saved()) }).Should(Equal([]string{"b"}))
+		})
+
+		It("restores the persisted stagings in front of new ones", func() {
+			limits := application.StagingLimits{Global: 1}
+			active["ns"] = 1
+
+			Expect(store.Save(context.Background(), []application.QueuedStaging{
+				{Entry: entry("a", "ns"), Payload: []byte("started")},
+				{Entry: entry("b", "ns"), Payload: []byte("broken")},
+				{Entry: entry("c", "ns")},
+			})).To(Succeed())
+
+			restored := application.NewStagingQueue(counter, nil)
+			position, err := restored.Submit(context.Background(), limits, entry("d", "ns"), nil, start("d", "ns"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(position).To(Equal(1))
+
+			Expect(restored.Restore(context.Background(), store, restore)).To(Succeed())
+
+			queued := []string{}
+			for _, e := range restored.List().Entries {
+				queued = append(queued, e.ID)
+			}
+			Expect(queued).To(Equal([]string{"c", "d"}))
+			Expect(ids(store.saved())).To(Equal([]string{"c", "d"}))
+		})
+	})
+})
+
+// memoryStagingStore is a staging store keeping the persisted stagings in memory.
+type memoryStagingStore struct {
+	mu     sync.Mutex
+	queued []application.QueuedStaging
+}
+
+func (s *memoryStagingStore) Load(ctx context.Context) ([]application.QueuedStaging, error) {
+	return s.saved(), nil
+}
+
+func (s *memoryStagingStore) Save(ctx context.Context, queued []application.QueuedStaging) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queued = append([]application.QueuedStaging{}, queued...)
+	return nil
+}
+
+func (s *memoryStagingStore) saved() []application.QueuedStaging {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]application.QueuedStaging{}, s.queued...)
+}
@@ -15,6 +15,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/epinio/epinio/helpers"
@@ -225,7 +226,15 @@ func (a *Workload) Get(ctx context.Context) (*models.AppDeployment, error) {
 		helpers.Logger.Errorw("metrics not available", "error", err)
 	}
 
-	return a.AssembleFromParts(ctx, podList, podMetrics, routes)
+	deployment, err := a.AssembleFromParts(ctx, podList, podMetrics, routes)
+	if err != nil || deployment == nil {
+		return deployment, err
+	}
+
+	a.populateProbeFailures(ctx, deployment.Replicas)
+	a.populatePreviousLogs(ctx, deployment.Replicas)
+
+	return deployment, nil
 }
 
 // AssembleFromParts is the core of Get constructing the deployment structure from the pods and
@@ -357,23 +366,58 @@ func (a *Workload) generatePodInfo(pods []corev1.Pod) map[string]*models.PodInfo
 
 	for i, pod := range pods {
 		restarts := int32(0)
+		message := ""
 		for _, cs := range pod.Status.ContainerStatuses {
 			if cs.Name == a.name {
 				restarts += cs.RestartCount
+				if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+					message = strings.TrimSpace(cs.State.Waiting.Reason + ": " + cs.State.Waiting.Message)
+					message = strings.TrimSuffix(message, ":")
+				}
 			}
 		}
 
 		result[pod.Name] = &models.PodInfo{
-			Name:      pod.Name,
-			Restarts:  restarts,
-			Ready:     podutils.IsPodReady(&pods[i]),
-			CreatedAt: pod.CreationTimestamp.Format(time.RFC3339), // ISO 8601
+			Name:        pod.Name,
+			Restarts:    restarts,
+			Ready:       podutils.IsPodReady(&pods[i]),
+			CreatedAt:   pod.CreationTimestamp.Format(time.RFC3339), // ISO 8601
+			Message:     message,
+			Diagnostics: podDiagnostics(pod, a.name),
 		}
 	}
 
 	return result
 }
 
+// populateProbeFailures sets the message of the not ready replicas to the last failure
+// reported by their health checks, if there is any. Errors are logged and ignored, as the
+// information is not essential.
+func (a *Workload) populateProbeFailures(ctx context.Context, podInfos map[string]*models.PodInfo) {
+	notReady := false
+	for _, podInfo := range podInfos {
+		if !podInfo.Ready {
+			notReady = true
+			break
+		}
+	}
+	if !notReady {
+		return
+	}
+
+	failures, err := probeFailures(ctx, a.cluster, a.app.Namespace)
+	if err != nil {
+		helpers.Logger.Infow("probe failures not available", "app", a.app.Name, "error", err)
+		return
+	}
+
+	for name, podInfo := range podInfos {
+		if message, ok := failures[name]; ok && !podInfo.Ready {
+			podInfo.Message = message
+		}
+	}
+}
+
 func (a *Workload) populatePodMetrics(podInfos map[string]*models.PodInfo, podMetrics []metricsv1beta1.PodMetrics) error {
 	for _, podMetric := range podMetrics {
 		if _, podExists := podInfos[podMetric.Name]; !podExists {
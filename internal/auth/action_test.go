@@ -59,6 +59,11 @@ var _ = Describe("Auth actions", func() {
 			Expect(found).To(BeTrue())
 			Expect(appUpdateSettings.Routes).To(ContainElement("AppUpdate"))
 
+			appUpdateAlerts, found := auth.ActionsMap["app_update_alerts"]
+			Expect(found).To(BeTrue())
+			Expect(appUpdateAlerts.Routes).To(ContainElement("AppAlertAdd"))
+			Expect(appUpdateAlerts.Routes).To(ContainElement("AppAlertRemove"))
+
 			appUpdateChart, found := auth.ActionsMap["app_update_chart"]
 			Expect(found).To(BeTrue())
 			Expect(appUpdateChart.Routes).To(ContainElement("AppUpdate"))
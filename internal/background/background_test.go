@@ -0,0 +1,121 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package background_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/background"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+// service counts its starts and stops.
+type service struct {
+	mu      sync.Mutex
+	started int
+	stopped int
+}
+
+func (s *service) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started++
+}
+
+func (s *service) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped++
+}
+
+func (s *service) counts() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started, s.stopped
+}
+
+var _ = Describe("Worker", func() {
+	It("ticks until stopped, canceling the context of the tick", func() {
+		var ticks atomic.Int32
+		canceled := make(chan struct{})
+
+		worker := background.Periodic(time.Millisecond, func(ctx context.Context) {
+			if ticks.Add(1) == 3 {
+				go func() {
+					<-ctx.Done()
+					close(canceled)
+				}()
+			}
+		})
+		worker.Start()
+		Eventually(ticks.Load).Should(BeNumerically(">=", 3))
+
+		worker.Stop()
+		Eventually(canceled).Should(BeClosed())
+	})
+
+	It("leaves the context usable for the cleanup after stop", func() {
+		var cleanupErr error
+		worker := background.New(func(ctx context.Context, stop <-chan struct{}) {
+			<-stop
+			cleanupErr = ctx.Err()
+		})
+		worker.Start()
+		worker.Stop()
+
+		Expect(cleanupErr).ToNot(HaveOccurred())
+	})
+})
+
+var _ = Describe("Elected", func() {
+	It("runs the services on one replica only, and stops them when canceled", func() {
+		cluster := &kubernetes.Cluster{Kubectl: k8sfake.NewSimpleClientset()}
+		first, second := &service{}, &service{}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			err := background.Elected(ctx, cluster, func() []background.Service {
+				return []background.Service{first}
+			})
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		Eventually(func() int { started, _ := first.counts(); return started }).Should(Equal(1))
+
+		otherCtx, otherCancel := context.WithCancel(context.Background())
+		otherDone := make(chan struct{})
+		go func() {
+			defer close(otherDone)
+			err := background.Elected(otherCtx, cluster, func() []background.Service {
+				return []background.Service{second}
+			})
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		Consistently(func() int { started, _ := second.counts(); return started }, "500ms").Should(Equal(0))
+
+		cancel()
+		Eventually(done, "5s").Should(BeClosed())
+		started, stopped := first.counts()
+		Expect(started).To(Equal(1))
+		Expect(stopped).To(Equal(1))
+
+		otherCancel()
+		Eventually(otherDone, "5s").Should(BeClosed())
+	})
+})
@@ -0,0 +1,110 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package background
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// LeaseName is the name of the lease in the epinio namespace held by the replica of the
+// server running the cluster-wide workers.
+const LeaseName = "epinio-server-workers"
+
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// Elected runs the services made by start while this replica of the server holds the lease,
+// so that the work spanning the cluster is done once, whatever the number of replicas. The
+// services are stopped, in reverse order, when the lease is lost, and made anew when it is
+// acquired again. Elected returns when the context is canceled, after releasing the lease
+// and stopping the services.
+func Elected(ctx context.Context, cluster *kubernetes.Cluster, start func() []Service) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      LeaseName,
+			Namespace: helmchart.Namespace(),
+		},
+		Client: cluster.Kubectl.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: hostname + "_" + uuid.NewString(),
+		},
+	}
+
+	// The services of the current term. They are started by the elector, and stopped once
+	// it returns, when the context of the term is canceled.
+	var mu sync.Mutex
+	var running []Service
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		ReleaseOnCancel: true,
+		Name:            LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				mu.Lock()
+				defer mu.Unlock()
+				if ctx.Err() != nil {
+					return
+				}
+
+				helpers.Logger.Infow("leader election: acquired the lease, starting the workers")
+				running = start()
+				for _, service := range running {
+					service.Start()
+				}
+			},
+			OnStoppedLeading: func() {
+				helpers.Logger.Infow("leader election: not holding the lease")
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Run returns when the lease is lost, or the context canceled. The services of the term
+	// are stopped before campaigning again.
+	for ctx.Err() == nil {
+		elector.Run(ctx)
+
+		mu.Lock()
+		for i := len(running) - 1; i >= 0; i-- {
+			running[i].Stop()
+		}
+		running = nil
+		mu.Unlock()
+	}
+
+	return nil
+}
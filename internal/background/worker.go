@@ -0,0 +1,90 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package background runs the workers of the server, like the git poller or the alert
+// evaluator, and elects the replica of the server running the cluster-wide ones.
+package background
+
+import (
+	"context"
+	"time"
+)
+
+// Service is a worker, or any other background activity which can be started and stopped.
+type Service interface {
+	Start()
+	Stop()
+}
+
+// Worker runs a function in the background, until stopped. A worker is started only once.
+type Worker struct {
+	run  func(ctx context.Context, stop <-chan struct{})
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New returns a new, not yet started, worker calling run. Run is expected to return soon
+// after stop is closed. Its context is canceled after it returned, leaving it usable for
+// the cleanup at the end of run.
+func New(run func(ctx context.Context, stop <-chan struct{})) *Worker {
+	return &Worker{
+		run:  run,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Periodic returns a new, not yet started, worker calling tick every interval. The context
+// of tick is canceled when the worker is stopped.
+func Periodic(interval time.Duration, tick func(ctx context.Context)) *Worker {
+	return New(func(ctx context.Context, stop <-chan struct{}) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		go func() {
+			select {
+			case <-stop:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				tick(ctx)
+			}
+		}
+	})
+}
+
+// Start runs the worker in the background.
+func (w *Worker) Start() {
+	go func() {
+		defer close(w.done)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		w.run(ctx, w.stop)
+	}()
+}
+
+// Stop stops the worker and waits for its function to return.
+func (w *Worker) Stop() {
+	close(w.stop)
+	<-w.done
+}
@@ -0,0 +1,133 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//counterfeiter:generate -header ../../../LICENSE_HEADER . AppAlertService
+type AppAlertService interface {
+	AppAlertList(appName string) error
+	AppAlertAdd(appName, alertName, kind string, threshold int64, notify []string) error
+	AppAlertRemove(appName, alertName string) error
+
+	AppMatcher
+}
+
+// NewAppAlertCmd returns a new 'epinio app alert' command
+func NewAppAlertCmd(client AppAlertService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alert",
+		Short: "Epinio application alerts",
+		Long: `Manage the alerting rules of an application. Epinio checks the rules periodically and
+notifies their endpoints when an alert fires, and again when it resolves.`,
+	}
+
+	cmd.AddCommand(
+		NewAppAlertAddCmd(client),
+		NewAppAlertListCmd(client, rootCfg),
+		NewAppAlertRemoveCmd(client),
+	)
+
+	return cmd
+}
+
+// NewAppAlertAddCmd returns a new `epinio app alert add` command
+func NewAppAlertAddCmd(client AppAlertService) *cobra.Command {
+	var threshold int64
+	var notify []string
+
+	cmd := &cobra.Command{
+		Use:   "add APPNAME ALERT KIND",
+		Short: "Add an alert",
+		Long: `Add an alert to the named application. The kind is one of
+
+  crashloop       an instance is not ready after --threshold restarts (default 3)
+  unready         fewer instances are ready than desired, for --threshold minutes (default 5)
+  staging-failed  the last staging of the application failed
+  memory          an instance uses more than --threshold percent of its memory limit (default 90)
+  certificate     a certificate of the routes expires within --threshold days (default 14)
+
+The scheme of a --notify url selects the kind of endpoint: http(s)://host/path receives
+the notification as JSON, slack+https://host/path is a Slack-compatible incoming webhook,
+and mailto:user@host sends an email through the SMTP server configured for Epinio.`,
+		Args:              cobra.ExactArgs(3),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppAlertAdd(args[0], args[1], args[2], threshold, notify)
+			if err != nil {
+				return errors.Wrap(err, "error adding alert")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&threshold, "threshold", 0, "threshold of the alert, in the unit of its kind. Zero selects the default")
+	cmd.Flags().StringSliceVar(&notify, "notify", []string{}, "endpoint to notify. Repeatable. At least one is required")
+
+	return cmd
+}
+
+// NewAppAlertListCmd returns a new `epinio app alert list` command
+func NewAppAlertListCmd(client AppAlertService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list APPNAME",
+		Short:             "List alerts",
+		Long:              "List the alerts of the named application, with their state",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppAlertList(args[0])
+			if err != nil {
+				return errors.Wrap(err, "error listing alerts")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// NewAppAlertRemoveCmd returns a new `epinio app alert remove` command
+func NewAppAlertRemoveCmd(client AppAlertService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove APPNAME ALERT",
+		Short:             "Remove an alert",
+		Long:              "Remove the named alert from the named application",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppAlertRemove(args[0], args[1])
+			if err != nil {
+				return errors.Wrap(err, "error removing alert")
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,120 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//counterfeiter:generate -header ../../../LICENSE_HEADER . AppLogDrainService
+type AppLogDrainService interface {
+	AppLogDrainList(appName string) error
+	AppLogDrainAdd(appName, drainName, drainURL string) error
+	AppLogDrainRemove(appName, drainName string) error
+
+	AppMatcher
+}
+
+// NewAppLogDrainCmd returns a new 'epinio app drain' command
+func NewAppLogDrainCmd(client AppLogDrainService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drain",
+		Short: "Epinio application log drains",
+		Long: `Manage the external endpoints the logs of an application are forwarded to. Supported are
+syslog (RFC5424 over TCP or TLS), HTTP endpoints accepting JSON, and OTLP logs endpoints`,
+	}
+
+	cmd.AddCommand(
+		NewAppLogDrainAddCmd(client),
+		NewAppLogDrainListCmd(client, rootCfg),
+		NewAppLogDrainRemoveCmd(client),
+	)
+
+	return cmd
+}
+
+// NewAppLogDrainAddCmd returns a new `epinio app drain add` command
+func NewAppLogDrainAddCmd(client AppLogDrainService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add APPNAME DRAIN URL",
+		Short: "Add a log drain",
+		Long: `Add a log drain to the named application. The scheme of the url selects the kind of
+drain: syslog://host:port, syslog+tls://host:port, http(s)://host/path, or
+otlp+http(s)://host[/path], where the path defaults to /v1/logs. User info of http and otlp
+urls is sent as basic authentication.`,
+		Args:              cobra.ExactArgs(3),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppLogDrainAdd(args[0], args[1], args[2])
+			if err != nil {
+				return errors.Wrap(err, "error adding log drain")
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// NewAppLogDrainListCmd returns a new `epinio app drain list` command
+func NewAppLogDrainListCmd(client AppLogDrainService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list APPNAME",
+		Short:             "List log drains",
+		Long:              "List the log drains of the named application, and of its namespace, with their health",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppLogDrainList(args[0])
+			if err != nil {
+				return errors.Wrap(err, "error listing log drains")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// NewAppLogDrainRemoveCmd returns a new `epinio app drain remove` command
+func NewAppLogDrainRemoveCmd(client AppLogDrainService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove APPNAME DRAIN",
+		Short:             "Remove a log drain",
+		Long:              "Remove the named log drain from the named application",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppLogDrainRemove(args[0], args[1])
+			if err != nil {
+				return errors.Wrap(err, "error removing log drain")
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,114 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//counterfeiter:generate -header ../../../LICENSE_HEADER . AppGitTriggerService
+type AppGitTriggerService interface {
+	AppGitTriggerEnable(appName string) error
+	AppGitTriggerDisable(appName string) error
+	AppGitTriggerShow(appName string) error
+
+	AppMatcher
+}
+
+// NewAppGitTriggerCmd returns a new 'epinio app git-trigger' command
+func NewAppGitTriggerCmd(client AppGitTriggerService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "git-trigger",
+		Short: "Epinio application push-to-deploy",
+		Long: `Manage the git webhook deploying an application when commits are pushed to the
+branch tracked by its git origin`,
+	}
+
+	cmd.AddCommand(
+		NewAppGitTriggerEnableCmd(client),
+		NewAppGitTriggerDisableCmd(client),
+		NewAppGitTriggerShowCmd(client),
+	)
+
+	return cmd
+}
+
+// NewAppGitTriggerEnableCmd returns a new `epinio app git-trigger enable` command
+func NewAppGitTriggerEnableCmd(client AppGitTriggerService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "enable APPNAME",
+		Short: "Enable push-to-deploy",
+		Long: `Enable push-to-deploy for the named application, and show the webhook url and secret
+to configure at the git provider. Enabling again replaces the secret.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppGitTriggerEnable(args[0])
+			if err != nil {
+				return errors.Wrap(err, "error enabling git trigger")
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// NewAppGitTriggerDisableCmd returns a new `epinio app git-trigger disable` command
+func NewAppGitTriggerDisableCmd(client AppGitTriggerService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "disable APPNAME",
+		Short:             "Disable push-to-deploy",
+		Long:              "Disable push-to-deploy for the named application",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppGitTriggerDisable(args[0])
+			if err != nil {
+				return errors.Wrap(err, "error disabling git trigger")
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// NewAppGitTriggerShowCmd returns a new `epinio app git-trigger show` command
+func NewAppGitTriggerShowCmd(client AppGitTriggerService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show APPNAME",
+		Short:             "Show push-to-deploy details",
+		Long:              "Show the push-to-deploy configuration of the named application, and its recent webhook deliveries",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppGitTriggerShow(args[0])
+			if err != nil {
+				return errors.Wrap(err, "error showing git trigger")
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
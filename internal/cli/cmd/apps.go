@@ -16,7 +16,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/internal/manifest"
 	"github.com/epinio/epinio/pkg/api/core/v1/client"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -34,10 +36,15 @@ type ApplicationsService interface {
 	AppManifest(name, path string) error
 	AppPortForward(ctx context.Context, name, instance string, address, ports []string) error
 	AppPush(ctxt context.Context, manifest models.ApplicationManifest) error
-	AppRestage(name string, restart bool) error
+	AppRestage(name string, restart, clearCache bool) error
 	AppRestart(name string) error
+	AppScan(name string) error
+	AppMetrics(name, span string) error
+	AppEvents(ctx context.Context, name string, follow bool) error
+	AppPromote(name, from string, request models.AppPromoteRequest) error
 	AppWatch(ctx context.Context, name, namespace, path string) error
 	AppShow(name string) error
+	AppSupportBundle(name, destination string) error
 	AppStageID(name string) (string, error)
 	AppUpdate(name string, updateRequest models.ApplicationUpdateRequest) error
 	Apps(all bool) error
@@ -48,9 +55,12 @@ type ApplicationsService interface {
 	GitconfigMatcher                                  // --git-config
 	ConfigurationMatching(toComplete string) []string // --bind
 
-	// interfaces for the env and chart sub-ensembles
+	// interfaces for the env, chart, git trigger, log drain and alert sub-ensembles
 	AppenvService
 	AppchartsService
+	AppGitTriggerService
+	AppLogDrainService
+	AppAlertService
 }
 
 // NewApplicationsCmd returns a new 'epinio app' command
@@ -74,17 +84,25 @@ func NewApplicationsCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.
 		NewAppChartCmd(client), // See appchart.go for implementation
 		NewAppCreateCmd(client),
 		NewAppDeleteCmd(client),
+		NewAppLogDrainCmd(client, rootCfg), // See appdrain.go for implementation
+		NewAppAlertCmd(client, rootCfg),    // See appalert.go for implementation
 		NewAppEnvCmd(client), // See appenv.go for implementation
+		NewAppEventsCmd(client, rootCfg),
 		NewAppExecCmd(client),
 		NewAppExportCmd(client),
+		NewAppGitTriggerCmd(client), // See appgittrigger.go for implementation
 		NewAppListCmd(client, rootCfg),
 		NewAppLogsCmd(client),
 		NewAppManifestCmd(client),
+		NewAppMetricsCmd(client, rootCfg),
 		NewAppPortForwardCmd(client),
 		NewAppPushCmd(client),
 		NewAppRestageCmd(client),
 		NewAppRestartCmd(client),
+		NewAppScanCmd(client, rootCfg),
+		NewAppPromoteCmd(client, rootCfg),
 		NewAppShowCmd(client, rootCfg),
+		NewAppSupportBundleCmd(client),
 		NewAppUpdateCmd(client),
 		NewAppWatchCmd(client),
 	)
@@ -128,6 +146,9 @@ func NewAppCreateCmd(client ApplicationsService) *cobra.Command {
 	bindOption(cmd, client)
 	envOption(cmd)
 	instancesOption(cmd)
+	resourcesOption(cmd)
+	healthcheckOption(cmd)
+	dependsOnOption(cmd)
 	chartValueOptionX(cmd)
 
 	cmd.Flags().String("app-chart", "", "App chart to use for deployment")
@@ -178,6 +199,38 @@ type AppExecConfig struct {
 	instance string
 }
 
+// NewAppEventsCmd returns a new `epinio app events` command
+func NewAppEventsCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events NAME",
+		Short: "Show the events timeline of the application",
+		Long: `Show the events timeline of the application, oldest first.
+It merges the kubernetes events of the application's pods, ingresses and staging jobs, the scaling events, the helm release transitions, and the changes made through the API.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			follow, err := cmd.Flags().GetBool("follow")
+			if err != nil {
+				return errors.Wrap(err, "failed to read option --follow")
+			}
+
+			err = client.AppEvents(cmd.Context(), args[0], follow)
+			// Note: errors.Wrap (nil, "...") == nil
+			return errors.Wrap(err, "error showing app events")
+		},
+	}
+
+	cmd.Flags().BoolP("follow", "f", false, "follow the timeline, showing new events as they appear")
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
 // NewAppExecCmd returns a new `epinio apps exec` command
 func NewAppExecCmd(client ApplicationsService) *cobra.Command {
 	cfg := AppExecConfig{}
@@ -207,6 +260,7 @@ type AppExportConfig struct {
 	imageTag     string
 	chartName    string
 	chartVersion string
+	sbom         bool
 }
 
 // NewAppExportCmd return a new `epinio apps export` command
@@ -245,6 +299,7 @@ func NewAppExportCmd(client ApplicationsService) *cobra.Command {
 				ChartName:    cfg.chartName,
 				ImageTag:     cfg.imageTag,
 				ChartVersion: cfg.chartVersion,
+				SBOM:         cfg.sbom,
 			})
 			// Note: errors.Wrap (nil, "...") == nil
 			return errors.Wrap(err, "error exporting app")
@@ -255,6 +310,7 @@ func NewAppExportCmd(client ApplicationsService) *cobra.Command {
 	cmd.Flags().StringVar(&cfg.imageTag, "image-tag", "", "User chosen tag for the image file")
 	cmd.Flags().StringVar(&cfg.chartName, "chart-name", "", "User chosen name for the chart file")
 	cmd.Flags().StringVar(&cfg.chartVersion, "chart-version", "", "User chosen version for the chart file")
+	cmd.Flags().BoolVar(&cfg.sbom, "sbom", false, "Include the build metadata and SBOM of the application")
 
 	cmd.Flags().StringVarP(&cfg.registry, "registry", "r", "", "The name of the registry to export to")
 	bindFlag(cmd, "registry")
@@ -263,6 +319,33 @@ func NewAppExportCmd(client ApplicationsService) *cobra.Command {
 	return cmd
 }
 
+// NewAppSupportBundleCmd returns a new `epinio apps support-bundle` command
+func NewAppSupportBundleCmd(client ApplicationsService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "support-bundle NAME [FILE]",
+		Short: "Download a support bundle for the named application",
+		Long: `Download a support bundle for the named application into the file, as a tar.gz archive.
+The default file is epinio-support-bundle-NAME.tar.gz in the current directory.
+The bundle contains the manifest, helm values, pod descriptions, events, recent staging logs and ingresses of the application, with the values of environment variables redacted.`,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			destination := fmt.Sprintf("epinio-support-bundle-%s.tar.gz", args[0])
+			if len(args) == 2 {
+				destination = args[1]
+			}
+
+			err := client.AppSupportBundle(args[0], destination)
+			// Note: errors.Wrap (nil, "...") == nil
+			return errors.Wrap(err, "error downloading app support bundle")
+		},
+	}
+
+	return cmd
+}
+
 type AppListConfig struct {
 	all bool
 }
@@ -294,8 +377,43 @@ func NewAppListCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.Comma
 }
 
 type AppLogsConfig struct {
-	follow  bool
-	staging bool
+	follow            bool
+	staging           bool
+	includeContainers []string
+	excludeContainers []string
+	grep              string
+	grepRegex         bool
+	level             string
+	jsonFields        []string
+	since             string
+}
+
+// logOptions returns the log options for the time range, container and content filters, if
+// any were specified.
+func (cfg AppLogsConfig) logOptions() (*client.LogOptions, error) {
+	if len(cfg.includeContainers) == 0 && len(cfg.excludeContainers) == 0 &&
+		cfg.grep == "" && cfg.level == "" && len(cfg.jsonFields) == 0 && cfg.since == "" {
+		return nil, nil
+	}
+
+	options := &client.LogOptions{
+		IncludeContainers: cfg.includeContainers,
+		ExcludeContainers: cfg.excludeContainers,
+		Grep:              cfg.grep,
+		GrepRegex:         cfg.grepRegex,
+		Level:             cfg.level,
+		Fields:            cfg.jsonFields,
+	}
+
+	if cfg.since != "" {
+		since, err := helpers.ParseDuration(cfg.since)
+		if err != nil || since < 0 {
+			return nil, fmt.Errorf("bad since '%s', expected a period like 3d or 90m", cfg.since)
+		}
+		options.Since = &since
+	}
+
+	return options, nil
 }
 
 // NewAppLogsCmd returns a new `epinio apps logs` command
@@ -318,7 +436,12 @@ func NewAppLogsCmd(client ApplicationsService) *cobra.Command {
 				stageID = stageIDHere
 			}
 
-			err := client.AppLogs(args[0], stageID, cfg.follow, nil)
+			options, err := cfg.logOptions()
+			if err != nil {
+				return err
+			}
+
+			err = client.AppLogs(args[0], stageID, cfg.follow, options)
 			// Note: errors.Wrap (nil, "...") == nil
 			return errors.Wrap(err, "error streaming application logs")
 		},
@@ -326,6 +449,15 @@ func NewAppLogsCmd(client ApplicationsService) *cobra.Command {
 
 	cmd.Flags().BoolVar(&cfg.follow, "follow", false, "follow the logs of the application")
 	cmd.Flags().BoolVar(&cfg.staging, "staging", false, "show the staging logs of the application")
+	cmd.Flags().StringSliceVar(&cfg.includeContainers, "include-containers", []string{}, "show only the logs of these containers, i.e. sidecars (names or regular expressions)")
+	cmd.Flags().StringSliceVar(&cfg.excludeContainers, "exclude-containers", []string{}, "do not show the logs of these containers (names or regular expressions)")
+	cmd.Flags().StringVar(&cfg.grep, "grep", "", "show only the log lines containing this text")
+	cmd.Flags().BoolVar(&cfg.grepRegex, "grep-regex", false, "use the --grep text as a regular expression")
+	cmd.Flags().StringVar(&cfg.level, "level", "", "show only the structured (JSON/logfmt) log lines of this level or above (e.g. warn)")
+	cmd.Flags().StringArrayVar(&cfg.jsonFields, "json-field", []string{}, "show only the structured log lines having the field with the value (key=value, nested keys joined by dots)")
+	cmd.Flags().StringVar(&cfg.since, "since", "", "show only the log lines of this period (e.g. 3d, 90m), including archived lines of pods which are gone")
+	bindFlagCompletionFunc(cmd, "level",
+		NewStaticFlagsCompletionFunc([]string{"debug", "info", "warn", "error", "fatal"}))
 
 	return cmd
 }
@@ -354,6 +486,37 @@ type AppForwardConfig struct {
 	instance string
 }
 
+// NewAppMetricsCmd returns a new `epinio app metrics` command
+func NewAppMetricsCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "metrics NAME",
+		Short:             "Show the CPU and memory usage history of the application",
+		Long:              "Show the CPU and memory usage history of the application as sparklines. Longer ranges are shown with coarser steps.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			span, err := cmd.Flags().GetString("range")
+			if err != nil {
+				return errors.Wrap(err, "failed to read option --range")
+			}
+
+			err = client.AppMetrics(args[0], span)
+			// Note: errors.Wrap (nil, "...") == nil
+			return errors.Wrap(err, "error showing app metrics")
+		},
+	}
+
+	cmd.Flags().String("range", "", "time range of the history, e.g. 6h or 7d (default: 24h)")
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
 // NewAppPortForwardCmd returns a new `epinio apps port-forward` command
 func NewAppPortForwardCmd(client ApplicationsService) *cobra.Command {
 	cfg := AppForwardConfig{}
@@ -385,6 +548,7 @@ func NewAppPortForwardCmd(client ApplicationsService) *cobra.Command {
 // NewAppPushCmd returns a new `epinio apps push` command
 func NewAppPushCmd(client ApplicationsService) *cobra.Command {
 	var envReplace bool
+	var clearCache bool
 
 	cmd := &cobra.Command{
 		Use:   "push [flags] [PATH_TO_APPLICATION_MANIFEST]",
@@ -425,6 +589,21 @@ func NewAppPushCmd(client ApplicationsService) *cobra.Command {
 				return err
 			}
 
+			m, err = manifest.UpdateGitPoll(m, cmd)
+			if err != nil {
+				return err
+			}
+
+			m, err = manifest.UpdateRegistryCredential(m, cmd)
+			if err != nil {
+				return err
+			}
+
+			m, err = manifest.UpdateImageWatch(m, cmd)
+			if err != nil {
+				return err
+			}
+
 			m, err = manifest.UpdateRoutes(m, cmd)
 			if err != nil {
 				return err
@@ -456,6 +635,8 @@ func NewAppPushCmd(client ApplicationsService) *cobra.Command {
 				m.Configuration.ReplaceEnv = &envReplace
 			}
 
+			m.Staging.ClearCache = clearCache
+
 			err = client.AppPush(cmd.Context(), m)
 			if err != nil {
 				return errors.Wrap(err, "error pushing app to server")
@@ -473,15 +654,22 @@ func NewAppPushCmd(client ApplicationsService) *cobra.Command {
 	cmd.Flags().StringP("name", "n", "", "Application name. (mandatory if no manifest is provided)")
 	cmd.Flags().StringP("path", "p", "", "Path to application sources.")
 	cmd.Flags().String("builder-image", "", "Paketo builder image to use for staging")
+	cmd.Flags().String("registry-credential", "", "Registry credential of the namespace used to pull the container image")
 
 	gitConfigOption(cmd, client)
+	gitPollIntervalOption(cmd)
+	imageWatchOption(cmd)
 	routeOption(cmd)
 	bindOption(cmd, client)
 	envOption(cmd)
 	instancesOption(cmd)
+	resourcesOption(cmd)
+	healthcheckOption(cmd)
+	dependsOnOption(cmd)
 	chartValueOptionX(cmd)
 	cmd.Flags().BoolVar(&envReplace, "env-replace", false, "Replace existing environment instead of merging")
 	bindFlag(cmd, "env-replace")
+	cmd.Flags().BoolVar(&clearCache, "clear-cache", false, "Clear the build cache before staging")
 
 	cmd.Flags().String("app-chart", "", "App chart to use for deployment")
 	bindFlag(cmd, "app-chart")
@@ -491,7 +679,8 @@ func NewAppPushCmd(client ApplicationsService) *cobra.Command {
 }
 
 type AppRestageConfig struct {
-	noRestart bool
+	noRestart  bool
+	clearCache bool
 }
 
 // NewAppRestageCmd returns a new `epinio app restage` command
@@ -507,7 +696,7 @@ func NewAppRestageCmd(client ApplicationsService) *cobra.Command {
 
 			restart := !cfg.noRestart
 
-			err := client.AppRestage(args[0], restart)
+			err := client.AppRestage(args[0], restart, cfg.clearCache)
 			// Note: errors.Wrap (nil, "...") == nil
 			return errors.Wrap(err, "error restaging app")
 		},
@@ -515,6 +704,8 @@ func NewAppRestageCmd(client ApplicationsService) *cobra.Command {
 
 	cmd.Flags().BoolVar(&cfg.noRestart, "no-restart", false,
 		"Do not restart application after restaging")
+	cmd.Flags().BoolVar(&cfg.clearCache, "clear-cache", false,
+		"Clear the build cache before restaging")
 
 	return cmd
 }
@@ -538,6 +729,118 @@ func NewAppRestartCmd(client ApplicationsService) *cobra.Command {
 	return cmd
 }
 
+// NewAppScanCmd returns a new `epinio app scan` command
+func NewAppScanCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "scan NAME",
+		Short:             "Show the image scan report of the last staging of the application",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppScan(args[0])
+			// Note: errors.Wrap (nil, "...") == nil
+			return errors.Wrap(err, "error showing image scan report")
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// NewAppPromoteCmd returns a new `epinio app promote` command
+func NewAppPromoteCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "promote NAME --to NAMESPACE",
+		Short: "Deploy the staged image of the application into another namespace",
+		Long: `Deploy the staged image of the application into another namespace, without staging it again.
+The configuration of the application is carried over, except for routes and instances of an existing target application.
+The configurations and services bound to the application have to exist in the target namespace.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			request, err := appPromoteRequest(cmd)
+			if err != nil {
+				return err
+			}
+
+			from, err := cmd.Flags().GetString("from")
+			if err != nil {
+				return errors.Wrap(err, "failed to read option --from")
+			}
+
+			err = client.AppPromote(args[0], from, request)
+			// Note: errors.Wrap (nil, "...") == nil
+			return errors.Wrap(err, "error promoting app")
+		},
+	}
+
+	cmd.Flags().String("from", "", "namespace of the application (default: current namespace)")
+	cmd.Flags().String("to", "", "namespace to promote the application into")
+	cmd.Flags().StringSliceP("route", "r", []string{}, "route of the promoted application, overriding the existing, resp. default route. Can be set multiple times")
+	cmd.Flags().Int32P("instances", "i", 0, "number of instances of the promoted application, overriding the existing, resp. source instances")
+	envOption(cmd)
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// appPromoteRequest assembles the promotion request from the options of the command.
+func appPromoteRequest(cmd *cobra.Command) (models.AppPromoteRequest, error) {
+	request := models.AppPromoteRequest{}
+
+	target, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return request, errors.Wrap(err, "failed to read option --to")
+	}
+	if target == "" {
+		return request, errors.New("target namespace missing, use --to")
+	}
+	request.Target = target
+
+	routes, err := cmd.Flags().GetStringSlice("route")
+	if err != nil {
+		return request, errors.Wrap(err, "failed to read option --route")
+	}
+	if len(routes) > 0 {
+		request.Routes = routes
+	}
+
+	if cmd.Flags().Changed("instances") {
+		instances, err := cmd.Flags().GetInt32("instances")
+		if err != nil {
+			return request, errors.Wrap(err, "failed to read option --instances")
+		}
+		request.Instances = &instances
+	}
+
+	assignments, err := cmd.Flags().GetStringSlice("env")
+	if err != nil {
+		return request, errors.Wrap(err, "failed to read option --env")
+	}
+	for _, assignment := range assignments {
+		pieces := strings.SplitN(assignment, "=", 2)
+		if len(pieces) < 2 {
+			return request, errors.New("Bad --env assignment `" + assignment + "`, expected `name=value` as value")
+		}
+		if request.Environment == nil {
+			request.Environment = models.EnvVariableMap{}
+		}
+		request.Environment[pieces[0]] = pieces[1]
+	}
+
+	return request, nil
+}
+
 // NewAppShowCmd returns a new `epinio apps show` command
 func NewAppShowCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.Command {
 	cmd := &cobra.Command{
@@ -605,6 +908,11 @@ func NewAppUpdateCmd(client ApplicationsService) *cobra.Command {
 				Routes:         manifestConfig.Routes,
 				AppChart:       manifestConfig.AppChart,
 				Settings:       manifestConfig.Settings,
+				Resources:      manifestConfig.Resources,
+				Healthcheck:    manifestConfig.Healthcheck,
+				DependsOn:      manifestConfig.DependsOn,
+				Sidecars:       manifestConfig.Sidecars,
+				InitContainers: manifestConfig.InitContainers,
 			}
 			
 			// Set restart flag based on --no-restart option
@@ -622,6 +930,9 @@ func NewAppUpdateCmd(client ApplicationsService) *cobra.Command {
 	bindOption(cmd, client)
 	envOption(cmd)
 	instancesOption(cmd)
+	resourcesOption(cmd)
+	healthcheckOption(cmd)
+	dependsOnOption(cmd)
 	chartValueOptionX(cmd)
 	cmd.Flags().BoolVar(&envReplace, "env-replace", false, "Replace existing environment instead of merging")
 	bindFlag(cmd, "env-replace")
@@ -164,6 +164,209 @@ var _ = Describe("Command 'epinio app'", func() {
 		})
 	})
 
+	Context("app promote", func() {
+
+		When("called without target namespace", func() {
+			It("fails", func() {
+				args = append(args, "myapp")
+
+				appCmd := cmd.NewAppPromoteCmd(mockAppService, cmd.NewRootConfig())
+				_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("target namespace missing, use --to"))
+				Expect(mockAppService.AppPromoteCallCount()).To(Equal(0))
+			})
+		})
+
+		When("called with overrides", func() {
+			It("passes them through", func() {
+				args = append(args, "myapp",
+					"--from", "dev",
+					"--to", "prod",
+					"--route", "myapp.example.com",
+					"--instances", "3",
+					"--env", "MODE=production",
+				)
+
+				mockAppService.AppPromoteStub = func(name, from string, request models.AppPromoteRequest) error {
+					Expect(name).To(Equal("myapp"))
+					Expect(from).To(Equal("dev"))
+					Expect(request.Target).To(Equal("prod"))
+					Expect(request.Routes).To(Equal([]string{"myapp.example.com"}))
+					Expect(request.Instances).ToNot(BeNil())
+					Expect(*request.Instances).To(Equal(int32(3)))
+					Expect(request.Environment).To(Equal(models.EnvVariableMap{"MODE": "production"}))
+					return nil
+				}
+
+				appCmd := cmd.NewAppPromoteCmd(mockAppService, cmd.NewRootConfig())
+				_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(mockAppService.AppPromoteCallCount()).To(Equal(1))
+			})
+		})
+
+		When("called without overrides", func() {
+			It("leaves them to the server", func() {
+				args = append(args, "myapp", "--to", "prod")
+
+				mockAppService.AppPromoteStub = func(name, from string, request models.AppPromoteRequest) error {
+					Expect(from).To(BeEmpty())
+					Expect(request.Routes).To(BeNil())
+					Expect(request.Instances).To(BeNil())
+					Expect(request.Environment).To(BeNil())
+					return errors.New("something bad happened")
+				}
+
+				appCmd := cmd.NewAppPromoteCmd(mockAppService, cmd.NewRootConfig())
+				_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("error promoting app: something bad happened"))
+			})
+		})
+	})
+
+	Context("app events", func() {
+
+		It("shows the timeline", func() {
+			args = append(args, "myapp")
+
+			appCmd := cmd.NewAppEventsCmd(mockAppService, cmd.NewRootConfig())
+			_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(mockAppService.AppEventsCallCount()).To(Equal(1))
+			_, name, follow := mockAppService.AppEventsArgsForCall(0)
+			Expect(name).To(Equal("myapp"))
+			Expect(follow).To(BeFalse())
+		})
+
+		It("follows the timeline", func() {
+			args = append(args, "myapp", "--follow")
+			mockAppService.AppEventsReturns(errors.New("something bad happened"))
+
+			appCmd := cmd.NewAppEventsCmd(mockAppService, cmd.NewRootConfig())
+			_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+			Expect(runErr).To(HaveOccurred())
+			Expect(runErr.Error()).To(Equal("error showing app events: something bad happened"))
+
+			_, _, follow := mockAppService.AppEventsArgsForCall(0)
+			Expect(follow).To(BeTrue())
+		})
+	})
+
+	Context("app support-bundle", func() {
+
+		It("downloads into the default file", func() {
+			args = append(args, "myapp")
+
+			appCmd := cmd.NewAppSupportBundleCmd(mockAppService)
+			_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(mockAppService.AppSupportBundleCallCount()).To(Equal(1))
+			name, destination := mockAppService.AppSupportBundleArgsForCall(0)
+			Expect(name).To(Equal("myapp"))
+			Expect(destination).To(Equal("epinio-support-bundle-myapp.tar.gz"))
+		})
+
+		It("downloads into the given file", func() {
+			args = append(args, "myapp", "/tmp/bundle.tar.gz")
+			mockAppService.AppSupportBundleReturns(errors.New("something bad happened"))
+
+			appCmd := cmd.NewAppSupportBundleCmd(mockAppService)
+			_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+			Expect(runErr).To(HaveOccurred())
+			Expect(runErr.Error()).To(Equal("error downloading app support bundle: something bad happened"))
+
+			_, destination := mockAppService.AppSupportBundleArgsForCall(0)
+			Expect(destination).To(Equal("/tmp/bundle.tar.gz"))
+		})
+	})
+
+	Context("app drain", func() {
+
+		It("adds a drain", func() {
+			args = append(args, "myapp", "audit", "syslog+tls://logs.example.com:6514")
+
+			appCmd := cmd.NewAppLogDrainAddCmd(mockAppService)
+			_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(mockAppService.AppLogDrainAddCallCount()).To(Equal(1))
+			name, drain, url := mockAppService.AppLogDrainAddArgsForCall(0)
+			Expect(name).To(Equal("myapp"))
+			Expect(drain).To(Equal("audit"))
+			Expect(url).To(Equal("syslog+tls://logs.example.com:6514"))
+		})
+
+		It("fails to add a drain without url", func() {
+			args = append(args, "myapp", "audit")
+
+			appCmd := cmd.NewAppLogDrainAddCmd(mockAppService)
+			_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+			Expect(runErr).To(HaveOccurred())
+			Expect(mockAppService.AppLogDrainAddCallCount()).To(Equal(0))
+		})
+
+		It("removes a drain", func() {
+			args = append(args, "myapp", "audit")
+			mockAppService.AppLogDrainRemoveReturns(errors.New("something bad happened"))
+
+			appCmd := cmd.NewAppLogDrainRemoveCmd(mockAppService)
+			_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+			Expect(runErr).To(HaveOccurred())
+			Expect(runErr.Error()).To(Equal("error removing log drain: something bad happened"))
+
+			name, drain := mockAppService.AppLogDrainRemoveArgsForCall(0)
+			Expect(name).To(Equal("myapp"))
+			Expect(drain).To(Equal("audit"))
+		})
+	})
+
+	Context("app alert", func() {
+
+		It("adds an alert", func() {
+			args = append(args, "myapp", "oncall", "unready", "--threshold", "10",
+				"--notify", "https://hooks.example.com/alert", "--notify", "mailto:ops@example.com")
+
+			appCmd := cmd.NewAppAlertAddCmd(mockAppService)
+			_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(mockAppService.AppAlertAddCallCount()).To(Equal(1))
+			name, alert, kind, threshold, notify := mockAppService.AppAlertAddArgsForCall(0)
+			Expect(name).To(Equal("myapp"))
+			Expect(alert).To(Equal("oncall"))
+			Expect(kind).To(Equal("unready"))
+			Expect(threshold).To(Equal(int64(10)))
+			Expect(notify).To(Equal([]string{"https://hooks.example.com/alert", "mailto:ops@example.com"}))
+		})
+
+		It("fails to add an alert without kind", func() {
+			args = append(args, "myapp", "oncall")
+
+			appCmd := cmd.NewAppAlertAddCmd(mockAppService)
+			_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+			Expect(runErr).To(HaveOccurred())
+			Expect(mockAppService.AppAlertAddCallCount()).To(Equal(0))
+		})
+
+		It("removes an alert", func() {
+			args = append(args, "myapp", "oncall")
+			mockAppService.AppAlertRemoveReturns(errors.New("something bad happened"))
+
+			appCmd := cmd.NewAppAlertRemoveCmd(mockAppService)
+			_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+			Expect(runErr).To(HaveOccurred())
+			Expect(runErr.Error()).To(Equal("error removing alert: something bad happened"))
+
+			name, alert := mockAppService.AppAlertRemoveArgsForCall(0)
+			Expect(name).To(Equal("myapp"))
+			Expect(alert).To(Equal("oncall"))
+		})
+	})
+
 	Context("app watch", func() {
 
 		When("called with no args", func() {
@@ -18,6 +18,7 @@ import (
 
 	"github.com/epinio/epinio/internal/api/v1/application"
 	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/spf13/cobra"
 )
 
@@ -458,6 +459,26 @@ func gitConfigOption(cmd *cobra.Command, client ApplicationsService) {
 	bindFlagCompletionFunc(cmd, "git-config", NewGitconfigMatcherValueFunc(client))
 }
 
+// gitPollIntervalOption initializes the --git-poll-interval option for the provided command.
+// The value asks the server to check the Git origin periodically for new commits, as an
+// alternative to the push webhook.
+func gitPollIntervalOption(cmd *cobra.Command) {
+	cmd.Flags().String("git-poll-interval", "", "Check the Git origin for new commits on the tracked branch at this interval (e.g. 5m). Empty disables polling")
+	bindFlag(cmd, "git-poll-interval")
+}
+
+// imageWatchOption initializes the --image-watch-interval and --image-watch-mode options
+// for the provided command. They ask the server to check the tag of the container image
+// periodically for a new digest, and to redeploy, or only notify, when it moved.
+func imageWatchOption(cmd *cobra.Command) {
+	cmd.Flags().String("image-watch-interval", "", "Check the tag of the container image for a new digest at this interval (e.g. 10m). Empty disables the watch")
+	bindFlag(cmd, "image-watch-interval")
+	cmd.Flags().String("image-watch-mode", "", "Reaction to a new digest of the container image tag, one of redeploy or notify (default notify)")
+	bindFlag(cmd, "image-watch-mode")
+	bindFlagCompletionFunc(cmd, "image-watch-mode",
+		NewStaticFlagsCompletionFunc([]string{models.ImageWatchRedeploy, models.ImageWatchNotify}))
+}
+
 // instancesOption initializes the --instances/-i option for the provided command
 func instancesOption(cmd *cobra.Command) {
 	cmd.Flags().Int32P("instances", "i", application.DefaultInstances,
@@ -508,6 +529,33 @@ func bindOption(cmd *cobra.Command, client ApplicationsService) {
 	fmt.Sprintf("Error occurred regisering flag completion function %s", regFlagCompError)
 }
 
+// dependsOnOption initializes the --depends-on option for the provided command
+func dependsOnOption(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("depends-on", []string{}, "App (app:NAME) or service (service:NAME) which has to be ready before the application is deployed. Can be set multiple times.")
+	cmd.Flags().Bool("clear-depends-on", false, "clear dependencies / no dependencies")
+}
+
+// healthcheckOption initializes the --healthcheck-* options for the provided command
+func healthcheckOption(cmd *cobra.Command) {
+	cmd.Flags().String("healthcheck-type", "", "Health check type of the application (http, tcp, process)")
+	cmd.Flags().String("healthcheck-path", "", "Path checked by a http health check (default: /)")
+	cmd.Flags().Int32("healthcheck-port", 0, "Port checked by a http or tcp health check (default: 8080)")
+	cmd.Flags().Int32("healthcheck-timeout", 0, "Seconds after which a health check times out")
+	cmd.Flags().Int32("healthcheck-period", 0, "Seconds between health checks")
+	cmd.Flags().Int32("healthcheck-success-threshold", 0, "Consecutive successful checks for an instance to become ready")
+	cmd.Flags().Int32("healthcheck-failure-threshold", 0, "Consecutive failed checks for an instance to become unready, or restarted")
+	cmd.Flags().Int32("healthcheck-startup-grace", 0, "Seconds after start of an instance before liveness is checked")
+}
+
+// resourcesOption initializes the --cpu-request, --cpu-limit, --memory-request, and
+// --memory-limit options for the provided command
+func resourcesOption(cmd *cobra.Command) {
+	cmd.Flags().String("cpu-request", "", "CPU requested by each instance of the application (e.g. 250m)")
+	cmd.Flags().String("cpu-limit", "", "CPU limit of each instance of the application (e.g. 1)")
+	cmd.Flags().String("memory-request", "", "Memory requested by each instance of the application (e.g. 256Mi)")
+	cmd.Flags().String("memory-limit", "", "Memory limit of each instance of the application (e.g. 1Gi)")
+}
+
 // envOption initializes the --env/-e option for the provided command
 func envOption(cmd *cobra.Command) {
 	cmd.Flags().StringSliceP("env", "e", []string{}, "environment variables to be used")
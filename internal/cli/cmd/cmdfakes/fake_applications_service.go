@@ -23,6 +23,44 @@ import (
 )
 
 type FakeApplicationsService struct {
+	AppAlertAddStub        func(string, string, string, int64, []string) error
+	appAlertAddMutex       sync.RWMutex
+	appAlertAddArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int64
+		arg5 []string
+	}
+	appAlertAddReturns struct {
+		result1 error
+	}
+	appAlertAddReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AppAlertListStub        func(string) error
+	appAlertListMutex       sync.RWMutex
+	appAlertListArgsForCall []struct {
+		arg1 string
+	}
+	appAlertListReturns struct {
+		result1 error
+	}
+	appAlertListReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AppAlertRemoveStub        func(string, string) error
+	appAlertRemoveMutex       sync.RWMutex
+	appAlertRemoveArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appAlertRemoveReturns struct {
+		result1 error
+	}
+	appAlertRemoveReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppCreateStub        func(string, models.ApplicationUpdateRequest) error
 	appCreateMutex       sync.RWMutex
 	appCreateArgsForCall []struct {
@@ -49,6 +87,19 @@ type FakeApplicationsService struct {
 	appDeleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppEventsStub        func(context.Context, string, bool) error
+	appEventsMutex       sync.RWMutex
+	appEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}
+	appEventsReturns struct {
+		result1 error
+	}
+	appEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppExecStub        func(context.Context, string, string) error
 	appExecMutex       sync.RWMutex
 	appExecArgsForCall []struct {
@@ -75,6 +126,75 @@ type FakeApplicationsService struct {
 	appExportReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppGitTriggerDisableStub        func(string) error
+	appGitTriggerDisableMutex       sync.RWMutex
+	appGitTriggerDisableArgsForCall []struct {
+		arg1 string
+	}
+	appGitTriggerDisableReturns struct {
+		result1 error
+	}
+	appGitTriggerDisableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AppGitTriggerEnableStub        func(string) error
+	appGitTriggerEnableMutex       sync.RWMutex
+	appGitTriggerEnableArgsForCall []struct {
+		arg1 string
+	}
+	appGitTriggerEnableReturns struct {
+		result1 error
+	}
+	appGitTriggerEnableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AppGitTriggerShowStub        func(string) error
+	appGitTriggerShowMutex       sync.RWMutex
+	appGitTriggerShowArgsForCall []struct {
+		arg1 string
+	}
+	appGitTriggerShowReturns struct {
+		result1 error
+	}
+	appGitTriggerShowReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AppLogDrainAddStub        func(string, string, string) error
+	appLogDrainAddMutex       sync.RWMutex
+	appLogDrainAddArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	appLogDrainAddReturns struct {
+		result1 error
+	}
+	appLogDrainAddReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AppLogDrainListStub        func(string) error
+	appLogDrainListMutex       sync.RWMutex
+	appLogDrainListArgsForCall []struct {
+		arg1 string
+	}
+	appLogDrainListReturns struct {
+		result1 error
+	}
+	appLogDrainListReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AppLogDrainRemoveStub        func(string, string) error
+	appLogDrainRemoveMutex       sync.RWMutex
+	appLogDrainRemoveArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appLogDrainRemoveReturns struct {
+		result1 error
+	}
+	appLogDrainRemoveReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppLogsStub        func(string, string, bool, *client.LogOptions) error
 	appLogsMutex       sync.RWMutex
 	appLogsArgsForCall []struct {
@@ -101,6 +221,18 @@ type FakeApplicationsService struct {
 	appManifestReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppMetricsStub        func(string, string) error
+	appMetricsMutex       sync.RWMutex
+	appMetricsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appMetricsReturns struct {
+		result1 error
+	}
+	appMetricsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppPortForwardStub        func(context.Context, string, string, []string, []string) error
 	appPortForwardMutex       sync.RWMutex
 	appPortForwardArgsForCall []struct {
@@ -116,6 +248,19 @@ type FakeApplicationsService struct {
 	appPortForwardReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppPromoteStub        func(string, string, models.AppPromoteRequest) error
+	appPromoteMutex       sync.RWMutex
+	appPromoteArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 models.AppPromoteRequest
+	}
+	appPromoteReturns struct {
+		result1 error
+	}
+	appPromoteReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppPushStub        func(context.Context, models.ApplicationManifest) error
 	appPushMutex       sync.RWMutex
 	appPushArgsForCall []struct {
@@ -128,11 +273,12 @@ type FakeApplicationsService struct {
 	appPushReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AppRestageStub        func(string, bool) error
+	AppRestageStub        func(string, bool, bool) error
 	appRestageMutex       sync.RWMutex
 	appRestageArgsForCall []struct {
 		arg1 string
 		arg2 bool
+		arg3 bool
 	}
 	appRestageReturns struct {
 		result1 error
@@ -151,6 +297,17 @@ type FakeApplicationsService struct {
 	appRestartReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppScanStub        func(string) error
+	appScanMutex       sync.RWMutex
+	appScanArgsForCall []struct {
+		arg1 string
+	}
+	appScanReturns struct {
+		result1 error
+	}
+	appScanReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppShowStub        func(string) error
 	appShowMutex       sync.RWMutex
 	appShowArgsForCall []struct {
@@ -175,6 +332,18 @@ type FakeApplicationsService struct {
 		result1 string
 		result2 error
 	}
+	AppSupportBundleStub        func(string, string) error
+	appSupportBundleMutex       sync.RWMutex
+	appSupportBundleArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appSupportBundleReturns struct {
+		result1 error
+	}
+	appSupportBundleReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppUpdateStub        func(string, models.ApplicationUpdateRequest) error
 	appUpdateMutex       sync.RWMutex
 	appUpdateArgsForCall []struct {
@@ -429,6 +598,199 @@ type FakeApplicationsService struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeApplicationsService) AppAlertAdd(arg1 string, arg2 string, arg3 string, arg4 int64, arg5 []string) error {
+	var arg5Copy []string
+	if arg5 != nil {
+		arg5Copy = make([]string, len(arg5))
+		copy(arg5Copy, arg5)
+	}
+	fake.appAlertAddMutex.Lock()
+	ret, specificReturn := fake.appAlertAddReturnsOnCall[len(fake.appAlertAddArgsForCall)]
+	fake.appAlertAddArgsForCall = append(fake.appAlertAddArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int64
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5Copy})
+	stub := fake.AppAlertAddStub
+	fakeReturns := fake.appAlertAddReturns
+	fake.recordInvocation("AppAlertAdd", []interface{}{arg1, arg2, arg3, arg4, arg5Copy})
+	fake.appAlertAddMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppAlertAddCallCount() int {
+	fake.appAlertAddMutex.RLock()
+	defer fake.appAlertAddMutex.RUnlock()
+	return len(fake.appAlertAddArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppAlertAddCalls(stub func(string, string, string, int64, []string) error) {
+	fake.appAlertAddMutex.Lock()
+	defer fake.appAlertAddMutex.Unlock()
+	fake.AppAlertAddStub = stub
+}
+
+func (fake *FakeApplicationsService) AppAlertAddArgsForCall(i int) (string, string, string, int64, []string) {
+	fake.appAlertAddMutex.RLock()
+	defer fake.appAlertAddMutex.RUnlock()
+	argsForCall := fake.appAlertAddArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeApplicationsService) AppAlertAddReturns(result1 error) {
+	fake.appAlertAddMutex.Lock()
+	defer fake.appAlertAddMutex.Unlock()
+	fake.AppAlertAddStub = nil
+	fake.appAlertAddReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppAlertAddReturnsOnCall(i int, result1 error) {
+	fake.appAlertAddMutex.Lock()
+	defer fake.appAlertAddMutex.Unlock()
+	fake.AppAlertAddStub = nil
+	if fake.appAlertAddReturnsOnCall == nil {
+		fake.appAlertAddReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appAlertAddReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppAlertList(arg1 string) error {
+	fake.appAlertListMutex.Lock()
+	ret, specificReturn := fake.appAlertListReturnsOnCall[len(fake.appAlertListArgsForCall)]
+	fake.appAlertListArgsForCall = append(fake.appAlertListArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AppAlertListStub
+	fakeReturns := fake.appAlertListReturns
+	fake.recordInvocation("AppAlertList", []interface{}{arg1})
+	fake.appAlertListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppAlertListCallCount() int {
+	fake.appAlertListMutex.RLock()
+	defer fake.appAlertListMutex.RUnlock()
+	return len(fake.appAlertListArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppAlertListCalls(stub func(string) error) {
+	fake.appAlertListMutex.Lock()
+	defer fake.appAlertListMutex.Unlock()
+	fake.AppAlertListStub = stub
+}
+
+func (fake *FakeApplicationsService) AppAlertListArgsForCall(i int) string {
+	fake.appAlertListMutex.RLock()
+	defer fake.appAlertListMutex.RUnlock()
+	argsForCall := fake.appAlertListArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApplicationsService) AppAlertListReturns(result1 error) {
+	fake.appAlertListMutex.Lock()
+	defer fake.appAlertListMutex.Unlock()
+	fake.AppAlertListStub = nil
+	fake.appAlertListReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppAlertListReturnsOnCall(i int, result1 error) {
+	fake.appAlertListMutex.Lock()
+	defer fake.appAlertListMutex.Unlock()
+	fake.AppAlertListStub = nil
+	if fake.appAlertListReturnsOnCall == nil {
+		fake.appAlertListReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appAlertListReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppAlertRemove(arg1 string, arg2 string) error {
+	fake.appAlertRemoveMutex.Lock()
+	ret, specificReturn := fake.appAlertRemoveReturnsOnCall[len(fake.appAlertRemoveArgsForCall)]
+	fake.appAlertRemoveArgsForCall = append(fake.appAlertRemoveArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppAlertRemoveStub
+	fakeReturns := fake.appAlertRemoveReturns
+	fake.recordInvocation("AppAlertRemove", []interface{}{arg1, arg2})
+	fake.appAlertRemoveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppAlertRemoveCallCount() int {
+	fake.appAlertRemoveMutex.RLock()
+	defer fake.appAlertRemoveMutex.RUnlock()
+	return len(fake.appAlertRemoveArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppAlertRemoveCalls(stub func(string, string) error) {
+	fake.appAlertRemoveMutex.Lock()
+	defer fake.appAlertRemoveMutex.Unlock()
+	fake.AppAlertRemoveStub = stub
+}
+
+func (fake *FakeApplicationsService) AppAlertRemoveArgsForCall(i int) (string, string) {
+	fake.appAlertRemoveMutex.RLock()
+	defer fake.appAlertRemoveMutex.RUnlock()
+	argsForCall := fake.appAlertRemoveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApplicationsService) AppAlertRemoveReturns(result1 error) {
+	fake.appAlertRemoveMutex.Lock()
+	defer fake.appAlertRemoveMutex.Unlock()
+	fake.AppAlertRemoveStub = nil
+	fake.appAlertRemoveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppAlertRemoveReturnsOnCall(i int, result1 error) {
+	fake.appAlertRemoveMutex.Lock()
+	defer fake.appAlertRemoveMutex.Unlock()
+	fake.AppAlertRemoveStub = nil
+	if fake.appAlertRemoveReturnsOnCall == nil {
+		fake.appAlertRemoveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appAlertRemoveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeApplicationsService) AppCreate(arg1 string, arg2 models.ApplicationUpdateRequest) error {
 	fake.appCreateMutex.Lock()
 	ret, specificReturn := fake.appCreateReturnsOnCall[len(fake.appCreateArgsForCall)]
@@ -560,6 +922,69 @@ func (fake *FakeApplicationsService) AppDeleteReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
+func (fake *FakeApplicationsService) AppEvents(arg1 context.Context, arg2 string, arg3 bool) error {
+	fake.appEventsMutex.Lock()
+	ret, specificReturn := fake.appEventsReturnsOnCall[len(fake.appEventsArgsForCall)]
+	fake.appEventsArgsForCall = append(fake.appEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.AppEventsStub
+	fakeReturns := fake.appEventsReturns
+	fake.recordInvocation("AppEvents", []interface{}{arg1, arg2, arg3})
+	fake.appEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppEventsCallCount() int {
+	fake.appEventsMutex.RLock()
+	defer fake.appEventsMutex.RUnlock()
+	return len(fake.appEventsArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppEventsCalls(stub func(context.Context, string, bool) error) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = stub
+}
+
+func (fake *FakeApplicationsService) AppEventsArgsForCall(i int) (context.Context, string, bool) {
+	fake.appEventsMutex.RLock()
+	defer fake.appEventsMutex.RUnlock()
+	argsForCall := fake.appEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeApplicationsService) AppEventsReturns(result1 error) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = nil
+	fake.appEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppEventsReturnsOnCall(i int, result1 error) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = nil
+	if fake.appEventsReturnsOnCall == nil {
+		fake.appEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeApplicationsService) AppExec(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.appExecMutex.Lock()
 	ret, specificReturn := fake.appExecReturnsOnCall[len(fake.appExecArgsForCall)]
@@ -686,21 +1111,18 @@ func (fake *FakeApplicationsService) AppExportReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
-func (fake *FakeApplicationsService) AppLogs(arg1 string, arg2 string, arg3 bool, arg4 *client.LogOptions) error {
-	fake.appLogsMutex.Lock()
-	ret, specificReturn := fake.appLogsReturnsOnCall[len(fake.appLogsArgsForCall)]
-	fake.appLogsArgsForCall = append(fake.appLogsArgsForCall, struct {
+func (fake *FakeApplicationsService) AppGitTriggerDisable(arg1 string) error {
+	fake.appGitTriggerDisableMutex.Lock()
+	ret, specificReturn := fake.appGitTriggerDisableReturnsOnCall[len(fake.appGitTriggerDisableArgsForCall)]
+	fake.appGitTriggerDisableArgsForCall = append(fake.appGitTriggerDisableArgsForCall, struct {
 		arg1 string
-		arg2 string
-		arg3 bool
-		arg4 *client.LogOptions
-	}{arg1, arg2, arg3, arg4})
-	stub := fake.AppLogsStub
-	fakeReturns := fake.appLogsReturns
-	fake.recordInvocation("AppLogs", []interface{}{arg1, arg2, arg3, arg4})
-	fake.appLogsMutex.Unlock()
+	}{arg1})
+	stub := fake.AppGitTriggerDisableStub
+	fakeReturns := fake.appGitTriggerDisableReturns
+	fake.recordInvocation("AppGitTriggerDisable", []interface{}{arg1})
+	fake.appGitTriggerDisableMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -708,29 +1130,401 @@ func (fake *FakeApplicationsService) AppLogs(arg1 string, arg2 string, arg3 bool
 	return fakeReturns.result1
 }
 
-func (fake *FakeApplicationsService) AppLogsCallCount() int {
-	fake.appLogsMutex.RLock()
-	defer fake.appLogsMutex.RUnlock()
-	return len(fake.appLogsArgsForCall)
+func (fake *FakeApplicationsService) AppGitTriggerDisableCallCount() int {
+	fake.appGitTriggerDisableMutex.RLock()
+	defer fake.appGitTriggerDisableMutex.RUnlock()
+	return len(fake.appGitTriggerDisableArgsForCall)
 }
 
-func (fake *FakeApplicationsService) AppLogsCalls(stub func(string, string, bool, *client.LogOptions) error) {
-	fake.appLogsMutex.Lock()
-	defer fake.appLogsMutex.Unlock()
-	fake.AppLogsStub = stub
+func (fake *FakeApplicationsService) AppGitTriggerDisableCalls(stub func(string) error) {
+	fake.appGitTriggerDisableMutex.Lock()
+	defer fake.appGitTriggerDisableMutex.Unlock()
+	fake.AppGitTriggerDisableStub = stub
 }
 
-func (fake *FakeApplicationsService) AppLogsArgsForCall(i int) (string, string, bool, *client.LogOptions) {
-	fake.appLogsMutex.RLock()
-	defer fake.appLogsMutex.RUnlock()
-	argsForCall := fake.appLogsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+func (fake *FakeApplicationsService) AppGitTriggerDisableArgsForCall(i int) string {
+	fake.appGitTriggerDisableMutex.RLock()
+	defer fake.appGitTriggerDisableMutex.RUnlock()
+	argsForCall := fake.appGitTriggerDisableArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakeApplicationsService) AppLogsReturns(result1 error) {
-	fake.appLogsMutex.Lock()
-	defer fake.appLogsMutex.Unlock()
-	fake.AppLogsStub = nil
+func (fake *FakeApplicationsService) AppGitTriggerDisableReturns(result1 error) {
+	fake.appGitTriggerDisableMutex.Lock()
+	defer fake.appGitTriggerDisableMutex.Unlock()
+	fake.AppGitTriggerDisableStub = nil
+	fake.appGitTriggerDisableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerDisableReturnsOnCall(i int, result1 error) {
+	fake.appGitTriggerDisableMutex.Lock()
+	defer fake.appGitTriggerDisableMutex.Unlock()
+	fake.AppGitTriggerDisableStub = nil
+	if fake.appGitTriggerDisableReturnsOnCall == nil {
+		fake.appGitTriggerDisableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appGitTriggerDisableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerEnable(arg1 string) error {
+	fake.appGitTriggerEnableMutex.Lock()
+	ret, specificReturn := fake.appGitTriggerEnableReturnsOnCall[len(fake.appGitTriggerEnableArgsForCall)]
+	fake.appGitTriggerEnableArgsForCall = append(fake.appGitTriggerEnableArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AppGitTriggerEnableStub
+	fakeReturns := fake.appGitTriggerEnableReturns
+	fake.recordInvocation("AppGitTriggerEnable", []interface{}{arg1})
+	fake.appGitTriggerEnableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerEnableCallCount() int {
+	fake.appGitTriggerEnableMutex.RLock()
+	defer fake.appGitTriggerEnableMutex.RUnlock()
+	return len(fake.appGitTriggerEnableArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerEnableCalls(stub func(string) error) {
+	fake.appGitTriggerEnableMutex.Lock()
+	defer fake.appGitTriggerEnableMutex.Unlock()
+	fake.AppGitTriggerEnableStub = stub
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerEnableArgsForCall(i int) string {
+	fake.appGitTriggerEnableMutex.RLock()
+	defer fake.appGitTriggerEnableMutex.RUnlock()
+	argsForCall := fake.appGitTriggerEnableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerEnableReturns(result1 error) {
+	fake.appGitTriggerEnableMutex.Lock()
+	defer fake.appGitTriggerEnableMutex.Unlock()
+	fake.AppGitTriggerEnableStub = nil
+	fake.appGitTriggerEnableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerEnableReturnsOnCall(i int, result1 error) {
+	fake.appGitTriggerEnableMutex.Lock()
+	defer fake.appGitTriggerEnableMutex.Unlock()
+	fake.AppGitTriggerEnableStub = nil
+	if fake.appGitTriggerEnableReturnsOnCall == nil {
+		fake.appGitTriggerEnableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appGitTriggerEnableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerShow(arg1 string) error {
+	fake.appGitTriggerShowMutex.Lock()
+	ret, specificReturn := fake.appGitTriggerShowReturnsOnCall[len(fake.appGitTriggerShowArgsForCall)]
+	fake.appGitTriggerShowArgsForCall = append(fake.appGitTriggerShowArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AppGitTriggerShowStub
+	fakeReturns := fake.appGitTriggerShowReturns
+	fake.recordInvocation("AppGitTriggerShow", []interface{}{arg1})
+	fake.appGitTriggerShowMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerShowCallCount() int {
+	fake.appGitTriggerShowMutex.RLock()
+	defer fake.appGitTriggerShowMutex.RUnlock()
+	return len(fake.appGitTriggerShowArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerShowCalls(stub func(string) error) {
+	fake.appGitTriggerShowMutex.Lock()
+	defer fake.appGitTriggerShowMutex.Unlock()
+	fake.AppGitTriggerShowStub = stub
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerShowArgsForCall(i int) string {
+	fake.appGitTriggerShowMutex.RLock()
+	defer fake.appGitTriggerShowMutex.RUnlock()
+	argsForCall := fake.appGitTriggerShowArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerShowReturns(result1 error) {
+	fake.appGitTriggerShowMutex.Lock()
+	defer fake.appGitTriggerShowMutex.Unlock()
+	fake.AppGitTriggerShowStub = nil
+	fake.appGitTriggerShowReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppGitTriggerShowReturnsOnCall(i int, result1 error) {
+	fake.appGitTriggerShowMutex.Lock()
+	defer fake.appGitTriggerShowMutex.Unlock()
+	fake.AppGitTriggerShowStub = nil
+	if fake.appGitTriggerShowReturnsOnCall == nil {
+		fake.appGitTriggerShowReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appGitTriggerShowReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppLogDrainAdd(arg1 string, arg2 string, arg3 string) error {
+	fake.appLogDrainAddMutex.Lock()
+	ret, specificReturn := fake.appLogDrainAddReturnsOnCall[len(fake.appLogDrainAddArgsForCall)]
+	fake.appLogDrainAddArgsForCall = append(fake.appLogDrainAddArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AppLogDrainAddStub
+	fakeReturns := fake.appLogDrainAddReturns
+	fake.recordInvocation("AppLogDrainAdd", []interface{}{arg1, arg2, arg3})
+	fake.appLogDrainAddMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppLogDrainAddCallCount() int {
+	fake.appLogDrainAddMutex.RLock()
+	defer fake.appLogDrainAddMutex.RUnlock()
+	return len(fake.appLogDrainAddArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppLogDrainAddCalls(stub func(string, string, string) error) {
+	fake.appLogDrainAddMutex.Lock()
+	defer fake.appLogDrainAddMutex.Unlock()
+	fake.AppLogDrainAddStub = stub
+}
+
+func (fake *FakeApplicationsService) AppLogDrainAddArgsForCall(i int) (string, string, string) {
+	fake.appLogDrainAddMutex.RLock()
+	defer fake.appLogDrainAddMutex.RUnlock()
+	argsForCall := fake.appLogDrainAddArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeApplicationsService) AppLogDrainAddReturns(result1 error) {
+	fake.appLogDrainAddMutex.Lock()
+	defer fake.appLogDrainAddMutex.Unlock()
+	fake.AppLogDrainAddStub = nil
+	fake.appLogDrainAddReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppLogDrainAddReturnsOnCall(i int, result1 error) {
+	fake.appLogDrainAddMutex.Lock()
+	defer fake.appLogDrainAddMutex.Unlock()
+	fake.AppLogDrainAddStub = nil
+	if fake.appLogDrainAddReturnsOnCall == nil {
+		fake.appLogDrainAddReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appLogDrainAddReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppLogDrainList(arg1 string) error {
+	fake.appLogDrainListMutex.Lock()
+	ret, specificReturn := fake.appLogDrainListReturnsOnCall[len(fake.appLogDrainListArgsForCall)]
+	fake.appLogDrainListArgsForCall = append(fake.appLogDrainListArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AppLogDrainListStub
+	fakeReturns := fake.appLogDrainListReturns
+	fake.recordInvocation("AppLogDrainList", []interface{}{arg1})
+	fake.appLogDrainListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppLogDrainListCallCount() int {
+	fake.appLogDrainListMutex.RLock()
+	defer fake.appLogDrainListMutex.RUnlock()
+	return len(fake.appLogDrainListArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppLogDrainListCalls(stub func(string) error) {
+	fake.appLogDrainListMutex.Lock()
+	defer fake.appLogDrainListMutex.Unlock()
+	fake.AppLogDrainListStub = stub
+}
+
+func (fake *FakeApplicationsService) AppLogDrainListArgsForCall(i int) string {
+	fake.appLogDrainListMutex.RLock()
+	defer fake.appLogDrainListMutex.RUnlock()
+	argsForCall := fake.appLogDrainListArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApplicationsService) AppLogDrainListReturns(result1 error) {
+	fake.appLogDrainListMutex.Lock()
+	defer fake.appLogDrainListMutex.Unlock()
+	fake.AppLogDrainListStub = nil
+	fake.appLogDrainListReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppLogDrainListReturnsOnCall(i int, result1 error) {
+	fake.appLogDrainListMutex.Lock()
+	defer fake.appLogDrainListMutex.Unlock()
+	fake.AppLogDrainListStub = nil
+	if fake.appLogDrainListReturnsOnCall == nil {
+		fake.appLogDrainListReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appLogDrainListReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppLogDrainRemove(arg1 string, arg2 string) error {
+	fake.appLogDrainRemoveMutex.Lock()
+	ret, specificReturn := fake.appLogDrainRemoveReturnsOnCall[len(fake.appLogDrainRemoveArgsForCall)]
+	fake.appLogDrainRemoveArgsForCall = append(fake.appLogDrainRemoveArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppLogDrainRemoveStub
+	fakeReturns := fake.appLogDrainRemoveReturns
+	fake.recordInvocation("AppLogDrainRemove", []interface{}{arg1, arg2})
+	fake.appLogDrainRemoveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppLogDrainRemoveCallCount() int {
+	fake.appLogDrainRemoveMutex.RLock()
+	defer fake.appLogDrainRemoveMutex.RUnlock()
+	return len(fake.appLogDrainRemoveArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppLogDrainRemoveCalls(stub func(string, string) error) {
+	fake.appLogDrainRemoveMutex.Lock()
+	defer fake.appLogDrainRemoveMutex.Unlock()
+	fake.AppLogDrainRemoveStub = stub
+}
+
+func (fake *FakeApplicationsService) AppLogDrainRemoveArgsForCall(i int) (string, string) {
+	fake.appLogDrainRemoveMutex.RLock()
+	defer fake.appLogDrainRemoveMutex.RUnlock()
+	argsForCall := fake.appLogDrainRemoveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApplicationsService) AppLogDrainRemoveReturns(result1 error) {
+	fake.appLogDrainRemoveMutex.Lock()
+	defer fake.appLogDrainRemoveMutex.Unlock()
+	fake.AppLogDrainRemoveStub = nil
+	fake.appLogDrainRemoveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppLogDrainRemoveReturnsOnCall(i int, result1 error) {
+	fake.appLogDrainRemoveMutex.Lock()
+	defer fake.appLogDrainRemoveMutex.Unlock()
+	fake.AppLogDrainRemoveStub = nil
+	if fake.appLogDrainRemoveReturnsOnCall == nil {
+		fake.appLogDrainRemoveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appLogDrainRemoveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppLogs(arg1 string, arg2 string, arg3 bool, arg4 *client.LogOptions) error {
+	fake.appLogsMutex.Lock()
+	ret, specificReturn := fake.appLogsReturnsOnCall[len(fake.appLogsArgsForCall)]
+	fake.appLogsArgsForCall = append(fake.appLogsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+		arg4 *client.LogOptions
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AppLogsStub
+	fakeReturns := fake.appLogsReturns
+	fake.recordInvocation("AppLogs", []interface{}{arg1, arg2, arg3, arg4})
+	fake.appLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppLogsCallCount() int {
+	fake.appLogsMutex.RLock()
+	defer fake.appLogsMutex.RUnlock()
+	return len(fake.appLogsArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppLogsCalls(stub func(string, string, bool, *client.LogOptions) error) {
+	fake.appLogsMutex.Lock()
+	defer fake.appLogsMutex.Unlock()
+	fake.AppLogsStub = stub
+}
+
+func (fake *FakeApplicationsService) AppLogsArgsForCall(i int) (string, string, bool, *client.LogOptions) {
+	fake.appLogsMutex.RLock()
+	defer fake.appLogsMutex.RUnlock()
+	argsForCall := fake.appLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeApplicationsService) AppLogsReturns(result1 error) {
+	fake.appLogsMutex.Lock()
+	defer fake.appLogsMutex.Unlock()
+	fake.AppLogsStub = nil
 	fake.appLogsReturns = struct {
 		result1 error
 	}{result1}
@@ -812,6 +1606,68 @@ func (fake *FakeApplicationsService) AppManifestReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
+func (fake *FakeApplicationsService) AppMetrics(arg1 string, arg2 string) error {
+	fake.appMetricsMutex.Lock()
+	ret, specificReturn := fake.appMetricsReturnsOnCall[len(fake.appMetricsArgsForCall)]
+	fake.appMetricsArgsForCall = append(fake.appMetricsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppMetricsStub
+	fakeReturns := fake.appMetricsReturns
+	fake.recordInvocation("AppMetrics", []interface{}{arg1, arg2})
+	fake.appMetricsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppMetricsCallCount() int {
+	fake.appMetricsMutex.RLock()
+	defer fake.appMetricsMutex.RUnlock()
+	return len(fake.appMetricsArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppMetricsCalls(stub func(string, string) error) {
+	fake.appMetricsMutex.Lock()
+	defer fake.appMetricsMutex.Unlock()
+	fake.AppMetricsStub = stub
+}
+
+func (fake *FakeApplicationsService) AppMetricsArgsForCall(i int) (string, string) {
+	fake.appMetricsMutex.RLock()
+	defer fake.appMetricsMutex.RUnlock()
+	argsForCall := fake.appMetricsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApplicationsService) AppMetricsReturns(result1 error) {
+	fake.appMetricsMutex.Lock()
+	defer fake.appMetricsMutex.Unlock()
+	fake.AppMetricsStub = nil
+	fake.appMetricsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppMetricsReturnsOnCall(i int, result1 error) {
+	fake.appMetricsMutex.Lock()
+	defer fake.appMetricsMutex.Unlock()
+	fake.AppMetricsStub = nil
+	if fake.appMetricsReturnsOnCall == nil {
+		fake.appMetricsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appMetricsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeApplicationsService) AppPortForward(arg1 context.Context, arg2 string, arg3 string, arg4 []string, arg5 []string) error {
 	var arg4Copy []string
 	if arg4 != nil {
@@ -887,6 +1743,69 @@ func (fake *FakeApplicationsService) AppPortForwardReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeApplicationsService) AppPromote(arg1 string, arg2 string, arg3 models.AppPromoteRequest) error {
+	fake.appPromoteMutex.Lock()
+	ret, specificReturn := fake.appPromoteReturnsOnCall[len(fake.appPromoteArgsForCall)]
+	fake.appPromoteArgsForCall = append(fake.appPromoteArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 models.AppPromoteRequest
+	}{arg1, arg2, arg3})
+	stub := fake.AppPromoteStub
+	fakeReturns := fake.appPromoteReturns
+	fake.recordInvocation("AppPromote", []interface{}{arg1, arg2, arg3})
+	fake.appPromoteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppPromoteCallCount() int {
+	fake.appPromoteMutex.RLock()
+	defer fake.appPromoteMutex.RUnlock()
+	return len(fake.appPromoteArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppPromoteCalls(stub func(string, string, models.AppPromoteRequest) error) {
+	fake.appPromoteMutex.Lock()
+	defer fake.appPromoteMutex.Unlock()
+	fake.AppPromoteStub = stub
+}
+
+func (fake *FakeApplicationsService) AppPromoteArgsForCall(i int) (string, string, models.AppPromoteRequest) {
+	fake.appPromoteMutex.RLock()
+	defer fake.appPromoteMutex.RUnlock()
+	argsForCall := fake.appPromoteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeApplicationsService) AppPromoteReturns(result1 error) {
+	fake.appPromoteMutex.Lock()
+	defer fake.appPromoteMutex.Unlock()
+	fake.AppPromoteStub = nil
+	fake.appPromoteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppPromoteReturnsOnCall(i int, result1 error) {
+	fake.appPromoteMutex.Lock()
+	defer fake.appPromoteMutex.Unlock()
+	fake.AppPromoteStub = nil
+	if fake.appPromoteReturnsOnCall == nil {
+		fake.appPromoteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appPromoteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeApplicationsService) AppPush(arg1 context.Context, arg2 models.ApplicationManifest) error {
 	fake.appPushMutex.Lock()
 	ret, specificReturn := fake.appPushReturnsOnCall[len(fake.appPushArgsForCall)]
@@ -949,19 +1868,20 @@ func (fake *FakeApplicationsService) AppPushReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeApplicationsService) AppRestage(arg1 string, arg2 bool) error {
+func (fake *FakeApplicationsService) AppRestage(arg1 string, arg2 bool, arg3 bool) error {
 	fake.appRestageMutex.Lock()
 	ret, specificReturn := fake.appRestageReturnsOnCall[len(fake.appRestageArgsForCall)]
 	fake.appRestageArgsForCall = append(fake.appRestageArgsForCall, struct {
 		arg1 string
 		arg2 bool
-	}{arg1, arg2})
+		arg3 bool
+	}{arg1, arg2, arg3})
 	stub := fake.AppRestageStub
 	fakeReturns := fake.appRestageReturns
-	fake.recordInvocation("AppRestage", []interface{}{arg1, arg2})
+	fake.recordInvocation("AppRestage", []interface{}{arg1, arg2, arg3})
 	fake.appRestageMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -975,17 +1895,17 @@ func (fake *FakeApplicationsService) AppRestageCallCount() int {
 	return len(fake.appRestageArgsForCall)
 }
 
-func (fake *FakeApplicationsService) AppRestageCalls(stub func(string, bool) error) {
+func (fake *FakeApplicationsService) AppRestageCalls(stub func(string, bool, bool) error) {
 	fake.appRestageMutex.Lock()
 	defer fake.appRestageMutex.Unlock()
 	fake.AppRestageStub = stub
 }
 
-func (fake *FakeApplicationsService) AppRestageArgsForCall(i int) (string, bool) {
+func (fake *FakeApplicationsService) AppRestageArgsForCall(i int) (string, bool, bool) {
 	fake.appRestageMutex.RLock()
 	defer fake.appRestageMutex.RUnlock()
 	argsForCall := fake.appRestageArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeApplicationsService) AppRestageReturns(result1 error) {
@@ -1072,6 +1992,67 @@ func (fake *FakeApplicationsService) AppRestartReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeApplicationsService) AppScan(arg1 string) error {
+	fake.appScanMutex.Lock()
+	ret, specificReturn := fake.appScanReturnsOnCall[len(fake.appScanArgsForCall)]
+	fake.appScanArgsForCall = append(fake.appScanArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AppScanStub
+	fakeReturns := fake.appScanReturns
+	fake.recordInvocation("AppScan", []interface{}{arg1})
+	fake.appScanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppScanCallCount() int {
+	fake.appScanMutex.RLock()
+	defer fake.appScanMutex.RUnlock()
+	return len(fake.appScanArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppScanCalls(stub func(string) error) {
+	fake.appScanMutex.Lock()
+	defer fake.appScanMutex.Unlock()
+	fake.AppScanStub = stub
+}
+
+func (fake *FakeApplicationsService) AppScanArgsForCall(i int) string {
+	fake.appScanMutex.RLock()
+	defer fake.appScanMutex.RUnlock()
+	argsForCall := fake.appScanArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApplicationsService) AppScanReturns(result1 error) {
+	fake.appScanMutex.Lock()
+	defer fake.appScanMutex.Unlock()
+	fake.AppScanStub = nil
+	fake.appScanReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppScanReturnsOnCall(i int, result1 error) {
+	fake.appScanMutex.Lock()
+	defer fake.appScanMutex.Unlock()
+	fake.AppScanStub = nil
+	if fake.appScanReturnsOnCall == nil {
+		fake.appScanReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appScanReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeApplicationsService) AppShow(arg1 string) error {
 	fake.appShowMutex.Lock()
 	ret, specificReturn := fake.appShowReturnsOnCall[len(fake.appShowArgsForCall)]
@@ -1197,6 +2178,68 @@ func (fake *FakeApplicationsService) AppStageIDReturnsOnCall(i int, result1 stri
 	}{result1, result2}
 }
 
+func (fake *FakeApplicationsService) AppSupportBundle(arg1 string, arg2 string) error {
+	fake.appSupportBundleMutex.Lock()
+	ret, specificReturn := fake.appSupportBundleReturnsOnCall[len(fake.appSupportBundleArgsForCall)]
+	fake.appSupportBundleArgsForCall = append(fake.appSupportBundleArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppSupportBundleStub
+	fakeReturns := fake.appSupportBundleReturns
+	fake.recordInvocation("AppSupportBundle", []interface{}{arg1, arg2})
+	fake.appSupportBundleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppSupportBundleCallCount() int {
+	fake.appSupportBundleMutex.RLock()
+	defer fake.appSupportBundleMutex.RUnlock()
+	return len(fake.appSupportBundleArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppSupportBundleCalls(stub func(string, string) error) {
+	fake.appSupportBundleMutex.Lock()
+	defer fake.appSupportBundleMutex.Unlock()
+	fake.AppSupportBundleStub = stub
+}
+
+func (fake *FakeApplicationsService) AppSupportBundleArgsForCall(i int) (string, string) {
+	fake.appSupportBundleMutex.RLock()
+	defer fake.appSupportBundleMutex.RUnlock()
+	argsForCall := fake.appSupportBundleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApplicationsService) AppSupportBundleReturns(result1 error) {
+	fake.appSupportBundleMutex.Lock()
+	defer fake.appSupportBundleMutex.Unlock()
+	fake.AppSupportBundleStub = nil
+	fake.appSupportBundleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppSupportBundleReturnsOnCall(i int, result1 error) {
+	fake.appSupportBundleMutex.Lock()
+	defer fake.appSupportBundleMutex.Unlock()
+	fake.AppSupportBundleStub = nil
+	if fake.appSupportBundleReturnsOnCall == nil {
+		fake.appSupportBundleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appSupportBundleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeApplicationsService) AppUpdate(arg1 string, arg2 models.ApplicationUpdateRequest) error {
 	fake.appUpdateMutex.Lock()
 	ret, specificReturn := fake.appUpdateReturnsOnCall[len(fake.appUpdateArgsForCall)]
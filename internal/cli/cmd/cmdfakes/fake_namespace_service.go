@@ -16,9 +16,23 @@ import (
 	"sync"
 
 	"github.com/epinio/epinio/internal/cli/cmd"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 )
 
 type FakeNamespaceService struct {
+	AddNamespaceLogDrainStub        func(string, string, string) error
+	addNamespaceLogDrainMutex       sync.RWMutex
+	addNamespaceLogDrainArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	addNamespaceLogDrainReturns struct {
+		result1 error
+	}
+	addNamespaceLogDrainReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateNamespaceStub        func(string) error
 	createNamespaceMutex       sync.RWMutex
 	createNamespaceArgsForCall []struct {
@@ -43,6 +57,17 @@ type FakeNamespaceService struct {
 	deleteNamespaceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ListNamespaceLogDrainsStub        func(string) error
+	listNamespaceLogDrainsMutex       sync.RWMutex
+	listNamespaceLogDrainsArgsForCall []struct {
+		arg1 string
+	}
+	listNamespaceLogDrainsReturns struct {
+		result1 error
+	}
+	listNamespaceLogDrainsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	NamespacesStub        func() error
 	namespacesMutex       sync.RWMutex
 	namespacesArgsForCall []struct {
@@ -64,6 +89,54 @@ type FakeNamespaceService struct {
 	namespacesMatchingReturnsOnCall map[int]struct {
 		result1 []string
 	}
+	RemoveNamespaceLogDrainStub        func(string, string) error
+	removeNamespaceLogDrainMutex       sync.RWMutex
+	removeNamespaceLogDrainArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	removeNamespaceLogDrainReturns struct {
+		result1 error
+	}
+	removeNamespaceLogDrainReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetNamespaceLogRetentionStub        func(string, string) error
+	setNamespaceLogRetentionMutex       sync.RWMutex
+	setNamespaceLogRetentionArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	setNamespaceLogRetentionReturns struct {
+		result1 error
+	}
+	setNamespaceLogRetentionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetNamespaceQuotaStub        func(string, models.NamespaceQuotaUpdateRequest) error
+	setNamespaceQuotaMutex       sync.RWMutex
+	setNamespaceQuotaArgsForCall []struct {
+		arg1 string
+		arg2 models.NamespaceQuotaUpdateRequest
+	}
+	setNamespaceQuotaReturns struct {
+		result1 error
+	}
+	setNamespaceQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetNamespaceScanPolicyStub        func(string, string) error
+	setNamespaceScanPolicyMutex       sync.RWMutex
+	setNamespaceScanPolicyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	setNamespaceScanPolicyReturns struct {
+		result1 error
+	}
+	setNamespaceScanPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ShowNamespaceStub        func(string) error
 	showNamespaceMutex       sync.RWMutex
 	showNamespaceArgsForCall []struct {
@@ -75,10 +148,106 @@ type FakeNamespaceService struct {
 	showNamespaceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ShowNamespaceLogRetentionStub        func(string) error
+	showNamespaceLogRetentionMutex       sync.RWMutex
+	showNamespaceLogRetentionArgsForCall []struct {
+		arg1 string
+	}
+	showNamespaceLogRetentionReturns struct {
+		result1 error
+	}
+	showNamespaceLogRetentionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ShowNamespaceQuotaStub        func(string) error
+	showNamespaceQuotaMutex       sync.RWMutex
+	showNamespaceQuotaArgsForCall []struct {
+		arg1 string
+	}
+	showNamespaceQuotaReturns struct {
+		result1 error
+	}
+	showNamespaceQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ShowNamespaceScanPolicyStub        func(string) error
+	showNamespaceScanPolicyMutex       sync.RWMutex
+	showNamespaceScanPolicyArgsForCall []struct {
+		arg1 string
+	}
+	showNamespaceScanPolicyReturns struct {
+		result1 error
+	}
+	showNamespaceScanPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeNamespaceService) AddNamespaceLogDrain(arg1 string, arg2 string, arg3 string) error {
+	fake.addNamespaceLogDrainMutex.Lock()
+	ret, specificReturn := fake.addNamespaceLogDrainReturnsOnCall[len(fake.addNamespaceLogDrainArgsForCall)]
+	fake.addNamespaceLogDrainArgsForCall = append(fake.addNamespaceLogDrainArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AddNamespaceLogDrainStub
+	fakeReturns := fake.addNamespaceLogDrainReturns
+	fake.recordInvocation("AddNamespaceLogDrain", []interface{}{arg1, arg2, arg3})
+	fake.addNamespaceLogDrainMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) AddNamespaceLogDrainCallCount() int {
+	fake.addNamespaceLogDrainMutex.RLock()
+	defer fake.addNamespaceLogDrainMutex.RUnlock()
+	return len(fake.addNamespaceLogDrainArgsForCall)
+}
+
+func (fake *FakeNamespaceService) AddNamespaceLogDrainCalls(stub func(string, string, string) error) {
+	fake.addNamespaceLogDrainMutex.Lock()
+	defer fake.addNamespaceLogDrainMutex.Unlock()
+	fake.AddNamespaceLogDrainStub = stub
+}
+
+func (fake *FakeNamespaceService) AddNamespaceLogDrainArgsForCall(i int) (string, string, string) {
+	fake.addNamespaceLogDrainMutex.RLock()
+	defer fake.addNamespaceLogDrainMutex.RUnlock()
+	argsForCall := fake.addNamespaceLogDrainArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNamespaceService) AddNamespaceLogDrainReturns(result1 error) {
+	fake.addNamespaceLogDrainMutex.Lock()
+	defer fake.addNamespaceLogDrainMutex.Unlock()
+	fake.AddNamespaceLogDrainStub = nil
+	fake.addNamespaceLogDrainReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) AddNamespaceLogDrainReturnsOnCall(i int, result1 error) {
+	fake.addNamespaceLogDrainMutex.Lock()
+	defer fake.addNamespaceLogDrainMutex.Unlock()
+	fake.AddNamespaceLogDrainStub = nil
+	if fake.addNamespaceLogDrainReturnsOnCall == nil {
+		fake.addNamespaceLogDrainReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addNamespaceLogDrainReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNamespaceService) CreateNamespace(arg1 string) error {
 	fake.createNamespaceMutex.Lock()
 	ret, specificReturn := fake.createNamespaceReturnsOnCall[len(fake.createNamespaceArgsForCall)]
@@ -208,6 +377,67 @@ func (fake *FakeNamespaceService) DeleteNamespaceReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
+func (fake *FakeNamespaceService) ListNamespaceLogDrains(arg1 string) error {
+	fake.listNamespaceLogDrainsMutex.Lock()
+	ret, specificReturn := fake.listNamespaceLogDrainsReturnsOnCall[len(fake.listNamespaceLogDrainsArgsForCall)]
+	fake.listNamespaceLogDrainsArgsForCall = append(fake.listNamespaceLogDrainsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListNamespaceLogDrainsStub
+	fakeReturns := fake.listNamespaceLogDrainsReturns
+	fake.recordInvocation("ListNamespaceLogDrains", []interface{}{arg1})
+	fake.listNamespaceLogDrainsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) ListNamespaceLogDrainsCallCount() int {
+	fake.listNamespaceLogDrainsMutex.RLock()
+	defer fake.listNamespaceLogDrainsMutex.RUnlock()
+	return len(fake.listNamespaceLogDrainsArgsForCall)
+}
+
+func (fake *FakeNamespaceService) ListNamespaceLogDrainsCalls(stub func(string) error) {
+	fake.listNamespaceLogDrainsMutex.Lock()
+	defer fake.listNamespaceLogDrainsMutex.Unlock()
+	fake.ListNamespaceLogDrainsStub = stub
+}
+
+func (fake *FakeNamespaceService) ListNamespaceLogDrainsArgsForCall(i int) string {
+	fake.listNamespaceLogDrainsMutex.RLock()
+	defer fake.listNamespaceLogDrainsMutex.RUnlock()
+	argsForCall := fake.listNamespaceLogDrainsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNamespaceService) ListNamespaceLogDrainsReturns(result1 error) {
+	fake.listNamespaceLogDrainsMutex.Lock()
+	defer fake.listNamespaceLogDrainsMutex.Unlock()
+	fake.ListNamespaceLogDrainsStub = nil
+	fake.listNamespaceLogDrainsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) ListNamespaceLogDrainsReturnsOnCall(i int, result1 error) {
+	fake.listNamespaceLogDrainsMutex.Lock()
+	defer fake.listNamespaceLogDrainsMutex.Unlock()
+	fake.ListNamespaceLogDrainsStub = nil
+	if fake.listNamespaceLogDrainsReturnsOnCall == nil {
+		fake.listNamespaceLogDrainsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.listNamespaceLogDrainsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNamespaceService) Namespaces() error {
 	fake.namespacesMutex.Lock()
 	ret, specificReturn := fake.namespacesReturnsOnCall[len(fake.namespacesArgsForCall)]
@@ -322,6 +552,254 @@ func (fake *FakeNamespaceService) NamespacesMatchingReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeNamespaceService) RemoveNamespaceLogDrain(arg1 string, arg2 string) error {
+	fake.removeNamespaceLogDrainMutex.Lock()
+	ret, specificReturn := fake.removeNamespaceLogDrainReturnsOnCall[len(fake.removeNamespaceLogDrainArgsForCall)]
+	fake.removeNamespaceLogDrainArgsForCall = append(fake.removeNamespaceLogDrainArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RemoveNamespaceLogDrainStub
+	fakeReturns := fake.removeNamespaceLogDrainReturns
+	fake.recordInvocation("RemoveNamespaceLogDrain", []interface{}{arg1, arg2})
+	fake.removeNamespaceLogDrainMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) RemoveNamespaceLogDrainCallCount() int {
+	fake.removeNamespaceLogDrainMutex.RLock()
+	defer fake.removeNamespaceLogDrainMutex.RUnlock()
+	return len(fake.removeNamespaceLogDrainArgsForCall)
+}
+
+func (fake *FakeNamespaceService) RemoveNamespaceLogDrainCalls(stub func(string, string) error) {
+	fake.removeNamespaceLogDrainMutex.Lock()
+	defer fake.removeNamespaceLogDrainMutex.Unlock()
+	fake.RemoveNamespaceLogDrainStub = stub
+}
+
+func (fake *FakeNamespaceService) RemoveNamespaceLogDrainArgsForCall(i int) (string, string) {
+	fake.removeNamespaceLogDrainMutex.RLock()
+	defer fake.removeNamespaceLogDrainMutex.RUnlock()
+	argsForCall := fake.removeNamespaceLogDrainArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNamespaceService) RemoveNamespaceLogDrainReturns(result1 error) {
+	fake.removeNamespaceLogDrainMutex.Lock()
+	defer fake.removeNamespaceLogDrainMutex.Unlock()
+	fake.RemoveNamespaceLogDrainStub = nil
+	fake.removeNamespaceLogDrainReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) RemoveNamespaceLogDrainReturnsOnCall(i int, result1 error) {
+	fake.removeNamespaceLogDrainMutex.Lock()
+	defer fake.removeNamespaceLogDrainMutex.Unlock()
+	fake.RemoveNamespaceLogDrainStub = nil
+	if fake.removeNamespaceLogDrainReturnsOnCall == nil {
+		fake.removeNamespaceLogDrainReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeNamespaceLogDrainReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) SetNamespaceLogRetention(arg1 string, arg2 string) error {
+	fake.setNamespaceLogRetentionMutex.Lock()
+	ret, specificReturn := fake.setNamespaceLogRetentionReturnsOnCall[len(fake.setNamespaceLogRetentionArgsForCall)]
+	fake.setNamespaceLogRetentionArgsForCall = append(fake.setNamespaceLogRetentionArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SetNamespaceLogRetentionStub
+	fakeReturns := fake.setNamespaceLogRetentionReturns
+	fake.recordInvocation("SetNamespaceLogRetention", []interface{}{arg1, arg2})
+	fake.setNamespaceLogRetentionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) SetNamespaceLogRetentionCallCount() int {
+	fake.setNamespaceLogRetentionMutex.RLock()
+	defer fake.setNamespaceLogRetentionMutex.RUnlock()
+	return len(fake.setNamespaceLogRetentionArgsForCall)
+}
+
+func (fake *FakeNamespaceService) SetNamespaceLogRetentionCalls(stub func(string, string) error) {
+	fake.setNamespaceLogRetentionMutex.Lock()
+	defer fake.setNamespaceLogRetentionMutex.Unlock()
+	fake.SetNamespaceLogRetentionStub = stub
+}
+
+func (fake *FakeNamespaceService) SetNamespaceLogRetentionArgsForCall(i int) (string, string) {
+	fake.setNamespaceLogRetentionMutex.RLock()
+	defer fake.setNamespaceLogRetentionMutex.RUnlock()
+	argsForCall := fake.setNamespaceLogRetentionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNamespaceService) SetNamespaceLogRetentionReturns(result1 error) {
+	fake.setNamespaceLogRetentionMutex.Lock()
+	defer fake.setNamespaceLogRetentionMutex.Unlock()
+	fake.SetNamespaceLogRetentionStub = nil
+	fake.setNamespaceLogRetentionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) SetNamespaceLogRetentionReturnsOnCall(i int, result1 error) {
+	fake.setNamespaceLogRetentionMutex.Lock()
+	defer fake.setNamespaceLogRetentionMutex.Unlock()
+	fake.SetNamespaceLogRetentionStub = nil
+	if fake.setNamespaceLogRetentionReturnsOnCall == nil {
+		fake.setNamespaceLogRetentionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setNamespaceLogRetentionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) SetNamespaceQuota(arg1 string, arg2 models.NamespaceQuotaUpdateRequest) error {
+	fake.setNamespaceQuotaMutex.Lock()
+	ret, specificReturn := fake.setNamespaceQuotaReturnsOnCall[len(fake.setNamespaceQuotaArgsForCall)]
+	fake.setNamespaceQuotaArgsForCall = append(fake.setNamespaceQuotaArgsForCall, struct {
+		arg1 string
+		arg2 models.NamespaceQuotaUpdateRequest
+	}{arg1, arg2})
+	stub := fake.SetNamespaceQuotaStub
+	fakeReturns := fake.setNamespaceQuotaReturns
+	fake.recordInvocation("SetNamespaceQuota", []interface{}{arg1, arg2})
+	fake.setNamespaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) SetNamespaceQuotaCallCount() int {
+	fake.setNamespaceQuotaMutex.RLock()
+	defer fake.setNamespaceQuotaMutex.RUnlock()
+	return len(fake.setNamespaceQuotaArgsForCall)
+}
+
+func (fake *FakeNamespaceService) SetNamespaceQuotaCalls(stub func(string, models.NamespaceQuotaUpdateRequest) error) {
+	fake.setNamespaceQuotaMutex.Lock()
+	defer fake.setNamespaceQuotaMutex.Unlock()
+	fake.SetNamespaceQuotaStub = stub
+}
+
+func (fake *FakeNamespaceService) SetNamespaceQuotaArgsForCall(i int) (string, models.NamespaceQuotaUpdateRequest) {
+	fake.setNamespaceQuotaMutex.RLock()
+	defer fake.setNamespaceQuotaMutex.RUnlock()
+	argsForCall := fake.setNamespaceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNamespaceService) SetNamespaceQuotaReturns(result1 error) {
+	fake.setNamespaceQuotaMutex.Lock()
+	defer fake.setNamespaceQuotaMutex.Unlock()
+	fake.SetNamespaceQuotaStub = nil
+	fake.setNamespaceQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) SetNamespaceQuotaReturnsOnCall(i int, result1 error) {
+	fake.setNamespaceQuotaMutex.Lock()
+	defer fake.setNamespaceQuotaMutex.Unlock()
+	fake.SetNamespaceQuotaStub = nil
+	if fake.setNamespaceQuotaReturnsOnCall == nil {
+		fake.setNamespaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setNamespaceQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) SetNamespaceScanPolicy(arg1 string, arg2 string) error {
+	fake.setNamespaceScanPolicyMutex.Lock()
+	ret, specificReturn := fake.setNamespaceScanPolicyReturnsOnCall[len(fake.setNamespaceScanPolicyArgsForCall)]
+	fake.setNamespaceScanPolicyArgsForCall = append(fake.setNamespaceScanPolicyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SetNamespaceScanPolicyStub
+	fakeReturns := fake.setNamespaceScanPolicyReturns
+	fake.recordInvocation("SetNamespaceScanPolicy", []interface{}{arg1, arg2})
+	fake.setNamespaceScanPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) SetNamespaceScanPolicyCallCount() int {
+	fake.setNamespaceScanPolicyMutex.RLock()
+	defer fake.setNamespaceScanPolicyMutex.RUnlock()
+	return len(fake.setNamespaceScanPolicyArgsForCall)
+}
+
+func (fake *FakeNamespaceService) SetNamespaceScanPolicyCalls(stub func(string, string) error) {
+	fake.setNamespaceScanPolicyMutex.Lock()
+	defer fake.setNamespaceScanPolicyMutex.Unlock()
+	fake.SetNamespaceScanPolicyStub = stub
+}
+
+func (fake *FakeNamespaceService) SetNamespaceScanPolicyArgsForCall(i int) (string, string) {
+	fake.setNamespaceScanPolicyMutex.RLock()
+	defer fake.setNamespaceScanPolicyMutex.RUnlock()
+	argsForCall := fake.setNamespaceScanPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNamespaceService) SetNamespaceScanPolicyReturns(result1 error) {
+	fake.setNamespaceScanPolicyMutex.Lock()
+	defer fake.setNamespaceScanPolicyMutex.Unlock()
+	fake.SetNamespaceScanPolicyStub = nil
+	fake.setNamespaceScanPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) SetNamespaceScanPolicyReturnsOnCall(i int, result1 error) {
+	fake.setNamespaceScanPolicyMutex.Lock()
+	defer fake.setNamespaceScanPolicyMutex.Unlock()
+	fake.SetNamespaceScanPolicyStub = nil
+	if fake.setNamespaceScanPolicyReturnsOnCall == nil {
+		fake.setNamespaceScanPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setNamespaceScanPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNamespaceService) ShowNamespace(arg1 string) error {
 	fake.showNamespaceMutex.Lock()
 	ret, specificReturn := fake.showNamespaceReturnsOnCall[len(fake.showNamespaceArgsForCall)]
@@ -383,6 +861,189 @@ func (fake *FakeNamespaceService) ShowNamespaceReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeNamespaceService) ShowNamespaceLogRetention(arg1 string) error {
+	fake.showNamespaceLogRetentionMutex.Lock()
+	ret, specificReturn := fake.showNamespaceLogRetentionReturnsOnCall[len(fake.showNamespaceLogRetentionArgsForCall)]
+	fake.showNamespaceLogRetentionArgsForCall = append(fake.showNamespaceLogRetentionArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ShowNamespaceLogRetentionStub
+	fakeReturns := fake.showNamespaceLogRetentionReturns
+	fake.recordInvocation("ShowNamespaceLogRetention", []interface{}{arg1})
+	fake.showNamespaceLogRetentionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceLogRetentionCallCount() int {
+	fake.showNamespaceLogRetentionMutex.RLock()
+	defer fake.showNamespaceLogRetentionMutex.RUnlock()
+	return len(fake.showNamespaceLogRetentionArgsForCall)
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceLogRetentionCalls(stub func(string) error) {
+	fake.showNamespaceLogRetentionMutex.Lock()
+	defer fake.showNamespaceLogRetentionMutex.Unlock()
+	fake.ShowNamespaceLogRetentionStub = stub
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceLogRetentionArgsForCall(i int) string {
+	fake.showNamespaceLogRetentionMutex.RLock()
+	defer fake.showNamespaceLogRetentionMutex.RUnlock()
+	argsForCall := fake.showNamespaceLogRetentionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceLogRetentionReturns(result1 error) {
+	fake.showNamespaceLogRetentionMutex.Lock()
+	defer fake.showNamespaceLogRetentionMutex.Unlock()
+	fake.ShowNamespaceLogRetentionStub = nil
+	fake.showNamespaceLogRetentionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceLogRetentionReturnsOnCall(i int, result1 error) {
+	fake.showNamespaceLogRetentionMutex.Lock()
+	defer fake.showNamespaceLogRetentionMutex.Unlock()
+	fake.ShowNamespaceLogRetentionStub = nil
+	if fake.showNamespaceLogRetentionReturnsOnCall == nil {
+		fake.showNamespaceLogRetentionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.showNamespaceLogRetentionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceQuota(arg1 string) error {
+	fake.showNamespaceQuotaMutex.Lock()
+	ret, specificReturn := fake.showNamespaceQuotaReturnsOnCall[len(fake.showNamespaceQuotaArgsForCall)]
+	fake.showNamespaceQuotaArgsForCall = append(fake.showNamespaceQuotaArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ShowNamespaceQuotaStub
+	fakeReturns := fake.showNamespaceQuotaReturns
+	fake.recordInvocation("ShowNamespaceQuota", []interface{}{arg1})
+	fake.showNamespaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceQuotaCallCount() int {
+	fake.showNamespaceQuotaMutex.RLock()
+	defer fake.showNamespaceQuotaMutex.RUnlock()
+	return len(fake.showNamespaceQuotaArgsForCall)
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceQuotaCalls(stub func(string) error) {
+	fake.showNamespaceQuotaMutex.Lock()
+	defer fake.showNamespaceQuotaMutex.Unlock()
+	fake.ShowNamespaceQuotaStub = stub
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceQuotaArgsForCall(i int) string {
+	fake.showNamespaceQuotaMutex.RLock()
+	defer fake.showNamespaceQuotaMutex.RUnlock()
+	argsForCall := fake.showNamespaceQuotaArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceQuotaReturns(result1 error) {
+	fake.showNamespaceQuotaMutex.Lock()
+	defer fake.showNamespaceQuotaMutex.Unlock()
+	fake.ShowNamespaceQuotaStub = nil
+	fake.showNamespaceQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceQuotaReturnsOnCall(i int, result1 error) {
+	fake.showNamespaceQuotaMutex.Lock()
+	defer fake.showNamespaceQuotaMutex.Unlock()
+	fake.ShowNamespaceQuotaStub = nil
+	if fake.showNamespaceQuotaReturnsOnCall == nil {
+		fake.showNamespaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.showNamespaceQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceScanPolicy(arg1 string) error {
+	fake.showNamespaceScanPolicyMutex.Lock()
+	ret, specificReturn := fake.showNamespaceScanPolicyReturnsOnCall[len(fake.showNamespaceScanPolicyArgsForCall)]
+	fake.showNamespaceScanPolicyArgsForCall = append(fake.showNamespaceScanPolicyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ShowNamespaceScanPolicyStub
+	fakeReturns := fake.showNamespaceScanPolicyReturns
+	fake.recordInvocation("ShowNamespaceScanPolicy", []interface{}{arg1})
+	fake.showNamespaceScanPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceScanPolicyCallCount() int {
+	fake.showNamespaceScanPolicyMutex.RLock()
+	defer fake.showNamespaceScanPolicyMutex.RUnlock()
+	return len(fake.showNamespaceScanPolicyArgsForCall)
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceScanPolicyCalls(stub func(string) error) {
+	fake.showNamespaceScanPolicyMutex.Lock()
+	defer fake.showNamespaceScanPolicyMutex.Unlock()
+	fake.ShowNamespaceScanPolicyStub = stub
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceScanPolicyArgsForCall(i int) string {
+	fake.showNamespaceScanPolicyMutex.RLock()
+	defer fake.showNamespaceScanPolicyMutex.RUnlock()
+	argsForCall := fake.showNamespaceScanPolicyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceScanPolicyReturns(result1 error) {
+	fake.showNamespaceScanPolicyMutex.Lock()
+	defer fake.showNamespaceScanPolicyMutex.Unlock()
+	fake.ShowNamespaceScanPolicyStub = nil
+	fake.showNamespaceScanPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) ShowNamespaceScanPolicyReturnsOnCall(i int, result1 error) {
+	fake.showNamespaceScanPolicyMutex.Lock()
+	defer fake.showNamespaceScanPolicyMutex.Unlock()
+	fake.ShowNamespaceScanPolicyStub = nil
+	if fake.showNamespaceScanPolicyReturnsOnCall == nil {
+		fake.showNamespaceScanPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.showNamespaceScanPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNamespaceService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -14,6 +14,7 @@ package cmd
 import (
 	"strings"
 
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -25,6 +26,15 @@ type NamespaceService interface {
 	DeleteNamespace(namespaces []string, force, all bool) error
 	ShowNamespace(namespace string) error
 	NamespacesMatching(toComplete string) []string
+	ShowNamespaceQuota(namespace string) error
+	SetNamespaceQuota(namespace string, request models.NamespaceQuotaUpdateRequest) error
+	ShowNamespaceScanPolicy(namespace string) error
+	SetNamespaceScanPolicy(namespace, threshold string) error
+	ShowNamespaceLogRetention(namespace string) error
+	SetNamespaceLogRetention(namespace, retention string) error
+	ListNamespaceLogDrains(namespace string) error
+	AddNamespaceLogDrain(namespace, drainName, drainURL string) error
+	RemoveNamespaceLogDrain(namespace, drainName string) error
 }
 
 // NewNamespaceCmd returns a new 'epinio namespace' command
@@ -44,6 +54,10 @@ func NewNamespaceCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Comman
 		NewNamespaceListCmd(client, rootCfg),
 		NewNamespaceDeleteCmd(client),
 		NewNamespaceShowCmd(client, rootCfg),
+		NewNamespaceQuotaCmd(client, rootCfg),
+		NewNamespaceScanPolicyCmd(client, rootCfg),
+		NewNamespaceLogRetentionCmd(client, rootCfg),
+		NewNamespaceLogDrainCmd(client, rootCfg),
 	)
 
 	return namespaceCmd
@@ -153,3 +167,371 @@ func NewNamespaceShowCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Co
 
 	return namespaceShowCmd
 }
+
+// NewNamespaceQuotaCmd returns a new 'epinio namespace quota' command
+func NewNamespaceQuotaCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	namespaceQuotaCmd := &cobra.Command{
+		Use:   "quota",
+		Short: "Namespace quotas",
+		Long:  `Manage the resource quotas of epinio-controlled namespaces`,
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	namespaceQuotaCmd.AddCommand(
+		NewNamespaceQuotaShowCmd(client, rootCfg),
+		NewNamespaceQuotaSetCmd(client, rootCfg),
+	)
+
+	return namespaceQuotaCmd
+}
+
+// NewNamespaceQuotaShowCmd returns a new 'epinio namespace quota show' command
+func NewNamespaceQuotaShowCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	namespaceQuotaShowCmd := &cobra.Command{
+		Use:               "show NAME",
+		Short:             "Shows the quota of an epinio-controlled namespace, and its current usage",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.ShowNamespaceQuota(args[0])
+			if err != nil {
+				return errors.Wrap(err, "error showing namespace quota")
+			}
+
+			return nil
+		},
+	}
+
+	namespaceQuotaShowCmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(namespaceQuotaShowCmd, "output")
+	bindFlagCompletionFunc(namespaceQuotaShowCmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return namespaceQuotaShowCmd
+}
+
+type NamespaceQuotaSetConfig struct {
+	apps        int32
+	instances   int32
+	services    int32
+	cpu         string
+	memory      string
+	blobStorage string
+	unset       []string
+}
+
+// NewNamespaceQuotaSetCmd returns a new 'epinio namespace quota set' command
+func NewNamespaceQuotaSetCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	cfg := NamespaceQuotaSetConfig{}
+
+	namespaceQuotaSetCmd := &cobra.Command{
+		Use:   "set NAME",
+		Short: "Sets the quota of an epinio-controlled namespace",
+		Long: `Sets the quota of an epinio-controlled namespace. Only the specified limits are changed.
+Use --unset to remove limits. Requires admin permissions.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			request := models.NamespaceQuotaUpdateRequest{
+				Set: models.NamespaceQuota{
+					CPU:         cfg.cpu,
+					Memory:      cfg.memory,
+					BlobStorage: cfg.blobStorage,
+				},
+				Remove: cfg.unset,
+			}
+			if cmd.Flags().Changed("apps") {
+				request.Set.Apps = &cfg.apps
+			}
+			if cmd.Flags().Changed("instances") {
+				request.Set.Instances = &cfg.instances
+			}
+			if cmd.Flags().Changed("services") {
+				request.Set.Services = &cfg.services
+			}
+
+			err := client.SetNamespaceQuota(args[0], request)
+			if err != nil {
+				return errors.Wrap(err, "error setting namespace quota")
+			}
+
+			return nil
+		},
+	}
+
+	flags := namespaceQuotaSetCmd.Flags()
+	flags.Int32Var(&cfg.apps, "apps", 0, "maximum number of applications")
+	flags.Int32Var(&cfg.instances, "instances", 0, "maximum number of application instances, across all applications")
+	flags.Int32Var(&cfg.services, "services", 0, "maximum number of services")
+	flags.StringVar(&cfg.cpu, "cpu", "", "maximum total cpu used by the applications (e.g. 500m, 2)")
+	flags.StringVar(&cfg.memory, "memory", "", "maximum total memory used by the applications (e.g. 512Mi, 4Gi)")
+	flags.StringVar(&cfg.blobStorage, "blob-storage", "", "maximum size of the uploaded application sources (e.g. 1Gi)")
+	flags.StringSliceVar(&cfg.unset, "unset", []string{},
+		"remove the named limits ("+strings.Join(models.QuotaKeys, ", ")+")")
+
+	namespaceQuotaSetCmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(namespaceQuotaSetCmd, "output")
+	bindFlagCompletionFunc(namespaceQuotaSetCmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+	bindFlagCompletionFunc(namespaceQuotaSetCmd, "unset", NewStaticFlagsCompletionFunc(models.QuotaKeys))
+
+	return namespaceQuotaSetCmd
+}
+
+// NewNamespaceScanPolicyCmd returns a new 'epinio namespace scan-policy' command
+func NewNamespaceScanPolicyCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	namespaceScanPolicyCmd := &cobra.Command{
+		Use:   "scan-policy",
+		Short: "Namespace image scan policies",
+		Long:  `Manage the image scan policies of epinio-controlled namespaces`,
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	namespaceScanPolicyCmd.AddCommand(
+		NewNamespaceScanPolicyShowCmd(client, rootCfg),
+		NewNamespaceScanPolicySetCmd(client, rootCfg),
+		NewNamespaceScanPolicyUnsetCmd(client, rootCfg),
+	)
+
+	return namespaceScanPolicyCmd
+}
+
+// NewNamespaceScanPolicyShowCmd returns a new 'epinio namespace scan-policy show' command
+func NewNamespaceScanPolicyShowCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show NAME",
+		Short:             "Shows the image scan policy of an epinio-controlled namespace",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.ShowNamespaceScanPolicy(args[0])
+			return errors.Wrap(err, "error showing namespace scan policy")
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// NewNamespaceScanPolicySetCmd returns a new 'epinio namespace scan-policy set' command
+func NewNamespaceScanPolicySetCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set NAME SEVERITY",
+		Short: "Sets the image scan policy of an epinio-controlled namespace",
+		Long: `Sets the lowest severity of image scan findings blocking the deployment of applications
+in an epinio-controlled namespace. The severity 'none' disables blocking. Requires admin permissions.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.SetNamespaceScanPolicy(args[0], args[1])
+			return errors.Wrap(err, "error setting namespace scan policy")
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// NewNamespaceScanPolicyUnsetCmd returns a new 'epinio namespace scan-policy unset' command
+func NewNamespaceScanPolicyUnsetCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unset NAME",
+		Short: "Removes the image scan policy of an epinio-controlled namespace",
+		Long: `Removes the image scan policy of an epinio-controlled namespace. The global policy applies
+to the namespace afterwards. Requires admin permissions.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.SetNamespaceScanPolicy(args[0], "")
+			return errors.Wrap(err, "error removing namespace scan policy")
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// NewNamespaceLogRetentionCmd returns a new 'epinio namespace log-retention' command
+func NewNamespaceLogRetentionCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	namespaceLogRetentionCmd := &cobra.Command{
+		Use:   "log-retention",
+		Short: "Namespace log retention",
+		Long:  `Manage how long the log archive keeps the logs of epinio-controlled namespaces`,
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	namespaceLogRetentionCmd.AddCommand(
+		NewNamespaceLogRetentionShowCmd(client, rootCfg),
+		NewNamespaceLogRetentionSetCmd(client, rootCfg),
+		NewNamespaceLogRetentionUnsetCmd(client, rootCfg),
+	)
+
+	return namespaceLogRetentionCmd
+}
+
+// NewNamespaceLogRetentionShowCmd returns a new 'epinio namespace log-retention show' command
+func NewNamespaceLogRetentionShowCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show NAME",
+		Short:             "Shows the log retention of an epinio-controlled namespace",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.ShowNamespaceLogRetention(args[0])
+			return errors.Wrap(err, "error showing namespace log retention")
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// NewNamespaceLogRetentionSetCmd returns a new 'epinio namespace log-retention set' command
+func NewNamespaceLogRetentionSetCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set NAME PERIOD",
+		Short: "Sets the log retention of an epinio-controlled namespace",
+		Long: `Sets how long the log archive keeps the logs of the applications in an epinio-controlled
+namespace, in days (7d) or as a duration (36h). The period 0 disables archiving for the namespace.
+Requires admin permissions.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.SetNamespaceLogRetention(args[0], args[1])
+			return errors.Wrap(err, "error setting namespace log retention")
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// NewNamespaceLogRetentionUnsetCmd returns a new 'epinio namespace log-retention unset' command
+func NewNamespaceLogRetentionUnsetCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unset NAME",
+		Short: "Removes the log retention of an epinio-controlled namespace",
+		Long: `Removes the log retention of an epinio-controlled namespace. The global retention applies
+to the namespace afterwards. Requires admin permissions.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.SetNamespaceLogRetention(args[0], "")
+			return errors.Wrap(err, "error removing namespace log retention")
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// NewNamespaceLogDrainCmd returns a new 'epinio namespace drain' command
+func NewNamespaceLogDrainCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	namespaceLogDrainCmd := &cobra.Command{
+		Use:   "drain",
+		Short: "Namespace log drains",
+		Long:  `Manage the external endpoints the logs of all applications in epinio-controlled namespaces are forwarded to`,
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	namespaceLogDrainCmd.AddCommand(
+		NewNamespaceLogDrainAddCmd(client),
+		NewNamespaceLogDrainListCmd(client, rootCfg),
+		NewNamespaceLogDrainRemoveCmd(client),
+	)
+
+	return namespaceLogDrainCmd
+}
+
+// NewNamespaceLogDrainAddCmd returns a new 'epinio namespace drain add' command
+func NewNamespaceLogDrainAddCmd(client NamespaceService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add NAME DRAIN URL",
+		Short: "Adds a log drain to an epinio-controlled namespace",
+		Long: `Adds a log drain receiving the logs of all applications in an epinio-controlled namespace.
+See 'epinio app drain add' for the supported urls. Requires admin permissions.`,
+		Args:              cobra.ExactArgs(3),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AddNamespaceLogDrain(args[0], args[1], args[2])
+			return errors.Wrap(err, "error adding namespace log drain")
+		},
+	}
+
+	return cmd
+}
+
+// NewNamespaceLogDrainListCmd returns a new 'epinio namespace drain list' command
+func NewNamespaceLogDrainListCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list NAME",
+		Short:             "Lists the log drains of an epinio-controlled namespace",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.ListNamespaceLogDrains(args[0])
+			return errors.Wrap(err, "error listing namespace log drains")
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+// NewNamespaceLogDrainRemoveCmd returns a new 'epinio namespace drain remove' command
+func NewNamespaceLogDrainRemoveCmd(client NamespaceService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove NAME DRAIN",
+		Short:             "Removes a log drain from an epinio-controlled namespace",
+		Long:              "Removes the named log drain from an epinio-controlled namespace. Requires admin permissions.",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.RemoveNamespaceLogDrain(args[0], args[1])
+			return errors.Wrap(err, "error removing namespace log drain")
+		},
+	}
+
+	return cmd
+}
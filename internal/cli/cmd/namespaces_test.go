@@ -17,6 +17,7 @@ import (
 
 	"github.com/epinio/epinio/internal/cli/cmd"
 	"github.com/epinio/epinio/internal/cli/cmd/cmdfakes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -153,4 +154,125 @@ var _ = Describe("Command 'epinio namespace'", func() {
 			})
 		})
 	})
+
+	Context("namespace quota set", func() {
+
+		When("called with limits", func() {
+			It("sends only the specified limits", func() {
+				args = append(args, "mynamespace", "--apps", "0", "--memory", "2Gi", "--unset", "cpu")
+
+				namespaceCmd := cmd.NewNamespaceQuotaSetCmd(mockNamespaceService, cmd.NewRootConfig())
+				_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+				Expect(runErr).ToNot(HaveOccurred())
+
+				Expect(mockNamespaceService.SetNamespaceQuotaCallCount()).To(Equal(1))
+				namespace, request := mockNamespaceService.SetNamespaceQuotaArgsForCall(0)
+				Expect(namespace).To(Equal("mynamespace"))
+				Expect(request.Set.Apps).ToNot(BeNil())
+				Expect(*request.Set.Apps).To(Equal(int32(0)))
+				Expect(request.Set.Instances).To(BeNil())
+				Expect(request.Set.Services).To(BeNil())
+				Expect(request.Set.Memory).To(Equal("2Gi"))
+				Expect(request.Remove).To(Equal([]string{models.QuotaCPU}))
+			})
+		})
+
+		When("the quota update fails", func() {
+			It("returns an error", func() {
+				args = append(args, "mynamespace", "--apps", "3")
+				mockNamespaceService.SetNamespaceQuotaReturns(errors.New("something bad happened"))
+
+				namespaceCmd := cmd.NewNamespaceQuotaSetCmd(mockNamespaceService, cmd.NewRootConfig())
+				_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("error setting namespace quota: something bad happened"))
+			})
+		})
+	})
+
+	Context("namespace scan-policy", func() {
+
+		It("sets the threshold", func() {
+			args = append(args, "mynamespace", "high")
+
+			namespaceCmd := cmd.NewNamespaceScanPolicySetCmd(mockNamespaceService, cmd.NewRootConfig())
+			_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(mockNamespaceService.SetNamespaceScanPolicyCallCount()).To(Equal(1))
+			namespace, threshold := mockNamespaceService.SetNamespaceScanPolicyArgsForCall(0)
+			Expect(namespace).To(Equal("mynamespace"))
+			Expect(threshold).To(Equal("high"))
+		})
+
+		It("removes the threshold", func() {
+			args = append(args, "mynamespace")
+
+			namespaceCmd := cmd.NewNamespaceScanPolicyUnsetCmd(mockNamespaceService, cmd.NewRootConfig())
+			_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(mockNamespaceService.SetNamespaceScanPolicyCallCount()).To(Equal(1))
+			_, threshold := mockNamespaceService.SetNamespaceScanPolicyArgsForCall(0)
+			Expect(threshold).To(BeEmpty())
+		})
+	})
+
+	Context("namespace log-retention", func() {
+
+		It("sets the retention", func() {
+			args = append(args, "mynamespace", "3d")
+
+			namespaceCmd := cmd.NewNamespaceLogRetentionSetCmd(mockNamespaceService, cmd.NewRootConfig())
+			_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(mockNamespaceService.SetNamespaceLogRetentionCallCount()).To(Equal(1))
+			namespace, retention := mockNamespaceService.SetNamespaceLogRetentionArgsForCall(0)
+			Expect(namespace).To(Equal("mynamespace"))
+			Expect(retention).To(Equal("3d"))
+		})
+
+		It("removes the retention", func() {
+			args = append(args, "mynamespace")
+
+			namespaceCmd := cmd.NewNamespaceLogRetentionUnsetCmd(mockNamespaceService, cmd.NewRootConfig())
+			_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(mockNamespaceService.SetNamespaceLogRetentionCallCount()).To(Equal(1))
+			_, retention := mockNamespaceService.SetNamespaceLogRetentionArgsForCall(0)
+			Expect(retention).To(BeEmpty())
+		})
+	})
+
+	Context("namespace drain", func() {
+
+		It("adds a drain", func() {
+			args = append(args, "mynamespace", "audit", "https://logs.example.com/ingest")
+
+			namespaceCmd := cmd.NewNamespaceLogDrainAddCmd(mockNamespaceService)
+			_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(mockNamespaceService.AddNamespaceLogDrainCallCount()).To(Equal(1))
+			namespace, drain, url := mockNamespaceService.AddNamespaceLogDrainArgsForCall(0)
+			Expect(namespace).To(Equal("mynamespace"))
+			Expect(drain).To(Equal("audit"))
+			Expect(url).To(Equal("https://logs.example.com/ingest"))
+		})
+
+		It("removes a drain", func() {
+			args = append(args, "mynamespace", "audit")
+
+			namespaceCmd := cmd.NewNamespaceLogDrainRemoveCmd(mockNamespaceService)
+			_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(mockNamespaceService.RemoveNamespaceLogDrainCallCount()).To(Equal(1))
+			namespace, drain := mockNamespaceService.RemoveNamespaceLogDrainArgsForCall(0)
+			Expect(namespace).To(Equal("mynamespace"))
+			Expect(drain).To(Equal("audit"))
+		})
+	})
 })
@@ -0,0 +1,124 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//counterfeiter:generate -header ../../../LICENSE_HEADER . RegistryCredentialService
+type RegistryCredentialService interface {
+	RegistryCredentials() error
+	CreateRegistryCredential(name, url, username, password string) error
+	DeleteRegistryCredential(name string) error
+}
+
+// NewRegistryCredentialCmd returns a new 'epinio registry-credential' command
+func NewRegistryCredentialCmd(client RegistryCredentialService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "registry-credential",
+		Aliases: []string{"registry-credentials"},
+		Short:   "Epinio registry credentials",
+		Long: `Manage the registry credentials of the current namespace, used to pull the images
+of applications deployed from private registries`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	cmd.AddCommand(
+		NewRegistryCredentialListCmd(client, rootCfg),
+		NewRegistryCredentialCreateCmd(client),
+		NewRegistryCredentialDeleteCmd(client),
+	)
+
+	return cmd
+}
+
+// NewRegistryCredentialListCmd returns a new 'epinio registry-credential list' command
+func NewRegistryCredentialListCmd(client RegistryCredentialService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the registry credentials of the current namespace",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.RegistryCredentials()
+			if err != nil {
+				return errors.Wrap(err, "error listing registry credentials")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
+type RegistryCredentialCreateConfig struct {
+	username string
+	password string
+}
+
+// NewRegistryCredentialCreateCmd returns a new 'epinio registry-credential create' command
+func NewRegistryCredentialCreateCmd(client RegistryCredentialService) *cobra.Command {
+	cfg := RegistryCredentialCreateConfig{}
+
+	cmd := &cobra.Command{
+		Use:   "create NAME URL [flags]",
+		Short: "Creates registry credentials in the current namespace",
+		Long: `Creates registry credentials in the current namespace. The URL is the registry host,
+e.g. 'registry.example.com', or 'docker.io'`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.CreateRegistryCredential(args[0], args[1], cfg.username, cfg.password)
+			if err != nil {
+				return errors.Wrap(err, "error creating registry credential")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.username, "username", "", "user name for logging into the registry")
+	cmd.Flags().StringVar(&cfg.password, "password", "", "password for logging into the registry")
+
+	return cmd
+}
+
+// NewRegistryCredentialDeleteCmd returns a new 'epinio registry-credential delete' command
+func NewRegistryCredentialDeleteCmd(client RegistryCredentialService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete NAME",
+		Short: "Deletes registry credentials of the current namespace",
+		Long:  "Deletes the named registry credentials of the current namespace. Credentials used by applications are kept.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.DeleteRegistryCredential(args[0])
+			if err != nil {
+				return errors.Wrap(err, "error deleting registry credential")
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,85 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"errors"
+	"io"
+
+	"github.com/epinio/epinio/internal/cli/cmd"
+	"github.com/epinio/epinio/internal/cli/cmd/cmdfakes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Command 'epinio registry-credential'", func() {
+
+	var (
+		mockRegistryCredentialService *cmdfakes.FakeRegistryCredentialService
+		output, outputErr             io.ReadWriter
+		args                          []string
+	)
+
+	BeforeEach(func() {
+		mockRegistryCredentialService = &cmdfakes.FakeRegistryCredentialService{}
+
+		args = []string{}
+	})
+
+	Context("registry-credential create", func() {
+
+		When("called with a single arg", func() {
+			It("fails", func() {
+				args = append(args, "private")
+
+				createCmd := cmd.NewRegistryCredentialCreateCmd(mockRegistryCredentialService)
+				_, _, runErr := executeCmd(createCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("accepts 2 arg(s), received 1"))
+			})
+		})
+
+		When("called with name, url, and credentials", func() {
+			It("creates the registry credential", func() {
+				args = append(args, "private", "registry.example.com", "--username", "user", "--password", "pass")
+
+				createCmd := cmd.NewRegistryCredentialCreateCmd(mockRegistryCredentialService)
+				_, _, runErr := executeCmd(createCmd, args, output, outputErr)
+				Expect(runErr).ToNot(HaveOccurred())
+
+				Expect(mockRegistryCredentialService.CreateRegistryCredentialCallCount()).To(Equal(1))
+				name, url, username, password := mockRegistryCredentialService.CreateRegistryCredentialArgsForCall(0)
+				Expect(name).To(Equal("private"))
+				Expect(url).To(Equal("registry.example.com"))
+				Expect(username).To(Equal("user"))
+				Expect(password).To(Equal("pass"))
+			})
+		})
+	})
+
+	Context("registry-credential delete", func() {
+
+		When("the delete fails", func() {
+			It("returns an error", func() {
+				args = append(args, "private")
+
+				mockRegistryCredentialService.DeleteRegistryCredentialReturns(errors.New("something bad happened"))
+
+				deleteCmd := cmd.NewRegistryCredentialDeleteCmd(mockRegistryCredentialService)
+				_, _, runErr := executeCmd(deleteCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("error deleting registry credential: something bad happened"))
+			})
+		})
+	})
+})
@@ -14,6 +14,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"github.com/epinio/epinio/internal/cli/termui"
 	"github.com/epinio/epinio/internal/cli/usercmd"
 	"github.com/epinio/epinio/internal/duration"
+	"github.com/epinio/epinio/internal/tracing"
 	"github.com/epinio/epinio/internal/version"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -67,7 +69,11 @@ func NewRootCmd() (*cobra.Command, error) {
 				// Fallback if logger initialization failed - use standard log
 				return errors.Wrap(err, "failed to initialize logger")
 			}
-			err := client.Init(cmd.Context())
+
+			ctx := startTrace(cmd)
+			cmd.SetContext(ctx)
+
+			err := client.Init(ctx)
 			if err != nil {
 				return errors.Wrap(err, "initializing client")
 			}
@@ -165,6 +171,7 @@ func NewRootCmd() (*cobra.Command, error) {
 		cmd.NewLoginCmd(client),
 		cmd.NewLogoutCmd(client),
 		cmd.NewExportRegistriesCmd(client),
+		cmd.NewRegistryCredentialCmd(client, cfg),
 	)
 
 	// Hidden command providing developer tools
@@ -173,6 +180,31 @@ func NewRootCmd() (*cobra.Command, error) {
 	return rootCmd, nil
 }
 
+// startTrace makes a trace of the command, and returns the context holding it. The requests
+// made by the command are part of the trace, which the server continues. The trace is
+// exported to the OTLP/HTTP endpoint set in EPINIO_TRACING_ENDPOINT, if any, when the
+// command finishes. The server sets up its own tracing instead.
+func startTrace(cmd *cobra.Command) context.Context {
+	ctx := cmd.Context()
+	if cmd == CmdServer {
+		return ctx
+	}
+
+	shutdown, err := tracing.Setup(ctx, "epinio-cli", os.Getenv("EPINIO_TRACING_ENDPOINT"))
+	if err != nil {
+		helpers.Logger.Warnw("tracing disabled", "error", err)
+		return ctx
+	}
+
+	ctx, span := tracing.Start(ctx, cmd.CommandPath())
+	cobra.OnFinalize(func() {
+		span.End()
+		_ = shutdown(context.Background())
+	})
+
+	return ctx
+}
+
 // Execute executes the root command.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
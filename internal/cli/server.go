@@ -24,8 +24,15 @@ import (
 	"time"
 
 	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/application"
+	"github.com/epinio/epinio/internal/background"
 	"github.com/epinio/epinio/internal/cli/server"
+	"github.com/epinio/epinio/internal/logarchive"
+	"github.com/epinio/epinio/internal/logdrain"
+	"github.com/epinio/epinio/internal/tracing"
 	"github.com/epinio/epinio/internal/upgraderesponder"
+	"github.com/epinio/epinio/internal/usage"
 	"github.com/epinio/epinio/internal/version"
 	"github.com/gin-gonic/gin"
 
@@ -98,6 +105,96 @@ func init() {
 	err = viper.BindEnv("default-builder-image", "DEFAULT_BUILDER_IMAGE")
 	checkErr(err)
 
+	flags.String("dockerfile-builder-image", "gcr.io/kaniko-project/executor:v1.23.2", "(DOCKERFILE_BUILDER_IMAGE) Name of the container image used to build images from staged sources containing a Dockerfile.")
+	err = viper.BindPFlag("dockerfile-builder-image", flags.Lookup("dockerfile-builder-image"))
+	checkErr(err)
+	err = viper.BindEnv("dockerfile-builder-image", "DOCKERFILE_BUILDER_IMAGE")
+	checkErr(err)
+
+	flags.String("max-cache-size", "", "(MAX_CACHE_SIZE) Maximum size of an application build cache, e.g. 5Gi. A larger cache is cleared before the next staging. Empty: no limit.")
+	err = viper.BindPFlag("max-cache-size", flags.Lookup("max-cache-size"))
+	checkErr(err)
+	err = viper.BindEnv("max-cache-size", "MAX_CACHE_SIZE")
+	checkErr(err)
+
+	flags.Int("staging-concurrency", 0, "(STAGING_CONCURRENCY) Maximum number of concurrent stagings. Further stagings are queued. 0: no limit.")
+	err = viper.BindPFlag("staging-concurrency", flags.Lookup("staging-concurrency"))
+	checkErr(err)
+	err = viper.BindEnv("staging-concurrency", "STAGING_CONCURRENCY")
+	checkErr(err)
+
+	flags.Int("staging-namespace-concurrency", 0, "(STAGING_NAMESPACE_CONCURRENCY) Maximum number of concurrent stagings per namespace. Further stagings are queued. 0: no limit.")
+	err = viper.BindPFlag("staging-namespace-concurrency", flags.Lookup("staging-namespace-concurrency"))
+	checkErr(err)
+	err = viper.BindEnv("staging-namespace-concurrency", "STAGING_NAMESPACE_CONCURRENCY")
+	checkErr(err)
+
+	flags.String("image-scanner", "", "(IMAGE_SCANNER) Name of the trivy container image used to scan staged images for vulnerabilities before deployment. Empty: no scanning.")
+	err = viper.BindPFlag("image-scanner", flags.Lookup("image-scanner"))
+	checkErr(err)
+	err = viper.BindEnv("image-scanner", "IMAGE_SCANNER")
+	checkErr(err)
+
+	flags.String("scan-block-severity", "", "(SCAN_BLOCK_SEVERITY) Lowest severity of image scan findings blocking a deployment, one of UNKNOWN, LOW, MEDIUM, HIGH, CRITICAL. Namespaces may override it. Empty: no blocking.")
+	err = viper.BindPFlag("scan-block-severity", flags.Lookup("scan-block-severity"))
+	checkErr(err)
+	err = viper.BindEnv("scan-block-severity", "SCAN_BLOCK_SEVERITY")
+	checkErr(err)
+
+	flags.Bool("log-archive", false, "(LOG_ARCHIVE) Archive application and staging logs into the S3 storage, to keep them beyond the life of their pods")
+	err = viper.BindPFlag("log-archive", flags.Lookup("log-archive"))
+	checkErr(err)
+	err = viper.BindEnv("log-archive", "LOG_ARCHIVE")
+	checkErr(err)
+
+	flags.Bool("metrics-history", true, "(METRICS_HISTORY) Record the CPU and memory usage of applications every minute, for their metrics history and the usage report")
+	err = viper.BindPFlag("metrics-history", flags.Lookup("metrics-history"))
+	checkErr(err)
+	err = viper.BindEnv("metrics-history", "METRICS_HISTORY")
+	checkErr(err)
+
+	flags.Int("metrics-port", 0, "(METRICS_PORT) Port serving the Prometheus metrics at /metrics, without authentication. Kept apart from the API port, to not expose it through the ingress. 0: no metrics.")
+	err = viper.BindPFlag("metrics-port", flags.Lookup("metrics-port"))
+	checkErr(err)
+	err = viper.BindEnv("metrics-port", "METRICS_PORT")
+	checkErr(err)
+
+	flags.String("tracing-endpoint", "", "(TRACING_ENDPOINT) OTLP/HTTP endpoint to export traces to, e.g. http://otel-collector:4318. Empty: no tracing.")
+	err = viper.BindPFlag("tracing-endpoint", flags.Lookup("tracing-endpoint"))
+	checkErr(err)
+	err = viper.BindEnv("tracing-endpoint", "TRACING_ENDPOINT")
+	checkErr(err)
+
+	flags.String("log-retention", "7d", "(LOG_RETENTION) How long archived logs are kept, e.g. `7d` or `36h`. Namespaces may override it. `0` disables archiving.")
+	err = viper.BindPFlag("log-retention", flags.Lookup("log-retention"))
+	checkErr(err)
+	err = viper.BindEnv("log-retention", "LOG_RETENTION")
+	checkErr(err)
+
+	flags.String("smtp-server", "", "(SMTP_SERVER) SMTP server sending the email notifications of application alerts, as host:port. Empty: no email notifications.")
+	err = viper.BindPFlag("smtp-server", flags.Lookup("smtp-server"))
+	checkErr(err)
+	err = viper.BindEnv("smtp-server", "SMTP_SERVER")
+	checkErr(err)
+
+	flags.String("smtp-from", "epinio@localhost", "(SMTP_FROM) Sender address of the email notifications of application alerts.")
+	err = viper.BindPFlag("smtp-from", flags.Lookup("smtp-from"))
+	checkErr(err)
+	err = viper.BindEnv("smtp-from", "SMTP_FROM")
+	checkErr(err)
+
+	flags.String("smtp-username", "", "(SMTP_USERNAME) User to authenticate as at the SMTP server. Empty: no authentication.")
+	err = viper.BindPFlag("smtp-username", flags.Lookup("smtp-username"))
+	checkErr(err)
+	err = viper.BindEnv("smtp-username", "SMTP_USERNAME")
+	checkErr(err)
+
+	flags.String("smtp-password", "", "(SMTP_PASSWORD) Password to authenticate with at the SMTP server.")
+	err = viper.BindPFlag("smtp-password", flags.Lookup("smtp-password"))
+	checkErr(err)
+	err = viper.BindEnv("smtp-password", "SMTP_PASSWORD")
+	checkErr(err)
+
 	flags.Bool("disable-tracking", false, "(DISABLE_TRACKING) Disable tracking of the running Epinio and Kubernetes versions")
 	err = viper.BindPFlag("disable-tracking", flags.Lookup("disable-tracking"))
 	checkErr(err)
@@ -142,11 +239,39 @@ var CmdServer = &cobra.Command{
 			}
 		}
 
+		// Export the traces of the requests, if asked for.
+		if endpoint := viper.GetString("tracing-endpoint"); endpoint != "" {
+			shutdown, err := tracing.Setup(context.Background(), "epinio-server", endpoint)
+			if err != nil {
+				return errors.Wrap(err, "error setting up tracing")
+			}
+			defer func() {
+				_ = shutdown(context.Background())
+			}()
+		}
+
 		handler, err := server.NewHandler()
 		if err != nil {
 			return errors.Wrap(err, "error creating handler")
 		}
 
+		// Serve the metrics apart from the API, if asked for.
+		if metricsPort := viper.GetInt("metrics-port"); metricsPort != 0 {
+			metricsListener, err := net.Listen("tcp", fmt.Sprintf(":%d", metricsPort))
+			if err != nil {
+				return errors.Wrap(err, "error creating metrics listener")
+			}
+			metricsServer := server.NewMetricsServer()
+			go func() {
+				if err := metricsServer.Serve(metricsListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					helpers.Logger.Errorw("metrics server listen error", "error", err)
+				}
+			}()
+			defer func() {
+				_ = metricsServer.Close()
+			}()
+		}
+
 		port := viper.GetInt("port")
 		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 		if err != nil {
@@ -182,6 +307,68 @@ var CmdServer = &cobra.Command{
 			defer checker.Stop()
 		}
 
+		// Start the stagings queued for a free slot, including those queued before a restart.
+		// Each replica holds the stagings submitted to it, the limits hold across replicas, see
+		// application.Staging.
+		if err := application.RestoreStagingQueue(context.Background()); err != nil {
+			helpers.Logger.Errorw("error restoring the staging queue", "error", err)
+		}
+		dispatcher := application.NewStagingDispatcher()
+		dispatcher.Start()
+		defer dispatcher.Stop()
+
+		ctx := context.Background()
+		cluster, err := kubernetes.GetCluster(ctx)
+		if err != nil {
+			return errors.Wrap(err, "error accessing the cluster for the background workers")
+		}
+
+		var archiveStore logarchive.Store
+		if logarchive.Enabled() {
+			archiveStore, err = logarchive.NewStore(ctx, cluster)
+			if err != nil {
+				return errors.Wrap(err, "error creating the log archive storage")
+			}
+		}
+
+		// The workers spanning the cluster run on the replica holding the lease only.
+		electionCtx, stopElection := context.WithCancel(ctx)
+		elected := make(chan struct{})
+		go func() {
+			defer close(elected)
+			err := background.Elected(electionCtx, cluster, func() []background.Service {
+				services := []background.Service{
+					// Check the git origins of the applications asking for it.
+					application.NewGitPoller(),
+					// Check the image tags of the applications asking for it.
+					application.NewImageWatcher(),
+					// Forward the logs of the applications to their drains.
+					logdrain.NewManager(),
+					// Check the alerts of the applications, and notify their endpoints.
+					application.NewAlertEvaluator(),
+				}
+
+				// Record the usage of the applications, if asked for.
+				if usage.Enabled() {
+					services = append(services, usage.NewRecorder(usage.History))
+				}
+
+				// Archive the logs of the applications and stagings, if asked for.
+				if archiveStore != nil {
+					services = append(services, logarchive.NewArchiver(archiveStore))
+				}
+
+				return services
+			})
+			if err != nil {
+				helpers.Logger.Errorw("error running the leader election", "error", err)
+			}
+		}()
+		defer func() {
+			stopElection()
+			<-elected
+		}()
+
 		return startServerGracefully(listener, handler)
 	},
 }
@@ -18,6 +18,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
@@ -26,6 +27,7 @@ import (
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/auth"
 	"github.com/epinio/epinio/internal/domain"
+	"github.com/epinio/epinio/internal/metrics"
 	apierrors "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/pkg/errors"
 
@@ -46,6 +48,7 @@ func NewHandler() (*gin.Engine, error) {
 	// | ---               | ---        | ----
 	// | <Root>/...        | API        | Via "<Root>" Group
 	// | /ready            | L/R Probes |
+	// | <Root>/webhooks/git/... | Git push webhooks, verified by signature | Yes
 	// | /namespaces/target/:namespace | ditto      | ditto
 
 	// Use gin.New() instead of gin.Default() to avoid gin's default logger
@@ -93,6 +96,8 @@ func NewHandler() (*gin.Engine, error) {
 
 	// Add common middlewares to all the routes declared after
 	router.Use(
+		middleware.Metrics(),
+		middleware.Tracing(),
 		middleware.GinLogger(),
 		middleware.Recovery,
 		middleware.InitContext(),
@@ -104,6 +109,11 @@ func NewHandler() (*gin.Engine, error) {
 		apiv1.ErrorHandler(apiv1.Info),
 	)
 
+	// No authentication, no session. Git push webhooks are verified by their signature.
+	router.Handle(apiv1.GitWebhook.Method, apiv1.Root+apiv1.GitWebhook.Path,
+		apiv1.GitWebhook.Handler,
+	)
+
 	// authenticated /me endpoint returns the current user (no other checks/middlewares needed)
 	router.GET("/api/v1/me",
 		middleware.Authentication,
@@ -134,6 +144,7 @@ func NewHandler() (*gin.Engine, error) {
 			middleware.NamespaceExists,
 			middleware.RoleAuthorization,
 			middleware.NamespaceAuthorization,
+			middleware.AppActions(),
 			// gitconfig authorization is enforced in the handlers (read allows
 			// global, delete is owner/admin only), which the middleware cannot
 			// express without loading the resource.
@@ -178,6 +189,18 @@ func NewHandler() (*gin.Engine, error) {
 	return router, nil
 }
 
+// NewMetricsServer returns the server of the Prometheus metrics at /metrics, without
+// authentication. It listens apart from the API, on a port not exposed through the ingress.
+func NewMetricsServer() *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	return &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second, // Prevent Slowloris attack
+	}
+}
+
 func swaggerHandler(c *gin.Context) {
 	swaggerFile, err := os.Open("swagger.json")
 	if err != nil {
@@ -168,6 +168,8 @@ func (c *EpinioClient) Apps(all bool) error {
 			routes = "n/a"
 
 			switch app.StagingStatus {
+			case models.ApplicationStagingQueued:
+				statusDetails = fmt.Sprintf("staging queued (%d)", app.QueuePosition)
 			case models.ApplicationStagingActive:
 				statusDetails = "staging"
 			case models.ApplicationStagingDone:
@@ -180,6 +182,10 @@ func (c *EpinioClient) Apps(all bool) error {
 			case models.ApplicationStagingFailed:
 				statusDetails = "staging failed"
 			}
+
+			if app.Status == models.ApplicationWaiting {
+				statusDetails = app.StatusMessage
+			}
 		} else {
 			status = app.Workload.Status
 			routes = formatRoutes(app.Workload.Routes)
@@ -320,6 +326,13 @@ func (c *EpinioClient) AppExport(appName string, toRegistry bool, param models.A
 		return err
 	}
 
+	if param.SBOM {
+		err = c.getPartAndWriteFile(appName, "sbom", filepath.Join(directory, "sbom.json"))
+		if err != nil {
+			return err
+		}
+	}
+
 	c.ui.Success().Msg("Ok")
 	return nil
 }
@@ -358,6 +371,56 @@ func (c *EpinioClient) getPartAndWriteFile(appName, part, destinationPath string
 	return err
 }
 
+// AppSupportBundle downloads the support bundle of the named app, in the targeted namespace,
+// into the destination file
+func (c *EpinioClient) AppSupportBundle(appName, destination string) error {
+	log := c.Log.WithName("AppSupportBundle").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		WithStringValue("File", destination).
+		Msg("Downloading application support bundle")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	bundle, err := c.API.NamespaceSupportBundle(c.Settings.Namespace, []string{appName})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := bundle.Data.Close(); err != nil {
+			c.Log.Error(err, "failed to close support bundle response")
+		}
+	}()
+
+	out, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := out.Close(); err != nil {
+			c.Log.Error(err, "failed to close file")
+		}
+	}()
+
+	bar := progressbar.DefaultBytes(bundle.ContentLength, "Downloading")
+
+	_, err = io.Copy(io.MultiWriter(out, bar), bundle.Data)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().WithStringValue("File", destination).Msg("Support bundle saved")
+	return nil
+}
+
 // AppManifest saves the information of the named app, in the targeted namespace, into a manifest file
 func (c *EpinioClient) AppManifest(appName, manifestPath string) error {
 	log := c.Log.WithName("Apps").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
@@ -661,8 +724,11 @@ func (c *EpinioClient) printAppDetails(app models.App) error {
 		if err != nil {
 			return err
 		}
-		msg = msg.WithTableRow("Status", app.Workload.Status).
-			WithTableRow("Username", app.Workload.Username).
+		msg = msg.WithTableRow("Status", app.Workload.Status)
+		if app.Status == models.ApplicationError {
+			msg = msg.WithTableRow("Problem", app.StatusMessage)
+		}
+		msg = msg.WithTableRow("Username", app.Workload.Username).
 			WithTableRow("Running StageId", app.Workload.StageID).
 			WithTableRow("Last StageId", app.StageID).
 			WithTableRow("Age", time.Since(createdAt).Round(time.Second).String()).
@@ -680,6 +746,8 @@ func (c *EpinioClient) printAppDetails(app models.App) error {
 			msg = msg.WithTableRow("Status", "not deployed")
 		} else {
 			switch app.StagingStatus {
+			case models.ApplicationStagingQueued:
+				msg = msg.WithTableRow("Status", fmt.Sprintf("not deployed, staging queued at position %d", app.QueuePosition))
 			case models.ApplicationStagingActive:
 				msg = msg.WithTableRow("Status", "not deployed, staging active")
 			case models.ApplicationStagingDone:
@@ -695,6 +763,10 @@ func (c *EpinioClient) printAppDetails(app models.App) error {
 			msg = msg.WithTableRow("Last StageId", app.StageID)
 		}
 
+		if app.Status == models.ApplicationWaiting || app.Status == models.ApplicationError {
+			msg = msg.WithTableRow("Status", app.StatusMessage)
+		}
+
 		if len(app.Configuration.Routes) > 0 {
 			msg = msg.WithTableRow("Desired Routes", "")
 			for _, route := range app.Configuration.Routes {
@@ -708,7 +780,70 @@ func (c *EpinioClient) printAppDetails(app models.App) error {
 	msg = msg.
 		WithTableRow("App Chart", app.Configuration.AppChart).
 		WithTableRow("Builder Image", app.Staging.Builder).
+		WithTableRow("Build Mode", app.Staging.Mode).
+		WithTableRow("Registry Credential", valueOrNone(app.Configuration.RegistryCredential)).
+		WithTableRow("Image Digest", valueOrNone(imageDigest(app))).
+		WithTableRow("Build Cache", buildCacheSummary(app.BuildCache)).
 		WithTableRow("Desired Instances", fmt.Sprintf("%d", *app.Configuration.Instances)).
+		WithTableRow("CPU Request/Limit", resourceRange(app.Configuration.Resources, "cpu")).
+		WithTableRow("Memory Request/Limit", resourceRange(app.Configuration.Resources, "memory")).
+		WithTableRow("Health Check", healthcheckSummary(app.Configuration.Healthcheck)).
+		WithTableRow("Depends On", dependenciesSummary(app.Configuration.DependsOn)).
+		WithTableRow("Sidecars", containersSummary(app.Configuration.Sidecars)).
+		WithTableRow("Init Containers", containersSummary(app.Configuration.InitContainers)).
+		WithTableRow("Git Polling", gitPollSummary(app.GitPoll))
+
+	if app.GitPoll != nil {
+		msg = msg.
+			WithTableRow(" - Last Checked", valueOrNone(app.GitPoll.LastChecked)).
+			WithTableRow(" - Last Commit", valueOrNone(shortRevision(app.GitPoll.LastCommit)))
+		if app.GitPoll.Failures > 0 {
+			msg = msg.WithTableRow(" - Last Error", fmt.Sprintf("%s (%d consecutive failures)",
+				app.GitPoll.LastError, app.GitPoll.Failures))
+		}
+	}
+
+	msg = msg.WithTableRow("Image Watch", imageWatchSummary(app.ImageWatch))
+	if app.ImageWatch != nil {
+		msg = msg.
+			WithTableRow(" - Last Checked", valueOrNone(app.ImageWatch.LastChecked)).
+			WithTableRow(" - Latest Digest", valueOrNone(app.ImageWatch.LatestDigest))
+		if app.ImageWatch.Failures > 0 {
+			msg = msg.WithTableRow(" - Last Error", fmt.Sprintf("%s (%d consecutive failures)",
+				app.ImageWatch.LastError, app.ImageWatch.Failures))
+		}
+	}
+
+	if len(app.LogDrains) > 0 {
+		msg = msg.WithTableRow("Log Drains", "")
+		for _, drain := range app.LogDrains {
+			msg = msg.WithTableRow(" - "+drain.Name, logDrainSummary(drain))
+		}
+	} else {
+		msg = msg.WithTableRow("Log Drains", "<<none>>")
+	}
+
+	if len(app.Alerts) > 0 {
+		msg = msg.WithTableRow("Alerts", "")
+		for _, alert := range app.Alerts {
+			msg = msg.WithTableRow(" - "+alert.Name, alertSummary(alert))
+		}
+	} else {
+		msg = msg.WithTableRow("Alerts", "<<none>>")
+	}
+
+	if len(app.Lineage) > 0 {
+		msg = msg.WithTableRow("Promoted From", "")
+		for _, step := range app.Lineage {
+			promotion := fmt.Sprintf("stage %s, %s", step.StageID, step.PromotedAt)
+			if step.PromotedBy != "" {
+				promotion += " by " + step.PromotedBy
+			}
+			msg = msg.WithTableRow(" - "+step.Namespace+"/"+step.App, promotion)
+		}
+	}
+
+	msg = msg.
 		WithTableRow("Bound Configurations", strings.Join(app.Configuration.Configurations, ", ")).
 		WithTableRow("User Environment", "")
 
@@ -745,6 +880,145 @@ func (c *EpinioClient) printAppDetails(app models.App) error {
 	return nil
 }
 
+// containersSummary formats the names and images of additional containers for display.
+func containersSummary(containers []models.AppContainer) string {
+	if len(containers) == 0 {
+		return "<<none>>"
+	}
+
+	parts := []string{}
+	for _, container := range containers {
+		parts = append(parts, fmt.Sprintf("%s (%s)", container.Name, container.Image))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// buildCacheSummary formats the size and usage of the build cache for display.
+func buildCacheSummary(cache *models.AppBuildCache) string {
+	if cache == nil {
+		return "<<none>>"
+	}
+	if cache.MeasuredAt == "" {
+		return fmt.Sprintf("%s, usage not measured", cache.Size)
+	}
+	return fmt.Sprintf("%s used of %s (measured %s)",
+		bytes.ByteCountIEC(cache.UsageBytes), cache.Size, cache.MeasuredAt)
+}
+
+// gitPollSummary formats the polling of the git origin for display.
+func gitPollSummary(poll *models.GitPollStatus) string {
+	if poll == nil {
+		return "<<none>>"
+	}
+	return fmt.Sprintf("every %s, next check %s", poll.Interval, valueOrNone(poll.NextCheck))
+}
+
+// imageWatchSummary formats the watch of the container image tag for display.
+func imageWatchSummary(watch *models.ImageWatchStatus) string {
+	if watch == nil {
+		return "<<none>>"
+	}
+	return fmt.Sprintf("%s every %s, next check %s", watch.Mode, watch.Interval, valueOrNone(watch.NextCheck))
+}
+
+// logDrainSummary formats the destination and health of a log drain for display.
+func logDrainSummary(drain models.LogDrainStatus) string {
+	summary := fmt.Sprintf("%s, %s, %d sent, %d dropped", drain.URL, drain.Health, drain.Sent, drain.Dropped)
+	if drain.Failures > 0 {
+		summary += fmt.Sprintf(", %s (%d consecutive failures)", drain.LastError, drain.Failures)
+	}
+	return summary
+}
+
+// alertSummary formats the kind, threshold and state of an alert for display.
+func alertSummary(alert models.AppAlertStatus) string {
+	summary := fmt.Sprintf("%s (%d), %s", alert.Kind, alert.EffectiveThreshold(), alert.State)
+	if alert.State != models.AlertOK && alert.Message != "" {
+		summary += ": " + alert.Message
+	}
+	return summary
+}
+
+// imageDigest returns the digest the deployed container image is pinned to, if any.
+func imageDigest(app models.App) string {
+	if app.Workload == nil {
+		return ""
+	}
+	return app.Workload.ImageDigest
+}
+
+// valueOrNone returns the value, or the marker for a missing value.
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<<none>>"
+	}
+	return value
+}
+
+// dependenciesSummary formats the dependencies of an application for display.
+func dependenciesSummary(dependencies []models.AppDependency) string {
+	if len(dependencies) == 0 {
+		return "<<none>>"
+	}
+
+	parts := []string{}
+	for _, dependency := range dependencies {
+		parts = append(parts, dependency.String())
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// healthcheckSummary formats the health check configuration for display.
+func healthcheckSummary(healthcheck *models.AppHealthcheck) string {
+	if healthcheck == nil || healthcheck.Type == "" {
+		return "<<default>>"
+	}
+
+	parts := []string{healthcheck.Type}
+	if healthcheck.Path != "" {
+		parts = append(parts, "path "+healthcheck.Path)
+	}
+	for _, field := range []struct {
+		name  string
+		value int32
+	}{
+		{"port", healthcheck.Port},
+		{"timeout", healthcheck.TimeoutSeconds},
+		{"period", healthcheck.PeriodSeconds},
+		{"success threshold", healthcheck.SuccessThreshold},
+		{"failure threshold", healthcheck.FailureThreshold},
+		{"start-up grace", healthcheck.StartupGraceSeconds},
+	} {
+		if field.value != 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", field.name, field.value))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// resourceRange formats the request and limit of the named resource for display.
+func resourceRange(resources *models.AppResources, kind string) string {
+	if resources == nil {
+		return "<<none>>/<<none>>"
+	}
+
+	request, limit := resources.Requests.CPU, resources.Limits.CPU
+	if kind == "memory" {
+		request, limit = resources.Requests.Memory, resources.Limits.Memory
+	}
+	if request == "" {
+		request = "<<none>>"
+	}
+	if limit == "" {
+		limit = "<<none>>"
+	}
+
+	return request + "/" + limit
+}
+
 func (c *EpinioClient) metricsOk(app *models.AppDeployment) bool {
 	if len(app.Replicas) == 0 {
 		return true
@@ -765,7 +1039,7 @@ func (c *EpinioClient) printReplicaDetails(app models.App) error {
 	}
 
 	if len(app.Workload.Replicas) > 0 {
-		msg := c.ui.Success().WithTable("Name", "Ready", "Memory", "MilliCPUs", "Restarts", "Age")
+		msg := c.ui.Success().WithTable("Name", "Ready", "Memory", "MilliCPUs", "Restarts", "Age", "Message")
 		for _, r := range app.Workload.Replicas {
 			createdAt, err := time.Parse(time.RFC3339, r.CreatedAt)
 			if err != nil {
@@ -786,16 +1060,61 @@ func (c *EpinioClient) printReplicaDetails(app models.App) error {
 				millis,
 				strconv.Itoa(int(r.Restarts)),
 				time.Since(createdAt).Round(time.Second).String(),
+				r.Message,
 			)
 		}
 		msg.Msg("Instances: ")
 	}
 
+	c.printReplicaDiagnostics(app.Workload.Replicas)
+
 	return nil
 }
 
+// printReplicaDiagnostics shows why the replicas are failing, or failed before, if they are.
+func (c *EpinioClient) printReplicaDiagnostics(replicas map[string]*models.PodInfo) {
+	names := []string{}
+	for name, replica := range replicas {
+		if replica.Diagnostics != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		diagnostics := replicas[name].Diagnostics
+
+		msg := c.ui.Exclamation().WithTable("Key", "Value").
+			WithTableRow("Problem", diagnostics.Summary())
+		if diagnostics.PendingReason != "" {
+			msg = msg.WithTableRow("Scheduling", diagnostics.PendingReason)
+		}
+		if diagnostics.ImagePullError != "" {
+			msg = msg.WithTableRow("Image Pull", diagnostics.ImagePullError)
+		}
+		if termination := diagnostics.LastTermination; termination != nil {
+			msg = msg.WithTableRow("Last Termination", fmt.Sprintf("%s, exit code %d", termination.Reason, termination.ExitCode)).
+				WithTableRow("Terminated At", termination.FinishedAt)
+			if termination.Message != "" {
+				msg = msg.WithTableRow("Termination Message", termination.Message)
+			}
+		}
+		if diagnostics.OOMKilled {
+			msg = msg.WithTableRow("Out Of Memory", "true")
+		}
+		msg.Msgf("Diagnostics of %s:", name)
+
+		if len(diagnostics.PreviousLogs) > 0 {
+			c.ui.Normal().Msgf("Last logs of the terminated container of %s:", name)
+			for _, line := range diagnostics.PreviousLogs {
+				c.ui.Normal().Compact().Msg("  " + line)
+			}
+		}
+	}
+}
+
 // AppRestage restage an application
-func (c *EpinioClient) AppRestage(appName string, restart bool) error {
+func (c *EpinioClient) AppRestage(appName string, restart, clearCache bool) error {
 	log := c.Log.WithName("AppRestage").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
 	log.Info("start")
 	defer log.Info("return")
@@ -806,7 +1125,7 @@ func (c *EpinioClient) AppRestage(appName string, restart bool) error {
 	}
 	if app.Workload == nil {
 		// No workload
-		if app.StagingStatus == models.ApplicationStagingActive {
+		if app.StagingStatus == models.ApplicationStagingActive || app.StagingStatus == models.ApplicationStagingQueued {
 			// Somebody already initiated staging.
 			c.ui.Exclamation().Msg("Attention: Application is already staging")
 			return nil
@@ -837,7 +1156,7 @@ func (c *EpinioClient) AppRestage(appName string, restart bool) error {
 		return nil
 	}
 
-	req := models.StageRequest{App: app.Meta}
+	req := models.StageRequest{App: app.Meta, ClearCache: clearCache}
 	stageResponse, err := c.API.AppStage(req)
 	if err != nil {
 		return err
@@ -846,6 +1165,10 @@ func (c *EpinioClient) AppRestage(appName string, restart bool) error {
 	log.V(3).Info("stage response", "response", stageResponse)
 	stageID := stageResponse.Stage.ID
 
+	if stageResponse.QueuePosition > 0 {
+		c.ui.Note().Msgf("Staging is queued at position %d", stageResponse.QueuePosition)
+	}
+
 	log.V(1).Info("start tailing logs", "StageID", stageID)
 	c.stageLogs(app.Meta, stageID)
 
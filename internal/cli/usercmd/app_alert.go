@@ -0,0 +1,141 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// AppAlertList lists the alerts of the named app, in the targeted namespace, with their state
+func (c *EpinioClient) AppAlertList(appName string) error {
+	log := c.Log.WithName("AppAlertList").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Listing alerts...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	appAlerts, err := c.API.AppAlertList(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	return c.printAlerts(appAlerts)
+}
+
+// AppAlertAdd adds an alert to the named app, in the targeted namespace
+func (c *EpinioClient) AppAlertAdd(appName, alertName, kind string, threshold int64, notify []string) error {
+	log := c.Log.WithName("AppAlertAdd").WithValues("Namespace", c.Settings.Namespace, "Application", appName, "Alert", alertName)
+	log.Info("start")
+	defer log.Info("return")
+
+	alert := models.AppAlert{Name: alertName, Kind: kind, Threshold: threshold, Notify: notify}
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		WithStringValue("Alert", alertName).
+		WithStringValue("Kind", kind).
+		WithStringValue("Threshold", fmt.Sprintf("%d", alert.EffectiveThreshold())).
+		WithStringValue("Notify", redactedEndpoints(notify)).
+		Msg("Adding alert...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	_, err := c.API.AppAlertAdd(c.Settings.Namespace, appName, models.AppAlertAddRequest{
+		Name:      alertName,
+		Kind:      kind,
+		Threshold: threshold,
+		Notify:    notify,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Alert added.")
+
+	return nil
+}
+
+// AppAlertRemove removes the named alert from the named app, in the targeted namespace
+func (c *EpinioClient) AppAlertRemove(appName, alertName string) error {
+	log := c.Log.WithName("AppAlertRemove").WithValues("Namespace", c.Settings.Namespace, "Application", appName, "Alert", alertName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		WithStringValue("Alert", alertName).
+		Msg("Removing alert...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	_, err := c.API.AppAlertRemove(c.Settings.Namespace, appName, alertName)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Alert removed.")
+
+	return nil
+}
+
+func (c *EpinioClient) printAlerts(appAlerts models.AppAlertsResponse) error {
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(appAlerts)
+	}
+
+	if len(appAlerts) == 0 {
+		c.ui.Exclamation().Msg("No alerts")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Name", "Kind", "Threshold", "Notify", "State", "Since", "Message", "Last Error")
+	for _, alert := range appAlerts {
+		msg = msg.WithTableRow(
+			alert.Name,
+			alert.Kind,
+			fmt.Sprintf("%d", alert.EffectiveThreshold()),
+			strings.Join(alert.Notify, ", "),
+			alert.State,
+			alert.Since,
+			alert.Message,
+			alert.LastError,
+		)
+	}
+	msg.Msg("Alerts:")
+
+	return nil
+}
+
+// redactedEndpoints returns the endpoints, with their credentials masked
+func redactedEndpoints(endpoints []string) string {
+	redacted := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		redacted = append(redacted, models.RedactedEndpoint(endpoint))
+	}
+	return strings.Join(redacted, ", ")
+}
@@ -0,0 +1,88 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// AppEvents shows the events timeline of the named application, and, when following, the
+// new events as they appear
+func (c *EpinioClient) AppEvents(ctx context.Context, appName string, follow bool) error {
+	log := c.Log.WithName("AppEvents").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	if !c.ui.JSONEnabled() {
+		c.ui.Note().
+			WithStringValue("Namespace", c.Settings.Namespace).
+			WithStringValue("Application", appName).
+			Msg("Showing application events...")
+	}
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	if follow {
+		return c.API.AppEventsStream(ctx, c.Settings.Namespace, appName, func(event models.AppEvent) error {
+			if c.ui.JSONEnabled() {
+				return c.ui.JSON(event)
+			}
+			c.ui.Normal().Compact().Msg(formatAppEvent(event))
+			return nil
+		})
+	}
+
+	timeline, err := c.API.AppEvents(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(timeline)
+	}
+
+	if len(timeline.Events) == 0 {
+		c.ui.Exclamation().Msg("No events found")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Time", "Source", "Type", "Reason", "Object", "Message")
+	for _, event := range timeline.Events {
+		message := event.Message
+		if event.Count > 1 {
+			message = fmt.Sprintf("%s (x%s)", message, strconv.Itoa(int(event.Count)))
+		}
+		msg = msg.WithTableRow(event.Time, event.Source, event.Type, event.Reason, event.Object, message)
+	}
+	msg.Msg("Events:")
+
+	return nil
+}
+
+// formatAppEvent renders an event as a single line, for following a timeline.
+func formatAppEvent(event models.AppEvent) string {
+	line := fmt.Sprintf("%s  %-10s  %-7s  %s", event.Time, event.Source, event.Type, event.Reason)
+	if event.Object != "" {
+		line = fmt.Sprintf("%s  %s", line, event.Object)
+	}
+	line = fmt.Sprintf("%s  %s", line, event.Message)
+	if event.Count > 1 {
+		line = fmt.Sprintf("%s (x%d)", line, event.Count)
+	}
+	return line
+}
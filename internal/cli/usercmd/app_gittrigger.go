@@ -0,0 +1,142 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"strings"
+
+	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// AppGitTriggerEnable enables push-to-deploy for the named app, in the targeted namespace,
+// and shows the webhook url and secret to configure at the git provider
+func (c *EpinioClient) AppGitTriggerEnable(appName string) error {
+	log := c.Log.WithName("AppGitTriggerEnable").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Enabling git trigger...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	trigger, err := c.API.AppGitTriggerEnable(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().
+		WithStringValue("Webhook URL", c.gitWebhookURL(trigger)).
+		WithStringValue("Secret", trigger.Secret).
+		Msg("Git trigger enabled. The secret is not shown again.")
+
+	return nil
+}
+
+// AppGitTriggerDisable disables push-to-deploy for the named app, in the targeted namespace
+func (c *EpinioClient) AppGitTriggerDisable(appName string) error {
+	log := c.Log.WithName("AppGitTriggerDisable").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Disabling git trigger...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	_, err := c.API.AppGitTriggerDisable(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Git trigger disabled.")
+
+	return nil
+}
+
+// AppGitTriggerShow shows the push-to-deploy configuration of the named app, in the targeted
+// namespace, and its recent webhook deliveries
+func (c *EpinioClient) AppGitTriggerShow(appName string) error {
+	log := c.Log.WithName("AppGitTriggerShow").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Showing git trigger...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	trigger, err := c.API.AppGitTriggerShow(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	if !trigger.Enabled {
+		c.ui.Exclamation().Msg("Git trigger is not enabled")
+		return nil
+	}
+
+	c.ui.Success().WithTable("Key", "Value").
+		WithTableRow("Webhook URL", c.gitWebhookURL(trigger)).
+		WithTableRow("Deploys As", trigger.Username).
+		Msg("Details:")
+
+	if len(trigger.Deliveries) == 0 {
+		c.ui.Exclamation().Msg("No deliveries received")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Received", "ID", "Provider", "Event", "Branch", "Revision", "Result", "Details")
+	for _, delivery := range trigger.Deliveries {
+		details := delivery.Message
+		if delivery.DeploymentID != "" {
+			details = "deployment " + delivery.DeploymentID
+		}
+		msg = msg.WithTableRow(
+			delivery.ReceivedAt,
+			delivery.ID,
+			string(delivery.Provider),
+			delivery.Event,
+			delivery.Branch,
+			shortRevision(delivery.Revision),
+			delivery.Result,
+			details,
+		)
+	}
+	msg.Msg("Recent deliveries:")
+
+	return nil
+}
+
+func (c *EpinioClient) gitWebhookURL(trigger models.GitTrigger) string {
+	return strings.TrimSuffix(c.Settings.API, "/") + api.Root + trigger.WebhookPath
+}
+
+func shortRevision(revision string) string {
+	if len(revision) > 12 {
+		return revision[:12]
+	}
+	return revision
+}
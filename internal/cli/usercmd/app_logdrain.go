@@ -0,0 +1,128 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// AppLogDrainList lists the log drains of the named app, in the targeted namespace, and of
+// the namespace, with their health
+func (c *EpinioClient) AppLogDrainList(appName string) error {
+	log := c.Log.WithName("AppLogDrainList").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Listing log drains...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	drains, err := c.API.AppLogDrainList(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	return c.printLogDrains(drains)
+}
+
+// AppLogDrainAdd adds a log drain to the named app, in the targeted namespace
+func (c *EpinioClient) AppLogDrainAdd(appName, drainName, drainURL string) error {
+	log := c.Log.WithName("AppLogDrainAdd").WithValues("Namespace", c.Settings.Namespace, "Application", appName, "Drain", drainName)
+	log.Info("start")
+	defer log.Info("return")
+
+	drain := models.LogDrain{Name: drainName, URL: drainURL}
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		WithStringValue("Drain", drainName).
+		WithStringValue("URL", drain.RedactedURL()).
+		Msg("Adding log drain...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	_, err := c.API.AppLogDrainAdd(c.Settings.Namespace, appName, models.LogDrainAddRequest{
+		Name: drainName,
+		URL:  drainURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Log drain added.")
+
+	return nil
+}
+
+// AppLogDrainRemove removes the named log drain from the named app, in the targeted namespace
+func (c *EpinioClient) AppLogDrainRemove(appName, drainName string) error {
+	log := c.Log.WithName("AppLogDrainRemove").WithValues("Namespace", c.Settings.Namespace, "Application", appName, "Drain", drainName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		WithStringValue("Drain", drainName).
+		Msg("Removing log drain...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	_, err := c.API.AppLogDrainRemove(c.Settings.Namespace, appName, drainName)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Log drain removed.")
+
+	return nil
+}
+
+func (c *EpinioClient) printLogDrains(drains models.LogDrainsResponse) error {
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(drains)
+	}
+
+	if len(drains) == 0 {
+		c.ui.Exclamation().Msg("No log drains")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Name", "Scope", "URL", "Health", "Sent", "Dropped", "Last Sent", "Last Error")
+	for _, drain := range drains {
+		msg = msg.WithTableRow(
+			drain.Name,
+			drain.Scope,
+			drain.URL,
+			drain.Health,
+			fmt.Sprintf("%d", drain.Sent),
+			fmt.Sprintf("%d", drain.Dropped),
+			drain.LastSent,
+			drain.LastError,
+		)
+	}
+	msg.Msg("Log drains:")
+
+	return nil
+}
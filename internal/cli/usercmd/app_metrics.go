@@ -0,0 +1,132 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/bytes"
+)
+
+// sparkWidth is the largest number of columns of a sparkline.
+const sparkWidth = 60
+
+// sparkBars are the levels of a sparkline, lowest first.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// AppMetrics shows the CPU and memory usage history of the named application
+func (c *EpinioClient) AppMetrics(appName, span string) error {
+	log := c.Log.WithName("AppMetrics").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Showing application metrics...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	history, err := c.API.AppMetrics(c.Settings.Namespace, appName, span)
+	if err != nil {
+		return err
+	}
+
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(history)
+	}
+
+	if len(history.Samples) == 0 {
+		c.ui.Exclamation().Msg("No metrics recorded")
+		return nil
+	}
+
+	cpu := make([]float64, len(history.Samples))
+	memory := make([]float64, len(history.Samples))
+	instances := make([]float64, len(history.Samples))
+	for idx, sample := range history.Samples {
+		cpu[idx] = float64(sample.MilliCPUs)
+		memory[idx] = float64(sample.MemoryBytes)
+		instances[idx] = float64(sample.Instances)
+	}
+
+	milliCPUs := func(value float64) string { return strconv.FormatInt(int64(value), 10) + "m" }
+	memoryBytes := func(value float64) string { return bytes.ByteCountIEC(int64(value)) }
+	count := func(value float64) string { return strconv.FormatInt(int64(value), 10) }
+
+	msg := c.ui.Success().WithTable("Metric", "History", "Min", "Avg", "Max", "Last")
+	msg = msg.WithTableRow(metricsRow("CPU", cpu, milliCPUs)...)
+	msg = msg.WithTableRow(metricsRow("Memory", memory, memoryBytes)...)
+	msg = msg.WithTableRow(metricsRow("Instances", instances, count)...)
+	msg.Msgf("Last %s, %s steps, from %s to %s:", history.Range, history.Step,
+		history.Samples[0].Time, history.Samples[len(history.Samples)-1].Time)
+
+	return nil
+}
+
+// metricsRow returns the table row of a metric, with its sparkline and statistics.
+func metricsRow(name string, values []float64, format func(float64) string) []string {
+	low, high, sum := math.Inf(1), math.Inf(-1), 0.0
+	for _, value := range values {
+		low = math.Min(low, value)
+		high = math.Max(high, value)
+		sum += value
+	}
+
+	return []string{
+		name,
+		sparkline(values, sparkWidth),
+		format(low),
+		format(sum / float64(len(values))),
+		format(high),
+		format(values[len(values)-1]),
+	}
+}
+
+// sparkline renders the values as a line of bars scaled between their minimum and maximum.
+// More values than columns are averaged into groups.
+func sparkline(values []float64, width int) string {
+	if len(values) > width {
+		grouped := make([]float64, width)
+		for column := range grouped {
+			start := column * len(values) / width
+			end := (column + 1) * len(values) / width
+
+			sum := 0.0
+			for _, value := range values[start:end] {
+				sum += value
+			}
+			grouped[column] = sum / float64(end-start)
+		}
+		values = grouped
+	}
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		low = math.Min(low, value)
+		high = math.Max(high, value)
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if high > low {
+			level = int(math.Round((value - low) / (high - low) * float64(len(sparkBars)-1)))
+		}
+		line.WriteRune(sparkBars[level])
+	}
+	return line.String()
+}
@@ -0,0 +1,42 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"unicode/utf8"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sparkline", func() {
+	It("scales the values between their minimum and maximum", func() {
+		Expect(sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}, 60)).To(Equal("▁▂▃▄▅▆▇█"))
+		Expect(sparkline([]float64{10, 80, 10}, 60)).To(Equal("▁█▁"))
+	})
+
+	It("renders constant values as the lowest bar", func() {
+		Expect(sparkline([]float64{3, 3, 3}, 60)).To(Equal("▁▁▁"))
+	})
+
+	It("averages more values than columns into groups", func() {
+		values := make([]float64, 240)
+		for idx := range values {
+			values[idx] = float64(idx / 120) // first half 0, second half 1
+		}
+
+		line := sparkline(values, 60)
+		Expect(utf8.RuneCountInString(line)).To(Equal(60))
+		Expect(line).To(HavePrefix("▁▁▁"))
+		Expect(line).To(HaveSuffix("███"))
+	})
+})
@@ -0,0 +1,81 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// AppPromote deploys the staged image of the named application in the source namespace into
+// the target namespace, with the configuration of the application and the given overrides.
+// An empty source namespace is the current namespace.
+func (c *EpinioClient) AppPromote(appName, from string, request models.AppPromoteRequest) error {
+	if from == "" {
+		from = c.Settings.Namespace
+	}
+
+	log := c.Log.WithName("AppPromote").WithValues("Namespace", from, "Application", appName, "Target", request.Target)
+	log.Info("start")
+	defer log.Info("return")
+
+	msg := c.ui.Note().
+		WithStringValue("Application", appName).
+		WithStringValue("From", from).
+		WithStringValue("To", request.Target)
+	if request.Instances != nil {
+		msg = msg.WithStringValue("Instances", strconv.Itoa(int(*request.Instances)))
+	}
+	if request.Routes != nil {
+		msg = routeMessage(msg, request.Routes)
+	}
+	msg.Msg("Promoting application...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	response, err := c.API.AppPromote(from, appName, request)
+	if err != nil {
+		return err
+	}
+
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(response)
+	}
+
+	for _, warning := range response.Warnings {
+		c.ui.Exclamation().Msg(warning)
+	}
+
+	msg = c.ui.Success().
+		WithStringValue("Name", appName).
+		WithStringValue("Namespace", request.Target)
+
+	if len(response.Lineage) > 0 {
+		last := response.Lineage[len(response.Lineage)-1]
+		msg = msg.WithStringValue("Stage ID", last.StageID)
+	}
+
+	msg = msg.WithStringValue("Routes", "")
+	routes := response.Routes
+	sort.Strings(routes)
+	for i, r := range routes {
+		msg = msg.WithStringValue(strconv.Itoa(i+1), fmt.Sprintf("https://%s", r))
+	}
+
+	msg.Msg("App is promoted.")
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"strconv"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// AppScan shows the image scan report of the last staging of the named application
+func (c *EpinioClient) AppScan(appName string) error {
+	log := c.Log.WithName("AppScan").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Showing image scan report...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	report, err := c.API.AppScan(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(report)
+	}
+
+	threshold := report.Threshold
+	if threshold == "" {
+		threshold = models.ScanThresholdNone
+	}
+
+	msg := c.ui.Success().WithTable("Key", "Value").
+		WithTableRow("Stage ID", report.StageID).
+		WithTableRow("Image", report.Image).
+		WithTableRow("Scanner", report.Scanner).
+		WithTableRow("Scanned", report.ScannedAt).
+		WithTableRow("Block Threshold", threshold)
+	for idx := len(models.Severities) - 1; idx >= 0; idx-- {
+		severity := models.Severities[idx]
+		msg = msg.WithTableRow(severity, strconv.Itoa(report.Summary[severity]))
+	}
+	msg.Msg("Details:")
+
+	if report.Blocked {
+		c.ui.Exclamation().Msgf("Deployment blocked: %s", report.Reason)
+	}
+
+	if len(report.Findings) == 0 {
+		c.ui.Exclamation().Msg("No vulnerabilities found")
+		return nil
+	}
+
+	msg = c.ui.Success().WithTable("Severity", "ID", "Package", "Installed", "Fixed", "Title")
+	for _, finding := range report.Findings {
+		msg = msg.WithTableRow(
+			finding.Severity,
+			finding.ID,
+			finding.Package,
+			finding.InstalledVersion,
+			finding.FixedVersion,
+			finding.Title,
+		)
+	}
+	msg.Msg("Findings:")
+
+	return nil
+}
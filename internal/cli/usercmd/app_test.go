@@ -56,7 +56,7 @@ var _ = Describe("Client Apps unit tests", Label("wip"), func() {
 				epinioClient.Settings = &settings.Settings{Namespace: "workspace"}
 				epinioClient.API = fake
 
-				err = epinioClient.AppRestage("appname", false)
+				err = epinioClient.AppRestage("appname", false, false)
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
@@ -84,7 +84,7 @@ var _ = Describe("Client Apps unit tests", Label("wip"), func() {
 				epinioClient.Settings = &settings.Settings{Namespace: "workspace"}
 				epinioClient.API = fake
 
-				err = epinioClient.AppRestage("appname", false)
+				err = epinioClient.AppRestage("appname", false, false)
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
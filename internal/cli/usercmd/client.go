@@ -73,6 +73,20 @@ type APIClient interface {
 	AppMatch(namespace, prefix string) (models.AppMatchResponse, error)
 	AppValidateCV(namespace string, name string) (models.Response, error)
 	AppExport(namespace, appName string, param models.AppExportRequest) (models.Response, error)
+	AppGitTriggerShow(namespace, appName string) (models.GitTrigger, error)
+	AppGitTriggerEnable(namespace, appName string) (models.GitTrigger, error)
+	AppGitTriggerDisable(namespace, appName string) (models.Response, error)
+	AppLogDrainList(namespace, appName string) (models.LogDrainsResponse, error)
+	AppLogDrainAdd(namespace, appName string, request models.LogDrainAddRequest) (models.Response, error)
+	AppLogDrainRemove(namespace, appName, drainName string) (models.Response, error)
+	AppAlertList(namespace, appName string) (models.AppAlertsResponse, error)
+	AppAlertAdd(namespace, appName string, request models.AppAlertAddRequest) (models.Response, error)
+	AppAlertRemove(namespace, appName, alertName string) (models.Response, error)
+	AppScan(namespace, appName string) (models.ScanReport, error)
+	AppMetrics(namespace, appName, span string) (models.AppMetricsResponse, error)
+	AppEvents(namespace, appName string) (models.AppEventsResponse, error)
+	AppEventsStream(ctx context.Context, namespace, appName string, callback func(models.AppEvent) error) error
+	AppPromote(namespace, appName string, request models.AppPromoteRequest) (models.AppPromoteResponse, error)
 
 	// env
 	EnvList(namespace string, appName string) (models.EnvVariableMap, error)
@@ -89,8 +103,18 @@ type APIClient interface {
 	NamespaceCreate(req models.NamespaceCreateRequest) (models.Response, error)
 	NamespaceDelete(namespaces []string) (models.Response, error)
 	NamespaceShow(namespace string) (models.Namespace, error)
+	NamespaceSupportBundle(namespace string, appNames []string) (models.AppPartResponse, error)
 	NamespacesMatch(prefix string) (models.NamespacesMatchResponse, error)
 	Namespaces() (models.NamespaceList, error)
+	NamespaceQuotaShow(namespace string) (models.NamespaceQuotaResponse, error)
+	NamespaceQuotaUpdate(namespace string, request models.NamespaceQuotaUpdateRequest) (models.NamespaceQuotaResponse, error)
+	NamespaceScanPolicyShow(namespace string) (models.NamespaceScanPolicy, error)
+	NamespaceScanPolicyUpdate(namespace string, request models.NamespaceScanPolicyUpdateRequest) (models.NamespaceScanPolicy, error)
+	NamespaceLogRetentionShow(namespace string) (models.NamespaceLogRetention, error)
+	NamespaceLogRetentionUpdate(namespace string, request models.NamespaceLogRetentionUpdateRequest) (models.NamespaceLogRetention, error)
+	NamespaceLogDrainList(namespace string) (models.LogDrainsResponse, error)
+	NamespaceLogDrainAdd(namespace string, request models.LogDrainAddRequest) (models.Response, error)
+	NamespaceLogDrainRemove(namespace, drainName string) (models.Response, error)
 
 	// configurations
 	Configurations(namespace string) (models.ConfigurationResponseList, error)
@@ -153,6 +177,11 @@ type APIClient interface {
 	ExportregistryList() ([]models.ExportregistryResponse, error)
 	ExportregistryMatch(prefix string) (models.ExportregistriesMatchResponse, error)
 
+	// registry credentials
+	RegistryCredentials(namespace string) (models.RegistryCredentialList, error)
+	RegistryCredentialCreate(namespace string, request models.RegistryCredentialCreateRequest) (models.Response, error)
+	RegistryCredentialDelete(namespace, name string) (models.Response, error)
+
 	DisableVersionWarning()
 	VersionWarningEnabled() bool
 
@@ -0,0 +1,82 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// ListNamespaceLogDrains lists the log drains of a namespace, with their health
+func (c *EpinioClient) ListNamespaceLogDrains(namespace string) error {
+	log := c.Log.WithName("ListNamespaceLogDrains").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		Msg("Listing namespace log drains...")
+
+	drains, err := c.API.NamespaceLogDrainList(namespace)
+	if err != nil {
+		return err
+	}
+
+	return c.printLogDrains(drains)
+}
+
+// AddNamespaceLogDrain adds a log drain for all applications of a namespace
+func (c *EpinioClient) AddNamespaceLogDrain(namespace, drainName, drainURL string) error {
+	log := c.Log.WithName("AddNamespaceLogDrain").WithValues("Namespace", namespace, "Drain", drainName)
+	log.Info("start")
+	defer log.Info("return")
+
+	drain := models.LogDrain{Name: drainName, URL: drainURL}
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Drain", drainName).
+		WithStringValue("URL", drain.RedactedURL()).
+		Msg("Adding namespace log drain...")
+
+	_, err := c.API.NamespaceLogDrainAdd(namespace, models.LogDrainAddRequest{
+		Name: drainName,
+		URL:  drainURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Log drain added.")
+
+	return nil
+}
+
+// RemoveNamespaceLogDrain removes the named log drain from a namespace
+func (c *EpinioClient) RemoveNamespaceLogDrain(namespace, drainName string) error {
+	log := c.Log.WithName("RemoveNamespaceLogDrain").WithValues("Namespace", namespace, "Drain", drainName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Drain", drainName).
+		Msg("Removing namespace log drain...")
+
+	_, err := c.API.NamespaceLogDrainRemove(namespace, drainName)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Log drain removed.")
+
+	return nil
+}
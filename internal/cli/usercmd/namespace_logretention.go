@@ -0,0 +1,78 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// ShowNamespaceLogRetention shows the log retention of a namespace
+func (c *EpinioClient) ShowNamespaceLogRetention(namespace string) error {
+	log := c.Log.WithName("ShowNamespaceLogRetention").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		Msg("Showing namespace log retention...")
+
+	retention, err := c.API.NamespaceLogRetentionShow(namespace)
+	if err != nil {
+		return err
+	}
+
+	return c.printNamespaceLogRetention(retention)
+}
+
+// SetNamespaceLogRetention changes the log retention of a namespace. An empty retention
+// removes the retention of the namespace.
+func (c *EpinioClient) SetNamespaceLogRetention(namespace, retention string) error {
+	log := c.Log.WithName("SetNamespaceLogRetention").WithValues("Namespace", namespace, "Retention", retention)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		Msg("Updating namespace log retention...")
+
+	result, err := c.API.NamespaceLogRetentionUpdate(namespace, models.NamespaceLogRetentionUpdateRequest{
+		Retention: retention,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printNamespaceLogRetention(result)
+}
+
+func (c *EpinioClient) printNamespaceLogRetention(retention models.NamespaceLogRetention) error {
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(retention)
+	}
+
+	namespaceRetention := retention.Retention
+	if namespaceRetention == "" {
+		namespaceRetention = "global"
+	}
+	archiving := "disabled"
+	if retention.Archiving {
+		archiving = "enabled"
+	}
+
+	c.ui.Success().WithTable("Key", "Value").
+		WithTableRow("Namespace Retention", namespaceRetention).
+		WithTableRow("Effective Retention", retention.Effective).
+		WithTableRow("Log Archive", archiving).
+		Msg("Log Retention:")
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"strconv"
+
+	"github.com/epinio/epinio/helpers/bytes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// ShowNamespaceQuota shows the quota of a namespace, and its current usage
+func (c *EpinioClient) ShowNamespaceQuota(namespace string) error {
+	log := c.Log.WithName("ShowNamespaceQuota").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		Msg("Showing namespace quota...")
+
+	quota, err := c.API.NamespaceQuotaShow(namespace)
+	if err != nil {
+		return err
+	}
+
+	return c.printNamespaceQuota(quota)
+}
+
+// SetNamespaceQuota changes the quota of a namespace
+func (c *EpinioClient) SetNamespaceQuota(namespace string, request models.NamespaceQuotaUpdateRequest) error {
+	log := c.Log.WithName("SetNamespaceQuota").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		Msg("Updating namespace quota...")
+
+	quota, err := c.API.NamespaceQuotaUpdate(namespace, request)
+	if err != nil {
+		return err
+	}
+
+	return c.printNamespaceQuota(quota)
+}
+
+func (c *EpinioClient) printNamespaceQuota(quota models.NamespaceQuotaResponse) error {
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(quota)
+	}
+
+	notAvailable := "not available"
+	milliCPUs := notAvailable
+	memory := notAvailable
+	if quota.Usage.MetricsOk {
+		milliCPUs = strconv.Itoa(int(quota.Usage.MilliCPUs)) + "m"
+		memory = bytes.ByteCountIEC(quota.Usage.MemoryBytes)
+	}
+
+	c.ui.Success().WithTable("Resource", "Used", "Limit").
+		WithTableRow(models.QuotaApps, strconv.Itoa(int(quota.Usage.Apps)), countLimit(quota.Quota.Apps)).
+		WithTableRow(models.QuotaInstances, strconv.Itoa(int(quota.Usage.Instances)), countLimit(quota.Quota.Instances)).
+		WithTableRow(models.QuotaServices, strconv.Itoa(int(quota.Usage.Services)), countLimit(quota.Quota.Services)).
+		WithTableRow(models.QuotaCPU, milliCPUs, quantityLimit(quota.Quota.CPU)).
+		WithTableRow(models.QuotaMemory, memory, quantityLimit(quota.Quota.Memory)).
+		WithTableRow(models.QuotaBlobStorage, bytes.ByteCountIEC(quota.Usage.BlobStorageBytes), quantityLimit(quota.Quota.BlobStorage)).
+		Msg("Quota:")
+
+	return nil
+}
+
+func countLimit(limit *int32) string {
+	if limit == nil {
+		return "unlimited"
+	}
+	return strconv.Itoa(int(*limit))
+}
+
+func quantityLimit(limit string) string {
+	if limit == "" {
+		return "unlimited"
+	}
+	return limit
+}
@@ -0,0 +1,77 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// ShowNamespaceScanPolicy shows the image scan policy of a namespace
+func (c *EpinioClient) ShowNamespaceScanPolicy(namespace string) error {
+	log := c.Log.WithName("ShowNamespaceScanPolicy").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		Msg("Showing namespace scan policy...")
+
+	policy, err := c.API.NamespaceScanPolicyShow(namespace)
+	if err != nil {
+		return err
+	}
+
+	return c.printNamespaceScanPolicy(policy)
+}
+
+// SetNamespaceScanPolicy changes the image scan policy of a namespace. An empty threshold
+// removes the policy of the namespace.
+func (c *EpinioClient) SetNamespaceScanPolicy(namespace, threshold string) error {
+	log := c.Log.WithName("SetNamespaceScanPolicy").WithValues("Namespace", namespace, "Threshold", threshold)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		Msg("Updating namespace scan policy...")
+
+	policy, err := c.API.NamespaceScanPolicyUpdate(namespace, models.NamespaceScanPolicyUpdateRequest{
+		Threshold: threshold,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printNamespaceScanPolicy(policy)
+}
+
+func (c *EpinioClient) printNamespaceScanPolicy(policy models.NamespaceScanPolicy) error {
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(policy)
+	}
+
+	threshold := policy.Threshold
+	if threshold == "" {
+		threshold = "global"
+	}
+	effective := policy.Effective
+	if effective == "" {
+		effective = models.ScanThresholdNone
+	}
+
+	c.ui.Success().WithTable("Key", "Value").
+		WithTableRow("Namespace Threshold", threshold).
+		WithTableRow("Effective Threshold", effective).
+		Msg("Scan Policy:")
+
+	return nil
+}
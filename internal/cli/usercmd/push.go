@@ -88,6 +88,18 @@ func (c *EpinioClient) AppPush(ctx context.Context, manifest models.ApplicationM
 		msg = msg.WithStringValue("Builder", manifest.Staging.Builder)
 	}
 
+	if manifest.Configuration.RegistryCredential != "" {
+		msg = msg.WithStringValue("Registry Credential", manifest.Configuration.RegistryCredential)
+	}
+
+	if watch := manifest.Configuration.ImageWatch; watch != nil && watch.Interval != "" {
+		mode := watch.Mode
+		if mode == "" {
+			mode = models.ImageWatchNotify
+		}
+		msg = msg.WithStringValue("Image Watch", fmt.Sprintf("%s every %s", mode, watch.Interval))
+	}
+
 	if manifest.Configuration.Instances != nil {
 		msg = msg.WithStringValue("Instances",
 			strconv.Itoa(int(*manifest.Configuration.Instances)))
@@ -195,6 +207,7 @@ func (c *EpinioClient) AppPush(ctx context.Context, manifest models.ApplicationM
 	} else {
 		asyncReq.BlobUID = blobUID
 		asyncReq.BuilderImage = manifest.Staging.Builder
+		asyncReq.ClearCache = manifest.Staging.ClearCache
 	}
 
 	c.ui.Normal().Msg("Building and deploying application on the server ...")
@@ -234,6 +247,9 @@ func (c *EpinioClient) AppPush(ctx context.Context, manifest models.ApplicationM
 func (c *EpinioClient) waitAsyncDeployment(ctx context.Context, appRef models.AppRef, deploymentID string, details logr.Logger) (*models.AsyncDeployStatus, error) {
 	deadline := time.Now().Add(duration.ToAppBuilt())
 	stagingLogsStarted := false
+	waitingNoted := false
+	scanningNoted := false
+	queuedAt := 0
 
 	for time.Now().Before(deadline) {
 		select {
@@ -247,6 +263,21 @@ func (c *EpinioClient) waitAsyncDeployment(ctx context.Context, appRef models.Ap
 			return nil, err
 		}
 
+		if status.Status == "waiting" && !waitingNoted {
+			waitingNoted = true
+			c.ui.Note().Msg("Waiting for the dependencies of the application ...")
+		}
+
+		if status.Status == "queued" && status.QueuePosition != queuedAt {
+			queuedAt = status.QueuePosition
+			c.ui.Note().Msgf("Staging is queued at position %d ...", queuedAt)
+		}
+
+		if status.Status == "scanning" && !scanningNoted {
+			scanningNoted = true
+			c.ui.Note().Msg("Scanning the application image ...")
+		}
+
 		if status.StageID != "" && !stagingLogsStarted {
 			stagingLogsStarted = true
 			details.Info("start tailing logs", "StageID", status.StageID)
@@ -0,0 +1,121 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// RegistryCredentials lists the registry credentials of the current namespace
+func (c *EpinioClient) RegistryCredentials() error {
+	log := c.Log.WithName("RegistryCredentials").WithValues("Namespace", c.Settings.Namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		Msg("Listing registry credentials")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	credentials, err := c.API.RegistryCredentials(c.Settings.Namespace)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(credentials, func(i, j int) bool {
+		return credentials[i].Name < credentials[j].Name
+	})
+
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(credentials)
+	}
+
+	msg := c.ui.Success().WithTable("Name", "Created", "URL", "Username")
+	for _, credential := range credentials {
+		msg = msg.WithTableRow(
+			credential.Name,
+			formatCreatedAt(credential.CreatedAt),
+			credential.URL,
+			credential.Username,
+		)
+	}
+
+	msg.Msg("Registry credentials:")
+	return nil
+}
+
+// CreateRegistryCredential creates registry credentials in the current namespace
+func (c *EpinioClient) CreateRegistryCredential(name, url, username, password string) error {
+	log := c.Log.WithName("CreateRegistryCredential").WithValues("Namespace", c.Settings.Namespace, "Name", name)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("URL", url).
+		WithStringValue("Username", username).
+		Msg("Creating registry credential...")
+
+	errorMsgs := validation.IsDNS1123Label(name)
+	if len(errorMsgs) > 0 {
+		return fmt.Errorf("the registry credential's name must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name', or '123-abc')")
+	}
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	_, err := c.API.RegistryCredentialCreate(c.Settings.Namespace, models.RegistryCredentialCreateRequest{
+		Name:     name,
+		URL:      url,
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Registry credential created.")
+	return nil
+}
+
+// DeleteRegistryCredential deletes the named registry credentials of the current namespace
+func (c *EpinioClient) DeleteRegistryCredential(name string) error {
+	log := c.Log.WithName("DeleteRegistryCredential").WithValues("Namespace", c.Settings.Namespace, "Name", name)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		Msg("Deleting registry credential...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	_, err := c.API.RegistryCredentialDelete(c.Settings.Namespace, name)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Registry credential deleted.")
+	return nil
+}
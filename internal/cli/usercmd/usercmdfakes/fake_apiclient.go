@@ -61,6 +61,50 @@ type FakeAPIClient struct {
 		result1 models.ServiceList
 		result2 error
 	}
+	AppAlertAddStub        func(string, string, models.AppAlertAddRequest) (models.Response, error)
+	appAlertAddMutex       sync.RWMutex
+	appAlertAddArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 models.AppAlertAddRequest
+	}
+	appAlertAddReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	appAlertAddReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	AppAlertListStub        func(string, string) (models.AppAlertsResponse, error)
+	appAlertListMutex       sync.RWMutex
+	appAlertListArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appAlertListReturns struct {
+		result1 models.AppAlertsResponse
+		result2 error
+	}
+	appAlertListReturnsOnCall map[int]struct {
+		result1 models.AppAlertsResponse
+		result2 error
+	}
+	AppAlertRemoveStub        func(string, string, string) (models.Response, error)
+	appAlertRemoveMutex       sync.RWMutex
+	appAlertRemoveArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	appAlertRemoveReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	appAlertRemoveReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
 	AppCreateStub        func(models.ApplicationCreateRequest, string) (models.Response, error)
 	appCreateMutex       sync.RWMutex
 	appCreateArgsForCall []struct {
@@ -131,6 +175,34 @@ type FakeAPIClient struct {
 		result1 *models.AsyncDeployStatus
 		result2 error
 	}
+	AppEventsStub        func(string, string) (models.AppEventsResponse, error)
+	appEventsMutex       sync.RWMutex
+	appEventsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appEventsReturns struct {
+		result1 models.AppEventsResponse
+		result2 error
+	}
+	appEventsReturnsOnCall map[int]struct {
+		result1 models.AppEventsResponse
+		result2 error
+	}
+	AppEventsStreamStub        func(context.Context, string, string, func(models.AppEvent) error) error
+	appEventsStreamMutex       sync.RWMutex
+	appEventsStreamArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 func(models.AppEvent) error
+	}
+	appEventsStreamReturns struct {
+		result1 error
+	}
+	appEventsStreamReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppExecStub        func(context.Context, string, string, string, term.TTY) error
 	appExecMutex       sync.RWMutex
 	appExecArgsForCall []struct {
@@ -176,6 +248,48 @@ type FakeAPIClient struct {
 		result1 models.AppPartResponse
 		result2 error
 	}
+	AppGitTriggerDisableStub        func(string, string) (models.Response, error)
+	appGitTriggerDisableMutex       sync.RWMutex
+	appGitTriggerDisableArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appGitTriggerDisableReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	appGitTriggerDisableReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	AppGitTriggerEnableStub        func(string, string) (models.GitTrigger, error)
+	appGitTriggerEnableMutex       sync.RWMutex
+	appGitTriggerEnableArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appGitTriggerEnableReturns struct {
+		result1 models.GitTrigger
+		result2 error
+	}
+	appGitTriggerEnableReturnsOnCall map[int]struct {
+		result1 models.GitTrigger
+		result2 error
+	}
+	AppGitTriggerShowStub        func(string, string) (models.GitTrigger, error)
+	appGitTriggerShowMutex       sync.RWMutex
+	appGitTriggerShowArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appGitTriggerShowReturns struct {
+		result1 models.GitTrigger
+		result2 error
+	}
+	appGitTriggerShowReturnsOnCall map[int]struct {
+		result1 models.GitTrigger
+		result2 error
+	}
 	AppImportGitStub        func(string, string, models.GitRef) (models.ImportGitResponse, error)
 	appImportGitMutex       sync.RWMutex
 	appImportGitArgsForCall []struct {
@@ -191,6 +305,50 @@ type FakeAPIClient struct {
 		result1 models.ImportGitResponse
 		result2 error
 	}
+	AppLogDrainAddStub        func(string, string, models.LogDrainAddRequest) (models.Response, error)
+	appLogDrainAddMutex       sync.RWMutex
+	appLogDrainAddArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 models.LogDrainAddRequest
+	}
+	appLogDrainAddReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	appLogDrainAddReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	AppLogDrainListStub        func(string, string) (models.LogDrainsResponse, error)
+	appLogDrainListMutex       sync.RWMutex
+	appLogDrainListArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appLogDrainListReturns struct {
+		result1 models.LogDrainsResponse
+		result2 error
+	}
+	appLogDrainListReturnsOnCall map[int]struct {
+		result1 models.LogDrainsResponse
+		result2 error
+	}
+	AppLogDrainRemoveStub        func(string, string, string) (models.Response, error)
+	appLogDrainRemoveMutex       sync.RWMutex
+	appLogDrainRemoveArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	appLogDrainRemoveReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	appLogDrainRemoveReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
 	AppLogsStub        func(string, string, string, bool, *client.LogOptions, func(tailer.ContainerLogLine)) error
 	appLogsMutex       sync.RWMutex
 	appLogsArgsForCall []struct {
@@ -221,6 +379,21 @@ type FakeAPIClient struct {
 		result1 models.AppMatchResponse
 		result2 error
 	}
+	AppMetricsStub        func(string, string, string) (models.AppMetricsResponse, error)
+	appMetricsMutex       sync.RWMutex
+	appMetricsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	appMetricsReturns struct {
+		result1 models.AppMetricsResponse
+		result2 error
+	}
+	appMetricsReturnsOnCall map[int]struct {
+		result1 models.AppMetricsResponse
+		result2 error
+	}
 	AppPortForwardStub        func(string, string, string, *client.PortForwardOpts) error
 	appPortForwardMutex       sync.RWMutex
 	appPortForwardArgsForCall []struct {
@@ -235,6 +408,21 @@ type FakeAPIClient struct {
 	appPortForwardReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppPromoteStub        func(string, string, models.AppPromoteRequest) (models.AppPromoteResponse, error)
+	appPromoteMutex       sync.RWMutex
+	appPromoteArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 models.AppPromoteRequest
+	}
+	appPromoteReturns struct {
+		result1 models.AppPromoteResponse
+		result2 error
+	}
+	appPromoteReturnsOnCall map[int]struct {
+		result1 models.AppPromoteResponse
+		result2 error
+	}
 	AppRestartStub        func(string, string) (models.Response, error)
 	appRestartMutex       sync.RWMutex
 	appRestartArgsForCall []struct {
@@ -262,6 +450,20 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	AppScanStub        func(string, string) (models.ScanReport, error)
+	appScanMutex       sync.RWMutex
+	appScanArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appScanReturns struct {
+		result1 models.ScanReport
+		result2 error
+	}
+	appScanReturnsOnCall map[int]struct {
+		result1 models.ScanReport
+		result2 error
+	}
 	AppShowStub        func(string, string) (models.App, error)
 	appShowMutex       sync.RWMutex
 	appShowArgsForCall []struct {
@@ -917,6 +1119,128 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	NamespaceLogDrainAddStub        func(string, models.LogDrainAddRequest) (models.Response, error)
+	namespaceLogDrainAddMutex       sync.RWMutex
+	namespaceLogDrainAddArgsForCall []struct {
+		arg1 string
+		arg2 models.LogDrainAddRequest
+	}
+	namespaceLogDrainAddReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceLogDrainAddReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceLogDrainListStub        func(string) (models.LogDrainsResponse, error)
+	namespaceLogDrainListMutex       sync.RWMutex
+	namespaceLogDrainListArgsForCall []struct {
+		arg1 string
+	}
+	namespaceLogDrainListReturns struct {
+		result1 models.LogDrainsResponse
+		result2 error
+	}
+	namespaceLogDrainListReturnsOnCall map[int]struct {
+		result1 models.LogDrainsResponse
+		result2 error
+	}
+	NamespaceLogDrainRemoveStub        func(string, string) (models.Response, error)
+	namespaceLogDrainRemoveMutex       sync.RWMutex
+	namespaceLogDrainRemoveArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	namespaceLogDrainRemoveReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceLogDrainRemoveReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceLogRetentionShowStub        func(string) (models.NamespaceLogRetention, error)
+	namespaceLogRetentionShowMutex       sync.RWMutex
+	namespaceLogRetentionShowArgsForCall []struct {
+		arg1 string
+	}
+	namespaceLogRetentionShowReturns struct {
+		result1 models.NamespaceLogRetention
+		result2 error
+	}
+	namespaceLogRetentionShowReturnsOnCall map[int]struct {
+		result1 models.NamespaceLogRetention
+		result2 error
+	}
+	NamespaceLogRetentionUpdateStub        func(string, models.NamespaceLogRetentionUpdateRequest) (models.NamespaceLogRetention, error)
+	namespaceLogRetentionUpdateMutex       sync.RWMutex
+	namespaceLogRetentionUpdateArgsForCall []struct {
+		arg1 string
+		arg2 models.NamespaceLogRetentionUpdateRequest
+	}
+	namespaceLogRetentionUpdateReturns struct {
+		result1 models.NamespaceLogRetention
+		result2 error
+	}
+	namespaceLogRetentionUpdateReturnsOnCall map[int]struct {
+		result1 models.NamespaceLogRetention
+		result2 error
+	}
+	NamespaceQuotaShowStub        func(string) (models.NamespaceQuotaResponse, error)
+	namespaceQuotaShowMutex       sync.RWMutex
+	namespaceQuotaShowArgsForCall []struct {
+		arg1 string
+	}
+	namespaceQuotaShowReturns struct {
+		result1 models.NamespaceQuotaResponse
+		result2 error
+	}
+	namespaceQuotaShowReturnsOnCall map[int]struct {
+		result1 models.NamespaceQuotaResponse
+		result2 error
+	}
+	NamespaceQuotaUpdateStub        func(string, models.NamespaceQuotaUpdateRequest) (models.NamespaceQuotaResponse, error)
+	namespaceQuotaUpdateMutex       sync.RWMutex
+	namespaceQuotaUpdateArgsForCall []struct {
+		arg1 string
+		arg2 models.NamespaceQuotaUpdateRequest
+	}
+	namespaceQuotaUpdateReturns struct {
+		result1 models.NamespaceQuotaResponse
+		result2 error
+	}
+	namespaceQuotaUpdateReturnsOnCall map[int]struct {
+		result1 models.NamespaceQuotaResponse
+		result2 error
+	}
+	NamespaceScanPolicyShowStub        func(string) (models.NamespaceScanPolicy, error)
+	namespaceScanPolicyShowMutex       sync.RWMutex
+	namespaceScanPolicyShowArgsForCall []struct {
+		arg1 string
+	}
+	namespaceScanPolicyShowReturns struct {
+		result1 models.NamespaceScanPolicy
+		result2 error
+	}
+	namespaceScanPolicyShowReturnsOnCall map[int]struct {
+		result1 models.NamespaceScanPolicy
+		result2 error
+	}
+	NamespaceScanPolicyUpdateStub        func(string, models.NamespaceScanPolicyUpdateRequest) (models.NamespaceScanPolicy, error)
+	namespaceScanPolicyUpdateMutex       sync.RWMutex
+	namespaceScanPolicyUpdateArgsForCall []struct {
+		arg1 string
+		arg2 models.NamespaceScanPolicyUpdateRequest
+	}
+	namespaceScanPolicyUpdateReturns struct {
+		result1 models.NamespaceScanPolicy
+		result2 error
+	}
+	namespaceScanPolicyUpdateReturnsOnCall map[int]struct {
+		result1 models.NamespaceScanPolicy
+		result2 error
+	}
 	NamespaceShowStub        func(string) (models.Namespace, error)
 	namespaceShowMutex       sync.RWMutex
 	namespaceShowArgsForCall []struct {
@@ -930,6 +1254,20 @@ type FakeAPIClient struct {
 		result1 models.Namespace
 		result2 error
 	}
+	NamespaceSupportBundleStub        func(string, []string) (models.AppPartResponse, error)
+	namespaceSupportBundleMutex       sync.RWMutex
+	namespaceSupportBundleArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	namespaceSupportBundleReturns struct {
+		result1 models.AppPartResponse
+		result2 error
+	}
+	namespaceSupportBundleReturnsOnCall map[int]struct {
+		result1 models.AppPartResponse
+		result2 error
+	}
 	NamespacesStub        func() (models.NamespaceList, error)
 	namespacesMutex       sync.RWMutex
 	namespacesArgsForCall []struct {
@@ -955,6 +1293,47 @@ type FakeAPIClient struct {
 		result1 models.NamespacesMatchResponse
 		result2 error
 	}
+	RegistryCredentialCreateStub        func(string, models.RegistryCredentialCreateRequest) (models.Response, error)
+	registryCredentialCreateMutex       sync.RWMutex
+	registryCredentialCreateArgsForCall []struct {
+		arg1 string
+		arg2 models.RegistryCredentialCreateRequest
+	}
+	registryCredentialCreateReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	registryCredentialCreateReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	RegistryCredentialDeleteStub        func(string, string) (models.Response, error)
+	registryCredentialDeleteMutex       sync.RWMutex
+	registryCredentialDeleteArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	registryCredentialDeleteReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	registryCredentialDeleteReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	RegistryCredentialsStub        func(string) (models.RegistryCredentialList, error)
+	registryCredentialsMutex       sync.RWMutex
+	registryCredentialsArgsForCall []struct {
+		arg1 string
+	}
+	registryCredentialsReturns struct {
+		result1 models.RegistryCredentialList
+		result2 error
+	}
+	registryCredentialsReturnsOnCall map[int]struct {
+		result1 models.RegistryCredentialList
+		result2 error
+	}
 	ServiceBatchBindStub        func(models.ServiceBatchBindRequest, string, string) (models.Response, error)
 	serviceBatchBindMutex       sync.RWMutex
 	serviceBatchBindArgsForCall []struct {
@@ -1392,19 +1771,20 @@ func (fake *FakeAPIClient) AllServicesReturnsOnCall(i int, result1 models.Servic
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) AppCreate(arg1 models.ApplicationCreateRequest, arg2 string) (models.Response, error) {
-	fake.appCreateMutex.Lock()
-	ret, specificReturn := fake.appCreateReturnsOnCall[len(fake.appCreateArgsForCall)]
-	fake.appCreateArgsForCall = append(fake.appCreateArgsForCall, struct {
-		arg1 models.ApplicationCreateRequest
+func (fake *FakeAPIClient) AppAlertAdd(arg1 string, arg2 string, arg3 models.AppAlertAddRequest) (models.Response, error) {
+	fake.appAlertAddMutex.Lock()
+	ret, specificReturn := fake.appAlertAddReturnsOnCall[len(fake.appAlertAddArgsForCall)]
+	fake.appAlertAddArgsForCall = append(fake.appAlertAddArgsForCall, struct {
+		arg1 string
 		arg2 string
-	}{arg1, arg2})
-	stub := fake.AppCreateStub
-	fakeReturns := fake.appCreateReturns
-	fake.recordInvocation("AppCreate", []interface{}{arg1, arg2})
-	fake.appCreateMutex.Unlock()
+		arg3 models.AppAlertAddRequest
+	}{arg1, arg2, arg3})
+	stub := fake.AppAlertAddStub
+	fakeReturns := fake.appAlertAddReturns
+	fake.recordInvocation("AppAlertAdd", []interface{}{arg1, arg2, arg3})
+	fake.appAlertAddMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1412,65 +1792,261 @@ func (fake *FakeAPIClient) AppCreate(arg1 models.ApplicationCreateRequest, arg2
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) AppCreateCallCount() int {
-	fake.appCreateMutex.RLock()
-	defer fake.appCreateMutex.RUnlock()
-	return len(fake.appCreateArgsForCall)
+func (fake *FakeAPIClient) AppAlertAddCallCount() int {
+	fake.appAlertAddMutex.RLock()
+	defer fake.appAlertAddMutex.RUnlock()
+	return len(fake.appAlertAddArgsForCall)
 }
 
-func (fake *FakeAPIClient) AppCreateCalls(stub func(models.ApplicationCreateRequest, string) (models.Response, error)) {
-	fake.appCreateMutex.Lock()
-	defer fake.appCreateMutex.Unlock()
-	fake.AppCreateStub = stub
+func (fake *FakeAPIClient) AppAlertAddCalls(stub func(string, string, models.AppAlertAddRequest) (models.Response, error)) {
+	fake.appAlertAddMutex.Lock()
+	defer fake.appAlertAddMutex.Unlock()
+	fake.AppAlertAddStub = stub
 }
 
-func (fake *FakeAPIClient) AppCreateArgsForCall(i int) (models.ApplicationCreateRequest, string) {
-	fake.appCreateMutex.RLock()
-	defer fake.appCreateMutex.RUnlock()
-	argsForCall := fake.appCreateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *FakeAPIClient) AppAlertAddArgsForCall(i int) (string, string, models.AppAlertAddRequest) {
+	fake.appAlertAddMutex.RLock()
+	defer fake.appAlertAddMutex.RUnlock()
+	argsForCall := fake.appAlertAddArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeAPIClient) AppCreateReturns(result1 models.Response, result2 error) {
-	fake.appCreateMutex.Lock()
-	defer fake.appCreateMutex.Unlock()
-	fake.AppCreateStub = nil
-	fake.appCreateReturns = struct {
+func (fake *FakeAPIClient) AppAlertAddReturns(result1 models.Response, result2 error) {
+	fake.appAlertAddMutex.Lock()
+	defer fake.appAlertAddMutex.Unlock()
+	fake.AppAlertAddStub = nil
+	fake.appAlertAddReturns = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) AppCreateReturnsOnCall(i int, result1 models.Response, result2 error) {
-	fake.appCreateMutex.Lock()
-	defer fake.appCreateMutex.Unlock()
-	fake.AppCreateStub = nil
-	if fake.appCreateReturnsOnCall == nil {
-		fake.appCreateReturnsOnCall = make(map[int]struct {
+func (fake *FakeAPIClient) AppAlertAddReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.appAlertAddMutex.Lock()
+	defer fake.appAlertAddMutex.Unlock()
+	fake.AppAlertAddStub = nil
+	if fake.appAlertAddReturnsOnCall == nil {
+		fake.appAlertAddReturnsOnCall = make(map[int]struct {
 			result1 models.Response
 			result2 error
 		})
 	}
-	fake.appCreateReturnsOnCall[i] = struct {
+	fake.appAlertAddReturnsOnCall[i] = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) AppDelete(arg1 string, arg2 []string, arg3 bool) (models.ApplicationDeleteResponse, error) {
-	var arg2Copy []string
-	if arg2 != nil {
-		arg2Copy = make([]string, len(arg2))
-		copy(arg2Copy, arg2)
-	}
-	fake.appDeleteMutex.Lock()
-	ret, specificReturn := fake.appDeleteReturnsOnCall[len(fake.appDeleteArgsForCall)]
-	fake.appDeleteArgsForCall = append(fake.appDeleteArgsForCall, struct {
+func (fake *FakeAPIClient) AppAlertList(arg1 string, arg2 string) (models.AppAlertsResponse, error) {
+	fake.appAlertListMutex.Lock()
+	ret, specificReturn := fake.appAlertListReturnsOnCall[len(fake.appAlertListArgsForCall)]
+	fake.appAlertListArgsForCall = append(fake.appAlertListArgsForCall, struct {
 		arg1 string
-		arg2 []string
-		arg3 bool
-	}{arg1, arg2Copy, arg3})
-	stub := fake.AppDeleteStub
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppAlertListStub
+	fakeReturns := fake.appAlertListReturns
+	fake.recordInvocation("AppAlertList", []interface{}{arg1, arg2})
+	fake.appAlertListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppAlertListCallCount() int {
+	fake.appAlertListMutex.RLock()
+	defer fake.appAlertListMutex.RUnlock()
+	return len(fake.appAlertListArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppAlertListCalls(stub func(string, string) (models.AppAlertsResponse, error)) {
+	fake.appAlertListMutex.Lock()
+	defer fake.appAlertListMutex.Unlock()
+	fake.AppAlertListStub = stub
+}
+
+func (fake *FakeAPIClient) AppAlertListArgsForCall(i int) (string, string) {
+	fake.appAlertListMutex.RLock()
+	defer fake.appAlertListMutex.RUnlock()
+	argsForCall := fake.appAlertListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppAlertListReturns(result1 models.AppAlertsResponse, result2 error) {
+	fake.appAlertListMutex.Lock()
+	defer fake.appAlertListMutex.Unlock()
+	fake.AppAlertListStub = nil
+	fake.appAlertListReturns = struct {
+		result1 models.AppAlertsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppAlertListReturnsOnCall(i int, result1 models.AppAlertsResponse, result2 error) {
+	fake.appAlertListMutex.Lock()
+	defer fake.appAlertListMutex.Unlock()
+	fake.AppAlertListStub = nil
+	if fake.appAlertListReturnsOnCall == nil {
+		fake.appAlertListReturnsOnCall = make(map[int]struct {
+			result1 models.AppAlertsResponse
+			result2 error
+		})
+	}
+	fake.appAlertListReturnsOnCall[i] = struct {
+		result1 models.AppAlertsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppAlertRemove(arg1 string, arg2 string, arg3 string) (models.Response, error) {
+	fake.appAlertRemoveMutex.Lock()
+	ret, specificReturn := fake.appAlertRemoveReturnsOnCall[len(fake.appAlertRemoveArgsForCall)]
+	fake.appAlertRemoveArgsForCall = append(fake.appAlertRemoveArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AppAlertRemoveStub
+	fakeReturns := fake.appAlertRemoveReturns
+	fake.recordInvocation("AppAlertRemove", []interface{}{arg1, arg2, arg3})
+	fake.appAlertRemoveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppAlertRemoveCallCount() int {
+	fake.appAlertRemoveMutex.RLock()
+	defer fake.appAlertRemoveMutex.RUnlock()
+	return len(fake.appAlertRemoveArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppAlertRemoveCalls(stub func(string, string, string) (models.Response, error)) {
+	fake.appAlertRemoveMutex.Lock()
+	defer fake.appAlertRemoveMutex.Unlock()
+	fake.AppAlertRemoveStub = stub
+}
+
+func (fake *FakeAPIClient) AppAlertRemoveArgsForCall(i int) (string, string, string) {
+	fake.appAlertRemoveMutex.RLock()
+	defer fake.appAlertRemoveMutex.RUnlock()
+	argsForCall := fake.appAlertRemoveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) AppAlertRemoveReturns(result1 models.Response, result2 error) {
+	fake.appAlertRemoveMutex.Lock()
+	defer fake.appAlertRemoveMutex.Unlock()
+	fake.AppAlertRemoveStub = nil
+	fake.appAlertRemoveReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppAlertRemoveReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.appAlertRemoveMutex.Lock()
+	defer fake.appAlertRemoveMutex.Unlock()
+	fake.AppAlertRemoveStub = nil
+	if fake.appAlertRemoveReturnsOnCall == nil {
+		fake.appAlertRemoveReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.appAlertRemoveReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppCreate(arg1 models.ApplicationCreateRequest, arg2 string) (models.Response, error) {
+	fake.appCreateMutex.Lock()
+	ret, specificReturn := fake.appCreateReturnsOnCall[len(fake.appCreateArgsForCall)]
+	fake.appCreateArgsForCall = append(fake.appCreateArgsForCall, struct {
+		arg1 models.ApplicationCreateRequest
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppCreateStub
+	fakeReturns := fake.appCreateReturns
+	fake.recordInvocation("AppCreate", []interface{}{arg1, arg2})
+	fake.appCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppCreateCallCount() int {
+	fake.appCreateMutex.RLock()
+	defer fake.appCreateMutex.RUnlock()
+	return len(fake.appCreateArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppCreateCalls(stub func(models.ApplicationCreateRequest, string) (models.Response, error)) {
+	fake.appCreateMutex.Lock()
+	defer fake.appCreateMutex.Unlock()
+	fake.AppCreateStub = stub
+}
+
+func (fake *FakeAPIClient) AppCreateArgsForCall(i int) (models.ApplicationCreateRequest, string) {
+	fake.appCreateMutex.RLock()
+	defer fake.appCreateMutex.RUnlock()
+	argsForCall := fake.appCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppCreateReturns(result1 models.Response, result2 error) {
+	fake.appCreateMutex.Lock()
+	defer fake.appCreateMutex.Unlock()
+	fake.AppCreateStub = nil
+	fake.appCreateReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppCreateReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.appCreateMutex.Lock()
+	defer fake.appCreateMutex.Unlock()
+	fake.AppCreateStub = nil
+	if fake.appCreateReturnsOnCall == nil {
+		fake.appCreateReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.appCreateReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppDelete(arg1 string, arg2 []string, arg3 bool) (models.ApplicationDeleteResponse, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.appDeleteMutex.Lock()
+	ret, specificReturn := fake.appDeleteReturnsOnCall[len(fake.appDeleteArgsForCall)]
+	fake.appDeleteArgsForCall = append(fake.appDeleteArgsForCall, struct {
+		arg1 string
+		arg2 []string
+		arg3 bool
+	}{arg1, arg2Copy, arg3})
+	stub := fake.AppDeleteStub
 	fakeReturns := fake.appDeleteReturns
 	fake.recordInvocation("AppDelete", []interface{}{arg1, arg2Copy, arg3})
 	fake.appDeleteMutex.Unlock()
@@ -1722,6 +2298,135 @@ func (fake *FakeAPIClient) AppDeploymentsStartReturnsOnCall(i int, result1 *mode
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppEvents(arg1 string, arg2 string) (models.AppEventsResponse, error) {
+	fake.appEventsMutex.Lock()
+	ret, specificReturn := fake.appEventsReturnsOnCall[len(fake.appEventsArgsForCall)]
+	fake.appEventsArgsForCall = append(fake.appEventsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppEventsStub
+	fakeReturns := fake.appEventsReturns
+	fake.recordInvocation("AppEvents", []interface{}{arg1, arg2})
+	fake.appEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppEventsCallCount() int {
+	fake.appEventsMutex.RLock()
+	defer fake.appEventsMutex.RUnlock()
+	return len(fake.appEventsArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppEventsCalls(stub func(string, string) (models.AppEventsResponse, error)) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = stub
+}
+
+func (fake *FakeAPIClient) AppEventsArgsForCall(i int) (string, string) {
+	fake.appEventsMutex.RLock()
+	defer fake.appEventsMutex.RUnlock()
+	argsForCall := fake.appEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppEventsReturns(result1 models.AppEventsResponse, result2 error) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = nil
+	fake.appEventsReturns = struct {
+		result1 models.AppEventsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppEventsReturnsOnCall(i int, result1 models.AppEventsResponse, result2 error) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = nil
+	if fake.appEventsReturnsOnCall == nil {
+		fake.appEventsReturnsOnCall = make(map[int]struct {
+			result1 models.AppEventsResponse
+			result2 error
+		})
+	}
+	fake.appEventsReturnsOnCall[i] = struct {
+		result1 models.AppEventsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppEventsStream(arg1 context.Context, arg2 string, arg3 string, arg4 func(models.AppEvent) error) error {
+	fake.appEventsStreamMutex.Lock()
+	ret, specificReturn := fake.appEventsStreamReturnsOnCall[len(fake.appEventsStreamArgsForCall)]
+	fake.appEventsStreamArgsForCall = append(fake.appEventsStreamArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 func(models.AppEvent) error
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AppEventsStreamStub
+	fakeReturns := fake.appEventsStreamReturns
+	fake.recordInvocation("AppEventsStream", []interface{}{arg1, arg2, arg3, arg4})
+	fake.appEventsStreamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) AppEventsStreamCallCount() int {
+	fake.appEventsStreamMutex.RLock()
+	defer fake.appEventsStreamMutex.RUnlock()
+	return len(fake.appEventsStreamArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppEventsStreamCalls(stub func(context.Context, string, string, func(models.AppEvent) error) error) {
+	fake.appEventsStreamMutex.Lock()
+	defer fake.appEventsStreamMutex.Unlock()
+	fake.AppEventsStreamStub = stub
+}
+
+func (fake *FakeAPIClient) AppEventsStreamArgsForCall(i int) (context.Context, string, string, func(models.AppEvent) error) {
+	fake.appEventsStreamMutex.RLock()
+	defer fake.appEventsStreamMutex.RUnlock()
+	argsForCall := fake.appEventsStreamArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeAPIClient) AppEventsStreamReturns(result1 error) {
+	fake.appEventsStreamMutex.Lock()
+	defer fake.appEventsStreamMutex.Unlock()
+	fake.AppEventsStreamStub = nil
+	fake.appEventsStreamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) AppEventsStreamReturnsOnCall(i int, result1 error) {
+	fake.appEventsStreamMutex.Lock()
+	defer fake.appEventsStreamMutex.Unlock()
+	fake.AppEventsStreamStub = nil
+	if fake.appEventsStreamReturnsOnCall == nil {
+		fake.appEventsStreamReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appEventsStreamReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeAPIClient) AppExec(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 term.TTY) error {
 	fake.appExecMutex.Lock()
 	ret, specificReturn := fake.appExecReturnsOnCall[len(fake.appExecArgsForCall)]
@@ -1919,20 +2624,19 @@ func (fake *FakeAPIClient) AppGetPartReturnsOnCall(i int, result1 models.AppPart
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) AppImportGit(arg1 string, arg2 string, arg3 models.GitRef) (models.ImportGitResponse, error) {
-	fake.appImportGitMutex.Lock()
-	ret, specificReturn := fake.appImportGitReturnsOnCall[len(fake.appImportGitArgsForCall)]
-	fake.appImportGitArgsForCall = append(fake.appImportGitArgsForCall, struct {
+func (fake *FakeAPIClient) AppGitTriggerDisable(arg1 string, arg2 string) (models.Response, error) {
+	fake.appGitTriggerDisableMutex.Lock()
+	ret, specificReturn := fake.appGitTriggerDisableReturnsOnCall[len(fake.appGitTriggerDisableArgsForCall)]
+	fake.appGitTriggerDisableArgsForCall = append(fake.appGitTriggerDisableArgsForCall, struct {
 		arg1 string
 		arg2 string
-		arg3 models.GitRef
-	}{arg1, arg2, arg3})
-	stub := fake.AppImportGitStub
-	fakeReturns := fake.appImportGitReturns
-	fake.recordInvocation("AppImportGit", []interface{}{arg1, arg2, arg3})
-	fake.appImportGitMutex.Unlock()
+	}{arg1, arg2})
+	stub := fake.AppGitTriggerDisableStub
+	fakeReturns := fake.appGitTriggerDisableReturns
+	fake.recordInvocation("AppGitTriggerDisable", []interface{}{arg1, arg2})
+	fake.appGitTriggerDisableMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1940,61 +2644,454 @@ func (fake *FakeAPIClient) AppImportGit(arg1 string, arg2 string, arg3 models.Gi
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) AppImportGitCallCount() int {
-	fake.appImportGitMutex.RLock()
-	defer fake.appImportGitMutex.RUnlock()
-	return len(fake.appImportGitArgsForCall)
+func (fake *FakeAPIClient) AppGitTriggerDisableCallCount() int {
+	fake.appGitTriggerDisableMutex.RLock()
+	defer fake.appGitTriggerDisableMutex.RUnlock()
+	return len(fake.appGitTriggerDisableArgsForCall)
 }
 
-func (fake *FakeAPIClient) AppImportGitCalls(stub func(string, string, models.GitRef) (models.ImportGitResponse, error)) {
-	fake.appImportGitMutex.Lock()
-	defer fake.appImportGitMutex.Unlock()
-	fake.AppImportGitStub = stub
+func (fake *FakeAPIClient) AppGitTriggerDisableCalls(stub func(string, string) (models.Response, error)) {
+	fake.appGitTriggerDisableMutex.Lock()
+	defer fake.appGitTriggerDisableMutex.Unlock()
+	fake.AppGitTriggerDisableStub = stub
 }
 
-func (fake *FakeAPIClient) AppImportGitArgsForCall(i int) (string, string, models.GitRef) {
-	fake.appImportGitMutex.RLock()
-	defer fake.appImportGitMutex.RUnlock()
-	argsForCall := fake.appImportGitArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+func (fake *FakeAPIClient) AppGitTriggerDisableArgsForCall(i int) (string, string) {
+	fake.appGitTriggerDisableMutex.RLock()
+	defer fake.appGitTriggerDisableMutex.RUnlock()
+	argsForCall := fake.appGitTriggerDisableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) AppImportGitReturns(result1 models.ImportGitResponse, result2 error) {
-	fake.appImportGitMutex.Lock()
-	defer fake.appImportGitMutex.Unlock()
-	fake.AppImportGitStub = nil
-	fake.appImportGitReturns = struct {
-		result1 models.ImportGitResponse
+func (fake *FakeAPIClient) AppGitTriggerDisableReturns(result1 models.Response, result2 error) {
+	fake.appGitTriggerDisableMutex.Lock()
+	defer fake.appGitTriggerDisableMutex.Unlock()
+	fake.AppGitTriggerDisableStub = nil
+	fake.appGitTriggerDisableReturns = struct {
+		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) AppImportGitReturnsOnCall(i int, result1 models.ImportGitResponse, result2 error) {
-	fake.appImportGitMutex.Lock()
-	defer fake.appImportGitMutex.Unlock()
-	fake.AppImportGitStub = nil
-	if fake.appImportGitReturnsOnCall == nil {
-		fake.appImportGitReturnsOnCall = make(map[int]struct {
-			result1 models.ImportGitResponse
+func (fake *FakeAPIClient) AppGitTriggerDisableReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.appGitTriggerDisableMutex.Lock()
+	defer fake.appGitTriggerDisableMutex.Unlock()
+	fake.AppGitTriggerDisableStub = nil
+	if fake.appGitTriggerDisableReturnsOnCall == nil {
+		fake.appGitTriggerDisableReturnsOnCall = make(map[int]struct {
+			result1 models.Response
 			result2 error
 		})
 	}
-	fake.appImportGitReturnsOnCall[i] = struct {
-		result1 models.ImportGitResponse
+	fake.appGitTriggerDisableReturnsOnCall[i] = struct {
+		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) AppLogs(arg1 string, arg2 string, arg3 string, arg4 bool, arg5 *client.LogOptions, arg6 func(tailer.ContainerLogLine)) error {
-	fake.appLogsMutex.Lock()
-	ret, specificReturn := fake.appLogsReturnsOnCall[len(fake.appLogsArgsForCall)]
-	fake.appLogsArgsForCall = append(fake.appLogsArgsForCall, struct {
+func (fake *FakeAPIClient) AppGitTriggerEnable(arg1 string, arg2 string) (models.GitTrigger, error) {
+	fake.appGitTriggerEnableMutex.Lock()
+	ret, specificReturn := fake.appGitTriggerEnableReturnsOnCall[len(fake.appGitTriggerEnableArgsForCall)]
+	fake.appGitTriggerEnableArgsForCall = append(fake.appGitTriggerEnableArgsForCall, struct {
 		arg1 string
 		arg2 string
-		arg3 string
-		arg4 bool
-		arg5 *client.LogOptions
-		arg6 func(tailer.ContainerLogLine)
+	}{arg1, arg2})
+	stub := fake.AppGitTriggerEnableStub
+	fakeReturns := fake.appGitTriggerEnableReturns
+	fake.recordInvocation("AppGitTriggerEnable", []interface{}{arg1, arg2})
+	fake.appGitTriggerEnableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppGitTriggerEnableCallCount() int {
+	fake.appGitTriggerEnableMutex.RLock()
+	defer fake.appGitTriggerEnableMutex.RUnlock()
+	return len(fake.appGitTriggerEnableArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppGitTriggerEnableCalls(stub func(string, string) (models.GitTrigger, error)) {
+	fake.appGitTriggerEnableMutex.Lock()
+	defer fake.appGitTriggerEnableMutex.Unlock()
+	fake.AppGitTriggerEnableStub = stub
+}
+
+func (fake *FakeAPIClient) AppGitTriggerEnableArgsForCall(i int) (string, string) {
+	fake.appGitTriggerEnableMutex.RLock()
+	defer fake.appGitTriggerEnableMutex.RUnlock()
+	argsForCall := fake.appGitTriggerEnableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppGitTriggerEnableReturns(result1 models.GitTrigger, result2 error) {
+	fake.appGitTriggerEnableMutex.Lock()
+	defer fake.appGitTriggerEnableMutex.Unlock()
+	fake.AppGitTriggerEnableStub = nil
+	fake.appGitTriggerEnableReturns = struct {
+		result1 models.GitTrigger
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppGitTriggerEnableReturnsOnCall(i int, result1 models.GitTrigger, result2 error) {
+	fake.appGitTriggerEnableMutex.Lock()
+	defer fake.appGitTriggerEnableMutex.Unlock()
+	fake.AppGitTriggerEnableStub = nil
+	if fake.appGitTriggerEnableReturnsOnCall == nil {
+		fake.appGitTriggerEnableReturnsOnCall = make(map[int]struct {
+			result1 models.GitTrigger
+			result2 error
+		})
+	}
+	fake.appGitTriggerEnableReturnsOnCall[i] = struct {
+		result1 models.GitTrigger
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppGitTriggerShow(arg1 string, arg2 string) (models.GitTrigger, error) {
+	fake.appGitTriggerShowMutex.Lock()
+	ret, specificReturn := fake.appGitTriggerShowReturnsOnCall[len(fake.appGitTriggerShowArgsForCall)]
+	fake.appGitTriggerShowArgsForCall = append(fake.appGitTriggerShowArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppGitTriggerShowStub
+	fakeReturns := fake.appGitTriggerShowReturns
+	fake.recordInvocation("AppGitTriggerShow", []interface{}{arg1, arg2})
+	fake.appGitTriggerShowMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppGitTriggerShowCallCount() int {
+	fake.appGitTriggerShowMutex.RLock()
+	defer fake.appGitTriggerShowMutex.RUnlock()
+	return len(fake.appGitTriggerShowArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppGitTriggerShowCalls(stub func(string, string) (models.GitTrigger, error)) {
+	fake.appGitTriggerShowMutex.Lock()
+	defer fake.appGitTriggerShowMutex.Unlock()
+	fake.AppGitTriggerShowStub = stub
+}
+
+func (fake *FakeAPIClient) AppGitTriggerShowArgsForCall(i int) (string, string) {
+	fake.appGitTriggerShowMutex.RLock()
+	defer fake.appGitTriggerShowMutex.RUnlock()
+	argsForCall := fake.appGitTriggerShowArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppGitTriggerShowReturns(result1 models.GitTrigger, result2 error) {
+	fake.appGitTriggerShowMutex.Lock()
+	defer fake.appGitTriggerShowMutex.Unlock()
+	fake.AppGitTriggerShowStub = nil
+	fake.appGitTriggerShowReturns = struct {
+		result1 models.GitTrigger
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppGitTriggerShowReturnsOnCall(i int, result1 models.GitTrigger, result2 error) {
+	fake.appGitTriggerShowMutex.Lock()
+	defer fake.appGitTriggerShowMutex.Unlock()
+	fake.AppGitTriggerShowStub = nil
+	if fake.appGitTriggerShowReturnsOnCall == nil {
+		fake.appGitTriggerShowReturnsOnCall = make(map[int]struct {
+			result1 models.GitTrigger
+			result2 error
+		})
+	}
+	fake.appGitTriggerShowReturnsOnCall[i] = struct {
+		result1 models.GitTrigger
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppImportGit(arg1 string, arg2 string, arg3 models.GitRef) (models.ImportGitResponse, error) {
+	fake.appImportGitMutex.Lock()
+	ret, specificReturn := fake.appImportGitReturnsOnCall[len(fake.appImportGitArgsForCall)]
+	fake.appImportGitArgsForCall = append(fake.appImportGitArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 models.GitRef
+	}{arg1, arg2, arg3})
+	stub := fake.AppImportGitStub
+	fakeReturns := fake.appImportGitReturns
+	fake.recordInvocation("AppImportGit", []interface{}{arg1, arg2, arg3})
+	fake.appImportGitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppImportGitCallCount() int {
+	fake.appImportGitMutex.RLock()
+	defer fake.appImportGitMutex.RUnlock()
+	return len(fake.appImportGitArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppImportGitCalls(stub func(string, string, models.GitRef) (models.ImportGitResponse, error)) {
+	fake.appImportGitMutex.Lock()
+	defer fake.appImportGitMutex.Unlock()
+	fake.AppImportGitStub = stub
+}
+
+func (fake *FakeAPIClient) AppImportGitArgsForCall(i int) (string, string, models.GitRef) {
+	fake.appImportGitMutex.RLock()
+	defer fake.appImportGitMutex.RUnlock()
+	argsForCall := fake.appImportGitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) AppImportGitReturns(result1 models.ImportGitResponse, result2 error) {
+	fake.appImportGitMutex.Lock()
+	defer fake.appImportGitMutex.Unlock()
+	fake.AppImportGitStub = nil
+	fake.appImportGitReturns = struct {
+		result1 models.ImportGitResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppImportGitReturnsOnCall(i int, result1 models.ImportGitResponse, result2 error) {
+	fake.appImportGitMutex.Lock()
+	defer fake.appImportGitMutex.Unlock()
+	fake.AppImportGitStub = nil
+	if fake.appImportGitReturnsOnCall == nil {
+		fake.appImportGitReturnsOnCall = make(map[int]struct {
+			result1 models.ImportGitResponse
+			result2 error
+		})
+	}
+	fake.appImportGitReturnsOnCall[i] = struct {
+		result1 models.ImportGitResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppLogDrainAdd(arg1 string, arg2 string, arg3 models.LogDrainAddRequest) (models.Response, error) {
+	fake.appLogDrainAddMutex.Lock()
+	ret, specificReturn := fake.appLogDrainAddReturnsOnCall[len(fake.appLogDrainAddArgsForCall)]
+	fake.appLogDrainAddArgsForCall = append(fake.appLogDrainAddArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 models.LogDrainAddRequest
+	}{arg1, arg2, arg3})
+	stub := fake.AppLogDrainAddStub
+	fakeReturns := fake.appLogDrainAddReturns
+	fake.recordInvocation("AppLogDrainAdd", []interface{}{arg1, arg2, arg3})
+	fake.appLogDrainAddMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppLogDrainAddCallCount() int {
+	fake.appLogDrainAddMutex.RLock()
+	defer fake.appLogDrainAddMutex.RUnlock()
+	return len(fake.appLogDrainAddArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppLogDrainAddCalls(stub func(string, string, models.LogDrainAddRequest) (models.Response, error)) {
+	fake.appLogDrainAddMutex.Lock()
+	defer fake.appLogDrainAddMutex.Unlock()
+	fake.AppLogDrainAddStub = stub
+}
+
+func (fake *FakeAPIClient) AppLogDrainAddArgsForCall(i int) (string, string, models.LogDrainAddRequest) {
+	fake.appLogDrainAddMutex.RLock()
+	defer fake.appLogDrainAddMutex.RUnlock()
+	argsForCall := fake.appLogDrainAddArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) AppLogDrainAddReturns(result1 models.Response, result2 error) {
+	fake.appLogDrainAddMutex.Lock()
+	defer fake.appLogDrainAddMutex.Unlock()
+	fake.AppLogDrainAddStub = nil
+	fake.appLogDrainAddReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppLogDrainAddReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.appLogDrainAddMutex.Lock()
+	defer fake.appLogDrainAddMutex.Unlock()
+	fake.AppLogDrainAddStub = nil
+	if fake.appLogDrainAddReturnsOnCall == nil {
+		fake.appLogDrainAddReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.appLogDrainAddReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppLogDrainList(arg1 string, arg2 string) (models.LogDrainsResponse, error) {
+	fake.appLogDrainListMutex.Lock()
+	ret, specificReturn := fake.appLogDrainListReturnsOnCall[len(fake.appLogDrainListArgsForCall)]
+	fake.appLogDrainListArgsForCall = append(fake.appLogDrainListArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppLogDrainListStub
+	fakeReturns := fake.appLogDrainListReturns
+	fake.recordInvocation("AppLogDrainList", []interface{}{arg1, arg2})
+	fake.appLogDrainListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppLogDrainListCallCount() int {
+	fake.appLogDrainListMutex.RLock()
+	defer fake.appLogDrainListMutex.RUnlock()
+	return len(fake.appLogDrainListArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppLogDrainListCalls(stub func(string, string) (models.LogDrainsResponse, error)) {
+	fake.appLogDrainListMutex.Lock()
+	defer fake.appLogDrainListMutex.Unlock()
+	fake.AppLogDrainListStub = stub
+}
+
+func (fake *FakeAPIClient) AppLogDrainListArgsForCall(i int) (string, string) {
+	fake.appLogDrainListMutex.RLock()
+	defer fake.appLogDrainListMutex.RUnlock()
+	argsForCall := fake.appLogDrainListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppLogDrainListReturns(result1 models.LogDrainsResponse, result2 error) {
+	fake.appLogDrainListMutex.Lock()
+	defer fake.appLogDrainListMutex.Unlock()
+	fake.AppLogDrainListStub = nil
+	fake.appLogDrainListReturns = struct {
+		result1 models.LogDrainsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppLogDrainListReturnsOnCall(i int, result1 models.LogDrainsResponse, result2 error) {
+	fake.appLogDrainListMutex.Lock()
+	defer fake.appLogDrainListMutex.Unlock()
+	fake.AppLogDrainListStub = nil
+	if fake.appLogDrainListReturnsOnCall == nil {
+		fake.appLogDrainListReturnsOnCall = make(map[int]struct {
+			result1 models.LogDrainsResponse
+			result2 error
+		})
+	}
+	fake.appLogDrainListReturnsOnCall[i] = struct {
+		result1 models.LogDrainsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppLogDrainRemove(arg1 string, arg2 string, arg3 string) (models.Response, error) {
+	fake.appLogDrainRemoveMutex.Lock()
+	ret, specificReturn := fake.appLogDrainRemoveReturnsOnCall[len(fake.appLogDrainRemoveArgsForCall)]
+	fake.appLogDrainRemoveArgsForCall = append(fake.appLogDrainRemoveArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AppLogDrainRemoveStub
+	fakeReturns := fake.appLogDrainRemoveReturns
+	fake.recordInvocation("AppLogDrainRemove", []interface{}{arg1, arg2, arg3})
+	fake.appLogDrainRemoveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppLogDrainRemoveCallCount() int {
+	fake.appLogDrainRemoveMutex.RLock()
+	defer fake.appLogDrainRemoveMutex.RUnlock()
+	return len(fake.appLogDrainRemoveArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppLogDrainRemoveCalls(stub func(string, string, string) (models.Response, error)) {
+	fake.appLogDrainRemoveMutex.Lock()
+	defer fake.appLogDrainRemoveMutex.Unlock()
+	fake.AppLogDrainRemoveStub = stub
+}
+
+func (fake *FakeAPIClient) AppLogDrainRemoveArgsForCall(i int) (string, string, string) {
+	fake.appLogDrainRemoveMutex.RLock()
+	defer fake.appLogDrainRemoveMutex.RUnlock()
+	argsForCall := fake.appLogDrainRemoveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) AppLogDrainRemoveReturns(result1 models.Response, result2 error) {
+	fake.appLogDrainRemoveMutex.Lock()
+	defer fake.appLogDrainRemoveMutex.Unlock()
+	fake.AppLogDrainRemoveStub = nil
+	fake.appLogDrainRemoveReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppLogDrainRemoveReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.appLogDrainRemoveMutex.Lock()
+	defer fake.appLogDrainRemoveMutex.Unlock()
+	fake.AppLogDrainRemoveStub = nil
+	if fake.appLogDrainRemoveReturnsOnCall == nil {
+		fake.appLogDrainRemoveReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.appLogDrainRemoveReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppLogs(arg1 string, arg2 string, arg3 string, arg4 bool, arg5 *client.LogOptions, arg6 func(tailer.ContainerLogLine)) error {
+	fake.appLogsMutex.Lock()
+	ret, specificReturn := fake.appLogsReturnsOnCall[len(fake.appLogsArgsForCall)]
+	fake.appLogsArgsForCall = append(fake.appLogsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 bool
+		arg5 *client.LogOptions
+		arg6 func(tailer.ContainerLogLine)
 	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.AppLogsStub
 	fakeReturns := fake.appLogsReturns
@@ -2116,38 +3213,104 @@ func (fake *FakeAPIClient) AppMatchReturnsOnCall(i int, result1 models.AppMatchR
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) AppPortForward(arg1 string, arg2 string, arg3 string, arg4 *client.PortForwardOpts) error {
-	fake.appPortForwardMutex.Lock()
-	ret, specificReturn := fake.appPortForwardReturnsOnCall[len(fake.appPortForwardArgsForCall)]
-	fake.appPortForwardArgsForCall = append(fake.appPortForwardArgsForCall, struct {
+func (fake *FakeAPIClient) AppMetrics(arg1 string, arg2 string, arg3 string) (models.AppMetricsResponse, error) {
+	fake.appMetricsMutex.Lock()
+	ret, specificReturn := fake.appMetricsReturnsOnCall[len(fake.appMetricsArgsForCall)]
+	fake.appMetricsArgsForCall = append(fake.appMetricsArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 string
-		arg4 *client.PortForwardOpts
-	}{arg1, arg2, arg3, arg4})
-	stub := fake.AppPortForwardStub
-	fakeReturns := fake.appPortForwardReturns
-	fake.recordInvocation("AppPortForward", []interface{}{arg1, arg2, arg3, arg4})
-	fake.appPortForwardMutex.Unlock()
+	}{arg1, arg2, arg3})
+	stub := fake.AppMetricsStub
+	fakeReturns := fake.appMetricsReturns
+	fake.recordInvocation("AppMetrics", []interface{}{arg1, arg2, arg3})
+	fake.appMetricsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) AppPortForwardCallCount() int {
-	fake.appPortForwardMutex.RLock()
-	defer fake.appPortForwardMutex.RUnlock()
-	return len(fake.appPortForwardArgsForCall)
+func (fake *FakeAPIClient) AppMetricsCallCount() int {
+	fake.appMetricsMutex.RLock()
+	defer fake.appMetricsMutex.RUnlock()
+	return len(fake.appMetricsArgsForCall)
 }
 
-func (fake *FakeAPIClient) AppPortForwardCalls(stub func(string, string, string, *client.PortForwardOpts) error) {
-	fake.appPortForwardMutex.Lock()
-	defer fake.appPortForwardMutex.Unlock()
-	fake.AppPortForwardStub = stub
+func (fake *FakeAPIClient) AppMetricsCalls(stub func(string, string, string) (models.AppMetricsResponse, error)) {
+	fake.appMetricsMutex.Lock()
+	defer fake.appMetricsMutex.Unlock()
+	fake.AppMetricsStub = stub
+}
+
+func (fake *FakeAPIClient) AppMetricsArgsForCall(i int) (string, string, string) {
+	fake.appMetricsMutex.RLock()
+	defer fake.appMetricsMutex.RUnlock()
+	argsForCall := fake.appMetricsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) AppMetricsReturns(result1 models.AppMetricsResponse, result2 error) {
+	fake.appMetricsMutex.Lock()
+	defer fake.appMetricsMutex.Unlock()
+	fake.AppMetricsStub = nil
+	fake.appMetricsReturns = struct {
+		result1 models.AppMetricsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppMetricsReturnsOnCall(i int, result1 models.AppMetricsResponse, result2 error) {
+	fake.appMetricsMutex.Lock()
+	defer fake.appMetricsMutex.Unlock()
+	fake.AppMetricsStub = nil
+	if fake.appMetricsReturnsOnCall == nil {
+		fake.appMetricsReturnsOnCall = make(map[int]struct {
+			result1 models.AppMetricsResponse
+			result2 error
+		})
+	}
+	fake.appMetricsReturnsOnCall[i] = struct {
+		result1 models.AppMetricsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppPortForward(arg1 string, arg2 string, arg3 string, arg4 *client.PortForwardOpts) error {
+	fake.appPortForwardMutex.Lock()
+	ret, specificReturn := fake.appPortForwardReturnsOnCall[len(fake.appPortForwardArgsForCall)]
+	fake.appPortForwardArgsForCall = append(fake.appPortForwardArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 *client.PortForwardOpts
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AppPortForwardStub
+	fakeReturns := fake.appPortForwardReturns
+	fake.recordInvocation("AppPortForward", []interface{}{arg1, arg2, arg3, arg4})
+	fake.appPortForwardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) AppPortForwardCallCount() int {
+	fake.appPortForwardMutex.RLock()
+	defer fake.appPortForwardMutex.RUnlock()
+	return len(fake.appPortForwardArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppPortForwardCalls(stub func(string, string, string, *client.PortForwardOpts) error) {
+	fake.appPortForwardMutex.Lock()
+	defer fake.appPortForwardMutex.Unlock()
+	fake.AppPortForwardStub = stub
 }
 
 func (fake *FakeAPIClient) AppPortForwardArgsForCall(i int) (string, string, string, *client.PortForwardOpts) {
@@ -2180,6 +3343,72 @@ func (fake *FakeAPIClient) AppPortForwardReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeAPIClient) AppPromote(arg1 string, arg2 string, arg3 models.AppPromoteRequest) (models.AppPromoteResponse, error) {
+	fake.appPromoteMutex.Lock()
+	ret, specificReturn := fake.appPromoteReturnsOnCall[len(fake.appPromoteArgsForCall)]
+	fake.appPromoteArgsForCall = append(fake.appPromoteArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 models.AppPromoteRequest
+	}{arg1, arg2, arg3})
+	stub := fake.AppPromoteStub
+	fakeReturns := fake.appPromoteReturns
+	fake.recordInvocation("AppPromote", []interface{}{arg1, arg2, arg3})
+	fake.appPromoteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppPromoteCallCount() int {
+	fake.appPromoteMutex.RLock()
+	defer fake.appPromoteMutex.RUnlock()
+	return len(fake.appPromoteArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppPromoteCalls(stub func(string, string, models.AppPromoteRequest) (models.AppPromoteResponse, error)) {
+	fake.appPromoteMutex.Lock()
+	defer fake.appPromoteMutex.Unlock()
+	fake.AppPromoteStub = stub
+}
+
+func (fake *FakeAPIClient) AppPromoteArgsForCall(i int) (string, string, models.AppPromoteRequest) {
+	fake.appPromoteMutex.RLock()
+	defer fake.appPromoteMutex.RUnlock()
+	argsForCall := fake.appPromoteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) AppPromoteReturns(result1 models.AppPromoteResponse, result2 error) {
+	fake.appPromoteMutex.Lock()
+	defer fake.appPromoteMutex.Unlock()
+	fake.AppPromoteStub = nil
+	fake.appPromoteReturns = struct {
+		result1 models.AppPromoteResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppPromoteReturnsOnCall(i int, result1 models.AppPromoteResponse, result2 error) {
+	fake.appPromoteMutex.Lock()
+	defer fake.appPromoteMutex.Unlock()
+	fake.AppPromoteStub = nil
+	if fake.appPromoteReturnsOnCall == nil {
+		fake.appPromoteReturnsOnCall = make(map[int]struct {
+			result1 models.AppPromoteResponse
+			result2 error
+		})
+	}
+	fake.appPromoteReturnsOnCall[i] = struct {
+		result1 models.AppPromoteResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) AppRestart(arg1 string, arg2 string) (models.Response, error) {
 	fake.appRestartMutex.Lock()
 	ret, specificReturn := fake.appRestartReturnsOnCall[len(fake.appRestartArgsForCall)]
@@ -2309,6 +3538,71 @@ func (fake *FakeAPIClient) AppRunningReturnsOnCall(i int, result1 models.Respons
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppScan(arg1 string, arg2 string) (models.ScanReport, error) {
+	fake.appScanMutex.Lock()
+	ret, specificReturn := fake.appScanReturnsOnCall[len(fake.appScanArgsForCall)]
+	fake.appScanArgsForCall = append(fake.appScanArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppScanStub
+	fakeReturns := fake.appScanReturns
+	fake.recordInvocation("AppScan", []interface{}{arg1, arg2})
+	fake.appScanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppScanCallCount() int {
+	fake.appScanMutex.RLock()
+	defer fake.appScanMutex.RUnlock()
+	return len(fake.appScanArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppScanCalls(stub func(string, string) (models.ScanReport, error)) {
+	fake.appScanMutex.Lock()
+	defer fake.appScanMutex.Unlock()
+	fake.AppScanStub = stub
+}
+
+func (fake *FakeAPIClient) AppScanArgsForCall(i int) (string, string) {
+	fake.appScanMutex.RLock()
+	defer fake.appScanMutex.RUnlock()
+	argsForCall := fake.appScanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppScanReturns(result1 models.ScanReport, result2 error) {
+	fake.appScanMutex.Lock()
+	defer fake.appScanMutex.Unlock()
+	fake.AppScanStub = nil
+	fake.appScanReturns = struct {
+		result1 models.ScanReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppScanReturnsOnCall(i int, result1 models.ScanReport, result2 error) {
+	fake.appScanMutex.Lock()
+	defer fake.appScanMutex.Unlock()
+	fake.AppScanStub = nil
+	if fake.appScanReturnsOnCall == nil {
+		fake.appScanReturnsOnCall = make(map[int]struct {
+			result1 models.ScanReport
+			result2 error
+		})
+	}
+	fake.appScanReturnsOnCall[i] = struct {
+		result1 models.ScanReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) AppShow(arg1 string, arg2 string) (models.App, error) {
 	fake.appShowMutex.Lock()
 	ret, specificReturn := fake.appShowReturnsOnCall[len(fake.appShowArgsForCall)]
@@ -4833,29 +6127,576 @@ func (fake *FakeAPIClient) GitconfigCreateReturnsOnCall(i int, result1 models.Re
 			result2 error
 		})
 	}
-	fake.gitconfigCreateReturnsOnCall[i] = struct {
+	fake.gitconfigCreateReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) GitconfigDelete(arg1 []string) (models.Response, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.gitconfigDeleteMutex.Lock()
+	ret, specificReturn := fake.gitconfigDeleteReturnsOnCall[len(fake.gitconfigDeleteArgsForCall)]
+	fake.gitconfigDeleteArgsForCall = append(fake.gitconfigDeleteArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.GitconfigDeleteStub
+	fakeReturns := fake.gitconfigDeleteReturns
+	fake.recordInvocation("GitconfigDelete", []interface{}{arg1Copy})
+	fake.gitconfigDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) GitconfigDeleteCallCount() int {
+	fake.gitconfigDeleteMutex.RLock()
+	defer fake.gitconfigDeleteMutex.RUnlock()
+	return len(fake.gitconfigDeleteArgsForCall)
+}
+
+func (fake *FakeAPIClient) GitconfigDeleteCalls(stub func([]string) (models.Response, error)) {
+	fake.gitconfigDeleteMutex.Lock()
+	defer fake.gitconfigDeleteMutex.Unlock()
+	fake.GitconfigDeleteStub = stub
+}
+
+func (fake *FakeAPIClient) GitconfigDeleteArgsForCall(i int) []string {
+	fake.gitconfigDeleteMutex.RLock()
+	defer fake.gitconfigDeleteMutex.RUnlock()
+	argsForCall := fake.gitconfigDeleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) GitconfigDeleteReturns(result1 models.Response, result2 error) {
+	fake.gitconfigDeleteMutex.Lock()
+	defer fake.gitconfigDeleteMutex.Unlock()
+	fake.GitconfigDeleteStub = nil
+	fake.gitconfigDeleteReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) GitconfigDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.gitconfigDeleteMutex.Lock()
+	defer fake.gitconfigDeleteMutex.Unlock()
+	fake.GitconfigDeleteStub = nil
+	if fake.gitconfigDeleteReturnsOnCall == nil {
+		fake.gitconfigDeleteReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.gitconfigDeleteReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) GitconfigShow(arg1 string) (models.Gitconfig, error) {
+	fake.gitconfigShowMutex.Lock()
+	ret, specificReturn := fake.gitconfigShowReturnsOnCall[len(fake.gitconfigShowArgsForCall)]
+	fake.gitconfigShowArgsForCall = append(fake.gitconfigShowArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GitconfigShowStub
+	fakeReturns := fake.gitconfigShowReturns
+	fake.recordInvocation("GitconfigShow", []interface{}{arg1})
+	fake.gitconfigShowMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) GitconfigShowCallCount() int {
+	fake.gitconfigShowMutex.RLock()
+	defer fake.gitconfigShowMutex.RUnlock()
+	return len(fake.gitconfigShowArgsForCall)
+}
+
+func (fake *FakeAPIClient) GitconfigShowCalls(stub func(string) (models.Gitconfig, error)) {
+	fake.gitconfigShowMutex.Lock()
+	defer fake.gitconfigShowMutex.Unlock()
+	fake.GitconfigShowStub = stub
+}
+
+func (fake *FakeAPIClient) GitconfigShowArgsForCall(i int) string {
+	fake.gitconfigShowMutex.RLock()
+	defer fake.gitconfigShowMutex.RUnlock()
+	argsForCall := fake.gitconfigShowArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) GitconfigShowReturns(result1 models.Gitconfig, result2 error) {
+	fake.gitconfigShowMutex.Lock()
+	defer fake.gitconfigShowMutex.Unlock()
+	fake.GitconfigShowStub = nil
+	fake.gitconfigShowReturns = struct {
+		result1 models.Gitconfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) GitconfigShowReturnsOnCall(i int, result1 models.Gitconfig, result2 error) {
+	fake.gitconfigShowMutex.Lock()
+	defer fake.gitconfigShowMutex.Unlock()
+	fake.GitconfigShowStub = nil
+	if fake.gitconfigShowReturnsOnCall == nil {
+		fake.gitconfigShowReturnsOnCall = make(map[int]struct {
+			result1 models.Gitconfig
+			result2 error
+		})
+	}
+	fake.gitconfigShowReturnsOnCall[i] = struct {
+		result1 models.Gitconfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) Gitconfigs() (models.GitconfigList, error) {
+	fake.gitconfigsMutex.Lock()
+	ret, specificReturn := fake.gitconfigsReturnsOnCall[len(fake.gitconfigsArgsForCall)]
+	fake.gitconfigsArgsForCall = append(fake.gitconfigsArgsForCall, struct {
+	}{})
+	stub := fake.GitconfigsStub
+	fakeReturns := fake.gitconfigsReturns
+	fake.recordInvocation("Gitconfigs", []interface{}{})
+	fake.gitconfigsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) GitconfigsCallCount() int {
+	fake.gitconfigsMutex.RLock()
+	defer fake.gitconfigsMutex.RUnlock()
+	return len(fake.gitconfigsArgsForCall)
+}
+
+func (fake *FakeAPIClient) GitconfigsCalls(stub func() (models.GitconfigList, error)) {
+	fake.gitconfigsMutex.Lock()
+	defer fake.gitconfigsMutex.Unlock()
+	fake.GitconfigsStub = stub
+}
+
+func (fake *FakeAPIClient) GitconfigsReturns(result1 models.GitconfigList, result2 error) {
+	fake.gitconfigsMutex.Lock()
+	defer fake.gitconfigsMutex.Unlock()
+	fake.GitconfigsStub = nil
+	fake.gitconfigsReturns = struct {
+		result1 models.GitconfigList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) GitconfigsReturnsOnCall(i int, result1 models.GitconfigList, result2 error) {
+	fake.gitconfigsMutex.Lock()
+	defer fake.gitconfigsMutex.Unlock()
+	fake.GitconfigsStub = nil
+	if fake.gitconfigsReturnsOnCall == nil {
+		fake.gitconfigsReturnsOnCall = make(map[int]struct {
+			result1 models.GitconfigList
+			result2 error
+		})
+	}
+	fake.gitconfigsReturnsOnCall[i] = struct {
+		result1 models.GitconfigList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) GitconfigsMatch(arg1 string) (models.GitconfigsMatchResponse, error) {
+	fake.gitconfigsMatchMutex.Lock()
+	ret, specificReturn := fake.gitconfigsMatchReturnsOnCall[len(fake.gitconfigsMatchArgsForCall)]
+	fake.gitconfigsMatchArgsForCall = append(fake.gitconfigsMatchArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GitconfigsMatchStub
+	fakeReturns := fake.gitconfigsMatchReturns
+	fake.recordInvocation("GitconfigsMatch", []interface{}{arg1})
+	fake.gitconfigsMatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) GitconfigsMatchCallCount() int {
+	fake.gitconfigsMatchMutex.RLock()
+	defer fake.gitconfigsMatchMutex.RUnlock()
+	return len(fake.gitconfigsMatchArgsForCall)
+}
+
+func (fake *FakeAPIClient) GitconfigsMatchCalls(stub func(string) (models.GitconfigsMatchResponse, error)) {
+	fake.gitconfigsMatchMutex.Lock()
+	defer fake.gitconfigsMatchMutex.Unlock()
+	fake.GitconfigsMatchStub = stub
+}
+
+func (fake *FakeAPIClient) GitconfigsMatchArgsForCall(i int) string {
+	fake.gitconfigsMatchMutex.RLock()
+	defer fake.gitconfigsMatchMutex.RUnlock()
+	argsForCall := fake.gitconfigsMatchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) GitconfigsMatchReturns(result1 models.GitconfigsMatchResponse, result2 error) {
+	fake.gitconfigsMatchMutex.Lock()
+	defer fake.gitconfigsMatchMutex.Unlock()
+	fake.GitconfigsMatchStub = nil
+	fake.gitconfigsMatchReturns = struct {
+		result1 models.GitconfigsMatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) GitconfigsMatchReturnsOnCall(i int, result1 models.GitconfigsMatchResponse, result2 error) {
+	fake.gitconfigsMatchMutex.Lock()
+	defer fake.gitconfigsMatchMutex.Unlock()
+	fake.GitconfigsMatchStub = nil
+	if fake.gitconfigsMatchReturnsOnCall == nil {
+		fake.gitconfigsMatchReturnsOnCall = make(map[int]struct {
+			result1 models.GitconfigsMatchResponse
+			result2 error
+		})
+	}
+	fake.gitconfigsMatchReturnsOnCall[i] = struct {
+		result1 models.GitconfigsMatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) Headers() http.Header {
+	fake.headersMutex.Lock()
+	ret, specificReturn := fake.headersReturnsOnCall[len(fake.headersArgsForCall)]
+	fake.headersArgsForCall = append(fake.headersArgsForCall, struct {
+	}{})
+	stub := fake.HeadersStub
+	fakeReturns := fake.headersReturns
+	fake.recordInvocation("Headers", []interface{}{})
+	fake.headersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) HeadersCallCount() int {
+	fake.headersMutex.RLock()
+	defer fake.headersMutex.RUnlock()
+	return len(fake.headersArgsForCall)
+}
+
+func (fake *FakeAPIClient) HeadersCalls(stub func() http.Header) {
+	fake.headersMutex.Lock()
+	defer fake.headersMutex.Unlock()
+	fake.HeadersStub = stub
+}
+
+func (fake *FakeAPIClient) HeadersReturns(result1 http.Header) {
+	fake.headersMutex.Lock()
+	defer fake.headersMutex.Unlock()
+	fake.HeadersStub = nil
+	fake.headersReturns = struct {
+		result1 http.Header
+	}{result1}
+}
+
+func (fake *FakeAPIClient) HeadersReturnsOnCall(i int, result1 http.Header) {
+	fake.headersMutex.Lock()
+	defer fake.headersMutex.Unlock()
+	fake.HeadersStub = nil
+	if fake.headersReturnsOnCall == nil {
+		fake.headersReturnsOnCall = make(map[int]struct {
+			result1 http.Header
+		})
+	}
+	fake.headersReturnsOnCall[i] = struct {
+		result1 http.Header
+	}{result1}
+}
+
+func (fake *FakeAPIClient) Info() (models.InfoResponse, error) {
+	fake.infoMutex.Lock()
+	ret, specificReturn := fake.infoReturnsOnCall[len(fake.infoArgsForCall)]
+	fake.infoArgsForCall = append(fake.infoArgsForCall, struct {
+	}{})
+	stub := fake.InfoStub
+	fakeReturns := fake.infoReturns
+	fake.recordInvocation("Info", []interface{}{})
+	fake.infoMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) InfoCallCount() int {
+	fake.infoMutex.RLock()
+	defer fake.infoMutex.RUnlock()
+	return len(fake.infoArgsForCall)
+}
+
+func (fake *FakeAPIClient) InfoCalls(stub func() (models.InfoResponse, error)) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = stub
+}
+
+func (fake *FakeAPIClient) InfoReturns(result1 models.InfoResponse, result2 error) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = nil
+	fake.infoReturns = struct {
+		result1 models.InfoResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) InfoReturnsOnCall(i int, result1 models.InfoResponse, result2 error) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = nil
+	if fake.infoReturnsOnCall == nil {
+		fake.infoReturnsOnCall = make(map[int]struct {
+			result1 models.InfoResponse
+			result2 error
+		})
+	}
+	fake.infoReturnsOnCall[i] = struct {
+		result1 models.InfoResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) Me() (models.MeResponse, error) {
+	fake.meMutex.Lock()
+	ret, specificReturn := fake.meReturnsOnCall[len(fake.meArgsForCall)]
+	fake.meArgsForCall = append(fake.meArgsForCall, struct {
+	}{})
+	stub := fake.MeStub
+	fakeReturns := fake.meReturns
+	fake.recordInvocation("Me", []interface{}{})
+	fake.meMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) MeCallCount() int {
+	fake.meMutex.RLock()
+	defer fake.meMutex.RUnlock()
+	return len(fake.meArgsForCall)
+}
+
+func (fake *FakeAPIClient) MeCalls(stub func() (models.MeResponse, error)) {
+	fake.meMutex.Lock()
+	defer fake.meMutex.Unlock()
+	fake.MeStub = stub
+}
+
+func (fake *FakeAPIClient) MeReturns(result1 models.MeResponse, result2 error) {
+	fake.meMutex.Lock()
+	defer fake.meMutex.Unlock()
+	fake.MeStub = nil
+	fake.meReturns = struct {
+		result1 models.MeResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) MeReturnsOnCall(i int, result1 models.MeResponse, result2 error) {
+	fake.meMutex.Lock()
+	defer fake.meMutex.Unlock()
+	fake.MeStub = nil
+	if fake.meReturnsOnCall == nil {
+		fake.meReturnsOnCall = make(map[int]struct {
+			result1 models.MeResponse
+			result2 error
+		})
+	}
+	fake.meReturnsOnCall[i] = struct {
+		result1 models.MeResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceCreate(arg1 models.NamespaceCreateRequest) (models.Response, error) {
+	fake.namespaceCreateMutex.Lock()
+	ret, specificReturn := fake.namespaceCreateReturnsOnCall[len(fake.namespaceCreateArgsForCall)]
+	fake.namespaceCreateArgsForCall = append(fake.namespaceCreateArgsForCall, struct {
+		arg1 models.NamespaceCreateRequest
+	}{arg1})
+	stub := fake.NamespaceCreateStub
+	fakeReturns := fake.namespaceCreateReturns
+	fake.recordInvocation("NamespaceCreate", []interface{}{arg1})
+	fake.namespaceCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceCreateCallCount() int {
+	fake.namespaceCreateMutex.RLock()
+	defer fake.namespaceCreateMutex.RUnlock()
+	return len(fake.namespaceCreateArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceCreateCalls(stub func(models.NamespaceCreateRequest) (models.Response, error)) {
+	fake.namespaceCreateMutex.Lock()
+	defer fake.namespaceCreateMutex.Unlock()
+	fake.NamespaceCreateStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceCreateArgsForCall(i int) models.NamespaceCreateRequest {
+	fake.namespaceCreateMutex.RLock()
+	defer fake.namespaceCreateMutex.RUnlock()
+	argsForCall := fake.namespaceCreateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespaceCreateReturns(result1 models.Response, result2 error) {
+	fake.namespaceCreateMutex.Lock()
+	defer fake.namespaceCreateMutex.Unlock()
+	fake.NamespaceCreateStub = nil
+	fake.namespaceCreateReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceCreateReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceCreateMutex.Lock()
+	defer fake.namespaceCreateMutex.Unlock()
+	fake.NamespaceCreateStub = nil
+	if fake.namespaceCreateReturnsOnCall == nil {
+		fake.namespaceCreateReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceCreateReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceDelete(arg1 []string) (models.Response, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.namespaceDeleteMutex.Lock()
+	ret, specificReturn := fake.namespaceDeleteReturnsOnCall[len(fake.namespaceDeleteArgsForCall)]
+	fake.namespaceDeleteArgsForCall = append(fake.namespaceDeleteArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.NamespaceDeleteStub
+	fakeReturns := fake.namespaceDeleteReturns
+	fake.recordInvocation("NamespaceDelete", []interface{}{arg1Copy})
+	fake.namespaceDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteCallCount() int {
+	fake.namespaceDeleteMutex.RLock()
+	defer fake.namespaceDeleteMutex.RUnlock()
+	return len(fake.namespaceDeleteArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteCalls(stub func([]string) (models.Response, error)) {
+	fake.namespaceDeleteMutex.Lock()
+	defer fake.namespaceDeleteMutex.Unlock()
+	fake.NamespaceDeleteStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteArgsForCall(i int) []string {
+	fake.namespaceDeleteMutex.RLock()
+	defer fake.namespaceDeleteMutex.RUnlock()
+	argsForCall := fake.namespaceDeleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteReturns(result1 models.Response, result2 error) {
+	fake.namespaceDeleteMutex.Lock()
+	defer fake.namespaceDeleteMutex.Unlock()
+	fake.NamespaceDeleteStub = nil
+	fake.namespaceDeleteReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceDeleteMutex.Lock()
+	defer fake.namespaceDeleteMutex.Unlock()
+	fake.NamespaceDeleteStub = nil
+	if fake.namespaceDeleteReturnsOnCall == nil {
+		fake.namespaceDeleteReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceDeleteReturnsOnCall[i] = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) GitconfigDelete(arg1 []string) (models.Response, error) {
-	var arg1Copy []string
-	if arg1 != nil {
-		arg1Copy = make([]string, len(arg1))
-		copy(arg1Copy, arg1)
-	}
-	fake.gitconfigDeleteMutex.Lock()
-	ret, specificReturn := fake.gitconfigDeleteReturnsOnCall[len(fake.gitconfigDeleteArgsForCall)]
-	fake.gitconfigDeleteArgsForCall = append(fake.gitconfigDeleteArgsForCall, struct {
-		arg1 []string
-	}{arg1Copy})
-	stub := fake.GitconfigDeleteStub
-	fakeReturns := fake.gitconfigDeleteReturns
-	fake.recordInvocation("GitconfigDelete", []interface{}{arg1Copy})
-	fake.gitconfigDeleteMutex.Unlock()
+func (fake *FakeAPIClient) NamespaceLogDrainAdd(arg1 string, arg2 models.LogDrainAddRequest) (models.Response, error) {
+	fake.namespaceLogDrainAddMutex.Lock()
+	ret, specificReturn := fake.namespaceLogDrainAddReturnsOnCall[len(fake.namespaceLogDrainAddArgsForCall)]
+	fake.namespaceLogDrainAddArgsForCall = append(fake.namespaceLogDrainAddArgsForCall, struct {
+		arg1 string
+		arg2 models.LogDrainAddRequest
+	}{arg1, arg2})
+	stub := fake.NamespaceLogDrainAddStub
+	fakeReturns := fake.namespaceLogDrainAddReturns
+	fake.recordInvocation("NamespaceLogDrainAdd", []interface{}{arg1, arg2})
+	fake.namespaceLogDrainAddMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -4863,61 +6704,61 @@ func (fake *FakeAPIClient) GitconfigDelete(arg1 []string) (models.Response, erro
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) GitconfigDeleteCallCount() int {
-	fake.gitconfigDeleteMutex.RLock()
-	defer fake.gitconfigDeleteMutex.RUnlock()
-	return len(fake.gitconfigDeleteArgsForCall)
+func (fake *FakeAPIClient) NamespaceLogDrainAddCallCount() int {
+	fake.namespaceLogDrainAddMutex.RLock()
+	defer fake.namespaceLogDrainAddMutex.RUnlock()
+	return len(fake.namespaceLogDrainAddArgsForCall)
 }
 
-func (fake *FakeAPIClient) GitconfigDeleteCalls(stub func([]string) (models.Response, error)) {
-	fake.gitconfigDeleteMutex.Lock()
-	defer fake.gitconfigDeleteMutex.Unlock()
-	fake.GitconfigDeleteStub = stub
+func (fake *FakeAPIClient) NamespaceLogDrainAddCalls(stub func(string, models.LogDrainAddRequest) (models.Response, error)) {
+	fake.namespaceLogDrainAddMutex.Lock()
+	defer fake.namespaceLogDrainAddMutex.Unlock()
+	fake.NamespaceLogDrainAddStub = stub
 }
 
-func (fake *FakeAPIClient) GitconfigDeleteArgsForCall(i int) []string {
-	fake.gitconfigDeleteMutex.RLock()
-	defer fake.gitconfigDeleteMutex.RUnlock()
-	argsForCall := fake.gitconfigDeleteArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeAPIClient) NamespaceLogDrainAddArgsForCall(i int) (string, models.LogDrainAddRequest) {
+	fake.namespaceLogDrainAddMutex.RLock()
+	defer fake.namespaceLogDrainAddMutex.RUnlock()
+	argsForCall := fake.namespaceLogDrainAddArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) GitconfigDeleteReturns(result1 models.Response, result2 error) {
-	fake.gitconfigDeleteMutex.Lock()
-	defer fake.gitconfigDeleteMutex.Unlock()
-	fake.GitconfigDeleteStub = nil
-	fake.gitconfigDeleteReturns = struct {
+func (fake *FakeAPIClient) NamespaceLogDrainAddReturns(result1 models.Response, result2 error) {
+	fake.namespaceLogDrainAddMutex.Lock()
+	defer fake.namespaceLogDrainAddMutex.Unlock()
+	fake.NamespaceLogDrainAddStub = nil
+	fake.namespaceLogDrainAddReturns = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) GitconfigDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
-	fake.gitconfigDeleteMutex.Lock()
-	defer fake.gitconfigDeleteMutex.Unlock()
-	fake.GitconfigDeleteStub = nil
-	if fake.gitconfigDeleteReturnsOnCall == nil {
-		fake.gitconfigDeleteReturnsOnCall = make(map[int]struct {
+func (fake *FakeAPIClient) NamespaceLogDrainAddReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceLogDrainAddMutex.Lock()
+	defer fake.namespaceLogDrainAddMutex.Unlock()
+	fake.NamespaceLogDrainAddStub = nil
+	if fake.namespaceLogDrainAddReturnsOnCall == nil {
+		fake.namespaceLogDrainAddReturnsOnCall = make(map[int]struct {
 			result1 models.Response
 			result2 error
 		})
 	}
-	fake.gitconfigDeleteReturnsOnCall[i] = struct {
+	fake.namespaceLogDrainAddReturnsOnCall[i] = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) GitconfigShow(arg1 string) (models.Gitconfig, error) {
-	fake.gitconfigShowMutex.Lock()
-	ret, specificReturn := fake.gitconfigShowReturnsOnCall[len(fake.gitconfigShowArgsForCall)]
-	fake.gitconfigShowArgsForCall = append(fake.gitconfigShowArgsForCall, struct {
+func (fake *FakeAPIClient) NamespaceLogDrainList(arg1 string) (models.LogDrainsResponse, error) {
+	fake.namespaceLogDrainListMutex.Lock()
+	ret, specificReturn := fake.namespaceLogDrainListReturnsOnCall[len(fake.namespaceLogDrainListArgsForCall)]
+	fake.namespaceLogDrainListArgsForCall = append(fake.namespaceLogDrainListArgsForCall, struct {
 		arg1 string
 	}{arg1})
-	stub := fake.GitconfigShowStub
-	fakeReturns := fake.gitconfigShowReturns
-	fake.recordInvocation("GitconfigShow", []interface{}{arg1})
-	fake.gitconfigShowMutex.Unlock()
+	stub := fake.NamespaceLogDrainListStub
+	fakeReturns := fake.namespaceLogDrainListReturns
+	fake.recordInvocation("NamespaceLogDrainList", []interface{}{arg1})
+	fake.namespaceLogDrainListMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -4927,62 +6768,64 @@ func (fake *FakeAPIClient) GitconfigShow(arg1 string) (models.Gitconfig, error)
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) GitconfigShowCallCount() int {
-	fake.gitconfigShowMutex.RLock()
-	defer fake.gitconfigShowMutex.RUnlock()
-	return len(fake.gitconfigShowArgsForCall)
+func (fake *FakeAPIClient) NamespaceLogDrainListCallCount() int {
+	fake.namespaceLogDrainListMutex.RLock()
+	defer fake.namespaceLogDrainListMutex.RUnlock()
+	return len(fake.namespaceLogDrainListArgsForCall)
 }
 
-func (fake *FakeAPIClient) GitconfigShowCalls(stub func(string) (models.Gitconfig, error)) {
-	fake.gitconfigShowMutex.Lock()
-	defer fake.gitconfigShowMutex.Unlock()
-	fake.GitconfigShowStub = stub
+func (fake *FakeAPIClient) NamespaceLogDrainListCalls(stub func(string) (models.LogDrainsResponse, error)) {
+	fake.namespaceLogDrainListMutex.Lock()
+	defer fake.namespaceLogDrainListMutex.Unlock()
+	fake.NamespaceLogDrainListStub = stub
 }
 
-func (fake *FakeAPIClient) GitconfigShowArgsForCall(i int) string {
-	fake.gitconfigShowMutex.RLock()
-	defer fake.gitconfigShowMutex.RUnlock()
-	argsForCall := fake.gitconfigShowArgsForCall[i]
+func (fake *FakeAPIClient) NamespaceLogDrainListArgsForCall(i int) string {
+	fake.namespaceLogDrainListMutex.RLock()
+	defer fake.namespaceLogDrainListMutex.RUnlock()
+	argsForCall := fake.namespaceLogDrainListArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeAPIClient) GitconfigShowReturns(result1 models.Gitconfig, result2 error) {
-	fake.gitconfigShowMutex.Lock()
-	defer fake.gitconfigShowMutex.Unlock()
-	fake.GitconfigShowStub = nil
-	fake.gitconfigShowReturns = struct {
-		result1 models.Gitconfig
+func (fake *FakeAPIClient) NamespaceLogDrainListReturns(result1 models.LogDrainsResponse, result2 error) {
+	fake.namespaceLogDrainListMutex.Lock()
+	defer fake.namespaceLogDrainListMutex.Unlock()
+	fake.NamespaceLogDrainListStub = nil
+	fake.namespaceLogDrainListReturns = struct {
+		result1 models.LogDrainsResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) GitconfigShowReturnsOnCall(i int, result1 models.Gitconfig, result2 error) {
-	fake.gitconfigShowMutex.Lock()
-	defer fake.gitconfigShowMutex.Unlock()
-	fake.GitconfigShowStub = nil
-	if fake.gitconfigShowReturnsOnCall == nil {
-		fake.gitconfigShowReturnsOnCall = make(map[int]struct {
-			result1 models.Gitconfig
+func (fake *FakeAPIClient) NamespaceLogDrainListReturnsOnCall(i int, result1 models.LogDrainsResponse, result2 error) {
+	fake.namespaceLogDrainListMutex.Lock()
+	defer fake.namespaceLogDrainListMutex.Unlock()
+	fake.NamespaceLogDrainListStub = nil
+	if fake.namespaceLogDrainListReturnsOnCall == nil {
+		fake.namespaceLogDrainListReturnsOnCall = make(map[int]struct {
+			result1 models.LogDrainsResponse
 			result2 error
 		})
 	}
-	fake.gitconfigShowReturnsOnCall[i] = struct {
-		result1 models.Gitconfig
+	fake.namespaceLogDrainListReturnsOnCall[i] = struct {
+		result1 models.LogDrainsResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) Gitconfigs() (models.GitconfigList, error) {
-	fake.gitconfigsMutex.Lock()
-	ret, specificReturn := fake.gitconfigsReturnsOnCall[len(fake.gitconfigsArgsForCall)]
-	fake.gitconfigsArgsForCall = append(fake.gitconfigsArgsForCall, struct {
-	}{})
-	stub := fake.GitconfigsStub
-	fakeReturns := fake.gitconfigsReturns
-	fake.recordInvocation("Gitconfigs", []interface{}{})
-	fake.gitconfigsMutex.Unlock()
+func (fake *FakeAPIClient) NamespaceLogDrainRemove(arg1 string, arg2 string) (models.Response, error) {
+	fake.namespaceLogDrainRemoveMutex.Lock()
+	ret, specificReturn := fake.namespaceLogDrainRemoveReturnsOnCall[len(fake.namespaceLogDrainRemoveArgsForCall)]
+	fake.namespaceLogDrainRemoveArgsForCall = append(fake.namespaceLogDrainRemoveArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NamespaceLogDrainRemoveStub
+	fakeReturns := fake.namespaceLogDrainRemoveReturns
+	fake.recordInvocation("NamespaceLogDrainRemove", []interface{}{arg1, arg2})
+	fake.namespaceLogDrainRemoveMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -4990,54 +6833,61 @@ func (fake *FakeAPIClient) Gitconfigs() (models.GitconfigList, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) GitconfigsCallCount() int {
-	fake.gitconfigsMutex.RLock()
-	defer fake.gitconfigsMutex.RUnlock()
-	return len(fake.gitconfigsArgsForCall)
+func (fake *FakeAPIClient) NamespaceLogDrainRemoveCallCount() int {
+	fake.namespaceLogDrainRemoveMutex.RLock()
+	defer fake.namespaceLogDrainRemoveMutex.RUnlock()
+	return len(fake.namespaceLogDrainRemoveArgsForCall)
 }
 
-func (fake *FakeAPIClient) GitconfigsCalls(stub func() (models.GitconfigList, error)) {
-	fake.gitconfigsMutex.Lock()
-	defer fake.gitconfigsMutex.Unlock()
-	fake.GitconfigsStub = stub
+func (fake *FakeAPIClient) NamespaceLogDrainRemoveCalls(stub func(string, string) (models.Response, error)) {
+	fake.namespaceLogDrainRemoveMutex.Lock()
+	defer fake.namespaceLogDrainRemoveMutex.Unlock()
+	fake.NamespaceLogDrainRemoveStub = stub
 }
 
-func (fake *FakeAPIClient) GitconfigsReturns(result1 models.GitconfigList, result2 error) {
-	fake.gitconfigsMutex.Lock()
-	defer fake.gitconfigsMutex.Unlock()
-	fake.GitconfigsStub = nil
-	fake.gitconfigsReturns = struct {
-		result1 models.GitconfigList
+func (fake *FakeAPIClient) NamespaceLogDrainRemoveArgsForCall(i int) (string, string) {
+	fake.namespaceLogDrainRemoveMutex.RLock()
+	defer fake.namespaceLogDrainRemoveMutex.RUnlock()
+	argsForCall := fake.namespaceLogDrainRemoveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceLogDrainRemoveReturns(result1 models.Response, result2 error) {
+	fake.namespaceLogDrainRemoveMutex.Lock()
+	defer fake.namespaceLogDrainRemoveMutex.Unlock()
+	fake.NamespaceLogDrainRemoveStub = nil
+	fake.namespaceLogDrainRemoveReturns = struct {
+		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) GitconfigsReturnsOnCall(i int, result1 models.GitconfigList, result2 error) {
-	fake.gitconfigsMutex.Lock()
-	defer fake.gitconfigsMutex.Unlock()
-	fake.GitconfigsStub = nil
-	if fake.gitconfigsReturnsOnCall == nil {
-		fake.gitconfigsReturnsOnCall = make(map[int]struct {
-			result1 models.GitconfigList
+func (fake *FakeAPIClient) NamespaceLogDrainRemoveReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceLogDrainRemoveMutex.Lock()
+	defer fake.namespaceLogDrainRemoveMutex.Unlock()
+	fake.NamespaceLogDrainRemoveStub = nil
+	if fake.namespaceLogDrainRemoveReturnsOnCall == nil {
+		fake.namespaceLogDrainRemoveReturnsOnCall = make(map[int]struct {
+			result1 models.Response
 			result2 error
 		})
 	}
-	fake.gitconfigsReturnsOnCall[i] = struct {
-		result1 models.GitconfigList
+	fake.namespaceLogDrainRemoveReturnsOnCall[i] = struct {
+		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) GitconfigsMatch(arg1 string) (models.GitconfigsMatchResponse, error) {
-	fake.gitconfigsMatchMutex.Lock()
-	ret, specificReturn := fake.gitconfigsMatchReturnsOnCall[len(fake.gitconfigsMatchArgsForCall)]
-	fake.gitconfigsMatchArgsForCall = append(fake.gitconfigsMatchArgsForCall, struct {
+func (fake *FakeAPIClient) NamespaceLogRetentionShow(arg1 string) (models.NamespaceLogRetention, error) {
+	fake.namespaceLogRetentionShowMutex.Lock()
+	ret, specificReturn := fake.namespaceLogRetentionShowReturnsOnCall[len(fake.namespaceLogRetentionShowArgsForCall)]
+	fake.namespaceLogRetentionShowArgsForCall = append(fake.namespaceLogRetentionShowArgsForCall, struct {
 		arg1 string
 	}{arg1})
-	stub := fake.GitconfigsMatchStub
-	fakeReturns := fake.gitconfigsMatchReturns
-	fake.recordInvocation("GitconfigsMatch", []interface{}{arg1})
-	fake.gitconfigsMatchMutex.Unlock()
+	stub := fake.NamespaceLogRetentionShowStub
+	fakeReturns := fake.namespaceLogRetentionShowReturns
+	fake.recordInvocation("NamespaceLogRetentionShow", []interface{}{arg1})
+	fake.namespaceLogRetentionShowMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -5047,115 +6897,128 @@ func (fake *FakeAPIClient) GitconfigsMatch(arg1 string) (models.GitconfigsMatchR
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) GitconfigsMatchCallCount() int {
-	fake.gitconfigsMatchMutex.RLock()
-	defer fake.gitconfigsMatchMutex.RUnlock()
-	return len(fake.gitconfigsMatchArgsForCall)
+func (fake *FakeAPIClient) NamespaceLogRetentionShowCallCount() int {
+	fake.namespaceLogRetentionShowMutex.RLock()
+	defer fake.namespaceLogRetentionShowMutex.RUnlock()
+	return len(fake.namespaceLogRetentionShowArgsForCall)
 }
 
-func (fake *FakeAPIClient) GitconfigsMatchCalls(stub func(string) (models.GitconfigsMatchResponse, error)) {
-	fake.gitconfigsMatchMutex.Lock()
-	defer fake.gitconfigsMatchMutex.Unlock()
-	fake.GitconfigsMatchStub = stub
+func (fake *FakeAPIClient) NamespaceLogRetentionShowCalls(stub func(string) (models.NamespaceLogRetention, error)) {
+	fake.namespaceLogRetentionShowMutex.Lock()
+	defer fake.namespaceLogRetentionShowMutex.Unlock()
+	fake.NamespaceLogRetentionShowStub = stub
 }
 
-func (fake *FakeAPIClient) GitconfigsMatchArgsForCall(i int) string {
-	fake.gitconfigsMatchMutex.RLock()
-	defer fake.gitconfigsMatchMutex.RUnlock()
-	argsForCall := fake.gitconfigsMatchArgsForCall[i]
+func (fake *FakeAPIClient) NamespaceLogRetentionShowArgsForCall(i int) string {
+	fake.namespaceLogRetentionShowMutex.RLock()
+	defer fake.namespaceLogRetentionShowMutex.RUnlock()
+	argsForCall := fake.namespaceLogRetentionShowArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeAPIClient) GitconfigsMatchReturns(result1 models.GitconfigsMatchResponse, result2 error) {
-	fake.gitconfigsMatchMutex.Lock()
-	defer fake.gitconfigsMatchMutex.Unlock()
-	fake.GitconfigsMatchStub = nil
-	fake.gitconfigsMatchReturns = struct {
-		result1 models.GitconfigsMatchResponse
+func (fake *FakeAPIClient) NamespaceLogRetentionShowReturns(result1 models.NamespaceLogRetention, result2 error) {
+	fake.namespaceLogRetentionShowMutex.Lock()
+	defer fake.namespaceLogRetentionShowMutex.Unlock()
+	fake.NamespaceLogRetentionShowStub = nil
+	fake.namespaceLogRetentionShowReturns = struct {
+		result1 models.NamespaceLogRetention
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) GitconfigsMatchReturnsOnCall(i int, result1 models.GitconfigsMatchResponse, result2 error) {
-	fake.gitconfigsMatchMutex.Lock()
-	defer fake.gitconfigsMatchMutex.Unlock()
-	fake.GitconfigsMatchStub = nil
-	if fake.gitconfigsMatchReturnsOnCall == nil {
-		fake.gitconfigsMatchReturnsOnCall = make(map[int]struct {
-			result1 models.GitconfigsMatchResponse
+func (fake *FakeAPIClient) NamespaceLogRetentionShowReturnsOnCall(i int, result1 models.NamespaceLogRetention, result2 error) {
+	fake.namespaceLogRetentionShowMutex.Lock()
+	defer fake.namespaceLogRetentionShowMutex.Unlock()
+	fake.NamespaceLogRetentionShowStub = nil
+	if fake.namespaceLogRetentionShowReturnsOnCall == nil {
+		fake.namespaceLogRetentionShowReturnsOnCall = make(map[int]struct {
+			result1 models.NamespaceLogRetention
 			result2 error
 		})
 	}
-	fake.gitconfigsMatchReturnsOnCall[i] = struct {
-		result1 models.GitconfigsMatchResponse
+	fake.namespaceLogRetentionShowReturnsOnCall[i] = struct {
+		result1 models.NamespaceLogRetention
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) Headers() http.Header {
-	fake.headersMutex.Lock()
-	ret, specificReturn := fake.headersReturnsOnCall[len(fake.headersArgsForCall)]
-	fake.headersArgsForCall = append(fake.headersArgsForCall, struct {
-	}{})
-	stub := fake.HeadersStub
-	fakeReturns := fake.headersReturns
-	fake.recordInvocation("Headers", []interface{}{})
-	fake.headersMutex.Unlock()
+func (fake *FakeAPIClient) NamespaceLogRetentionUpdate(arg1 string, arg2 models.NamespaceLogRetentionUpdateRequest) (models.NamespaceLogRetention, error) {
+	fake.namespaceLogRetentionUpdateMutex.Lock()
+	ret, specificReturn := fake.namespaceLogRetentionUpdateReturnsOnCall[len(fake.namespaceLogRetentionUpdateArgsForCall)]
+	fake.namespaceLogRetentionUpdateArgsForCall = append(fake.namespaceLogRetentionUpdateArgsForCall, struct {
+		arg1 string
+		arg2 models.NamespaceLogRetentionUpdateRequest
+	}{arg1, arg2})
+	stub := fake.NamespaceLogRetentionUpdateStub
+	fakeReturns := fake.namespaceLogRetentionUpdateReturns
+	fake.recordInvocation("NamespaceLogRetentionUpdate", []interface{}{arg1, arg2})
+	fake.namespaceLogRetentionUpdateMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) HeadersCallCount() int {
-	fake.headersMutex.RLock()
-	defer fake.headersMutex.RUnlock()
-	return len(fake.headersArgsForCall)
+func (fake *FakeAPIClient) NamespaceLogRetentionUpdateCallCount() int {
+	fake.namespaceLogRetentionUpdateMutex.RLock()
+	defer fake.namespaceLogRetentionUpdateMutex.RUnlock()
+	return len(fake.namespaceLogRetentionUpdateArgsForCall)
 }
 
-func (fake *FakeAPIClient) HeadersCalls(stub func() http.Header) {
-	fake.headersMutex.Lock()
-	defer fake.headersMutex.Unlock()
-	fake.HeadersStub = stub
+func (fake *FakeAPIClient) NamespaceLogRetentionUpdateCalls(stub func(string, models.NamespaceLogRetentionUpdateRequest) (models.NamespaceLogRetention, error)) {
+	fake.namespaceLogRetentionUpdateMutex.Lock()
+	defer fake.namespaceLogRetentionUpdateMutex.Unlock()
+	fake.NamespaceLogRetentionUpdateStub = stub
 }
 
-func (fake *FakeAPIClient) HeadersReturns(result1 http.Header) {
-	fake.headersMutex.Lock()
-	defer fake.headersMutex.Unlock()
-	fake.HeadersStub = nil
-	fake.headersReturns = struct {
-		result1 http.Header
-	}{result1}
+func (fake *FakeAPIClient) NamespaceLogRetentionUpdateArgsForCall(i int) (string, models.NamespaceLogRetentionUpdateRequest) {
+	fake.namespaceLogRetentionUpdateMutex.RLock()
+	defer fake.namespaceLogRetentionUpdateMutex.RUnlock()
+	argsForCall := fake.namespaceLogRetentionUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) HeadersReturnsOnCall(i int, result1 http.Header) {
-	fake.headersMutex.Lock()
-	defer fake.headersMutex.Unlock()
-	fake.HeadersStub = nil
-	if fake.headersReturnsOnCall == nil {
-		fake.headersReturnsOnCall = make(map[int]struct {
-			result1 http.Header
+func (fake *FakeAPIClient) NamespaceLogRetentionUpdateReturns(result1 models.NamespaceLogRetention, result2 error) {
+	fake.namespaceLogRetentionUpdateMutex.Lock()
+	defer fake.namespaceLogRetentionUpdateMutex.Unlock()
+	fake.NamespaceLogRetentionUpdateStub = nil
+	fake.namespaceLogRetentionUpdateReturns = struct {
+		result1 models.NamespaceLogRetention
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceLogRetentionUpdateReturnsOnCall(i int, result1 models.NamespaceLogRetention, result2 error) {
+	fake.namespaceLogRetentionUpdateMutex.Lock()
+	defer fake.namespaceLogRetentionUpdateMutex.Unlock()
+	fake.NamespaceLogRetentionUpdateStub = nil
+	if fake.namespaceLogRetentionUpdateReturnsOnCall == nil {
+		fake.namespaceLogRetentionUpdateReturnsOnCall = make(map[int]struct {
+			result1 models.NamespaceLogRetention
+			result2 error
 		})
 	}
-	fake.headersReturnsOnCall[i] = struct {
-		result1 http.Header
-	}{result1}
+	fake.namespaceLogRetentionUpdateReturnsOnCall[i] = struct {
+		result1 models.NamespaceLogRetention
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) Info() (models.InfoResponse, error) {
-	fake.infoMutex.Lock()
-	ret, specificReturn := fake.infoReturnsOnCall[len(fake.infoArgsForCall)]
-	fake.infoArgsForCall = append(fake.infoArgsForCall, struct {
-	}{})
-	stub := fake.InfoStub
-	fakeReturns := fake.infoReturns
-	fake.recordInvocation("Info", []interface{}{})
-	fake.infoMutex.Unlock()
+func (fake *FakeAPIClient) NamespaceQuotaShow(arg1 string) (models.NamespaceQuotaResponse, error) {
+	fake.namespaceQuotaShowMutex.Lock()
+	ret, specificReturn := fake.namespaceQuotaShowReturnsOnCall[len(fake.namespaceQuotaShowArgsForCall)]
+	fake.namespaceQuotaShowArgsForCall = append(fake.namespaceQuotaShowArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespaceQuotaShowStub
+	fakeReturns := fake.namespaceQuotaShowReturns
+	fake.recordInvocation("NamespaceQuotaShow", []interface{}{arg1})
+	fake.namespaceQuotaShowMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -5163,55 +7026,64 @@ func (fake *FakeAPIClient) Info() (models.InfoResponse, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) InfoCallCount() int {
-	fake.infoMutex.RLock()
-	defer fake.infoMutex.RUnlock()
-	return len(fake.infoArgsForCall)
+func (fake *FakeAPIClient) NamespaceQuotaShowCallCount() int {
+	fake.namespaceQuotaShowMutex.RLock()
+	defer fake.namespaceQuotaShowMutex.RUnlock()
+	return len(fake.namespaceQuotaShowArgsForCall)
 }
 
-func (fake *FakeAPIClient) InfoCalls(stub func() (models.InfoResponse, error)) {
-	fake.infoMutex.Lock()
-	defer fake.infoMutex.Unlock()
-	fake.InfoStub = stub
+func (fake *FakeAPIClient) NamespaceQuotaShowCalls(stub func(string) (models.NamespaceQuotaResponse, error)) {
+	fake.namespaceQuotaShowMutex.Lock()
+	defer fake.namespaceQuotaShowMutex.Unlock()
+	fake.NamespaceQuotaShowStub = stub
 }
 
-func (fake *FakeAPIClient) InfoReturns(result1 models.InfoResponse, result2 error) {
-	fake.infoMutex.Lock()
-	defer fake.infoMutex.Unlock()
-	fake.InfoStub = nil
-	fake.infoReturns = struct {
-		result1 models.InfoResponse
+func (fake *FakeAPIClient) NamespaceQuotaShowArgsForCall(i int) string {
+	fake.namespaceQuotaShowMutex.RLock()
+	defer fake.namespaceQuotaShowMutex.RUnlock()
+	argsForCall := fake.namespaceQuotaShowArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespaceQuotaShowReturns(result1 models.NamespaceQuotaResponse, result2 error) {
+	fake.namespaceQuotaShowMutex.Lock()
+	defer fake.namespaceQuotaShowMutex.Unlock()
+	fake.NamespaceQuotaShowStub = nil
+	fake.namespaceQuotaShowReturns = struct {
+		result1 models.NamespaceQuotaResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) InfoReturnsOnCall(i int, result1 models.InfoResponse, result2 error) {
-	fake.infoMutex.Lock()
-	defer fake.infoMutex.Unlock()
-	fake.InfoStub = nil
-	if fake.infoReturnsOnCall == nil {
-		fake.infoReturnsOnCall = make(map[int]struct {
-			result1 models.InfoResponse
+func (fake *FakeAPIClient) NamespaceQuotaShowReturnsOnCall(i int, result1 models.NamespaceQuotaResponse, result2 error) {
+	fake.namespaceQuotaShowMutex.Lock()
+	defer fake.namespaceQuotaShowMutex.Unlock()
+	fake.NamespaceQuotaShowStub = nil
+	if fake.namespaceQuotaShowReturnsOnCall == nil {
+		fake.namespaceQuotaShowReturnsOnCall = make(map[int]struct {
+			result1 models.NamespaceQuotaResponse
 			result2 error
 		})
-	}
-	fake.infoReturnsOnCall[i] = struct {
-		result1 models.InfoResponse
+	}
+	fake.namespaceQuotaShowReturnsOnCall[i] = struct {
+		result1 models.NamespaceQuotaResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) Me() (models.MeResponse, error) {
-	fake.meMutex.Lock()
-	ret, specificReturn := fake.meReturnsOnCall[len(fake.meArgsForCall)]
-	fake.meArgsForCall = append(fake.meArgsForCall, struct {
-	}{})
-	stub := fake.MeStub
-	fakeReturns := fake.meReturns
-	fake.recordInvocation("Me", []interface{}{})
-	fake.meMutex.Unlock()
+func (fake *FakeAPIClient) NamespaceQuotaUpdate(arg1 string, arg2 models.NamespaceQuotaUpdateRequest) (models.NamespaceQuotaResponse, error) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	ret, specificReturn := fake.namespaceQuotaUpdateReturnsOnCall[len(fake.namespaceQuotaUpdateArgsForCall)]
+	fake.namespaceQuotaUpdateArgsForCall = append(fake.namespaceQuotaUpdateArgsForCall, struct {
+		arg1 string
+		arg2 models.NamespaceQuotaUpdateRequest
+	}{arg1, arg2})
+	stub := fake.NamespaceQuotaUpdateStub
+	fakeReturns := fake.namespaceQuotaUpdateReturns
+	fake.recordInvocation("NamespaceQuotaUpdate", []interface{}{arg1, arg2})
+	fake.namespaceQuotaUpdateMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -5219,54 +7091,61 @@ func (fake *FakeAPIClient) Me() (models.MeResponse, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) MeCallCount() int {
-	fake.meMutex.RLock()
-	defer fake.meMutex.RUnlock()
-	return len(fake.meArgsForCall)
+func (fake *FakeAPIClient) NamespaceQuotaUpdateCallCount() int {
+	fake.namespaceQuotaUpdateMutex.RLock()
+	defer fake.namespaceQuotaUpdateMutex.RUnlock()
+	return len(fake.namespaceQuotaUpdateArgsForCall)
 }
 
-func (fake *FakeAPIClient) MeCalls(stub func() (models.MeResponse, error)) {
-	fake.meMutex.Lock()
-	defer fake.meMutex.Unlock()
-	fake.MeStub = stub
+func (fake *FakeAPIClient) NamespaceQuotaUpdateCalls(stub func(string, models.NamespaceQuotaUpdateRequest) (models.NamespaceQuotaResponse, error)) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	defer fake.namespaceQuotaUpdateMutex.Unlock()
+	fake.NamespaceQuotaUpdateStub = stub
 }
 
-func (fake *FakeAPIClient) MeReturns(result1 models.MeResponse, result2 error) {
-	fake.meMutex.Lock()
-	defer fake.meMutex.Unlock()
-	fake.MeStub = nil
-	fake.meReturns = struct {
-		result1 models.MeResponse
+func (fake *FakeAPIClient) NamespaceQuotaUpdateArgsForCall(i int) (string, models.NamespaceQuotaUpdateRequest) {
+	fake.namespaceQuotaUpdateMutex.RLock()
+	defer fake.namespaceQuotaUpdateMutex.RUnlock()
+	argsForCall := fake.namespaceQuotaUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceQuotaUpdateReturns(result1 models.NamespaceQuotaResponse, result2 error) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	defer fake.namespaceQuotaUpdateMutex.Unlock()
+	fake.NamespaceQuotaUpdateStub = nil
+	fake.namespaceQuotaUpdateReturns = struct {
+		result1 models.NamespaceQuotaResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) MeReturnsOnCall(i int, result1 models.MeResponse, result2 error) {
-	fake.meMutex.Lock()
-	defer fake.meMutex.Unlock()
-	fake.MeStub = nil
-	if fake.meReturnsOnCall == nil {
-		fake.meReturnsOnCall = make(map[int]struct {
-			result1 models.MeResponse
+func (fake *FakeAPIClient) NamespaceQuotaUpdateReturnsOnCall(i int, result1 models.NamespaceQuotaResponse, result2 error) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	defer fake.namespaceQuotaUpdateMutex.Unlock()
+	fake.NamespaceQuotaUpdateStub = nil
+	if fake.namespaceQuotaUpdateReturnsOnCall == nil {
+		fake.namespaceQuotaUpdateReturnsOnCall = make(map[int]struct {
+			result1 models.NamespaceQuotaResponse
 			result2 error
 		})
 	}
-	fake.meReturnsOnCall[i] = struct {
-		result1 models.MeResponse
+	fake.namespaceQuotaUpdateReturnsOnCall[i] = struct {
+		result1 models.NamespaceQuotaResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceCreate(arg1 models.NamespaceCreateRequest) (models.Response, error) {
-	fake.namespaceCreateMutex.Lock()
-	ret, specificReturn := fake.namespaceCreateReturnsOnCall[len(fake.namespaceCreateArgsForCall)]
-	fake.namespaceCreateArgsForCall = append(fake.namespaceCreateArgsForCall, struct {
-		arg1 models.NamespaceCreateRequest
+func (fake *FakeAPIClient) NamespaceScanPolicyShow(arg1 string) (models.NamespaceScanPolicy, error) {
+	fake.namespaceScanPolicyShowMutex.Lock()
+	ret, specificReturn := fake.namespaceScanPolicyShowReturnsOnCall[len(fake.namespaceScanPolicyShowArgsForCall)]
+	fake.namespaceScanPolicyShowArgsForCall = append(fake.namespaceScanPolicyShowArgsForCall, struct {
+		arg1 string
 	}{arg1})
-	stub := fake.NamespaceCreateStub
-	fakeReturns := fake.namespaceCreateReturns
-	fake.recordInvocation("NamespaceCreate", []interface{}{arg1})
-	fake.namespaceCreateMutex.Unlock()
+	stub := fake.NamespaceScanPolicyShowStub
+	fakeReturns := fake.namespaceScanPolicyShowReturns
+	fake.recordInvocation("NamespaceScanPolicyShow", []interface{}{arg1})
+	fake.namespaceScanPolicyShowMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -5276,68 +7155,64 @@ func (fake *FakeAPIClient) NamespaceCreate(arg1 models.NamespaceCreateRequest) (
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) NamespaceCreateCallCount() int {
-	fake.namespaceCreateMutex.RLock()
-	defer fake.namespaceCreateMutex.RUnlock()
-	return len(fake.namespaceCreateArgsForCall)
+func (fake *FakeAPIClient) NamespaceScanPolicyShowCallCount() int {
+	fake.namespaceScanPolicyShowMutex.RLock()
+	defer fake.namespaceScanPolicyShowMutex.RUnlock()
+	return len(fake.namespaceScanPolicyShowArgsForCall)
 }
 
-func (fake *FakeAPIClient) NamespaceCreateCalls(stub func(models.NamespaceCreateRequest) (models.Response, error)) {
-	fake.namespaceCreateMutex.Lock()
-	defer fake.namespaceCreateMutex.Unlock()
-	fake.NamespaceCreateStub = stub
+func (fake *FakeAPIClient) NamespaceScanPolicyShowCalls(stub func(string) (models.NamespaceScanPolicy, error)) {
+	fake.namespaceScanPolicyShowMutex.Lock()
+	defer fake.namespaceScanPolicyShowMutex.Unlock()
+	fake.NamespaceScanPolicyShowStub = stub
 }
 
-func (fake *FakeAPIClient) NamespaceCreateArgsForCall(i int) models.NamespaceCreateRequest {
-	fake.namespaceCreateMutex.RLock()
-	defer fake.namespaceCreateMutex.RUnlock()
-	argsForCall := fake.namespaceCreateArgsForCall[i]
+func (fake *FakeAPIClient) NamespaceScanPolicyShowArgsForCall(i int) string {
+	fake.namespaceScanPolicyShowMutex.RLock()
+	defer fake.namespaceScanPolicyShowMutex.RUnlock()
+	argsForCall := fake.namespaceScanPolicyShowArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeAPIClient) NamespaceCreateReturns(result1 models.Response, result2 error) {
-	fake.namespaceCreateMutex.Lock()
-	defer fake.namespaceCreateMutex.Unlock()
-	fake.NamespaceCreateStub = nil
-	fake.namespaceCreateReturns = struct {
-		result1 models.Response
+func (fake *FakeAPIClient) NamespaceScanPolicyShowReturns(result1 models.NamespaceScanPolicy, result2 error) {
+	fake.namespaceScanPolicyShowMutex.Lock()
+	defer fake.namespaceScanPolicyShowMutex.Unlock()
+	fake.NamespaceScanPolicyShowStub = nil
+	fake.namespaceScanPolicyShowReturns = struct {
+		result1 models.NamespaceScanPolicy
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceCreateReturnsOnCall(i int, result1 models.Response, result2 error) {
-	fake.namespaceCreateMutex.Lock()
-	defer fake.namespaceCreateMutex.Unlock()
-	fake.NamespaceCreateStub = nil
-	if fake.namespaceCreateReturnsOnCall == nil {
-		fake.namespaceCreateReturnsOnCall = make(map[int]struct {
-			result1 models.Response
+func (fake *FakeAPIClient) NamespaceScanPolicyShowReturnsOnCall(i int, result1 models.NamespaceScanPolicy, result2 error) {
+	fake.namespaceScanPolicyShowMutex.Lock()
+	defer fake.namespaceScanPolicyShowMutex.Unlock()
+	fake.NamespaceScanPolicyShowStub = nil
+	if fake.namespaceScanPolicyShowReturnsOnCall == nil {
+		fake.namespaceScanPolicyShowReturnsOnCall = make(map[int]struct {
+			result1 models.NamespaceScanPolicy
 			result2 error
 		})
 	}
-	fake.namespaceCreateReturnsOnCall[i] = struct {
-		result1 models.Response
+	fake.namespaceScanPolicyShowReturnsOnCall[i] = struct {
+		result1 models.NamespaceScanPolicy
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceDelete(arg1 []string) (models.Response, error) {
-	var arg1Copy []string
-	if arg1 != nil {
-		arg1Copy = make([]string, len(arg1))
-		copy(arg1Copy, arg1)
-	}
-	fake.namespaceDeleteMutex.Lock()
-	ret, specificReturn := fake.namespaceDeleteReturnsOnCall[len(fake.namespaceDeleteArgsForCall)]
-	fake.namespaceDeleteArgsForCall = append(fake.namespaceDeleteArgsForCall, struct {
-		arg1 []string
-	}{arg1Copy})
-	stub := fake.NamespaceDeleteStub
-	fakeReturns := fake.namespaceDeleteReturns
-	fake.recordInvocation("NamespaceDelete", []interface{}{arg1Copy})
-	fake.namespaceDeleteMutex.Unlock()
+func (fake *FakeAPIClient) NamespaceScanPolicyUpdate(arg1 string, arg2 models.NamespaceScanPolicyUpdateRequest) (models.NamespaceScanPolicy, error) {
+	fake.namespaceScanPolicyUpdateMutex.Lock()
+	ret, specificReturn := fake.namespaceScanPolicyUpdateReturnsOnCall[len(fake.namespaceScanPolicyUpdateArgsForCall)]
+	fake.namespaceScanPolicyUpdateArgsForCall = append(fake.namespaceScanPolicyUpdateArgsForCall, struct {
+		arg1 string
+		arg2 models.NamespaceScanPolicyUpdateRequest
+	}{arg1, arg2})
+	stub := fake.NamespaceScanPolicyUpdateStub
+	fakeReturns := fake.namespaceScanPolicyUpdateReturns
+	fake.recordInvocation("NamespaceScanPolicyUpdate", []interface{}{arg1, arg2})
+	fake.namespaceScanPolicyUpdateMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -5345,47 +7220,47 @@ func (fake *FakeAPIClient) NamespaceDelete(arg1 []string) (models.Response, erro
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteCallCount() int {
-	fake.namespaceDeleteMutex.RLock()
-	defer fake.namespaceDeleteMutex.RUnlock()
-	return len(fake.namespaceDeleteArgsForCall)
+func (fake *FakeAPIClient) NamespaceScanPolicyUpdateCallCount() int {
+	fake.namespaceScanPolicyUpdateMutex.RLock()
+	defer fake.namespaceScanPolicyUpdateMutex.RUnlock()
+	return len(fake.namespaceScanPolicyUpdateArgsForCall)
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteCalls(stub func([]string) (models.Response, error)) {
-	fake.namespaceDeleteMutex.Lock()
-	defer fake.namespaceDeleteMutex.Unlock()
-	fake.NamespaceDeleteStub = stub
+func (fake *FakeAPIClient) NamespaceScanPolicyUpdateCalls(stub func(string, models.NamespaceScanPolicyUpdateRequest) (models.NamespaceScanPolicy, error)) {
+	fake.namespaceScanPolicyUpdateMutex.Lock()
+	defer fake.namespaceScanPolicyUpdateMutex.Unlock()
+	fake.NamespaceScanPolicyUpdateStub = stub
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteArgsForCall(i int) []string {
-	fake.namespaceDeleteMutex.RLock()
-	defer fake.namespaceDeleteMutex.RUnlock()
-	argsForCall := fake.namespaceDeleteArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeAPIClient) NamespaceScanPolicyUpdateArgsForCall(i int) (string, models.NamespaceScanPolicyUpdateRequest) {
+	fake.namespaceScanPolicyUpdateMutex.RLock()
+	defer fake.namespaceScanPolicyUpdateMutex.RUnlock()
+	argsForCall := fake.namespaceScanPolicyUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteReturns(result1 models.Response, result2 error) {
-	fake.namespaceDeleteMutex.Lock()
-	defer fake.namespaceDeleteMutex.Unlock()
-	fake.NamespaceDeleteStub = nil
-	fake.namespaceDeleteReturns = struct {
-		result1 models.Response
+func (fake *FakeAPIClient) NamespaceScanPolicyUpdateReturns(result1 models.NamespaceScanPolicy, result2 error) {
+	fake.namespaceScanPolicyUpdateMutex.Lock()
+	defer fake.namespaceScanPolicyUpdateMutex.Unlock()
+	fake.NamespaceScanPolicyUpdateStub = nil
+	fake.namespaceScanPolicyUpdateReturns = struct {
+		result1 models.NamespaceScanPolicy
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
-	fake.namespaceDeleteMutex.Lock()
-	defer fake.namespaceDeleteMutex.Unlock()
-	fake.NamespaceDeleteStub = nil
-	if fake.namespaceDeleteReturnsOnCall == nil {
-		fake.namespaceDeleteReturnsOnCall = make(map[int]struct {
-			result1 models.Response
+func (fake *FakeAPIClient) NamespaceScanPolicyUpdateReturnsOnCall(i int, result1 models.NamespaceScanPolicy, result2 error) {
+	fake.namespaceScanPolicyUpdateMutex.Lock()
+	defer fake.namespaceScanPolicyUpdateMutex.Unlock()
+	fake.NamespaceScanPolicyUpdateStub = nil
+	if fake.namespaceScanPolicyUpdateReturnsOnCall == nil {
+		fake.namespaceScanPolicyUpdateReturnsOnCall = make(map[int]struct {
+			result1 models.NamespaceScanPolicy
 			result2 error
 		})
 	}
-	fake.namespaceDeleteReturnsOnCall[i] = struct {
-		result1 models.Response
+	fake.namespaceScanPolicyUpdateReturnsOnCall[i] = struct {
+		result1 models.NamespaceScanPolicy
 		result2 error
 	}{result1, result2}
 }
@@ -5454,6 +7329,76 @@ func (fake *FakeAPIClient) NamespaceShowReturnsOnCall(i int, result1 models.Name
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) NamespaceSupportBundle(arg1 string, arg2 []string) (models.AppPartResponse, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.namespaceSupportBundleMutex.Lock()
+	ret, specificReturn := fake.namespaceSupportBundleReturnsOnCall[len(fake.namespaceSupportBundleArgsForCall)]
+	fake.namespaceSupportBundleArgsForCall = append(fake.namespaceSupportBundleArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.NamespaceSupportBundleStub
+	fakeReturns := fake.namespaceSupportBundleReturns
+	fake.recordInvocation("NamespaceSupportBundle", []interface{}{arg1, arg2Copy})
+	fake.namespaceSupportBundleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceSupportBundleCallCount() int {
+	fake.namespaceSupportBundleMutex.RLock()
+	defer fake.namespaceSupportBundleMutex.RUnlock()
+	return len(fake.namespaceSupportBundleArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceSupportBundleCalls(stub func(string, []string) (models.AppPartResponse, error)) {
+	fake.namespaceSupportBundleMutex.Lock()
+	defer fake.namespaceSupportBundleMutex.Unlock()
+	fake.NamespaceSupportBundleStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceSupportBundleArgsForCall(i int) (string, []string) {
+	fake.namespaceSupportBundleMutex.RLock()
+	defer fake.namespaceSupportBundleMutex.RUnlock()
+	argsForCall := fake.namespaceSupportBundleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceSupportBundleReturns(result1 models.AppPartResponse, result2 error) {
+	fake.namespaceSupportBundleMutex.Lock()
+	defer fake.namespaceSupportBundleMutex.Unlock()
+	fake.NamespaceSupportBundleStub = nil
+	fake.namespaceSupportBundleReturns = struct {
+		result1 models.AppPartResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceSupportBundleReturnsOnCall(i int, result1 models.AppPartResponse, result2 error) {
+	fake.namespaceSupportBundleMutex.Lock()
+	defer fake.namespaceSupportBundleMutex.Unlock()
+	fake.NamespaceSupportBundleStub = nil
+	if fake.namespaceSupportBundleReturnsOnCall == nil {
+		fake.namespaceSupportBundleReturnsOnCall = make(map[int]struct {
+			result1 models.AppPartResponse
+			result2 error
+		})
+	}
+	fake.namespaceSupportBundleReturnsOnCall[i] = struct {
+		result1 models.AppPartResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) Namespaces() (models.NamespaceList, error) {
 	fake.namespacesMutex.Lock()
 	ret, specificReturn := fake.namespacesReturnsOnCall[len(fake.namespacesArgsForCall)]
@@ -5574,6 +7519,200 @@ func (fake *FakeAPIClient) NamespacesMatchReturnsOnCall(i int, result1 models.Na
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) RegistryCredentialCreate(arg1 string, arg2 models.RegistryCredentialCreateRequest) (models.Response, error) {
+	fake.registryCredentialCreateMutex.Lock()
+	ret, specificReturn := fake.registryCredentialCreateReturnsOnCall[len(fake.registryCredentialCreateArgsForCall)]
+	fake.registryCredentialCreateArgsForCall = append(fake.registryCredentialCreateArgsForCall, struct {
+		arg1 string
+		arg2 models.RegistryCredentialCreateRequest
+	}{arg1, arg2})
+	stub := fake.RegistryCredentialCreateStub
+	fakeReturns := fake.registryCredentialCreateReturns
+	fake.recordInvocation("RegistryCredentialCreate", []interface{}{arg1, arg2})
+	fake.registryCredentialCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) RegistryCredentialCreateCallCount() int {
+	fake.registryCredentialCreateMutex.RLock()
+	defer fake.registryCredentialCreateMutex.RUnlock()
+	return len(fake.registryCredentialCreateArgsForCall)
+}
+
+func (fake *FakeAPIClient) RegistryCredentialCreateCalls(stub func(string, models.RegistryCredentialCreateRequest) (models.Response, error)) {
+	fake.registryCredentialCreateMutex.Lock()
+	defer fake.registryCredentialCreateMutex.Unlock()
+	fake.RegistryCredentialCreateStub = stub
+}
+
+func (fake *FakeAPIClient) RegistryCredentialCreateArgsForCall(i int) (string, models.RegistryCredentialCreateRequest) {
+	fake.registryCredentialCreateMutex.RLock()
+	defer fake.registryCredentialCreateMutex.RUnlock()
+	argsForCall := fake.registryCredentialCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) RegistryCredentialCreateReturns(result1 models.Response, result2 error) {
+	fake.registryCredentialCreateMutex.Lock()
+	defer fake.registryCredentialCreateMutex.Unlock()
+	fake.RegistryCredentialCreateStub = nil
+	fake.registryCredentialCreateReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryCredentialCreateReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.registryCredentialCreateMutex.Lock()
+	defer fake.registryCredentialCreateMutex.Unlock()
+	fake.RegistryCredentialCreateStub = nil
+	if fake.registryCredentialCreateReturnsOnCall == nil {
+		fake.registryCredentialCreateReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.registryCredentialCreateReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryCredentialDelete(arg1 string, arg2 string) (models.Response, error) {
+	fake.registryCredentialDeleteMutex.Lock()
+	ret, specificReturn := fake.registryCredentialDeleteReturnsOnCall[len(fake.registryCredentialDeleteArgsForCall)]
+	fake.registryCredentialDeleteArgsForCall = append(fake.registryCredentialDeleteArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RegistryCredentialDeleteStub
+	fakeReturns := fake.registryCredentialDeleteReturns
+	fake.recordInvocation("RegistryCredentialDelete", []interface{}{arg1, arg2})
+	fake.registryCredentialDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) RegistryCredentialDeleteCallCount() int {
+	fake.registryCredentialDeleteMutex.RLock()
+	defer fake.registryCredentialDeleteMutex.RUnlock()
+	return len(fake.registryCredentialDeleteArgsForCall)
+}
+
+func (fake *FakeAPIClient) RegistryCredentialDeleteCalls(stub func(string, string) (models.Response, error)) {
+	fake.registryCredentialDeleteMutex.Lock()
+	defer fake.registryCredentialDeleteMutex.Unlock()
+	fake.RegistryCredentialDeleteStub = stub
+}
+
+func (fake *FakeAPIClient) RegistryCredentialDeleteArgsForCall(i int) (string, string) {
+	fake.registryCredentialDeleteMutex.RLock()
+	defer fake.registryCredentialDeleteMutex.RUnlock()
+	argsForCall := fake.registryCredentialDeleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) RegistryCredentialDeleteReturns(result1 models.Response, result2 error) {
+	fake.registryCredentialDeleteMutex.Lock()
+	defer fake.registryCredentialDeleteMutex.Unlock()
+	fake.RegistryCredentialDeleteStub = nil
+	fake.registryCredentialDeleteReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryCredentialDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.registryCredentialDeleteMutex.Lock()
+	defer fake.registryCredentialDeleteMutex.Unlock()
+	fake.RegistryCredentialDeleteStub = nil
+	if fake.registryCredentialDeleteReturnsOnCall == nil {
+		fake.registryCredentialDeleteReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.registryCredentialDeleteReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryCredentials(arg1 string) (models.RegistryCredentialList, error) {
+	fake.registryCredentialsMutex.Lock()
+	ret, specificReturn := fake.registryCredentialsReturnsOnCall[len(fake.registryCredentialsArgsForCall)]
+	fake.registryCredentialsArgsForCall = append(fake.registryCredentialsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RegistryCredentialsStub
+	fakeReturns := fake.registryCredentialsReturns
+	fake.recordInvocation("RegistryCredentials", []interface{}{arg1})
+	fake.registryCredentialsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) RegistryCredentialsCallCount() int {
+	fake.registryCredentialsMutex.RLock()
+	defer fake.registryCredentialsMutex.RUnlock()
+	return len(fake.registryCredentialsArgsForCall)
+}
+
+func (fake *FakeAPIClient) RegistryCredentialsCalls(stub func(string) (models.RegistryCredentialList, error)) {
+	fake.registryCredentialsMutex.Lock()
+	defer fake.registryCredentialsMutex.Unlock()
+	fake.RegistryCredentialsStub = stub
+}
+
+func (fake *FakeAPIClient) RegistryCredentialsArgsForCall(i int) string {
+	fake.registryCredentialsMutex.RLock()
+	defer fake.registryCredentialsMutex.RUnlock()
+	argsForCall := fake.registryCredentialsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) RegistryCredentialsReturns(result1 models.RegistryCredentialList, result2 error) {
+	fake.registryCredentialsMutex.Lock()
+	defer fake.registryCredentialsMutex.Unlock()
+	fake.RegistryCredentialsStub = nil
+	fake.registryCredentialsReturns = struct {
+		result1 models.RegistryCredentialList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryCredentialsReturnsOnCall(i int, result1 models.RegistryCredentialList, result2 error) {
+	fake.registryCredentialsMutex.Lock()
+	defer fake.registryCredentialsMutex.Unlock()
+	fake.RegistryCredentialsStub = nil
+	if fake.registryCredentialsReturnsOnCall == nil {
+		fake.registryCredentialsReturnsOnCall = make(map[int]struct {
+			result1 models.RegistryCredentialList
+			result2 error
+		})
+	}
+	fake.registryCredentialsReturnsOnCall[i] = struct {
+		result1 models.RegistryCredentialList
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) ServiceBatchBind(arg1 models.ServiceBatchBindRequest, arg2 string, arg3 string) (models.Response, error) {
 	fake.serviceBatchBindMutex.Lock()
 	ret, specificReturn := fake.serviceBatchBindReturnsOnCall[len(fake.serviceBatchBindArgsForCall)]
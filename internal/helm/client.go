@@ -4,6 +4,11 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/epinio/epinio/internal/metrics"
+	"github.com/epinio/epinio/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 
 	hc "github.com/mittwald/go-helm-client"
 	"helm.sh/helm/v3/pkg/action"
@@ -18,24 +23,32 @@ var _ hc.Client = (*SynchronizedClient)(nil)
 
 // InstallOrUpgradeChart implements helmclient.Client
 func (c *SynchronizedClient) InstallOrUpgradeChart(ctx context.Context, spec *hc.ChartSpec, opts *hc.GenericHelmOptions) (*helmrelease.Release, error) {
+	start := time.Now()
+	_, span := tracing.Start(ctx, "helm install_or_upgrade", attribute.String("helm.release", spec.ReleaseName))
 	anyMutex, _ := c.mutexMap.LoadOrStore(spec.ReleaseName, &sync.Mutex{})
 	if m, ok := anyMutex.(*sync.Mutex); ok {
 		m.Lock()
 		defer m.Unlock()
 	}
 
-	return c.helmClient.InstallOrUpgradeChart(ctx, spec, opts)
+	release, err := c.helmClient.InstallOrUpgradeChart(ctx, spec, opts)
+	metrics.ObserveHelm("install_or_upgrade", start, err)
+	tracing.End(span, err)
+	return release, err
 }
 
 // RollbackRelease implements helmclient.Client
 func (c *SynchronizedClient) RollbackRelease(spec *hc.ChartSpec) error {
+	start := time.Now()
 	anyMutex, _ := c.mutexMap.LoadOrStore(spec.ReleaseName, &sync.Mutex{})
 	if m, ok := anyMutex.(*sync.Mutex); ok {
 		m.Lock()
 		defer m.Unlock()
 	}
 
-	return c.helmClient.RollbackRelease(spec)
+	err := c.helmClient.RollbackRelease(spec)
+	metrics.ObserveHelm("rollback", start, err)
+	return err
 }
 
 // AddOrUpdateChartRepo implements helmclient.Client
@@ -60,13 +73,18 @@ func (c *SynchronizedClient) GetReleaseValues(name string, allValues bool) (map[
 
 // InstallChart implements helmclient.Client
 func (c *SynchronizedClient) InstallChart(ctx context.Context, spec *hc.ChartSpec, opts *hc.GenericHelmOptions) (*helmrelease.Release, error) {
+	start := time.Now()
+	_, span := tracing.Start(ctx, "helm install", attribute.String("helm.release", spec.ReleaseName))
 	anyMutex, _ := c.mutexMap.LoadOrStore(spec.ReleaseName, &sync.Mutex{})
 	if m, ok := anyMutex.(*sync.Mutex); ok {
 		m.Lock()
 		defer m.Unlock()
 	}
 
-	return c.helmClient.InstallChart(ctx, spec, opts)
+	release, err := c.helmClient.InstallChart(ctx, spec, opts)
+	metrics.ObserveHelm("install", start, err)
+	tracing.End(span, err)
+	return release, err
 }
 
 // LintChart implements helmclient.Client
@@ -101,24 +119,30 @@ func (c *SynchronizedClient) TemplateChart(spec *hc.ChartSpec, options *hc.HelmT
 
 // UninstallRelease implements helmclient.Client
 func (c *SynchronizedClient) UninstallRelease(spec *hc.ChartSpec) error {
+	start := time.Now()
 	anyMutex, _ := c.mutexMap.LoadOrStore(spec.ReleaseName, &sync.Mutex{})
 	if m, ok := anyMutex.(*sync.Mutex); ok {
 		m.Lock()
 		defer m.Unlock()
 	}
 
-	return c.helmClient.UninstallRelease(spec)
+	err := c.helmClient.UninstallRelease(spec)
+	metrics.ObserveHelm("uninstall", start, err)
+	return err
 }
 
 // UninstallReleaseByName implements helmclient.Client
 func (c *SynchronizedClient) UninstallReleaseByName(name string) error {
+	start := time.Now()
 	anyMutex, _ := c.mutexMap.LoadOrStore(name, &sync.Mutex{})
 	if m, ok := anyMutex.(*sync.Mutex); ok {
 		m.Lock()
 		defer m.Unlock()
 	}
 
-	return c.helmClient.UninstallReleaseByName(name)
+	err := c.helmClient.UninstallReleaseByName(name)
+	metrics.ObserveHelm("uninstall", start, err)
+	return err
 }
 
 // UpdateChartRepos implements helmclient.Client
@@ -128,13 +152,18 @@ func (c *SynchronizedClient) UpdateChartRepos() error {
 
 // UpgradeChart implements helmclient.Client
 func (c *SynchronizedClient) UpgradeChart(ctx context.Context, spec *hc.ChartSpec, opts *hc.GenericHelmOptions) (*helmrelease.Release, error) {
+	start := time.Now()
+	_, span := tracing.Start(ctx, "helm upgrade", attribute.String("helm.release", spec.ReleaseName))
 	anyMutex, _ := c.mutexMap.LoadOrStore(spec.ReleaseName, &sync.Mutex{})
 	if m, ok := anyMutex.(*sync.Mutex); ok {
 		m.Lock()
 		defer m.Unlock()
 	}
 
-	return c.helmClient.UpgradeChart(ctx, spec, opts)
+	release, err := c.helmClient.UpgradeChart(ctx, spec, opts)
+	metrics.ObserveHelm("upgrade", start, err)
+	tracing.End(span, err)
+	return release, err
 }
 
 // Status implements the 'helm status' command
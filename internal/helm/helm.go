@@ -31,10 +31,12 @@ import (
 	"github.com/epinio/epinio/internal/duration"
 	"github.com/epinio/epinio/internal/names"
 	"github.com/epinio/epinio/internal/routes"
+	"github.com/epinio/epinio/internal/tracing"
 	"github.com/epinio/epinio/internal/urlcache"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	hc "github.com/mittwald/go-helm-client"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 	"gopkg.in/yaml.v2"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/registry"
@@ -46,6 +48,9 @@ import (
 	"k8s.io/client-go/util/retry"
 )
 
+// DefaultAppPort is the port application instances are expected to listen on.
+const DefaultAppPort int32 = 8080
+
 type PostDeployFunction func(ctx context.Context) error
 
 type ServiceParameters struct {
@@ -77,6 +82,11 @@ type ChartParameters struct {
 	Domains        domain.DomainMap      // Map of domains with secrets covering them
 	Start          *int64                // Nano-epoch of deployment. Optional. Used to force a restart, even when nothing else has changed.
 	Settings       models.ChartValueSettings
+	Resources      *models.AppResources   // CPU and memory requests and limits. Optional.
+	Healthcheck    *models.AppHealthcheck // Readiness and liveness checks. Optional.
+	Sidecars       []models.AppContainer  // Containers running next to the app. Optional.
+	InitContainers []models.AppContainer  // Containers running before the app. Optional.
+	PullSecrets    []string               // Image pull secrets of the app pods. Optional.
 }
 
 func Values(
@@ -103,6 +113,20 @@ func Values(
 	return yaml, nil
 }
 
+// History returns up to max revisions of the app's release, oldest first.
+func History(
+	cluster *kubernetes.Cluster,
+	app models.AppRef,
+	max int,
+) ([]*helmrelease.Release, error) {
+	client, err := GetHelmClient(cluster.RestConfig, app.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ListReleaseHistory(names.ReleaseName(app.Name), max)
+}
+
 func Remove(
 	cluster *kubernetes.Cluster,
 	app models.AppRef,
@@ -321,20 +345,85 @@ type RouteParam struct {
 	Path   string `yaml:"path"`
 	Secret string `yaml:"secret,omitempty"` // nolint:gosec // route secret for ingress, not credentials
 }
+
+// ResourcesParam is the standard kubernetes shape of container resources. Every app chart
+// receives it as `epinio.resources`, and can use it verbatim in its container spec.
+type ResourcesParam struct {
+	Requests map[string]string `yaml:"requests,omitempty"`
+	Limits   map[string]string `yaml:"limits,omitempty"`
+}
+
+// HealthcheckParam carries the health check configuration of the application. The probes
+// are in the standard kubernetes shape, and can be used verbatim in the container spec of
+// an app chart. A `process` health check has no probes.
+type HealthcheckParam struct {
+	Type           string      `yaml:"type"`
+	ReadinessProbe *ProbeParam `yaml:"readinessProbe,omitempty"`
+	LivenessProbe  *ProbeParam `yaml:"livenessProbe,omitempty"`
+}
+
+// ProbeParam is the standard kubernetes shape of a container probe.
+type ProbeParam struct {
+	HTTPGet             *ProbeHandlerParam `yaml:"httpGet,omitempty"`
+	TCPSocket           *ProbeHandlerParam `yaml:"tcpSocket,omitempty"`
+	InitialDelaySeconds int32              `yaml:"initialDelaySeconds,omitempty"`
+	TimeoutSeconds      int32              `yaml:"timeoutSeconds,omitempty"`
+	PeriodSeconds       int32              `yaml:"periodSeconds,omitempty"`
+	SuccessThreshold    int32              `yaml:"successThreshold,omitempty"`
+	FailureThreshold    int32              `yaml:"failureThreshold,omitempty"`
+}
+
+// ProbeHandlerParam is the target of a http or tcp probe.
+type ProbeHandlerParam struct {
+	Path string `yaml:"path,omitempty"`
+	Port int32  `yaml:"port"`
+}
+
+// ContainerParam is the standard kubernetes shape of a container. Every app chart receives
+// the sidecars and init containers of the application as `epinio.sidecars` and
+// `epinio.initContainers`, and can use them verbatim in its pod spec.
+type ContainerParam struct {
+	Name         string             `yaml:"name"`
+	Image        string             `yaml:"image"`
+	Command      []string           `yaml:"command,omitempty"`
+	Env          []EnvParam         `yaml:"env,omitempty"`
+	VolumeMounts []VolumeMountParam `yaml:"volumeMounts,omitempty"`
+}
+
+// EnvParam is the standard kubernetes shape of an environment variable.
+type EnvParam struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// VolumeMountParam is the standard kubernetes shape of a volume mount. The same shape is
+// used for `epinio.sharedVolumes`, the emptyDir volumes the app chart has to create, and
+// mount into the application container.
+type VolumeMountParam struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+}
+
 type EpinioParam struct {
-	AppName        string               `yaml:"appName"`
-	Configurations []string             `yaml:"configurations"`
-	ConfigPaths    []ConfigParameter    `yaml:"configpaths"`
-	Env            []models.EnvVariable `yaml:"env"`
-	ImageUrl       string               `yaml:"imageURL"`
-	Ingress        string               `yaml:"ingress,omitempty"`
-	Gateway        string               `yaml:"gateway,omitempty"`
-	ReplicaCount   int32                `yaml:"replicaCount"`
-	Routes         []RouteParam         `yaml:"routes"`
-	StageID        string               `yaml:"stageID"`
-	Start          string               `yaml:"start,omitempty"`
-	TlsIssuer      string               `yaml:"tlsIssuer"`
-	Username       string               `yaml:"username"`
+	AppName          string               `yaml:"appName"`
+	Configurations   []string             `yaml:"configurations"`
+	ConfigPaths      []ConfigParameter    `yaml:"configpaths"`
+	Env              []models.EnvVariable `yaml:"env"`
+	ImageUrl         string               `yaml:"imageURL"`
+	Ingress          string               `yaml:"ingress,omitempty"`
+	Gateway          string               `yaml:"gateway,omitempty"`
+	Healthcheck      *HealthcheckParam    `yaml:"healthcheck,omitempty"`
+	ImagePullSecrets []string             `yaml:"imagePullSecrets,omitempty"`
+	InitContainers   []ContainerParam     `yaml:"initContainers,omitempty"`
+	ReplicaCount     int32                `yaml:"replicaCount"`
+	Resources        *ResourcesParam      `yaml:"resources,omitempty"`
+	Routes           []RouteParam         `yaml:"routes"`
+	SharedVolumes    []VolumeMountParam   `yaml:"sharedVolumes,omitempty"`
+	Sidecars         []ContainerParam     `yaml:"sidecars,omitempty"`
+	StageID          string               `yaml:"stageID"`
+	Start            string               `yaml:"start,omitempty"`
+	TlsIssuer        string               `yaml:"tlsIssuer"`
+	Username         string               `yaml:"username"`
 }
 type ChartParam struct {
 	Epinio EpinioParam            `yaml:"epinio"`
@@ -342,7 +431,16 @@ type ChartParam struct {
 	User   map[string]interface{} `yaml:"userConfig,omitempty"`
 }
 
-func Deploy(parameters ChartParameters) error {
+func Deploy(parameters ChartParameters) (err error) {
+	ctx, span := tracing.Start(parameters.Context, "helm deploy",
+		attribute.String("epinio.namespace", parameters.Namespace),
+		attribute.String("epinio.app", parameters.Name),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
+	parameters.Context = ctx
+
 	logger := helpers.Logger.With("component", "helm-deploy")
 	logger.Infow("deploy app", "parameters", parameters)
 
@@ -404,7 +502,8 @@ func Deploy(parameters ChartParameters) error {
 		ReuseValues: true,
 	}
 
-	_, err = client.InstallOrUpgradeChart(context.Background(), &chartSpec, nil)
+	// Not canceled with the request, but still part of its trace.
+	_, err = client.InstallOrUpgradeChart(tracing.Detach(ctx), &chartSpec, nil)
 
 	return err
 }
@@ -690,6 +789,109 @@ func getChartReference(ctx context.Context, client hc.Client, appChart *models.A
 	return helmChart, helmVersion, nil
 }
 
+// resourcesParam converts the application resources into the standard values shape.
+func resourcesParam(resources *models.AppResources) *ResourcesParam {
+	values := func(v models.ResourceValues) map[string]string {
+		result := map[string]string{}
+		if v.CPU != "" {
+			result["cpu"] = v.CPU
+		}
+		if v.Memory != "" {
+			result["memory"] = v.Memory
+		}
+		if len(result) == 0 {
+			return nil
+		}
+		return result
+	}
+
+	return &ResourcesParam{
+		Requests: values(resources.Requests),
+		Limits:   values(resources.Limits),
+	}
+}
+
+// healthcheckParam translates the health check configuration into probes. Readiness is
+// checked from the start, while liveness is only checked after the start-up grace period,
+// to not kill slow starting instances. Missing port and path default to the standard
+// application port, and the root path.
+func healthcheckParam(healthcheck *models.AppHealthcheck) *HealthcheckParam {
+	param := &HealthcheckParam{Type: healthcheck.Type}
+	if healthcheck.Type == models.HealthcheckProcess {
+		return param
+	}
+
+	port := healthcheck.Port
+	if port == 0 {
+		port = DefaultAppPort
+	}
+
+	probe := func() *ProbeParam {
+		probe := &ProbeParam{
+			TimeoutSeconds:   healthcheck.TimeoutSeconds,
+			PeriodSeconds:    healthcheck.PeriodSeconds,
+			FailureThreshold: healthcheck.FailureThreshold,
+		}
+		if healthcheck.Type == models.HealthcheckTCP {
+			probe.TCPSocket = &ProbeHandlerParam{Port: port}
+		} else {
+			path := healthcheck.Path
+			if path == "" {
+				path = "/"
+			}
+			probe.HTTPGet = &ProbeHandlerParam{Path: path, Port: port}
+		}
+		return probe
+	}
+
+	param.ReadinessProbe = probe()
+	param.ReadinessProbe.SuccessThreshold = healthcheck.SuccessThreshold
+
+	// Kubernetes requires a success threshold of 1 for liveness probes, the default.
+	param.LivenessProbe = probe()
+	param.LivenessProbe.InitialDelaySeconds = healthcheck.StartupGraceSeconds
+
+	return param
+}
+
+// containerParams translates the sidecars and init containers of an application into
+// their chart parameters. It further returns the volumes shared by the containers. The
+// path of a shared volume is taken from the first mount declaring it.
+func containerParams(sidecars, initContainers []models.AppContainer) ([]ContainerParam, []ContainerParam, []VolumeMountParam) {
+	shared := []VolumeMountParam{}
+	known := map[string]bool{}
+
+	convert := func(containers []models.AppContainer) []ContainerParam {
+		var result []ContainerParam
+		for _, container := range containers {
+			param := ContainerParam{
+				Name:    container.Name,
+				Image:   container.Image,
+				Command: container.Command,
+			}
+			for _, ev := range container.Env.List() {
+				param.Env = append(param.Env, EnvParam{Name: ev.Name, Value: ev.Value})
+			}
+			for _, volume := range container.Volumes {
+				mount := VolumeMountParam{Name: volume.Name, MountPath: volume.Path}
+				param.VolumeMounts = append(param.VolumeMounts, mount)
+				if !known[volume.Name] {
+					known[volume.Name] = true
+					shared = append(shared, mount)
+				}
+			}
+			result = append(result, param)
+		}
+		return result
+	}
+
+	// Init containers first, they are the first to see the shared volumes.
+	initParams := convert(initContainers)
+	sidecarParams := convert(sidecars)
+
+	return sidecarParams, initParams, shared
+}
+
 func getValuesYAML(appChart *models.AppChartFull, parameters ChartParameters) (string, error) {
 	logger := helpers.Logger.With("component", "helm-values")
 	logger.Infow("deploy app, get values.yaml")
@@ -741,6 +943,25 @@ func getValuesYAML(appChart *models.AppChartFull, parameters ChartParameters) (s
 		params.Epinio.Start = fmt.Sprintf(`%d`, *parameters.Start)
 		logger.Infow("deploy app", "start", params.Epinio.Start)
 	}
+	if !parameters.Resources.IsEmpty() {
+		params.Epinio.Resources = resourcesParam(parameters.Resources)
+		logger.Infow("deploy app", "resources", params.Epinio.Resources)
+	}
+	if len(parameters.Sidecars) > 0 || len(parameters.InitContainers) > 0 {
+		sidecars, initContainers, shared := containerParams(parameters.Sidecars, parameters.InitContainers)
+		params.Epinio.Sidecars = sidecars
+		params.Epinio.InitContainers = initContainers
+		params.Epinio.SharedVolumes = shared
+		logger.Infow("deploy app", "sidecars", sidecars, "initContainers", initContainers, "sharedVolumes", shared)
+	}
+	if len(parameters.PullSecrets) > 0 {
+		params.Epinio.ImagePullSecrets = parameters.PullSecrets
+		logger.Infow("deploy app", "imagePullSecrets", parameters.PullSecrets)
+	}
+	if parameters.Healthcheck != nil && parameters.Healthcheck.Type != "" {
+		params.Epinio.Healthcheck = healthcheckParam(parameters.Healthcheck)
+		logger.Infow("deploy app", "healthcheck", params.Epinio.Healthcheck)
+	}
 	if len(parameters.Routes) > 0 {
 		logger.Infow("deploy app, routes and domains")
 
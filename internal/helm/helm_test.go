@@ -220,3 +220,97 @@ var _ = Describe("ValidateField()", func() {
 		Expect(err.Error()).To(Equal(`setting "field": expected boolean, got "hound"`))
 	})
 })
+
+var _ = Describe("resourcesParam()", func() {
+
+	It("maps the set values, and drops empty sections", func() {
+		param := resourcesParam(&models.AppResources{
+			Requests: models.ResourceValues{CPU: "250m", Memory: "256Mi"},
+		})
+		Expect(param.Requests).To(Equal(map[string]string{
+			"cpu":    "250m",
+			"memory": "256Mi",
+		}))
+		Expect(param.Limits).To(BeNil())
+	})
+
+	It("maps partial values", func() {
+		param := resourcesParam(&models.AppResources{
+			Limits: models.ResourceValues{Memory: "1Gi"},
+		})
+		Expect(param.Requests).To(BeNil())
+		Expect(param.Limits).To(Equal(map[string]string{
+			"memory": "1Gi",
+		}))
+	})
+})
+
+var _ = Describe("healthcheckParam()", func() {
+
+	It("has no probes for a process check", func() {
+		param := healthcheckParam(&models.AppHealthcheck{Type: models.HealthcheckProcess})
+		Expect(param.Type).To(Equal(models.HealthcheckProcess))
+		Expect(param.ReadinessProbe).To(BeNil())
+		Expect(param.LivenessProbe).To(BeNil())
+	})
+
+	It("defaults path and port of a http check", func() {
+		param := healthcheckParam(&models.AppHealthcheck{Type: models.HealthcheckHTTP})
+		Expect(param.ReadinessProbe.HTTPGet).To(Equal(&ProbeHandlerParam{Path: "/", Port: DefaultAppPort}))
+		Expect(param.LivenessProbe.HTTPGet).To(Equal(&ProbeHandlerParam{Path: "/", Port: DefaultAppPort}))
+	})
+
+	It("delays only the liveness probe by the start-up grace", func() {
+		param := healthcheckParam(&models.AppHealthcheck{
+			Type:                models.HealthcheckTCP,
+			Port:                9000,
+			SuccessThreshold:    2,
+			FailureThreshold:    4,
+			StartupGraceSeconds: 60,
+		})
+		Expect(param.ReadinessProbe).To(Equal(&ProbeParam{
+			TCPSocket:        &ProbeHandlerParam{Port: 9000},
+			SuccessThreshold: 2,
+			FailureThreshold: 4,
+		}))
+		Expect(param.LivenessProbe).To(Equal(&ProbeParam{
+			TCPSocket:           &ProbeHandlerParam{Port: 9000},
+			InitialDelaySeconds: 60,
+			FailureThreshold:    4,
+		}))
+	})
+})
+
+var _ = Describe("containerParams()", func() {
+
+	It("converts the containers and collects the shared volumes", func() {
+		sidecars, initContainers, shared := containerParams(
+			[]models.AppContainer{{
+				Name:    "agent",
+				Image:   "fluent-bit",
+				Env:     models.EnvVariableMap{"LEVEL": "info"},
+				Volumes: []models.AppVolumeMount{{Name: "logs", Path: "/var/log/agent"}},
+			}},
+			[]models.AppContainer{{
+				Name:    "migrate",
+				Image:   "app-migrations",
+				Command: []string{"migrate", "up"},
+				Volumes: []models.AppVolumeMount{{Name: "logs", Path: "/var/log/app"}},
+			}},
+		)
+
+		Expect(sidecars).To(Equal([]ContainerParam{{
+			Name:         "agent",
+			Image:        "fluent-bit",
+			Env:          []EnvParam{{Name: "LEVEL", Value: "info"}},
+			VolumeMounts: []VolumeMountParam{{Name: "logs", MountPath: "/var/log/agent"}},
+		}}))
+		Expect(initContainers).To(Equal([]ContainerParam{{
+			Name:         "migrate",
+			Image:        "app-migrations",
+			Command:      []string{"migrate", "up"},
+			VolumeMounts: []VolumeMountParam{{Name: "logs", MountPath: "/var/log/app"}},
+		}}))
+		Expect(shared).To(Equal([]VolumeMountParam{{Name: "logs", MountPath: "/var/log/app"}}))
+	})
+})
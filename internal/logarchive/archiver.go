@@ -0,0 +1,348 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logarchive
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/background"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+const (
+	// archiveScanInterval is the period of the scans for namespaces to start or stop tailing.
+	archiveScanInterval = 30 * time.Second
+	// archiveFlushInterval is the period after which buffered lines are written, at the latest.
+	archiveFlushInterval = 2 * time.Minute
+	// archivePruneInterval is the period of the removal of chunks beyond their retention.
+	archivePruneInterval = time.Hour
+	// archiveChunkSize is the size of buffered lines, in bytes, causing an immediate write.
+	archiveChunkSize = 1024 * 1024
+)
+
+// Enabled returns true if the log archive is enabled for the installation.
+func Enabled() bool {
+	return viper.GetBool("log-archive")
+}
+
+// NewStore returns the S3 storage of the installation, for use by the archive.
+func NewStore(ctx context.Context, cluster *kubernetes.Cluster) (Store, error) {
+	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching the S3 connection details")
+	}
+
+	return s3manager.New(connectionDetails)
+}
+
+// Retention returns the period the archive keeps the logs of the named namespace. This is
+// the retention of the namespace, if it has one, and the global retention otherwise.
+func Retention(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (time.Duration, error) {
+	retention, err := namespaces.GetLogRetention(ctx, cluster, namespace)
+	if err != nil {
+		return 0, err
+	}
+	if retention == "" {
+		retention = viper.GetString("log-retention")
+	}
+	if retention == "" {
+		return 0, nil
+	}
+
+	return helpers.ParseDuration(retention)
+}
+
+// source identifies the application or staging a pod belongs to.
+type source struct {
+	kind string
+	name string
+}
+
+// buffer holds the lines of an application or staging not yet written to the archive.
+type buffer struct {
+	namespace string
+	source    source
+	lines     []tailer.ContainerLogLine
+	size      int
+}
+
+// Archiver tails the application and staging containers of all namespaces with a non-zero
+// log retention, and writes their lines to the archive, in chunks.
+type Archiver struct {
+	*background.Worker
+
+	store Store
+
+	lines   chan tailer.ContainerLogLine
+	tailWg  sync.WaitGroup
+	tails   map[string]context.CancelFunc // By namespace
+	buffers map[string]*buffer            // By namespace/kind/name
+	newest  map[string]time.Time          // By namespace/pod/container, time of the newest line taken
+}
+
+// NewArchiver returns a new, not yet started, archiver writing to the store.
+func NewArchiver(store Store) *Archiver {
+	a := &Archiver{
+		store:   store,
+		lines:   make(chan tailer.ContainerLogLine),
+		tails:   map[string]context.CancelFunc{},
+		buffers: map[string]*buffer{},
+		newest:  map[string]time.Time{},
+	}
+	a.Worker = background.New(a.run)
+	return a
+}
+
+func (a *Archiver) run(ctx context.Context, stop <-chan struct{}) {
+	scanTicker := time.NewTicker(archiveScanInterval)
+	defer scanTicker.Stop()
+	flushTicker := time.NewTicker(archiveFlushInterval)
+	defer flushTicker.Stop()
+	pruneTicker := time.NewTicker(archivePruneInterval)
+	defer pruneTicker.Stop()
+
+	a.scan(ctx)
+
+	for {
+		select {
+		case <-stop:
+			a.shutdown(ctx)
+			return
+		case line := <-a.lines:
+			a.take(ctx, line)
+		case <-scanTicker.C:
+			a.scan(ctx)
+		case <-flushTicker.C:
+			a.flushAll(ctx)
+		case <-pruneTicker.C:
+			a.prune(ctx)
+		}
+	}
+}
+
+// shutdown stops all tails, takes the lines they still deliver, and writes all buffers.
+func (a *Archiver) shutdown(ctx context.Context) {
+	for namespace, cancelTail := range a.tails {
+		cancelTail()
+		delete(a.tails, namespace)
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		a.tailWg.Wait()
+		close(drained)
+	}()
+
+	for {
+		select {
+		case line := <-a.lines:
+			a.take(ctx, line)
+		case <-drained:
+			a.flushAll(ctx)
+			return
+		}
+	}
+}
+
+// scan starts tailing the namespaces with a non-zero retention, and stops tailing the
+// namespaces which are gone, or had their retention set to zero.
+func (a *Archiver) scan(ctx context.Context) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		helpers.Logger.Errorw("log archive: cluster access", "error", err)
+		return
+	}
+
+	list, err := namespaces.List(ctx, cluster)
+	if err != nil {
+		helpers.Logger.Errorw("log archive: listing namespaces", "error", err)
+		return
+	}
+
+	wanted := map[string]bool{}
+	for _, namespace := range list {
+		retention, err := Retention(ctx, cluster, namespace.Name)
+		if err != nil {
+			helpers.Logger.Infow("log archive: bad retention", "namespace", namespace.Name, "error", err)
+			continue
+		}
+		if retention > 0 {
+			wanted[namespace.Name] = true
+		}
+	}
+
+	for namespace, cancelTail := range a.tails {
+		if !wanted[namespace] {
+			helpers.Logger.Infow("log archive: stop tailing", "namespace", namespace)
+			cancelTail()
+			delete(a.tails, namespace)
+		}
+	}
+
+	for namespace := range wanted {
+		if _, found := a.tails[namespace]; found {
+			continue
+		}
+
+		err := a.tail(ctx, cluster, namespace)
+		if err != nil {
+			helpers.Logger.Errorw("log archive: tailing", "namespace", namespace, "error", err)
+			continue
+		}
+		helpers.Logger.Infow("log archive: start tailing", "namespace", namespace)
+	}
+}
+
+// tail starts following the application and staging containers of the namespace.
+func (a *Archiver) tail(ctx context.Context, cluster *kubernetes.Cluster, namespace string) error {
+	components, err := labels.NewRequirement("app.kubernetes.io/component", selection.In,
+		[]string{"application", "staging"})
+	if err != nil {
+		return err
+	}
+	partOf, err := labels.NewRequirement("app.kubernetes.io/part-of", selection.Equals,
+		[]string{namespace})
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	config := &tailer.Config{
+		ContainerQuery:        regexp.MustCompile(".*"),
+		ExcludeContainerQuery: regexp.MustCompile("linkerd-(proxy|init)"),
+		PodQuery:              regexp.MustCompile(".*"),
+		Timestamps:            true,
+		SinceTime:             &start,
+		AllNamespaces:         true,
+		LabelSelector:         labels.NewSelector().Add(*components, *partOf),
+	}
+
+	tailCtx, cancelTail := context.WithCancel(ctx)
+	a.tails[namespace] = cancelTail
+
+	a.tailWg.Add(1)
+	go func() {
+		defer a.tailWg.Done()
+
+		err := tailer.StreamLogs(tailCtx, a.lines, &a.tailWg, config, cluster)
+		if err != nil {
+			helpers.Logger.Errorw("log archive: streaming", "namespace", namespace, "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// take adds the line to the buffer of its application or staging, writing the buffer when
+// it is full. Lines already taken, from a container tailed again, are ignored.
+func (a *Archiver) take(ctx context.Context, line tailer.ContainerLogLine) {
+	t := LineTime(line)
+	containerKey := line.Namespace + "/" + line.PodName + "/" + line.ContainerName
+	if !t.After(a.newest[containerKey]) {
+		return
+	}
+	a.newest[containerKey] = t
+
+	src, ok := lineSource(line)
+	if !ok {
+		return
+	}
+
+	bufferKey := line.Namespace + "/" + src.kind + "/" + src.name
+	buf, found := a.buffers[bufferKey]
+	if !found {
+		buf = &buffer{namespace: line.Namespace, source: src}
+		a.buffers[bufferKey] = buf
+	}
+
+	buf.lines = append(buf.lines, line)
+	buf.size += len(line.Message)
+
+	if buf.size >= archiveChunkSize {
+		a.flush(ctx, bufferKey, buf)
+	}
+}
+
+// lineSource returns the application or staging the line belongs to, from the labels of the
+// pod, passed on by the tailer.
+func lineSource(line tailer.ContainerLogLine) (source, bool) {
+	src := source{kind: KindApp, name: line.Labels["app.kubernetes.io/name"]}
+	if line.Labels["app.kubernetes.io/component"] == "staging" {
+		src = source{kind: KindStaging, name: line.Labels[models.EpinioStageIDLabel]}
+	}
+	if src.name == "" {
+		return source{}, false
+	}
+
+	return src, true
+}
+
+// flushAll writes all buffers, and forgets the pods and containers not seen for a while.
+func (a *Archiver) flushAll(ctx context.Context) {
+	for bufferKey, buf := range a.buffers {
+		a.flush(ctx, bufferKey, buf)
+	}
+
+	stale := time.Now().Add(-archivePruneInterval)
+	for containerKey, t := range a.newest {
+		if t.Before(stale) {
+			delete(a.newest, containerKey)
+		}
+	}
+}
+
+// flush writes the lines of the buffer as a new chunk. Lines failing to be written are
+// dropped, to keep the memory in use bounded.
+func (a *Archiver) flush(ctx context.Context, bufferKey string, buf *buffer) {
+	delete(a.buffers, bufferKey)
+
+	err := Write(ctx, a.store, buf.namespace, buf.source.kind, buf.source.name,
+		uuid.New().String()[:8], buf.lines)
+	if err != nil {
+		helpers.Logger.Errorw("log archive: writing chunk", "namespace", buf.namespace,
+			"kind", buf.source.kind, "name", buf.source.name, "lines", len(buf.lines), "error", err)
+	}
+}
+
+// prune removes the chunks beyond the retention of their namespace. The chunks of namespaces
+// which are gone are kept for the global retention.
+func (a *Archiver) prune(ctx context.Context) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		helpers.Logger.Errorw("log archive: cluster access", "error", err)
+		return
+	}
+
+	deleted, err := Prune(ctx, a.store, time.Now(), func(namespace string) (time.Duration, error) {
+		return Retention(ctx, cluster, namespace)
+	})
+	if err != nil {
+		helpers.Logger.Errorw("log archive: pruning", "error", err)
+	}
+	if deleted > 0 {
+		helpers.Logger.Infow("log archive: pruned", "chunks", deleted)
+	}
+}
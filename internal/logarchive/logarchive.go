@@ -0,0 +1,363 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logarchive keeps the logs of applications and stagings in the S3 storage, beyond
+// the life of the pods writing them. The archive is a set of compressed chunks of log lines,
+// partitioned by namespace, application or staging, and hour.
+package logarchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Root is the key prefix of all chunks of the archive.
+	Root = "logs"
+	// KindApp marks the chunks holding the logs of an application.
+	KindApp = "app"
+	// KindStaging marks the chunks holding the logs of a staging.
+	KindStaging = "staging"
+
+	chunkSuffix      = ".jsonl.gz"
+	chunkContentType = "application/gzip"
+	hourLayout       = "2006010215"
+)
+
+// Store is the part of the S3 storage used by the archive.
+type Store interface {
+	PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string, metadata map[string]string) error
+	ListObjects(ctx context.Context, prefix string) ([]s3manager.ObjectInfo, error)
+	Download(ctx context.Context, key string) (io.ReadCloser, error)
+	DeleteObject(ctx context.Context, key string) error
+}
+
+// Chunk describes a chunk of the archive, as found from its key.
+type Chunk struct {
+	Key       string
+	Namespace string
+	Kind      string
+	Name      string // Name of the application, or id of the staging
+	First     time.Time
+	Last      time.Time
+}
+
+// Prefix returns the key prefix of the chunks of the named application or staging. An empty
+// kind returns the prefix of all chunks of the namespace.
+func Prefix(namespace, kind, name string) string {
+	if kind == "" {
+		return path.Join(Root, namespace) + "/"
+	}
+	return path.Join(Root, namespace, kind, name) + "/"
+}
+
+// ChunkKey returns the key of a chunk of the named application or staging, holding the lines
+// written between first and last. The id distinguishes chunks with the same time range.
+func ChunkKey(namespace, kind, name string, first, last time.Time, id string) string {
+	return Prefix(namespace, kind, name) +
+		first.UTC().Format(hourLayout) + "/" +
+		fmt.Sprintf("%d-%d-%s%s", first.UnixNano(), last.UnixNano(), id, chunkSuffix)
+}
+
+// ParseChunkKey is the inverse of ChunkKey. It returns false for keys not of a chunk.
+func ParseChunkKey(key string) (Chunk, bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 6 || parts[0] != Root || !strings.HasSuffix(parts[5], chunkSuffix) {
+		return Chunk{}, false
+	}
+	if parts[2] != KindApp && parts[2] != KindStaging {
+		return Chunk{}, false
+	}
+
+	fields := strings.SplitN(strings.TrimSuffix(parts[5], chunkSuffix), "-", 3)
+	if len(fields) != 3 {
+		return Chunk{}, false
+	}
+	first, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return Chunk{}, false
+	}
+	last, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return Chunk{}, false
+	}
+
+	return Chunk{
+		Key:       key,
+		Namespace: parts[1],
+		Kind:      parts[2],
+		Name:      parts[3],
+		First:     time.Unix(0, first).UTC(),
+		Last:      time.Unix(0, last).UTC(),
+	}, true
+}
+
+// LineTime returns the time of the log line. Lines without a valid timestamp have the zero
+// time.
+func LineTime(line tailer.ContainerLogLine) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, line.Timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// EncodeChunk returns the gzipped JSON lines of the log lines.
+func EncodeChunk(lines []tailer.ContainerLogLine) ([]byte, error) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(zw)
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return nil, errors.Wrap(err, "encoding log line")
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, errors.Wrap(err, "compressing log lines")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeChunk is the inverse of EncodeChunk.
+func DecodeChunk(r io.Reader) ([]tailer.ContainerLogLine, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "decompressing log lines")
+	}
+	defer func() {
+		_ = zr.Close()
+	}()
+
+	lines := []tailer.ContainerLogLine{}
+	decoder := json.NewDecoder(zr)
+	for {
+		var line tailer.ContainerLogLine
+		err := decoder.Decode(&line)
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding log line")
+		}
+		lines = append(lines, line)
+	}
+}
+
+// Write stores the log lines of the named application or staging as a new chunk. The
+// lines are expected in time order.
+func Write(ctx context.Context, store Store, namespace, kind, name, id string, lines []tailer.ContainerLogLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	data, err := EncodeChunk(lines)
+	if err != nil {
+		return err
+	}
+
+	key := ChunkKey(namespace, kind, name, LineTime(lines[0]), LineTime(lines[len(lines)-1]), id)
+	return store.PutObject(ctx, key, bytes.NewReader(data), int64(len(data)), chunkContentType,
+		map[string]string{"namespace": namespace})
+}
+
+// ReadOptions select the archived log lines passed on by Read.
+type ReadOptions struct {
+	Since time.Time
+	Until time.Time                          // A zero until leaves the range open
+	Match func(tailer.ContainerLogLine) bool // Selects the lines, all when nil
+	Tail  int64                              // Only the newest matching lines, when positive
+}
+
+// Read passes the archived log lines of the named application or staging selected by the
+// options to emit, in time order. Chunks are read one overlapping group at a time, so that
+// at most a group, plus the tail, is held in memory. With a tail the chunks are read newest
+// first, and reading stops as soon as the tail is complete. An error returned by emit stops
+// the reading, and is returned.
+func Read(ctx context.Context, store Store, namespace, kind, name string, options ReadOptions, emit func(tailer.ContainerLogLine) error) error {
+	objects, err := store.ListObjects(ctx, Prefix(namespace, kind, name))
+	if err != nil {
+		return err
+	}
+
+	chunks := []Chunk{}
+	for _, object := range objects {
+		chunk, ok := ParseChunkKey(object.Key)
+		if !ok {
+			continue
+		}
+		if chunk.Last.Before(options.Since) || (!options.Until.IsZero() && chunk.First.After(options.Until)) {
+			continue
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	if options.Tail > 0 {
+		return readTail(ctx, store, chunks, options, emit)
+	}
+
+	// Oldest first. A group extends while the next chunk starts before the group ends.
+	sort.SliceStable(chunks, func(i, j int) bool {
+		return chunks[i].First.Before(chunks[j].First)
+	})
+	for len(chunks) > 0 {
+		size, last := 1, chunks[0].Last
+		for ; size < len(chunks) && !chunks[size].First.After(last); size++ {
+			if chunks[size].Last.After(last) {
+				last = chunks[size].Last
+			}
+		}
+
+		lines, err := readGroup(ctx, store, chunks[:size], options)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			if err := emit(line); err != nil {
+				return err
+			}
+		}
+		chunks = chunks[size:]
+	}
+
+	return nil
+}
+
+// readTail passes the newest options.Tail lines of the chunks to emit, in time order.
+func readTail(ctx context.Context, store Store, chunks []Chunk, options ReadOptions, emit func(tailer.ContainerLogLine) error) error {
+	// Newest first. A group extends while the next chunk ends after the group starts.
+	sort.SliceStable(chunks, func(i, j int) bool {
+		return chunks[i].Last.After(chunks[j].Last)
+	})
+
+	// The groups are collected newest first, each holding its lines in time order.
+	groups := [][]tailer.ContainerLogLine{}
+	count := int64(0)
+	for len(chunks) > 0 && count < options.Tail {
+		size, first := 1, chunks[0].First
+		for ; size < len(chunks) && !chunks[size].Last.Before(first); size++ {
+			if chunks[size].First.Before(first) {
+				first = chunks[size].First
+			}
+		}
+
+		lines, err := readGroup(ctx, store, chunks[:size], options)
+		if err != nil {
+			return err
+		}
+		if missing := options.Tail - count; int64(len(lines)) > missing {
+			lines = lines[int64(len(lines))-missing:]
+		}
+		groups = append(groups, lines)
+		count += int64(len(lines))
+		chunks = chunks[size:]
+	}
+
+	for i := len(groups) - 1; i >= 0; i-- {
+		for _, line := range groups[i] {
+			if err := emit(line); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readGroup returns the lines of the chunks selected by the options, in time order.
+func readGroup(ctx context.Context, store Store, chunks []Chunk, options ReadOptions) ([]tailer.ContainerLogLine, error) {
+	result := []tailer.ContainerLogLine{}
+	for _, chunk := range chunks {
+		lines, err := readChunk(ctx, store, chunk.Key)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range lines {
+			t := LineTime(line)
+			if t.Before(options.Since) || (!options.Until.IsZero() && t.After(options.Until)) {
+				continue
+			}
+			if options.Match != nil && !options.Match(line) {
+				continue
+			}
+			result = append(result, line)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return LineTime(result[i]).Before(LineTime(result[j]))
+	})
+
+	return result, nil
+}
+
+// Prune deletes the chunks whose newest line is older than the retention of their namespace,
+// as returned by the retention function. The chunks of a namespace whose retention is not
+// known are kept. It returns the number of deleted chunks.
+func Prune(ctx context.Context, store Store, now time.Time, retention func(namespace string) (time.Duration, error)) (int, error) {
+	objects, err := store.ListObjects(ctx, Root+"/")
+	if err != nil {
+		return 0, err
+	}
+
+	periods := map[string]*time.Duration{}
+	deleted := 0
+	for _, object := range objects {
+		chunk, ok := ParseChunkKey(object.Key)
+		if !ok {
+			continue
+		}
+
+		period, found := periods[chunk.Namespace]
+		if !found {
+			if p, err := retention(chunk.Namespace); err == nil {
+				period = &p
+			}
+			periods[chunk.Namespace] = period
+		}
+		if period == nil || !chunk.Last.Before(now.Add(-*period)) {
+			continue
+		}
+
+		if err := store.DeleteObject(ctx, chunk.Key); err != nil {
+			return deleted, errors.Wrapf(err, "deleting chunk %s", chunk.Key)
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+func readChunk(ctx context.Context, store Store, key string) ([]tailer.ContainerLogLine, error) {
+	body, err := store.Download(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading chunk %s", key)
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	return DecodeChunk(body)
+}
@@ -0,0 +1,227 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logarchive_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/logarchive"
+	"github.com/epinio/epinio/internal/s3manager"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// memoryStore is an in-memory logarchive.Store.
+type memoryStore struct {
+	objects   map[string][]byte
+	downloads int
+}
+
+func (m *memoryStore) PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string, metadata map[string]string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.objects[key] = data
+	return nil
+}
+
+func (m *memoryStore) ListObjects(ctx context.Context, prefix string) ([]s3manager.ObjectInfo, error) {
+	result := []s3manager.ObjectInfo{}
+	for key, data := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			result = append(result, s3manager.ObjectInfo{Key: key, Size: int64(len(data))})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result, nil
+}
+
+func (m *memoryStore) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.downloads++
+	return io.NopCloser(bytes.NewReader(m.objects[key])), nil
+}
+
+func (m *memoryStore) DeleteObject(ctx context.Context, key string) error {
+	delete(m.objects, key)
+	return nil
+}
+
+func logLine(t time.Time, message string) tailer.ContainerLogLine {
+	return tailer.ContainerLogLine{
+		Message:       message,
+		ContainerName: "app",
+		PodName:       "app-0",
+		Namespace:     "workspace",
+		Timestamp:     t.Format(time.RFC3339Nano),
+	}
+}
+
+// readLines collects the lines passed on by logarchive.Read.
+func readLines(ctx context.Context, store logarchive.Store, name string, options logarchive.ReadOptions) ([]tailer.ContainerLogLine, error) {
+	lines := []tailer.ContainerLogLine{}
+	err := logarchive.Read(ctx, store, "workspace", logarchive.KindApp, name, options,
+		func(line tailer.ContainerLogLine) error {
+			lines = append(lines, line)
+			return nil
+		})
+	return lines, err
+}
+
+var _ = Describe("Log archive", func() {
+	var ctx context.Context
+	var store *memoryStore
+	var base time.Time
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		store = &memoryStore{objects: map[string][]byte{}}
+		base = time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	})
+
+	Describe("ChunkKey", func() {
+		It("round trips through ParseChunkKey", func() {
+			key := logarchive.ChunkKey("workspace", logarchive.KindApp, "myapp", base, base.Add(time.Minute), "abcd1234")
+			Expect(key).To(HavePrefix("logs/workspace/app/myapp/2024050110/"))
+
+			chunk, ok := logarchive.ParseChunkKey(key)
+			Expect(ok).To(BeTrue())
+			Expect(chunk.Namespace).To(Equal("workspace"))
+			Expect(chunk.Kind).To(Equal(logarchive.KindApp))
+			Expect(chunk.Name).To(Equal("myapp"))
+			Expect(chunk.First).To(Equal(base))
+			Expect(chunk.Last).To(Equal(base.Add(time.Minute)))
+		})
+
+		It("rejects foreign keys", func() {
+			_, ok := logarchive.ParseChunkKey("0b5c2c8e-7e9a-4d7a-9f5e-0a1b2c3d4e5f")
+			Expect(ok).To(BeFalse())
+			_, ok = logarchive.ParseChunkKey("logs/workspace/other/myapp/2024050110/1-2-x.jsonl.gz")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	It("encodes and decodes chunks", func() {
+		lines := []tailer.ContainerLogLine{logLine(base, "one"), logLine(base.Add(time.Second), "two")}
+
+		data, err := logarchive.EncodeChunk(lines)
+		Expect(err).ToNot(HaveOccurred())
+
+		decoded, err := logarchive.DecodeChunk(bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decoded).To(Equal(lines))
+	})
+
+	It("reads the lines of the time range, in order", func() {
+		err := logarchive.Write(ctx, store, "workspace", logarchive.KindApp, "myapp", "b",
+			[]tailer.ContainerLogLine{logLine(base.Add(2*time.Hour), "late")})
+		Expect(err).ToNot(HaveOccurred())
+		err = logarchive.Write(ctx, store, "workspace", logarchive.KindApp, "myapp", "a",
+			[]tailer.ContainerLogLine{logLine(base, "early"), logLine(base.Add(time.Hour), "middle")})
+		Expect(err).ToNot(HaveOccurred())
+		err = logarchive.Write(ctx, store, "workspace", logarchive.KindApp, "other", "c",
+			[]tailer.ContainerLogLine{logLine(base.Add(time.Hour), "foreign")})
+		Expect(err).ToNot(HaveOccurred())
+
+		lines, err := readLines(ctx, store, "myapp", logarchive.ReadOptions{Since: base.Add(time.Minute)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0].Message).To(Equal("middle"))
+		Expect(lines[1].Message).To(Equal("late"))
+
+		lines, err = readLines(ctx, store, "myapp", logarchive.ReadOptions{Since: base, Until: base.Add(90 * time.Minute)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0].Message).To(Equal("early"))
+	})
+
+	It("merges overlapping chunks, and filters the lines", func() {
+		err := logarchive.Write(ctx, store, "workspace", logarchive.KindApp, "myapp", "a",
+			[]tailer.ContainerLogLine{logLine(base, "one"), logLine(base.Add(2*time.Minute), "three")})
+		Expect(err).ToNot(HaveOccurred())
+		err = logarchive.Write(ctx, store, "workspace", logarchive.KindApp, "myapp", "b",
+			[]tailer.ContainerLogLine{logLine(base.Add(time.Minute), "two"), logLine(base.Add(3*time.Minute), "skip")})
+		Expect(err).ToNot(HaveOccurred())
+
+		lines, err := readLines(ctx, store, "myapp", logarchive.ReadOptions{
+			Match: func(line tailer.ContainerLogLine) bool { return line.Message != "skip" },
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0].Message).To(Equal("one"))
+		Expect(lines[1].Message).To(Equal("two"))
+		Expect(lines[2].Message).To(Equal("three"))
+	})
+
+	It("reads only the newest chunks needed for the tail", func() {
+		for i, message := range []string{"one", "two", "three"} {
+			err := logarchive.Write(ctx, store, "workspace", logarchive.KindApp, "myapp", message,
+				[]tailer.ContainerLogLine{
+					logLine(base.Add(time.Duration(2*i)*time.Hour), message+"-a"),
+					logLine(base.Add(time.Duration(2*i+1)*time.Hour), message+"-b"),
+				})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		lines, err := readLines(ctx, store, "myapp", logarchive.ReadOptions{Tail: 3})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(store.downloads).To(Equal(2))
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0].Message).To(Equal("two-b"))
+		Expect(lines[1].Message).To(Equal("three-a"))
+		Expect(lines[2].Message).To(Equal("three-b"))
+	})
+
+	It("stops reading when emit fails", func() {
+		err := logarchive.Write(ctx, store, "workspace", logarchive.KindApp, "myapp", "a",
+			[]tailer.ContainerLogLine{logLine(base, "one"), logLine(base.Add(time.Minute), "two")})
+		Expect(err).ToNot(HaveOccurred())
+
+		count := 0
+		err = logarchive.Read(ctx, store, "workspace", logarchive.KindApp, "myapp", logarchive.ReadOptions{},
+			func(line tailer.ContainerLogLine) error {
+				count++
+				return context.Canceled
+			})
+		Expect(err).To(Equal(context.Canceled))
+		Expect(count).To(Equal(1))
+	})
+
+	It("prunes the chunks beyond the retention of their namespace", func() {
+		err := logarchive.Write(ctx, store, "short", logarchive.KindApp, "myapp", "a",
+			[]tailer.ContainerLogLine{logLine(base, "old")})
+		Expect(err).ToNot(HaveOccurred())
+		err = logarchive.Write(ctx, store, "long", logarchive.KindStaging, "stage-1", "b",
+			[]tailer.ContainerLogLine{logLine(base, "old")})
+		Expect(err).ToNot(HaveOccurred())
+
+		deleted, err := logarchive.Prune(ctx, store, base.Add(48*time.Hour), func(namespace string) (time.Duration, error) {
+			if namespace == "short" {
+				return 24 * time.Hour, nil
+			}
+			return 72 * time.Hour, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deleted).To(Equal(1))
+		Expect(store.objects).To(HaveLen(1))
+
+		for key := range store.objects {
+			Expect(key).To(HavePrefix("logs/long/staging/stage-1/"))
+		}
+	})
+})
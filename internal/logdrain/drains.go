@@ -0,0 +1,269 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logdrain forwards the logs of applications to external sinks, i.e. syslog, HTTP
+// and OTLP endpoints. The drains of an application are kept in a secret of the
+// application, the drains of a namespace in a secret of the namespace. The status of the
+// forwarding is recorded in the same secrets.
+package logdrain
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// AreaLabel is the value of the `epinio.io/area` label of the secrets holding drains.
+	AreaLabel = "drains"
+	// NamespaceSecretName is the name of the secret holding the drains of a namespace.
+	NamespaceSecretName = "epinio-log-drains"
+
+	drainKeyPrefix  = "drain."
+	statusKeyPrefix = "status."
+)
+
+// Validate checks the name and URL of the drain. It returns a bad request API error for
+// any issue.
+func Validate(drain models.LogDrain) error {
+	if drain.Name == "" {
+		return apierror.NewBadRequestError("name of log drain not found")
+	}
+	if len(validation.IsDNS1123Label(drain.Name)) > 0 {
+		return apierror.NewBadRequestErrorf("Log drain name must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name', or '123-abc').")
+	}
+
+	u, err := url.Parse(drain.URL)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("bad log drain url '%s'", drain.RedactedURL()).WithDetails(err.Error())
+	}
+
+	known := false
+	for _, kind := range models.LogDrainKinds {
+		if u.Scheme == kind {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return apierror.NewBadRequestErrorf("bad log drain url scheme '%s', expected one of %s",
+			u.Scheme, strings.Join(models.LogDrainKinds, ", "))
+	}
+	if u.Hostname() == "" {
+		return apierror.NewBadRequestErrorf("log drain url '%s' has no host", drain.RedactedURL())
+	}
+	if (u.Scheme == models.LogDrainSyslog || u.Scheme == models.LogDrainSyslogTLS) && u.Port() == "" {
+		return apierror.NewBadRequestErrorf("syslog drain url '%s' has no port", drain.RedactedURL())
+	}
+	if internalHost(u.Hostname()) {
+		return apierror.NewBadRequestErrorf("log drain url '%s' targets an internal host", drain.RedactedURL())
+	}
+
+	return nil
+}
+
+// internalHost returns true for the hosts the server must not send logs to: loopback,
+// link-local, private and unspecified addresses, and the names of the cluster, i.e. names
+// without a domain, and below `.svc` or `.cluster.local`.
+func internalHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+			ip.IsPrivate() || ip.IsUnspecified()
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if !strings.Contains(host, ".") {
+		return true
+	}
+	for _, suffix := range []string{".localhost", ".svc", ".cluster.local"} {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FromSecret returns the drains held by the secret, with their status, ordered by name.
+// The scope is recorded in the drains.
+func FromSecret(secret *v1.Secret, scope string) ([]models.LogDrainStatus, error) {
+	result := []models.LogDrainStatus{}
+
+	for key, value := range secret.Data {
+		if !strings.HasPrefix(key, drainKeyPrefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(key, drainKeyPrefix)
+
+		// The status is decoded first, as the drain fields it has are empty.
+		status := models.LogDrainStatus{Health: models.LogDrainPending}
+		if raw, found := secret.Data[statusKeyPrefix+name]; found {
+			if err := json.Unmarshal(raw, &status); err != nil {
+				return nil, errors.Wrapf(err, "bad status of log drain '%s'", name)
+			}
+		}
+		status.LogDrain = models.LogDrain{}
+		if err := json.Unmarshal(value, &status.LogDrain); err != nil {
+			return nil, errors.Wrapf(err, "bad log drain '%s'", name)
+		}
+		status.Scope = scope
+
+		result = append(result, status)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// Redact replaces the URLs of the drains by their redacted form, for display.
+func Redact(drains []models.LogDrainStatus) []models.LogDrainStatus {
+	for idx := range drains {
+		drains[idx].URL = drains[idx].RedactedURL()
+	}
+	return drains
+}
+
+// AddToSecret adds the drain to the secret. It returns a conflict API error if the secret
+// already has a drain of the same name.
+func AddToSecret(secret *v1.Secret, drain models.LogDrain) error {
+	if _, found := secret.Data[drainKeyPrefix+drain.Name]; found {
+		return apierror.NewConflictError("log drain", drain.Name)
+	}
+
+	spec, err := json.Marshal(models.LogDrain{Name: drain.Name, URL: drain.URL})
+	if err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[drainKeyPrefix+drain.Name] = spec
+	return nil
+}
+
+// RemoveFromSecret removes the named drain, and its status, from the secret. It returns a
+// not found API error if the secret has no such drain.
+func RemoveFromSecret(secret *v1.Secret, name string) error {
+	if _, found := secret.Data[drainKeyPrefix+name]; !found {
+		return apierror.NewNotFoundError("log drain", name)
+	}
+
+	delete(secret.Data, drainKeyPrefix+name)
+	delete(secret.Data, statusKeyPrefix+name)
+	return nil
+}
+
+// RecordInSecret records the status of the named drain in the secret. It returns false if
+// the secret has no such drain, or the status is unchanged.
+func RecordInSecret(secret *v1.Secret, name string, status models.LogDrainStatus) (bool, error) {
+	if _, found := secret.Data[drainKeyPrefix+name]; !found {
+		return false, nil
+	}
+
+	// The drain itself is not part of the recorded status.
+	status.LogDrain = models.LogDrain{}
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return false, err
+	}
+	if string(secret.Data[statusKeyPrefix+name]) == string(raw) {
+		return false, nil
+	}
+
+	secret.Data[statusKeyPrefix+name] = raw
+	return true, nil
+}
+
+// NamespaceDrains returns the drains of the named namespace, with their status.
+func NamespaceDrains(ctx context.Context, cluster *kubernetes.Cluster, namespace string) ([]models.LogDrainStatus, error) {
+	secret, err := cluster.GetSecret(ctx, namespace, NamespaceSecretName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return []models.LogDrainStatus{}, nil
+		}
+		return nil, err
+	}
+
+	return FromSecret(secret, models.LogDrainScopeNamespace)
+}
+
+// NamespaceDrainAdd adds the drain to the named namespace. The drain is expected to be
+// validated already.
+func NamespaceDrainAdd(ctx context.Context, cluster *kubernetes.Cluster, namespace string, drain models.LogDrain) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := cluster.GetSecret(ctx, namespace, NamespaceSecretName)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+
+			secret = &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      NamespaceSecretName,
+					Namespace: namespace,
+					Labels: map[string]string{
+						"app.kubernetes.io/part-of":    namespace,
+						"app.kubernetes.io/managed-by": "epinio",
+						"epinio.io/area":               AreaLabel,
+					},
+				},
+			}
+			if err := AddToSecret(secret, drain); err != nil {
+				return err
+			}
+
+			_, err = cluster.Kubectl.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+			return err
+		}
+
+		if err := AddToSecret(secret, drain); err != nil {
+			return err
+		}
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// NamespaceDrainRemove removes the named drain from the named namespace.
+func NamespaceDrainRemove(ctx context.Context, cluster *kubernetes.Cluster, namespace, name string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := cluster.GetSecret(ctx, namespace, NamespaceSecretName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return apierror.NewNotFoundError("log drain", name)
+			}
+			return err
+		}
+
+		if err := RemoveFromSecret(secret, name); err != nil {
+			return err
+		}
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,202 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdrain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// ForwarderOptions configures the buffering, batching and retrying of a forwarder. Zero
+// fields take the defaults.
+type ForwarderOptions struct {
+	BufferSize    int           // Records held before new records are dropped. Default 10000
+	BatchSize     int           // Records sent at once, at most. Default 500
+	FlushInterval time.Duration // Period after which a partial batch is sent. Default 1s
+	Attempts      int           // Attempts to send a batch before it is dropped. Default 3
+	RetryDelay    time.Duration // Delay before the first retry, doubled for each further retry. Default 1s
+	Sent          int64         // Lines forwarded before, by an earlier forwarder of the drain
+	Dropped       int64         // Lines dropped before, by an earlier forwarder of the drain
+}
+
+func (o ForwarderOptions) withDefaults() ForwarderOptions {
+	if o.BufferSize <= 0 {
+		o.BufferSize = 10000
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 500
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = time.Second
+	}
+	if o.Attempts <= 0 {
+		o.Attempts = 3
+	}
+	if o.RetryDelay <= 0 {
+		o.RetryDelay = time.Second
+	}
+	return o
+}
+
+// Forwarder sends the records given to it to a sink, in batches. Records are buffered up to
+// a limit, beyond which they are dropped, so that a slow or failing sink does not hold up
+// the logs of other drains.
+type Forwarder struct {
+	sink    Sink
+	options ForwarderOptions
+	queue   chan Record
+	stop    chan struct{}
+	done    chan struct{}
+
+	mu     sync.Mutex
+	status models.LogDrainStatus
+}
+
+// NewForwarder returns a new, not yet started, forwarder to the sink.
+func NewForwarder(sink Sink, options ForwarderOptions) *Forwarder {
+	options = options.withDefaults()
+	return &Forwarder{
+		sink:    sink,
+		options: options,
+		queue:   make(chan Record, options.BufferSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		status: models.LogDrainStatus{
+			Health:  models.LogDrainPending,
+			Sent:    options.Sent,
+			Dropped: options.Dropped,
+		},
+	}
+}
+
+// Start runs the forwarder in the background.
+func (f *Forwarder) Start() {
+	go f.run()
+}
+
+// Stop stops the forwarder, after a last attempt to send the buffered records, and closes
+// the sink.
+func (f *Forwarder) Stop() {
+	close(f.stop)
+	<-f.done
+}
+
+// Enqueue hands the record to the forwarder. It never blocks. It returns false if the
+// buffer is full, and the record was dropped.
+func (f *Forwarder) Enqueue(record Record) bool {
+	select {
+	case f.queue <- record:
+		return true
+	default:
+		f.mu.Lock()
+		f.status.Dropped++
+		f.mu.Unlock()
+		return false
+	}
+}
+
+// Status returns the statistics of the forwarder, with an empty drain.
+func (f *Forwarder) Status() models.LogDrainStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.status
+}
+
+func (f *Forwarder) run() {
+	defer close(f.done)
+	defer func() {
+		_ = f.sink.Close()
+	}()
+
+	ticker := time.NewTicker(f.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, f.options.BatchSize)
+	for {
+		select {
+		case <-f.stop:
+			// Last attempt for what is left, without retries.
+			for {
+				select {
+				case record := <-f.queue:
+					batch = append(batch, record)
+					if len(batch) >= f.options.BatchSize {
+						f.send(batch, 1)
+						batch = batch[:0]
+					}
+				default:
+					f.send(batch, 1)
+					return
+				}
+			}
+		case record := <-f.queue:
+			batch = append(batch, record)
+			if len(batch) >= f.options.BatchSize {
+				f.send(batch, f.options.Attempts)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			f.send(batch, f.options.Attempts)
+			batch = batch[:0]
+		}
+	}
+}
+
+// send sends the batch to the sink, retrying with exponential backoff. A batch failing all
+// attempts is dropped.
+func (f *Forwarder) send(batch []Record, attempts int) {
+	if len(batch) == 0 {
+		return
+	}
+
+	delay := f.options.RetryDelay
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), sinkTimeout)
+		err = f.sink.Send(ctx, batch)
+		cancel()
+		if err == nil {
+			break
+		}
+
+		f.mu.Lock()
+		f.status.Health = models.LogDrainFailing
+		f.status.Failures++
+		f.status.LastError = err.Error()
+		f.mu.Unlock()
+
+		if attempt == attempts {
+			break
+		}
+		select {
+		case <-f.stop:
+			attempts = attempt
+		case <-time.After(delay):
+			delay *= 2
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err != nil {
+		f.status.Dropped += int64(len(batch))
+		return
+	}
+	f.status.Health = models.LogDrainHealthy
+	f.status.Failures = 0
+	f.status.LastError = ""
+	f.status.Sent += int64(len(batch))
+	f.status.LastSent = time.Now().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,350 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdrain
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/background"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+const (
+	// drainScanInterval is the period of the scans for drains added or removed, and of the
+	// recording of changes to the health of the drains.
+	drainScanInterval = 30 * time.Second
+	// drainRecordInterval is the period of the recording of the counters of the drains.
+	drainRecordInterval = 5 * time.Minute
+)
+
+// drainKey identifies a drain. The application is empty for the drains of a namespace.
+type drainKey struct {
+	namespace string
+	app       string
+	name      string
+	url       string
+}
+
+// Manager tails the application and staging containers of all namespaces having drains,
+// and hands their lines to the forwarders of the drains of the application, and of the
+// namespace.
+type Manager struct {
+	*background.Worker
+
+	lines      chan tailer.ContainerLogLine
+	tailWg     sync.WaitGroup
+	tails      map[string]context.CancelFunc // By namespace
+	forwarders map[drainKey]*Forwarder
+	routes     map[string][]*Forwarder // By namespace/app, and namespace/ for the namespace drains
+	recorded   map[string]time.Time    // Time of the last recording, by namespace/secret
+}
+
+// NewManager returns a new, not yet started, manager.
+func NewManager() *Manager {
+	m := &Manager{
+		lines:      make(chan tailer.ContainerLogLine),
+		tails:      map[string]context.CancelFunc{},
+		forwarders: map[drainKey]*Forwarder{},
+		routes:     map[string][]*Forwarder{},
+		recorded:   map[string]time.Time{},
+	}
+	m.Worker = background.New(m.run)
+	return m
+}
+
+func (m *Manager) run(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(drainScanInterval)
+	defer ticker.Stop()
+
+	m.scan(ctx)
+
+	for {
+		select {
+		case <-stop:
+			m.shutdown(ctx)
+			return
+		case line := <-m.lines:
+			m.route(line)
+		case <-ticker.C:
+			m.scan(ctx)
+		}
+	}
+}
+
+// shutdown stops all tails, discarding the lines they still deliver, and all forwarders,
+// recording their status.
+func (m *Manager) shutdown(ctx context.Context) {
+	for namespace, cancelTail := range m.tails {
+		cancelTail()
+		delete(m.tails, namespace)
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		m.tailWg.Wait()
+		close(drained)
+	}()
+
+	for {
+		select {
+		case <-m.lines:
+		case <-drained:
+			for _, forwarder := range m.forwarders {
+				forwarder.Stop()
+			}
+			m.recordAll(ctx)
+			m.forwarders = map[drainKey]*Forwarder{}
+			return
+		}
+	}
+}
+
+// scan records the status of the running drains, starts forwarders and tails for new
+// drains, and stops them for removed drains.
+func (m *Manager) scan(ctx context.Context) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		helpers.Logger.Errorw("log drains: cluster access", "error", err)
+		return
+	}
+
+	secrets, err := cluster.Kubectl.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		LabelSelector: "epinio.io/area=" + AreaLabel,
+	})
+	if err != nil {
+		helpers.Logger.Errorw("log drains: listing drains", "error", err)
+		return
+	}
+
+	wanted := map[drainKey]models.LogDrainStatus{} // With the recorded status
+	namespaces := map[string]bool{}
+	listed := map[string]bool{} // By namespace/secret
+	for idx := range secrets.Items {
+		secret := &secrets.Items[idx]
+
+		app, drains, ok := secretDrains(secret)
+		if !ok {
+			continue
+		}
+
+		for _, drain := range drains {
+			wanted[drainKey{namespace: secret.Namespace, app: app, name: drain.Name, url: drain.URL}] = drain
+			namespaces[secret.Namespace] = true
+		}
+
+		listed[secret.Namespace+"/"+secret.Name] = true
+		m.record(ctx, cluster, secret, app, drains, false)
+	}
+	for secretKey := range m.recorded {
+		if !listed[secretKey] {
+			delete(m.recorded, secretKey)
+		}
+	}
+
+	for key, forwarder := range m.forwarders {
+		if _, found := wanted[key]; !found {
+			helpers.Logger.Infow("log drains: stop", "namespace", key.namespace, "app", key.app, "drain", key.name)
+			forwarder.Stop()
+			delete(m.forwarders, key)
+		}
+	}
+	for key, status := range wanted {
+		if _, found := m.forwarders[key]; found {
+			continue
+		}
+
+		sink, err := NewSink(key.url)
+		if err != nil {
+			helpers.Logger.Infow("log drains: bad sink", "namespace", key.namespace, "app", key.app, "drain", key.name, "error", err)
+			continue
+		}
+
+		helpers.Logger.Infow("log drains: start", "namespace", key.namespace, "app", key.app, "drain", key.name)
+		// The counters continue from the recorded status, across restarts of the server.
+		forwarder := NewForwarder(sink, ForwarderOptions{Sent: status.Sent, Dropped: status.Dropped})
+		forwarder.Start()
+		m.forwarders[key] = forwarder
+	}
+
+	m.routes = map[string][]*Forwarder{}
+	for key, forwarder := range m.forwarders {
+		route := key.namespace + "/" + key.app
+		m.routes[route] = append(m.routes[route], forwarder)
+	}
+
+	for namespace, cancelTail := range m.tails {
+		if !namespaces[namespace] {
+			cancelTail()
+			delete(m.tails, namespace)
+		}
+	}
+	for namespace := range namespaces {
+		if _, found := m.tails[namespace]; found {
+			continue
+		}
+		if err := m.tail(ctx, cluster, namespace); err != nil {
+			helpers.Logger.Errorw("log drains: tailing", "namespace", namespace, "error", err)
+		}
+	}
+}
+
+// secretDrains returns the application, empty for a namespace, and the drains, with their
+// recorded status, held by the secret.
+func secretDrains(secret *v1.Secret) (string, []models.LogDrainStatus, bool) {
+	app, scope := secret.Labels["app.kubernetes.io/name"], models.LogDrainScopeApp
+	if secret.Name == NamespaceSecretName {
+		app, scope = "", models.LogDrainScopeNamespace
+	} else if app == "" {
+		return "", nil, false
+	}
+
+	drains, err := FromSecret(secret, scope)
+	if err != nil {
+		helpers.Logger.Infow("log drains: bad drains", "namespace", secret.Namespace, "secret", secret.Name, "error", err)
+		return "", nil, false
+	}
+
+	return app, drains, true
+}
+
+// record saves the status of the running drains held by the secret into the secret. Changes
+// to the health of a drain are saved right away, changes to the counters only every
+// drainRecordInterval, or when forced. Conflicts are left for the next scan to resolve.
+func (m *Manager) record(ctx context.Context, cluster *kubernetes.Cluster, secret *v1.Secret, app string, drains []models.LogDrainStatus, force bool) {
+	secretKey := secret.Namespace + "/" + secret.Name
+	due := force || time.Since(m.recorded[secretKey]) >= drainRecordInterval
+
+	previous := map[string]models.LogDrainStatus{}
+	for _, drain := range drains {
+		previous[drain.Name] = drain
+	}
+
+	changed := false
+	for key, forwarder := range m.forwarders {
+		if key.namespace != secret.Namespace || key.app != app {
+			continue
+		}
+
+		status := forwarder.Status()
+		if !due && status.Health == previous[key.name].Health && status.LastError == previous[key.name].LastError {
+			continue
+		}
+
+		updated, err := RecordInSecret(secret, key.name, status)
+		if err != nil {
+			helpers.Logger.Infow("log drains: recording status", "namespace", key.namespace, "app", key.app, "drain", key.name, "error", err)
+			continue
+		}
+		changed = changed || updated
+	}
+	if !changed {
+		return
+	}
+
+	_, err := cluster.Kubectl.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		helpers.Logger.Infow("log drains: saving status", "namespace", secret.Namespace, "secret", secret.Name, "error", err)
+		return
+	}
+	m.recorded[secretKey] = time.Now()
+}
+
+// recordAll saves the status of all running drains, counters included.
+func (m *Manager) recordAll(ctx context.Context) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		helpers.Logger.Errorw("log drains: cluster access", "error", err)
+		return
+	}
+
+	secrets, err := cluster.Kubectl.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		LabelSelector: "epinio.io/area=" + AreaLabel,
+	})
+	if err != nil {
+		helpers.Logger.Errorw("log drains: listing drains", "error", err)
+		return
+	}
+
+	for idx := range secrets.Items {
+		secret := &secrets.Items[idx]
+		if app, drains, ok := secretDrains(secret); ok {
+			m.record(ctx, cluster, secret, app, drains, true)
+		}
+	}
+}
+
+// tail starts following the application and staging containers of the namespace.
+func (m *Manager) tail(ctx context.Context, cluster *kubernetes.Cluster, namespace string) error {
+	components, err := labels.NewRequirement("app.kubernetes.io/component", selection.In,
+		[]string{"application", "staging"})
+	if err != nil {
+		return err
+	}
+	partOf, err := labels.NewRequirement("app.kubernetes.io/part-of", selection.Equals,
+		[]string{namespace})
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	config := &tailer.Config{
+		ContainerQuery:        regexp.MustCompile(".*"),
+		ExcludeContainerQuery: regexp.MustCompile("linkerd-(proxy|init)"),
+		PodQuery:              regexp.MustCompile(".*"),
+		Timestamps:            true,
+		SinceTime:             &start,
+		AllNamespaces:         true,
+		LabelSelector:         labels.NewSelector().Add(*components, *partOf),
+	}
+
+	tailCtx, cancelTail := context.WithCancel(ctx)
+	m.tails[namespace] = cancelTail
+
+	m.tailWg.Add(1)
+	go func() {
+		defer m.tailWg.Done()
+
+		err := tailer.StreamLogs(tailCtx, m.lines, &m.tailWg, config, cluster)
+		if err != nil {
+			helpers.Logger.Errorw("log drains: streaming", "namespace", namespace, "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// route hands the line to the drains of its application, and of its namespace. The
+// application is taken from the labels of the pod, passed on by the tailer.
+func (m *Manager) route(line tailer.ContainerLogLine) {
+	app := line.Labels["app.kubernetes.io/name"]
+	if app == "" {
+		return
+	}
+
+	record := Record{App: app, Line: line}
+	for _, forwarder := range m.routes[line.Namespace+"/"+app] {
+		forwarder.Enqueue(record)
+	}
+	for _, forwarder := range m.routes[line.Namespace+"/"] {
+		forwarder.Enqueue(record)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdrain
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+)
+
+// sinkTimeout limits the time spent on connecting to a sink, and on sending a batch.
+const sinkTimeout = 10 * time.Second
+
+// Record is a log line of an application, to be forwarded.
+type Record struct {
+	App  string
+	Line tailer.ContainerLogLine
+}
+
+// Time returns the time of the log line, or the current time if the line has no valid
+// timestamp.
+func (r Record) Time() time.Time {
+	t, err := time.Parse(time.RFC3339Nano, r.Line.Timestamp)
+	if err != nil {
+		return time.Now()
+	}
+	return t
+}
+
+// Sink is an external endpoint receiving log records.
+type Sink interface {
+	// Send forwards the batch of records. A batch is either forwarded as a whole, or not.
+	Send(ctx context.Context, records []Record) error
+	// Close releases the resources held by the sink, i.e. connections.
+	Close() error
+}
+
+// NewSink returns the sink for the URL of a drain. The scheme of the URL selects the kind
+// of sink, see models.LogDrainKinds.
+func NewSink(rawURL string) (Sink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case models.LogDrainSyslog:
+		return &syslogSink{address: u.Host}, nil
+	case models.LogDrainSyslogTLS:
+		return &syslogSink{address: u.Host, tlsConfig: &tls.Config{
+			ServerName: u.Hostname(),
+			MinVersion: tls.VersionTLS12,
+		}}, nil
+	case models.LogDrainHTTP, models.LogDrainHTTPS:
+		return &httpSink{url: u.String(), client: &http.Client{Timeout: sinkTimeout}}, nil
+	case models.LogDrainOTLP, models.LogDrainOTLPS:
+		u.Scheme = strings.TrimPrefix(u.Scheme, "otlp+")
+		if u.Path == "" || u.Path == "/" {
+			u.Path = "/v1/logs"
+		}
+		return &otlpSink{url: u.String(), client: &http.Client{Timeout: sinkTimeout}}, nil
+	}
+
+	return nil, fmt.Errorf("unknown log drain kind '%s'", u.Scheme)
+}
+
+// syslogSink writes RFC5424 messages to a TCP, or TLS, connection, framed by octet counting
+// (RFC6587). The connection is kept open across batches, and reopened after a failure.
+type syslogSink struct {
+	address   string
+	tlsConfig *tls.Config
+	conn      net.Conn
+}
+
+func (s *syslogSink) Send(ctx context.Context, records []Record) error {
+	var buf bytes.Buffer
+	for _, record := range records {
+		message := SyslogMessage(record)
+		buf.WriteString(strconv.Itoa(len(message)))
+		buf.WriteByte(' ')
+		buf.WriteString(message)
+	}
+
+	if s.conn == nil {
+		dialer := &net.Dialer{Timeout: sinkTimeout}
+
+		var conn net.Conn
+		var err error
+		if s.tlsConfig != nil {
+			conn, err = (&tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}).DialContext(ctx, "tcp", s.address)
+		} else {
+			conn, err = dialer.DialContext(ctx, "tcp", s.address)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "connecting to %s", s.address)
+		}
+		s.conn = conn
+	}
+
+	if err := s.conn.SetWriteDeadline(time.Now().Add(sinkTimeout)); err != nil {
+		_ = s.Close()
+		return err
+	}
+	if _, err := s.conn.Write(buf.Bytes()); err != nil {
+		_ = s.Close()
+		return errors.Wrapf(err, "writing to %s", s.address)
+	}
+
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// SyslogMessage returns the RFC5424 message for the record. The host is the namespace and
+// application, the app name the application, the process id the pod, and the message id
+// the container.
+func SyslogMessage(record Record) string {
+	// Facility user (1), severity informational (6)
+	const priority = 1*8 + 6
+
+	line := record.Line
+	return fmt.Sprintf("<%d>1 %s %s %s %s %s - %s",
+		priority,
+		record.Time().UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogField(line.Namespace+"."+record.App, 255),
+		syslogField(record.App, 48),
+		syslogField(line.PodName, 128),
+		syslogField(line.ContainerName, 32),
+		line.Message)
+}
+
+// syslogField returns the value as a syslog header field, of printable characters without
+// spaces, limited in size. Empty values are replaced by the nil value.
+func syslogField(value string, limit int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, value)
+
+	if value == "" || value == "." {
+		return "-"
+	}
+	if len(value) > limit {
+		value = value[:limit]
+	}
+	return value
+}
+
+// httpEntry is the JSON form of a record sent to HTTP sinks.
+type httpEntry struct {
+	Timestamp string `json:"timestamp"`
+	Namespace string `json:"namespace"`
+	App       string `json:"app"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Message   string `json:"message"`
+}
+
+// httpSink posts batches of records as a JSON array. User info of the URL is sent as basic
+// authentication.
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *httpSink) Send(ctx context.Context, records []Record) error {
+	entries := make([]httpEntry, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, httpEntry{
+			Timestamp: record.Time().UTC().Format(time.RFC3339Nano),
+			Namespace: record.Line.Namespace,
+			App:       record.App,
+			Pod:       record.Line.PodName,
+			Container: record.Line.ContainerName,
+			Message:   record.Line.Message,
+		})
+	}
+
+	return postJSON(ctx, s.client, s.url, entries)
+}
+
+func (s *httpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// otlpSink posts batches of records as OTLP logs, in the JSON encoding of OTLP/HTTP.
+type otlpSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *otlpSink) Send(ctx context.Context, records []Record) error {
+	return postJSON(ctx, s.client, s.url, OTLPLogs(records))
+}
+
+func (s *otlpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// OTLPLogs returns the OTLP logs request for the records, grouping them by namespace and
+// application into resources.
+func OTLPLogs(records []Record) map[string]interface{} {
+	type resourceKey struct{ namespace, app string }
+
+	order := []resourceKey{}
+	logRecords := map[resourceKey][]interface{}{}
+	for _, record := range records {
+		key := resourceKey{record.Line.Namespace, record.App}
+		if _, found := logRecords[key]; !found {
+			order = append(order, key)
+		}
+
+		logRecords[key] = append(logRecords[key], map[string]interface{}{
+			"timeUnixNano": strconv.FormatInt(record.Time().UnixNano(), 10),
+			"body":         otlpString(record.Line.Message),
+			"attributes": []interface{}{
+				otlpAttribute("k8s.pod.name", record.Line.PodName),
+				otlpAttribute("k8s.container.name", record.Line.ContainerName),
+			},
+		})
+	}
+
+	resourceLogs := []interface{}{}
+	for _, key := range order {
+		resourceLogs = append(resourceLogs, map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": []interface{}{
+					otlpAttribute("service.name", key.app),
+					otlpAttribute("service.namespace", key.namespace),
+					otlpAttribute("k8s.namespace.name", key.namespace),
+				},
+			},
+			"scopeLogs": []interface{}{
+				map[string]interface{}{
+					"scope":      map[string]interface{}{"name": "epinio"},
+					"logRecords": logRecords[key],
+				},
+			},
+		})
+	}
+
+	return map[string]interface{}{"resourceLogs": resourceLogs}
+}
+
+func otlpString(value string) map[string]interface{} {
+	return map[string]interface{}{"stringValue": value}
+}
+
+func otlpAttribute(key, value string) map[string]interface{} {
+	return map[string]interface{}{"key": key, "value": otlpString(value)}
+}
+
+// postJSON posts the value as JSON to the URL, expecting a 2xx response.
+func postJSON(ctx context.Context, client *http.Client, rawURL string, value interface{}) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("log drain responded with %s", response.Status)
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -210,6 +211,102 @@ func UpdateSources(manifest models.ApplicationManifest, cmd *cobra.Command) (mod
 	return manifest, nil
 }
 
+// UpdateRegistryCredential updates the incoming manifest with information pulled from the
+// --registry-credential option. The credentials are only used to pull container images.
+func UpdateRegistryCredential(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	if !cmd.Flags().Changed("registry-credential") {
+		return manifest, nil
+	}
+
+	name, err := cmd.Flags().GetString("registry-credential")
+	if err != nil {
+		return manifest, errors.Wrap(err, "failed to read option --registry-credential")
+	}
+
+	if manifest.Origin.Kind != models.OriginContainer {
+		return manifest, errors.New("Option --registry-credential requires a container image origin")
+	}
+
+	manifest.Configuration.RegistryCredential = name
+	return manifest, nil
+}
+
+// UpdateImageWatch updates the incoming manifest with information pulled from the
+// --image-watch-interval and --image-watch-mode options. The options require a container
+// image origin. An empty interval disables the watch.
+func UpdateImageWatch(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	if !cmd.Flags().Changed("image-watch-interval") && !cmd.Flags().Changed("image-watch-mode") {
+		return manifest, nil
+	}
+
+	if manifest.Origin.Kind != models.OriginContainer {
+		return manifest, errors.New("Options --image-watch-interval and --image-watch-mode require a container image origin")
+	}
+
+	watch := models.AppImageWatch{}
+	if manifest.Configuration.ImageWatch != nil {
+		watch = *manifest.Configuration.ImageWatch
+	}
+
+	if cmd.Flags().Changed("image-watch-interval") {
+		interval, err := cmd.Flags().GetString("image-watch-interval")
+		if err != nil {
+			return manifest, errors.Wrap(err, "failed to read option --image-watch-interval")
+		}
+		if interval != "" {
+			if _, err := time.ParseDuration(interval); err != nil {
+				return manifest, errors.Wrap(err, "bad --image-watch-interval")
+			}
+		}
+		watch.Interval = interval
+	}
+
+	if cmd.Flags().Changed("image-watch-mode") {
+		mode, err := cmd.Flags().GetString("image-watch-mode")
+		if err != nil {
+			return manifest, errors.Wrap(err, "failed to read option --image-watch-mode")
+		}
+		if mode != models.ImageWatchRedeploy && mode != models.ImageWatchNotify {
+			return manifest, errors.Errorf("bad --image-watch-mode '%s', expected one of %s, %s",
+				mode, models.ImageWatchRedeploy, models.ImageWatchNotify)
+		}
+		watch.Mode = mode
+	}
+
+	manifest.Configuration.ImageWatch = &watch
+	return manifest, nil
+}
+
+// UpdateGitPoll updates the incoming manifest with information pulled from the
+// --git-poll-interval option. The option requires a git origin, from the manifest or the
+// --git option. An empty interval disables polling.
+func UpdateGitPoll(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	if !cmd.Flags().Changed("git-poll-interval") {
+		return manifest, nil
+	}
+
+	interval, err := cmd.Flags().GetString("git-poll-interval")
+	if err != nil {
+		return manifest, errors.Wrap(err, "failed to read option --git-poll-interval")
+	}
+
+	if manifest.Origin.Kind != models.OriginGit || manifest.Origin.Git == nil {
+		return manifest, errors.New("Option --git-poll-interval requires a git origin")
+	}
+
+	if interval != "" {
+		if _, err := time.ParseDuration(interval); err != nil {
+			return manifest, errors.Wrap(err, "bad --git-poll-interval")
+		}
+	}
+
+	gitRef := *manifest.Origin.Git
+	gitRef.PollInterval = interval
+	manifest.Origin.Git = &gitRef
+
+	return manifest, nil
+}
+
 // UpdateName updates the incoming manifest with information pulled from the --name option
 func UpdateName(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
 	name, err := cmd.Flags().GetString("name")
@@ -257,6 +354,68 @@ func UpdateICE(manifest models.ApplicationManifest, cmd *cobra.Command) (models.
 		return manifest, err
 	}
 
+	// Resources - Retrieve from options
+	manifest, err = UpdateResources(manifest, cmd)
+	if err != nil {
+		return manifest, err
+	}
+
+	// Healthcheck - Retrieve from options
+	manifest, err = UpdateHealthcheck(manifest, cmd)
+	if err != nil {
+		return manifest, err
+	}
+
+	// Dependencies - Retrieve from options
+	manifest, err = UpdateDependencies(manifest, cmd)
+	if err != nil {
+		return manifest, err
+	}
+
+	return manifest, nil
+}
+
+// UpdateResources updates the incoming manifest with information pulled from the
+// --cpu-request, --cpu-limit, --memory-request, and --memory-limit options.
+// Each option replaces only its own part of the existing information.
+func UpdateResources(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	options := map[string]string{}
+	for _, name := range []string{"cpu-request", "cpu-limit", "memory-request", "memory-limit"} {
+		value, err := cmd.Flags().GetString(name)
+		if err != nil {
+			return manifest, errors.Wrap(err, "failed to read option --"+name)
+		}
+		if value != "" {
+			options[name] = value
+		}
+	}
+
+	// Resources - Merge
+
+	if len(options) == 0 {
+		return manifest, nil
+	}
+
+	resources := models.AppResources{}
+	if manifest.Configuration.Resources != nil {
+		resources = *manifest.Configuration.Resources
+	}
+
+	for name, value := range options {
+		switch name {
+		case "cpu-request":
+			resources.Requests.CPU = value
+		case "cpu-limit":
+			resources.Limits.CPU = value
+		case "memory-request":
+			resources.Requests.Memory = value
+		case "memory-limit":
+			resources.Limits.Memory = value
+		}
+	}
+
+	manifest.Configuration.Resources = &resources
+
 	return manifest, nil
 }
 
@@ -470,3 +629,94 @@ func fileExists(path string) (bool, error) {
 		return false, errors.Wrapf(err, "failed to stat file '%s'", path)
 	}
 }
+
+// UpdateHealthcheck updates the incoming manifest with information pulled from the
+// --healthcheck-* options. Each option replaces only its own part of the existing
+// information.
+func UpdateHealthcheck(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	healthcheck := models.AppHealthcheck{}
+	if manifest.Configuration.Healthcheck != nil {
+		healthcheck = *manifest.Configuration.Healthcheck
+	}
+	changed := false
+
+	for name, field := range map[string]*string{
+		"healthcheck-type": &healthcheck.Type,
+		"healthcheck-path": &healthcheck.Path,
+	} {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		value, err := cmd.Flags().GetString(name)
+		if err != nil {
+			return manifest, errors.Wrap(err, "failed to read option --"+name)
+		}
+		*field = value
+		changed = true
+	}
+
+	for name, field := range map[string]*int32{
+		"healthcheck-port":              &healthcheck.Port,
+		"healthcheck-timeout":           &healthcheck.TimeoutSeconds,
+		"healthcheck-period":            &healthcheck.PeriodSeconds,
+		"healthcheck-success-threshold": &healthcheck.SuccessThreshold,
+		"healthcheck-failure-threshold": &healthcheck.FailureThreshold,
+		"healthcheck-startup-grace":     &healthcheck.StartupGraceSeconds,
+	} {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		value, err := cmd.Flags().GetInt32(name)
+		if err != nil {
+			return manifest, errors.Wrap(err, "failed to read option --"+name)
+		}
+		*field = value
+		changed = true
+	}
+
+	if changed {
+		manifest.Configuration.Healthcheck = &healthcheck
+	}
+
+	return manifest, nil
+}
+
+// UpdateDependencies updates the incoming manifest with information pulled from the
+// --depends-on and --clear-depends-on options. Options override existing information.
+func UpdateDependencies(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	clearDependencies, err := cmd.Flags().GetBool("clear-depends-on")
+	if err != nil {
+		return manifest, errors.Wrap(err, "could not read option --clear-depends-on")
+	}
+
+	dependsOn, err := cmd.Flags().GetStringSlice("depends-on")
+	if err != nil {
+		return manifest, errors.Wrap(err, "could not read option --depends-on")
+	}
+
+	// Dependencies - Merge
+
+	if clearDependencies {
+		if len(dependsOn) > 0 {
+			return manifest, errors.New("Conflict between --clear-depends-on and --depends-on")
+		}
+		manifest.Configuration.DependsOn = []models.AppDependency{}
+		return manifest, nil
+	}
+
+	if len(dependsOn) == 0 {
+		return manifest, nil
+	}
+
+	dependencies := []models.AppDependency{}
+	for _, value := range dependsOn {
+		dependency, err := models.ParseAppDependency(value)
+		if err != nil {
+			return manifest, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	manifest.Configuration.DependsOn = dependencies
+
+	return manifest, nil
+}
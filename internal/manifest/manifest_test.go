@@ -182,4 +182,239 @@ configuration:
 			})
 		})
 	})
+
+	Describe("UpdateResources", func() {
+		var c *cobra.Command
+
+		BeforeEach(func() {
+			c = &cobra.Command{}
+			c.Flags().String("cpu-request", "", "")
+			c.Flags().String("cpu-limit", "", "")
+			c.Flags().String("memory-request", "", "")
+			c.Flags().String("memory-limit", "", "")
+		})
+
+		When("no option is set", func() {
+			It("leaves the manifest resources untouched", func() {
+				m, err := manifest.UpdateResources(models.ApplicationManifest{}, c)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(m.Configuration.Resources).To(BeNil())
+			})
+		})
+
+		When("some options are set", func() {
+			It("replaces only the specified values", func() {
+				Expect(c.Flags().Set("cpu-limit", "1")).To(Succeed())
+				Expect(c.Flags().Set("memory-request", "256Mi")).To(Succeed())
+
+				m, err := manifest.UpdateResources(models.ApplicationManifest{
+					Configuration: models.ApplicationConfiguration{
+						Resources: &models.AppResources{
+							Requests: models.ResourceValues{CPU: "100m", Memory: "128Mi"},
+						},
+					},
+				}, c)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(m.Configuration.Resources).To(Equal(&models.AppResources{
+					Requests: models.ResourceValues{CPU: "100m", Memory: "256Mi"},
+					Limits:   models.ResourceValues{CPU: "1"},
+				}))
+			})
+		})
+	})
+
+	Describe("UpdateDependencies", func() {
+		var c *cobra.Command
+
+		BeforeEach(func() {
+			c = &cobra.Command{}
+			c.Flags().StringSlice("depends-on", []string{}, "")
+			c.Flags().Bool("clear-depends-on", false, "")
+		})
+
+		It("replaces the dependencies", func() {
+			Expect(c.Flags().Set("depends-on", "app:backend")).To(Succeed())
+			Expect(c.Flags().Set("depends-on", "service:mydb")).To(Succeed())
+
+			m, err := manifest.UpdateDependencies(models.ApplicationManifest{}, c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Configuration.DependsOn).To(Equal([]models.AppDependency{
+				{App: "backend"},
+				{Service: "mydb"},
+			}))
+		})
+
+		It("clears the dependencies", func() {
+			Expect(c.Flags().Set("clear-depends-on", "true")).To(Succeed())
+
+			m, err := manifest.UpdateDependencies(models.ApplicationManifest{
+				Configuration: models.ApplicationConfiguration{
+					DependsOn: []models.AppDependency{{App: "backend"}},
+				},
+			}, c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Configuration.DependsOn).ToNot(BeNil())
+			Expect(m.Configuration.DependsOn).To(BeEmpty())
+		})
+
+		It("rejects bad dependencies", func() {
+			Expect(c.Flags().Set("depends-on", "backend")).To(Succeed())
+
+			_, err := manifest.UpdateDependencies(models.ApplicationManifest{}, c)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UpdateGitPoll", func() {
+		var c *cobra.Command
+
+		BeforeEach(func() {
+			c = &cobra.Command{}
+			c.Flags().String("git-poll-interval", "", "")
+		})
+
+		It("sets the poll interval of the git origin", func() {
+			Expect(c.Flags().Set("git-poll-interval", "5m")).To(Succeed())
+
+			m, err := manifest.UpdateGitPoll(models.ApplicationManifest{
+				Origin: models.ApplicationOrigin{
+					Kind: models.OriginGit,
+					Git:  &models.GitRef{URL: "https://github.com/org/repo"},
+				},
+			}, c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Origin.Git.PollInterval).To(Equal("5m"))
+		})
+
+		It("leaves the origin untouched without the option", func() {
+			m, err := manifest.UpdateGitPoll(models.ApplicationManifest{
+				Origin: models.ApplicationOrigin{
+					Kind: models.OriginGit,
+					Git:  &models.GitRef{URL: "https://github.com/org/repo", PollInterval: "10m"},
+				},
+			}, c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Origin.Git.PollInterval).To(Equal("10m"))
+		})
+
+		It("rejects other origins and bad intervals", func() {
+			Expect(c.Flags().Set("git-poll-interval", "5m")).To(Succeed())
+			_, err := manifest.UpdateGitPoll(models.ApplicationManifest{
+				Origin: models.ApplicationOrigin{Kind: models.OriginPath, Path: "."},
+			}, c)
+			Expect(err).To(HaveOccurred())
+
+			Expect(c.Flags().Set("git-poll-interval", "often")).To(Succeed())
+			_, err = manifest.UpdateGitPoll(models.ApplicationManifest{
+				Origin: models.ApplicationOrigin{
+					Kind: models.OriginGit,
+					Git:  &models.GitRef{URL: "https://github.com/org/repo"},
+				},
+			}, c)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UpdateRegistryCredential", func() {
+		var c *cobra.Command
+
+		BeforeEach(func() {
+			c = &cobra.Command{}
+			c.Flags().String("registry-credential", "", "")
+		})
+
+		It("sets the registry credential of a container image origin", func() {
+			Expect(c.Flags().Set("registry-credential", "private")).To(Succeed())
+
+			m, err := manifest.UpdateRegistryCredential(models.ApplicationManifest{
+				Origin: models.ApplicationOrigin{
+					Kind:      models.OriginContainer,
+					Container: "registry.example.com/org/app:1.0",
+				},
+			}, c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Configuration.RegistryCredential).To(Equal("private"))
+		})
+
+		It("keeps the manifest value without the option", func() {
+			m, err := manifest.UpdateRegistryCredential(models.ApplicationManifest{
+				Configuration: models.ApplicationConfiguration{RegistryCredential: "other"},
+			}, c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Configuration.RegistryCredential).To(Equal("other"))
+		})
+
+		It("rejects other origins", func() {
+			Expect(c.Flags().Set("registry-credential", "private")).To(Succeed())
+
+			_, err := manifest.UpdateRegistryCredential(models.ApplicationManifest{
+				Origin: models.ApplicationOrigin{Kind: models.OriginPath, Path: "."},
+			}, c)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UpdateImageWatch", func() {
+		var c *cobra.Command
+		var container models.ApplicationManifest
+
+		BeforeEach(func() {
+			c = &cobra.Command{}
+			c.Flags().String("image-watch-interval", "", "")
+			c.Flags().String("image-watch-mode", "", "")
+			container = models.ApplicationManifest{
+				Origin: models.ApplicationOrigin{
+					Kind:      models.OriginContainer,
+					Container: "registry.example.com/org/app:latest",
+				},
+			}
+		})
+
+		It("sets the watch of a container image origin", func() {
+			Expect(c.Flags().Set("image-watch-interval", "10m")).To(Succeed())
+			Expect(c.Flags().Set("image-watch-mode", "redeploy")).To(Succeed())
+
+			m, err := manifest.UpdateImageWatch(container, c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Configuration.ImageWatch).To(Equal(&models.AppImageWatch{
+				Interval: "10m",
+				Mode:     models.ImageWatchRedeploy,
+			}))
+		})
+
+		It("merges the options into the manifest value", func() {
+			Expect(c.Flags().Set("image-watch-mode", "notify")).To(Succeed())
+			container.Configuration.ImageWatch = &models.AppImageWatch{Interval: "1h", Mode: "redeploy"}
+
+			m, err := manifest.UpdateImageWatch(container, c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Configuration.ImageWatch).To(Equal(&models.AppImageWatch{
+				Interval: "1h",
+				Mode:     models.ImageWatchNotify,
+			}))
+		})
+
+		It("keeps the manifest value without the options", func() {
+			m, err := manifest.UpdateImageWatch(container, c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Configuration.ImageWatch).To(BeNil())
+		})
+
+		It("rejects bad values and other origins", func() {
+			Expect(c.Flags().Set("image-watch-interval", "often")).To(Succeed())
+			_, err := manifest.UpdateImageWatch(container, c)
+			Expect(err).To(HaveOccurred())
+
+			Expect(c.Flags().Set("image-watch-interval", "10m")).To(Succeed())
+			Expect(c.Flags().Set("image-watch-mode", "restart")).To(Succeed())
+			_, err = manifest.UpdateImageWatch(container, c)
+			Expect(err).To(HaveOccurred())
+
+			Expect(c.Flags().Set("image-watch-mode", "notify")).To(Succeed())
+			_, err = manifest.UpdateImageWatch(models.ApplicationManifest{
+				Origin: models.ApplicationOrigin{Kind: models.OriginPath, Path: "."},
+			}, c)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -0,0 +1,236 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics holds the Prometheus metrics of the epinio server, and serves them.
+//
+// All metrics are prefixed with `epinio_`. Their labels are bounded: requests are labeled
+// by route name, method and status code, never by namespace, application or user. This
+// keeps the series stable for scraping by a ServiceMonitor selecting the epinio server
+// service, and pointed at the `/metrics` path of its API port.
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "epinio"
+
+// Outcomes of operations, the values of the `outcome` label.
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+)
+
+// Kinds of websocket streams, the values of the `kind` label.
+const (
+	StreamLogs        = "logs"
+	StreamExec        = "exec"
+	StreamPortForward = "portforward"
+)
+
+// Kinds of S3 uploads, the values of the `kind` label.
+const (
+	UploadBlob   = "blob"   // Application sources
+	UploadObject = "object" // Objects with a chosen key, i.e. log archive chunks
+)
+
+// Reasons for rejecting git webhook deliveries, the values of the `reason` label.
+const (
+	GitWebhookBadSignature = "signature"
+	GitWebhookBadPayload   = "payload"
+)
+
+// UnnamedRoute labels requests which matched no route.
+const UnnamedRoute = "unmatched"
+
+var (
+	// Registry holds the metrics of the server. It is separate from the default registry,
+	// so that libraries registering with the latter do not leak into the served metrics.
+	Registry = prometheus.NewRegistry()
+
+	requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Requests handled by the API server, by route name, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken to handle requests, by route name and method.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"route", "method"})
+
+	stagingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "staging",
+		Name:      "duration_seconds",
+		Help:      "Time from the creation of staging jobs to their completion, by outcome.",
+		Buckets:   []float64{10, 30, 60, 120, 180, 300, 600, 900, 1200, 1800, 3600},
+	}, []string{"outcome"})
+
+	deployQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "deploy",
+		Name:      "queue_depth",
+		Help:      "Asynchronous deployments started and not yet finished.",
+	})
+
+	helmDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "helm",
+		Name:      "operation_duration_seconds",
+		Help:      "Time taken by helm operations changing releases, waiting for the release lock included, by operation and outcome.",
+		Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"operation", "outcome"})
+
+	uploadSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "s3",
+		Name:      "upload_bytes",
+		Help:      "Size of the objects uploaded to the S3 storage, by kind.",
+		Buckets:   prometheus.ExponentialBuckets(1024, 4, 12), // 1KiB to 4GiB
+	}, []string{"kind"})
+
+	gitWebhookRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "git_webhook",
+		Name:      "rejected_total",
+		Help:      "Git webhook deliveries rejected as unverified or malformed, by reason.",
+	}, []string{"reason"})
+
+	streams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "websocket",
+		Name:      "active_streams",
+		Help:      "Websocket streams currently open, by kind.",
+	}, []string{"kind"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requests,
+		requestDuration,
+		stagingDuration,
+		deployQueueDepth,
+		helmDuration,
+		uploadSize,
+		gitWebhookRejections,
+		streams,
+	)
+
+	// Show the stream kinds before their first use.
+	for _, kind := range []string{StreamLogs, StreamExec, StreamPortForward} {
+		streams.WithLabelValues(kind)
+	}
+}
+
+// Handler returns the handler serving the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records a handled request.
+func ObserveRequest(route, method string, code int, elapsed time.Duration) {
+	if route == "" {
+		route = UnnamedRoute
+	}
+	requests.WithLabelValues(route, method, strconv.Itoa(code)).Inc()
+	requestDuration.WithLabelValues(route, method).Observe(elapsed.Seconds())
+}
+
+// stagings remembers the staging jobs already observed. Several requests may wait for the
+// same job, and each job is to be counted once. The set is reset when it grows beyond
+// stagingsLimit, as jobs are not waited for that long after their completion.
+var (
+	stagingsMu sync.Mutex
+	stagings   = map[string]struct{}{}
+)
+
+const stagingsLimit = 1000
+
+// ObserveStaging records the completion of the identified staging job, once. The result
+// is false when the job was recorded before.
+func ObserveStaging(jobID string, outcome string, elapsed time.Duration) bool {
+	stagingsMu.Lock()
+	defer stagingsMu.Unlock()
+
+	if _, seen := stagings[jobID]; seen {
+		return false
+	}
+	if len(stagings) >= stagingsLimit {
+		stagings = map[string]struct{}{}
+	}
+	stagings[jobID] = struct{}{}
+
+	stagingDuration.WithLabelValues(outcome).Observe(elapsed.Seconds())
+	return true
+}
+
+// DeployQueued records the start of an asynchronous deployment. The returned function
+// records its end.
+func DeployQueued() func() {
+	deployQueueDepth.Inc()
+	return deployQueueDepth.Dec
+}
+
+// ObserveHelm records a helm operation started at the given time, and failed if err is
+// not nil.
+func ObserveHelm(operation string, start time.Time, err error) {
+	outcome := OutcomeSucceeded
+	if err != nil {
+		outcome = OutcomeFailed
+	}
+	helmDuration.WithLabelValues(operation, outcome).Observe(time.Since(start).Seconds())
+}
+
+// ObserveUpload records the size of an object uploaded to the S3 storage.
+func ObserveUpload(kind string, size int64) {
+	uploadSize.WithLabelValues(kind).Observe(float64(size))
+}
+
+// GitWebhookRejected records the rejection of a git webhook delivery for the given reason.
+func GitWebhookRejected(reason string) {
+	gitWebhookRejections.WithLabelValues(reason).Inc()
+}
+
+// StreamOpened records the opening of a websocket stream of the given kind. The returned
+// function records its closing.
+func StreamOpened(kind string) func() {
+	gauge := streams.WithLabelValues(kind)
+	gauge.Inc()
+	return gauge.Dec
+}
+
+// CountingReader counts the bytes read through it. It is used to learn the size of
+// uploads of unknown length.
+type CountingReader struct {
+	io.Reader
+	Count int64
+}
+
+func (r *CountingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.Count += int64(n)
+	return n, err
+}
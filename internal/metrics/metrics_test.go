@@ -0,0 +1,115 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// scrape returns the metrics served by the handler.
+func scrape() string {
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	Expect(rec.Code).To(Equal(200))
+	return rec.Body.String()
+}
+
+var _ = Describe("Metrics", func() {
+	It("serves the epinio metrics, with the stream kinds shown before use", func() {
+		body := scrape()
+		Expect(body).To(ContainSubstring(`epinio_websocket_active_streams{kind="logs"} 0`))
+		Expect(body).To(ContainSubstring(`epinio_websocket_active_streams{kind="exec"} 0`))
+		Expect(body).To(ContainSubstring(`epinio_websocket_active_streams{kind="portforward"} 0`))
+		Expect(body).To(ContainSubstring("epinio_deploy_queue_depth 0"))
+		Expect(body).To(ContainSubstring("go_goroutines"))
+	})
+
+	It("labels requests by route name, method and code", func() {
+		metrics.ObserveRequest("AppShow", "GET", 200, 10*time.Millisecond)
+		metrics.ObserveRequest("", "GET", 404, time.Millisecond)
+
+		body := scrape()
+		Expect(body).To(ContainSubstring(`epinio_http_requests_total{code="200",method="GET",route="AppShow"} 1`))
+		Expect(body).To(ContainSubstring(`epinio_http_requests_total{code="404",method="GET",route="unmatched"} 1`))
+		Expect(body).To(ContainSubstring(`epinio_http_request_duration_seconds_count{method="GET",route="AppShow"} 1`))
+	})
+
+	It("counts each staging job once", func() {
+		metrics.ObserveStaging("job-a", metrics.OutcomeSucceeded, time.Minute)
+		metrics.ObserveStaging("job-a", metrics.OutcomeSucceeded, time.Minute)
+		metrics.ObserveStaging("job-b", metrics.OutcomeFailed, time.Minute)
+
+		body := scrape()
+		Expect(body).To(ContainSubstring(`epinio_staging_duration_seconds_count{outcome="succeeded"} 1`))
+		Expect(body).To(ContainSubstring(`epinio_staging_duration_seconds_count{outcome="failed"} 1`))
+	})
+
+	It("tracks the deploy queue and the open streams", func() {
+		done1 := metrics.DeployQueued()
+		done2 := metrics.DeployQueued()
+		closed := metrics.StreamOpened(metrics.StreamExec)
+
+		body := scrape()
+		Expect(body).To(ContainSubstring("epinio_deploy_queue_depth 2"))
+		Expect(body).To(ContainSubstring(`epinio_websocket_active_streams{kind="exec"} 1`))
+
+		done1()
+		done2()
+		closed()
+
+		body = scrape()
+		Expect(body).To(ContainSubstring("epinio_deploy_queue_depth 0"))
+		Expect(body).To(ContainSubstring(`epinio_websocket_active_streams{kind="exec"} 0`))
+	})
+
+	It("labels helm operations by outcome", func() {
+		metrics.ObserveHelm("upgrade", time.Now(), nil)
+		metrics.ObserveHelm("upgrade", time.Now(), errors.New("boom"))
+
+		body := scrape()
+		Expect(body).To(ContainSubstring(`epinio_helm_operation_duration_seconds_count{operation="upgrade",outcome="succeeded"} 1`))
+		Expect(body).To(ContainSubstring(`epinio_helm_operation_duration_seconds_count{operation="upgrade",outcome="failed"} 1`))
+	})
+
+	It("records upload sizes", func() {
+		metrics.ObserveUpload(metrics.UploadObject, 2048)
+
+		body := scrape()
+		Expect(body).To(ContainSubstring(`epinio_s3_upload_bytes_sum{kind="object"} 2048`))
+	})
+
+	It("passes the metrics lint", func() {
+		problems, err := testutil.GatherAndLint(metrics.Registry)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(problems).To(BeEmpty())
+	})
+})
+
+var _ = Describe("CountingReader", func() {
+	It("counts the bytes read", func() {
+		r := &metrics.CountingReader{Reader: strings.NewReader("hello world")}
+		data, err := io.ReadAll(r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal("hello world"))
+		Expect(r.Count).To(Equal(int64(11)))
+	})
+})
@@ -0,0 +1,140 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// BlobUsageAnnotation is the namespace annotation holding the number of bytes the source
+// blobs of the applications of the namespace take in the blob store. It is maintained by
+// the uploads and the blob cleanup, so that quota checks do not have to list the store.
+const BlobUsageAnnotation = "usage.epinio.io/blob-storage"
+
+// BlobUsageScanner determines the blob storage usage of a namespace from the blob store
+// itself. This is expensive, and only done once per namespace, to seed the recorded usage.
+type BlobUsageScanner interface {
+	NamespaceUsage(ctx context.Context, namespace string) (int64, error)
+}
+
+// BlobStorageUsage returns the blob storage usage recorded for the namespace. A namespace
+// without a recorded usage, i.e. one whose blobs were uploaded by an older epinio, is
+// scanned once, and the result is recorded.
+func BlobStorageUsage(ctx context.Context, kubeClient *kubernetes.Cluster, scanner BlobUsageScanner, namespace string) (int64, error) {
+	used, recorded, err := blobUsage(ctx, kubeClient, namespace)
+	if err != nil || recorded {
+		return used, err
+	}
+
+	used, err = scanner.NamespaceUsage(ctx, namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	err = updateBlobUsage(ctx, kubeClient, namespace, func(current int64, recorded bool) (int64, bool) {
+		if recorded {
+			// Seeded concurrently, keep that.
+			used = current
+			return current, false
+		}
+		return used, true
+	})
+
+	return used, err
+}
+
+// AddBlobUsage changes the recorded blob storage usage of the namespace by delta bytes.
+// Nothing is done for a namespace without a recorded usage. Its first quota check scans
+// the blob store, and will see the change.
+func AddBlobUsage(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string, delta int64) error {
+	if delta == 0 {
+		return nil
+	}
+
+	return updateBlobUsage(ctx, kubeClient, namespace, func(current int64, recorded bool) (int64, bool) {
+		if !recorded {
+			return 0, false
+		}
+		current += delta
+		if current < 0 {
+			current = 0
+		}
+		return current, true
+	})
+}
+
+// blobUsage returns the recorded blob storage usage of the namespace, and whether there is
+// one. A missing namespace has no usage.
+func blobUsage(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string) (int64, bool, error) {
+	ns, err := kubeClient.Kubectl.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, true, nil
+		}
+		return 0, false, err
+	}
+
+	used, recorded := parseBlobUsage(ns.GetAnnotations())
+	return used, recorded, nil
+}
+
+// updateBlobUsage applies the update to the recorded blob storage usage of the namespace.
+// The update returns the new usage, and whether to store it.
+func updateBlobUsage(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string, update func(int64, bool) (int64, bool)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ns, err := kubeClient.Kubectl.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		annotations := ns.GetAnnotations()
+		current, recorded := parseBlobUsage(annotations)
+
+		used, store := update(current, recorded)
+		if !store {
+			return nil
+		}
+
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[BlobUsageAnnotation] = strconv.FormatInt(used, 10)
+		ns.SetAnnotations(annotations)
+
+		_, err = kubeClient.Kubectl.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// parseBlobUsage decodes the recorded blob storage usage. A broken value is treated as not
+// recorded, causing a rescan.
+func parseBlobUsage(annotations map[string]string) (int64, bool) {
+	value, ok := annotations[BlobUsageAnnotation]
+	if !ok {
+		return 0, false
+	}
+
+	used, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || used < 0 {
+		return 0, false
+	}
+	return used, true
+}
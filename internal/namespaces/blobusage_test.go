@@ -0,0 +1,92 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces_test
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/namespaces"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// fakeScanner counts the scans of the blob store.
+type fakeScanner struct {
+	used  int64
+	scans int
+}
+
+func (s *fakeScanner) NamespaceUsage(ctx context.Context, namespace string) (int64, error) {
+	s.scans++
+	return s.used, nil
+}
+
+var _ = Describe("Namespace blob storage usage", func() {
+	var ctx context.Context
+	var cluster *kubernetes.Cluster
+	var scanner *fakeScanner
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cluster = &kubernetes.Cluster{
+			Kubectl: fake.NewSimpleClientset(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "workspace"},
+			}),
+		}
+		scanner = &fakeScanner{used: 1000}
+	})
+
+	It("scans the blob store once, and then tracks uploads and cleanups", func() {
+		// Not yet seeded, changes are left to the scan
+		Expect(namespaces.AddBlobUsage(ctx, cluster, "workspace", 500)).To(Succeed())
+
+		used, err := namespaces.BlobStorageUsage(ctx, cluster, scanner, "workspace")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(used).To(Equal(int64(1000)))
+		Expect(scanner.scans).To(Equal(1))
+
+		Expect(namespaces.AddBlobUsage(ctx, cluster, "workspace", 250)).To(Succeed())
+		Expect(namespaces.AddBlobUsage(ctx, cluster, "workspace", -100)).To(Succeed())
+
+		used, err = namespaces.BlobStorageUsage(ctx, cluster, scanner, "workspace")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(used).To(Equal(int64(1150)))
+		Expect(scanner.scans).To(Equal(1))
+
+		ns, err := cluster.Kubectl.CoreV1().Namespaces().Get(ctx, "workspace", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ns.Annotations).To(HaveKeyWithValue(namespaces.BlobUsageAnnotation, "1150"))
+	})
+
+	It("never records a negative usage", func() {
+		_, err := namespaces.BlobStorageUsage(ctx, cluster, scanner, "workspace")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(namespaces.AddBlobUsage(ctx, cluster, "workspace", -5000)).To(Succeed())
+
+		used, err := namespaces.BlobStorageUsage(ctx, cluster, scanner, "workspace")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(used).To(BeZero())
+	})
+
+	It("ignores missing namespaces", func() {
+		used, err := namespaces.BlobStorageUsage(ctx, cluster, scanner, "missing")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(used).To(BeZero())
+		Expect(scanner.scans).To(BeZero())
+		Expect(namespaces.AddBlobUsage(ctx, cluster, "missing", 10)).To(Succeed())
+	})
+})
@@ -0,0 +1,96 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"strings"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// LogRetentionAnnotation is the namespace annotation holding the period the log archive
+// keeps the logs of the applications in the namespace.
+const LogRetentionAnnotation = "epinio.io/log-retention"
+
+// GetLogRetention returns the log retention of the named namespace. The result is empty if
+// the namespace has no retention of its own, or does not exist.
+func GetLogRetention(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string) (string, error) {
+	ns, err := kubeClient.Kubectl.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return ns.GetAnnotations()[LogRetentionAnnotation], nil
+}
+
+// SetLogRetention sets the log retention of the named namespace. An empty retention removes
+// the setting of the namespace. The retention is validated before anything is changed, and
+// returned normalized.
+func SetLogRetention(ctx context.Context, kubeClient *kubernetes.Cluster, namespace, retention string) (string, error) {
+	retention, err := NormalizeLogRetention(retention)
+	if err != nil {
+		return "", err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ns, err := kubeClient.Kubectl.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		annotations := ns.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		if retention == "" {
+			delete(annotations, LogRetentionAnnotation)
+		} else {
+			annotations[LogRetentionAnnotation] = retention
+		}
+
+		ns.SetAnnotations(annotations)
+
+		_, err = kubeClient.Kubectl.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+		return err
+	})
+
+	return retention, err
+}
+
+// NormalizeLogRetention checks that the retention is empty, or a non-negative period, in
+// days (`7d`) or as a duration (`36h`). A bad retention results in a bad request API error.
+func NormalizeLogRetention(retention string) (string, error) {
+	retention = strings.TrimSpace(retention)
+	if retention == "" {
+		return "", nil
+	}
+
+	period, err := helpers.ParseDuration(retention)
+	if err != nil {
+		return "", apierror.NewBadRequestErrorf("bad log retention '%s'", retention).WithDetails(err.Error())
+	}
+	if period < 0 {
+		return "", apierror.NewBadRequestErrorf("log retention '%s' is negative", retention)
+	}
+
+	return retention, nil
+}
@@ -0,0 +1,74 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Namespace log retention", func() {
+	var ctx context.Context
+	var cluster *kubernetes.Cluster
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cluster = &kubernetes.Cluster{
+			Kubectl: fake.NewSimpleClientset(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "workspace"},
+			}),
+		}
+	})
+
+	It("has no retention by default", func() {
+		retention, err := namespaces.GetLogRetention(ctx, cluster, "workspace")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(retention).To(BeEmpty())
+	})
+
+	It("sets and removes the retention", func() {
+		retention, err := namespaces.SetLogRetention(ctx, cluster, "workspace", " 3d ")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(retention).To(Equal("3d"))
+
+		retention, err = namespaces.GetLogRetention(ctx, cluster, "workspace")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(retention).To(Equal("3d"))
+
+		_, err = namespaces.SetLogRetention(ctx, cluster, "workspace", "")
+		Expect(err).ToNot(HaveOccurred())
+
+		retention, err = namespaces.GetLogRetention(ctx, cluster, "workspace")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(retention).To(BeEmpty())
+	})
+
+	It("rejects bad and negative periods", func() {
+		for _, retention := range []string{"forever", "-1d"} {
+			_, err := namespaces.SetLogRetention(ctx, cluster, "workspace", retention)
+			Expect(err).To(HaveOccurred())
+
+			apiErr, ok := err.(apierror.APIError)
+			Expect(ok).To(BeTrue())
+			Expect(apiErr.Status).To(Equal(http.StatusBadRequest))
+		}
+	})
+})
@@ -0,0 +1,258 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// QuotaAnnotationPrefix is the prefix of the namespace annotations holding the quota of an
+// epinio-controlled namespace. The full annotation key is the prefix plus one of the
+// `models.QuotaKeys`, i.e. `quota.epinio.io/apps`.
+const QuotaAnnotationPrefix = "quota.epinio.io/"
+
+// GetQuota returns the quota of the named namespace. A namespace without quota annotations
+// (or a missing namespace) has no limits.
+func GetQuota(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string) (models.NamespaceQuota, error) {
+	quota := models.NamespaceQuota{}
+
+	ns, err := kubeClient.Kubectl.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return quota, nil
+		}
+		return quota, err
+	}
+
+	return QuotaFromAnnotations(ns.GetAnnotations())
+}
+
+// QuotaFromAnnotations decodes the quota stored in the given namespace annotations.
+func QuotaFromAnnotations(annotations map[string]string) (models.NamespaceQuota, error) {
+	quota := models.NamespaceQuota{}
+
+	for _, key := range models.QuotaKeys {
+		value, ok := annotations[QuotaAnnotationPrefix+key]
+		if !ok || value == "" {
+			continue
+		}
+
+		switch key {
+		case models.QuotaApps, models.QuotaInstances, models.QuotaServices:
+			count, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return quota, errors.Wrapf(err, "bad %s quota '%s'", key, value)
+			}
+			limit := int32(count)
+			setCount(&quota, key, &limit)
+		default:
+			setQuantity(&quota, key, value)
+		}
+	}
+
+	return quota, nil
+}
+
+// UpdateQuota applies the changes of the request to the quota of the named namespace. The
+// request is validated before anything is changed.
+func UpdateQuota(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string, request models.NamespaceQuotaUpdateRequest) (models.NamespaceQuota, error) {
+	if err := ValidateQuotaUpdate(request); err != nil {
+		return models.NamespaceQuota{}, err
+	}
+
+	var quota models.NamespaceQuota
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ns, err := kubeClient.Kubectl.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		annotations := ns.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		for _, key := range request.Remove {
+			delete(annotations, QuotaAnnotationPrefix+key)
+		}
+		for key, value := range quotaValues(request.Set) {
+			annotations[QuotaAnnotationPrefix+key] = value
+		}
+
+		ns.SetAnnotations(annotations)
+
+		_, err = kubeClient.Kubectl.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+
+		quota, err = QuotaFromAnnotations(annotations)
+		return err
+	})
+
+	return quota, err
+}
+
+// ValidateQuotaUpdate checks the request for bad keys, negative counts and unparseable
+// quantities, and returns a bad request API error describing the first issue found.
+func ValidateQuotaUpdate(request models.NamespaceQuotaUpdateRequest) error {
+	for _, key := range request.Remove {
+		if !isQuotaKey(key) {
+			return apierror.NewBadRequestErrorf("unknown quota '%s'", key).
+				WithDetailsf("known quotas: %v", models.QuotaKeys)
+		}
+	}
+
+	for key, value := range quotaValues(request.Set) {
+		switch key {
+		case models.QuotaApps, models.QuotaInstances, models.QuotaServices:
+			count, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || count < 0 {
+				return apierror.NewBadRequestErrorf("%s quota must be an integer equal or greater than zero", key)
+			}
+		default:
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return apierror.NewBadRequestErrorf("bad %s quota '%s'", key, value).WithDetails(err.Error())
+			}
+			if quantity.Sign() < 0 {
+				return apierror.NewBadRequestErrorf("%s quota must not be negative", key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckCount verifies that adding `added` items of the given kind to the `current` number of
+// items stays within the limit. A nil limit is unlimited.
+func CheckCount(namespace, kind string, limit *int32, current, added int32) error {
+	if limit == nil || added <= 0 {
+		return nil
+	}
+	if current+added <= *limit {
+		return nil
+	}
+
+	return QuotaExceeded(namespace, kind, fmt.Sprintf("%d", *limit), fmt.Sprintf("%d", current), fmt.Sprintf("%d", added))
+}
+
+// CheckQuantity verifies that adding `added` to the `current` amount of a resource stays
+// within the limit given as kubernetes quantity. An empty limit is unlimited. Amounts are in
+// base units, i.e. millicores for cpu, and bytes for memory and storage.
+func CheckQuantity(namespace, kind, limit string, milli bool, current, added int64) error {
+	if limit == "" || added <= 0 {
+		return nil
+	}
+
+	quantity, err := resource.ParseQuantity(limit)
+	if err != nil {
+		return errors.Wrapf(err, "bad %s quota '%s'", kind, limit)
+	}
+
+	max := quantity.Value()
+	format := resource.BinarySI
+	if milli {
+		max = quantity.MilliValue()
+		format = resource.DecimalSI
+	}
+
+	if current+added <= max {
+		return nil
+	}
+
+	return QuotaExceeded(namespace, kind, limit,
+		amount(current, milli, format),
+		amount(added, milli, format))
+}
+
+// QuotaExceeded constructs the API error returned when an operation would go beyond the quota
+// of a namespace.
+func QuotaExceeded(namespace, kind, limit, current, requested string) apierror.APIError {
+	return apierror.NamespaceQuotaExceeded(namespace, kind).
+		WithDetailsf("limit: %s, in use: %s, requested: %s", limit, current, requested)
+}
+
+func amount(value int64, milli bool, format resource.Format) string {
+	if milli {
+		return resource.NewMilliQuantity(value, format).String()
+	}
+	return resource.NewQuantity(value, format).String()
+}
+
+func isQuotaKey(key string) bool {
+	for _, known := range models.QuotaKeys {
+		if key == known {
+			return true
+		}
+	}
+	return false
+}
+
+// quotaValues returns the set fields of the quota as map from quota key to string value.
+func quotaValues(quota models.NamespaceQuota) map[string]string {
+	values := map[string]string{}
+
+	if quota.Apps != nil {
+		values[models.QuotaApps] = strconv.Itoa(int(*quota.Apps))
+	}
+	if quota.Instances != nil {
+		values[models.QuotaInstances] = strconv.Itoa(int(*quota.Instances))
+	}
+	if quota.Services != nil {
+		values[models.QuotaServices] = strconv.Itoa(int(*quota.Services))
+	}
+	if quota.CPU != "" {
+		values[models.QuotaCPU] = quota.CPU
+	}
+	if quota.Memory != "" {
+		values[models.QuotaMemory] = quota.Memory
+	}
+	if quota.BlobStorage != "" {
+		values[models.QuotaBlobStorage] = quota.BlobStorage
+	}
+
+	return values
+}
+
+func setCount(quota *models.NamespaceQuota, key string, limit *int32) {
+	switch key {
+	case models.QuotaApps:
+		quota.Apps = limit
+	case models.QuotaInstances:
+		quota.Instances = limit
+	case models.QuotaServices:
+		quota.Services = limit
+	}
+}
+
+func setQuantity(quota *models.NamespaceQuota, key, value string) {
+	switch key {
+	case models.QuotaCPU:
+		quota.CPU = value
+	case models.QuotaMemory:
+		quota.Memory = value
+	case models.QuotaBlobStorage:
+		quota.BlobStorage = value
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Namespace quotas", func() {
+	var ctx context.Context
+	var cluster *kubernetes.Cluster
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cluster = &kubernetes.Cluster{
+			Kubectl: fake.NewSimpleClientset(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "workspace"},
+			}),
+		}
+	})
+
+	Describe("GetQuota", func() {
+		It("returns an unlimited quota for a namespace without annotations", func() {
+			quota, err := namespaces.GetQuota(ctx, cluster, "workspace")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(quota).To(Equal(models.NamespaceQuota{}))
+		})
+
+		It("returns an unlimited quota for a missing namespace", func() {
+			quota, err := namespaces.GetQuota(ctx, cluster, "missing")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(quota).To(Equal(models.NamespaceQuota{}))
+		})
+	})
+
+	Describe("UpdateQuota", func() {
+		It("sets and removes limits", func() {
+			apps := int32(3)
+			quota, err := namespaces.UpdateQuota(ctx, cluster, "workspace", models.NamespaceQuotaUpdateRequest{
+				Set: models.NamespaceQuota{Apps: &apps, Memory: "2Gi"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*quota.Apps).To(Equal(int32(3)))
+			Expect(quota.Memory).To(Equal("2Gi"))
+
+			quota, err = namespaces.GetQuota(ctx, cluster, "workspace")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*quota.Apps).To(Equal(int32(3)))
+			Expect(quota.Memory).To(Equal("2Gi"))
+
+			quota, err = namespaces.UpdateQuota(ctx, cluster, "workspace", models.NamespaceQuotaUpdateRequest{
+				Remove: []string{models.QuotaApps},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(quota.Apps).To(BeNil())
+			Expect(quota.Memory).To(Equal("2Gi"))
+		})
+
+		It("rejects bad quantities", func() {
+			_, err := namespaces.UpdateQuota(ctx, cluster, "workspace", models.NamespaceQuotaUpdateRequest{
+				Set: models.NamespaceQuota{CPU: "lots"},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.(apierror.APIError).Status).To(Equal(http.StatusBadRequest))
+		})
+
+		It("rejects unknown quota keys", func() {
+			_, err := namespaces.UpdateQuota(ctx, cluster, "workspace", models.NamespaceQuotaUpdateRequest{
+				Remove: []string{"bogus"},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown quota 'bogus'"))
+		})
+	})
+
+	Describe("CheckCount", func() {
+		It("accepts an unlimited quota", func() {
+			Expect(namespaces.CheckCount("workspace", models.QuotaApps, nil, 100, 1)).To(Succeed())
+		})
+
+		It("rejects going beyond the limit", func() {
+			limit := int32(2)
+			Expect(namespaces.CheckCount("workspace", models.QuotaApps, &limit, 1, 1)).To(Succeed())
+
+			err := namespaces.CheckCount("workspace", models.QuotaApps, &limit, 2, 1)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(apierror.APIError).Status).To(Equal(http.StatusForbidden))
+			Expect(err.Error()).To(Equal("apps quota of namespace 'workspace' exceeded"))
+		})
+	})
+
+	Describe("CheckQuantity", func() {
+		It("compares cpu in millicores", func() {
+			Expect(namespaces.CheckQuantity("workspace", models.QuotaCPU, "1", true, 500, 500)).To(Succeed())
+			Expect(namespaces.CheckQuantity("workspace", models.QuotaCPU, "1", true, 500, 501)).ToNot(Succeed())
+		})
+
+		It("compares memory in bytes", func() {
+			Expect(namespaces.CheckQuantity("workspace", models.QuotaMemory, "1Ki", false, 512, 512)).To(Succeed())
+			Expect(namespaces.CheckQuantity("workspace", models.QuotaMemory, "1Ki", false, 512, 513)).ToNot(Succeed())
+		})
+	})
+})
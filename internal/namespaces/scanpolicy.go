@@ -0,0 +1,93 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// ScanThresholdAnnotation is the namespace annotation holding the lowest severity of image
+// scan findings blocking the deployment of applications in the namespace.
+const ScanThresholdAnnotation = "epinio.io/scan-block-severity"
+
+// GetScanThreshold returns the image scan threshold of the named namespace. The result is
+// empty if the namespace has no policy of its own, or does not exist.
+func GetScanThreshold(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string) (string, error) {
+	ns, err := kubeClient.Kubectl.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return ns.GetAnnotations()[ScanThresholdAnnotation], nil
+}
+
+// SetScanThreshold sets the image scan threshold of the named namespace. An empty threshold
+// removes the policy of the namespace. The threshold is validated before anything is
+// changed, and returned normalized.
+func SetScanThreshold(ctx context.Context, kubeClient *kubernetes.Cluster, namespace, threshold string) (string, error) {
+	threshold, err := NormalizeScanThreshold(threshold)
+	if err != nil {
+		return "", err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ns, err := kubeClient.Kubectl.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		annotations := ns.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		if threshold == "" {
+			delete(annotations, ScanThresholdAnnotation)
+		} else {
+			annotations[ScanThresholdAnnotation] = threshold
+		}
+
+		ns.SetAnnotations(annotations)
+
+		_, err = kubeClient.Kubectl.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+		return err
+	})
+
+	return threshold, err
+}
+
+// NormalizeScanThreshold checks that the threshold is empty, `none`, or a known severity,
+// and returns it in canonical case. A bad threshold results in a bad request API error.
+func NormalizeScanThreshold(threshold string) (string, error) {
+	if threshold == "" || strings.EqualFold(threshold, models.ScanThresholdNone) {
+		return strings.ToLower(threshold), nil
+	}
+
+	rank, ok := models.SeverityRank(threshold)
+	if !ok {
+		return "", apierror.NewBadRequestErrorf("unknown severity '%s'", threshold).
+			WithDetailsf("known severities: %v, or %s", models.Severities, models.ScanThresholdNone)
+	}
+
+	return models.Severities[rank], nil
+}
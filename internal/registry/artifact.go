@@ -0,0 +1,244 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	parser "github.com/novln/docker-parser"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// manifestMediaTypes are the manifest types accepted when retrieving an image manifest.
+var manifestMediaTypes = []string{
+	ocispec.MediaTypeImageManifest,
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// ImageInfo describes an image stored in a registry.
+type ImageInfo struct {
+	Manifest ocispec.Descriptor // The image manifest
+	Labels   map[string]string  // The labels of the image configuration
+	Layers   []ocispec.Descriptor
+	DiffIDs  []digest.Digest // Digests of the uncompressed layers, in the order of the layers
+}
+
+// Inspect retrieves the manifest and configuration of the image from its registry.
+func Inspect(
+	ctx context.Context,
+	imageURL string,
+	credentials RegistryCredentials,
+	tlsConfig *tls.Config,
+) (*ImageInfo, error) {
+	repo, reference, err := newRepository(imageURL, credentials, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	repo.ManifestMediaTypes = manifestMediaTypes
+
+	desc, manifest, err := fetchManifest(ctx, repo, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	configData, err := content.FetchAll(ctx, repo, manifest.Config)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching image configuration")
+	}
+
+	config := ocispec.Image{}
+	if err := json.Unmarshal(configData, &config); err != nil {
+		return nil, errors.Wrap(err, "parsing image configuration")
+	}
+
+	return &ImageInfo{
+		Manifest: desc,
+		Labels:   config.Config.Labels,
+		Layers:   manifest.Layers,
+		DiffIDs:  config.RootFS.DiffIDs,
+	}, nil
+}
+
+// FetchBlob retrieves the identified blob of the image's repository. The content is
+// verified against the digest.
+func FetchBlob(
+	ctx context.Context,
+	imageURL string,
+	blobDigest string,
+	credentials RegistryCredentials,
+	tlsConfig *tls.Config,
+) ([]byte, error) {
+	dgst, err := digest.Parse(blobDigest)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad blob digest")
+	}
+
+	repo, _, err := newRepository(imageURL, credentials, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := repo.Blobs().Resolve(ctx, dgst.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving blob")
+	}
+
+	data, err := content.FetchAll(ctx, repo.Blobs(), desc)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching blob")
+	}
+	return data, nil
+}
+
+// ImageDigest resolves the image to the digest of its manifest, or of its index for
+// multi-platform images. This also checks that the image exists, and is accessible with the
+// credentials.
+func ImageDigest(
+	ctx context.Context,
+	imageURL string,
+	credentials RegistryCredentials,
+	tlsConfig *tls.Config,
+) (string, error) {
+	repo, reference, err := newRepository(imageURL, credentials, tlsConfig)
+	if err != nil {
+		return "", err
+	}
+
+	// Fetched instead of resolved, as registries need not report the digest of a manifest.
+	// It is then computed from the content.
+	desc, rc, err := repo.FetchReference(ctx, reference)
+	if err != nil {
+		return "", errors.Wrapf(err, "image %s not accessible", imageURL)
+	}
+	_ = rc.Close()
+
+	return desc.Digest.String(), nil
+}
+
+// AttachArtifact stores the content as an artifact of the given type referring to the image,
+// i.e. with the image as subject. Registries supporting the OCI referrers API list the
+// artifact as a referrer of the image. For others the artifact is added to the referrers
+// index tagged after the image digest, as per the OCI distribution specification. It
+// returns the digest of the artifact manifest.
+func AttachArtifact(
+	ctx context.Context,
+	imageURL string,
+	artifactType string,
+	data []byte,
+	credentials RegistryCredentials,
+	tlsConfig *tls.Config,
+) (string, error) {
+	repo, reference, err := newRepository(imageURL, credentials, tlsConfig)
+	if err != nil {
+		return "", err
+	}
+	repo.ManifestMediaTypes = manifestMediaTypes
+
+	subject, _, err := fetchManifest(ctx, repo, reference)
+	if err != nil {
+		return "", errors.Wrap(err, "resolving the subject image")
+	}
+
+	layer := content.NewDescriptorFromBytes(artifactType, data)
+	if err := pushBlob(ctx, repo, layer, data); err != nil {
+		return "", errors.Wrap(err, "uploading the artifact")
+	}
+
+	manifest, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{
+		Subject: &subject,
+		Layers:  []ocispec.Descriptor{layer},
+		ManifestAnnotations: map[string]string{
+			ocispec.AnnotationCreated: time.Now().UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "uploading the artifact manifest")
+	}
+
+	return manifest.Digest.String(), nil
+}
+
+// newRepository returns a client of the repository of the image, and the tag or digest of
+// the image.
+func newRepository(imageURL string, credentials RegistryCredentials, tlsConfig *tls.Config) (*remote.Repository, string, error) {
+	ref, err := parser.Parse(imageURL)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "parsing image URL")
+	}
+
+	reference := ref.Tag()
+	if reference == "" {
+		reference = "latest"
+	}
+
+	scheme, host := registryEndpoint(ref.Registry(), credentials.URL)
+
+	repo, err := remote.NewRepository(host + "/" + ref.ShortName())
+	if err != nil {
+		return nil, "", errors.Wrap(err, "parsing image repository")
+	}
+	repo.PlainHTTP = scheme == "http"
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	client := &auth.Client{
+		Client: &http.Client{Transport: transport},
+		Cache:  auth.NewCache(),
+	}
+	if credentials.Username != "" {
+		client.Credential = auth.StaticCredential(repo.Reference.Registry, auth.Credential{
+			Username: credentials.Username,
+			Password: credentials.Password,
+		})
+	}
+	repo.Client = client
+
+	return repo, reference, nil
+}
+
+// fetchManifest returns the descriptor and content of the image manifest.
+func fetchManifest(ctx context.Context, repo *remote.Repository, reference string) (ocispec.Descriptor, ocispec.Manifest, error) {
+	desc, data, err := oras.FetchBytes(ctx, repo, reference, oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, ocispec.Manifest{}, errors.Wrap(err, "fetching manifest")
+	}
+
+	manifest := ocispec.Manifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return ocispec.Descriptor{}, ocispec.Manifest{}, errors.Wrap(err, "parsing manifest")
+	}
+
+	return desc, manifest, nil
+}
+
+// pushBlob uploads the content as a blob, unless the registry has it already.
+func pushBlob(ctx context.Context, repo *remote.Repository, desc ocispec.Descriptor, data []byte) error {
+	exists, err := repo.Exists(ctx, desc)
+	if err != nil || exists {
+		return err
+	}
+
+	return repo.Push(ctx, desc, bytes.NewReader(data))
+}
@@ -0,0 +1,239 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/epinio/epinio/internal/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// fakeRegistry is an in-memory registry for the single repository `apps/demo`.
+type fakeRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	referrers bool // report support of the referrers API
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	const prefix = "/v2/apps/demo/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, prefix)
+
+	switch {
+	case path == "blobs/uploads/" && r.Method == http.MethodPost:
+		w.Header().Set("Location", "/v2/apps/demo/blobs/uploads/session?state=x")
+		w.WriteHeader(http.StatusAccepted)
+	case path == "blobs/uploads/session" && r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		if r.URL.Query().Get("state") != "x" || digest.FromBytes(data).String() != r.URL.Query().Get("digest") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.blobs[r.URL.Query().Get("digest")] = data
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "blobs/"):
+		data, ok := f.blobs[strings.TrimPrefix(path, "blobs/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case strings.HasPrefix(path, "manifests/") && r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.manifests[strings.TrimPrefix(path, "manifests/")] = data
+		f.manifests[digest.FromBytes(data).String()] = data
+		if f.referrers {
+			w.Header().Set("OCI-Subject", "present")
+		}
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "manifests/"):
+		data, ok := f.manifests[strings.TrimPrefix(path, "manifests/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mediaType := struct {
+			MediaType string `json:"mediaType"`
+		}{}
+		_ = json.Unmarshal(data, &mediaType)
+		w.Header().Set("Content-Type", mediaType.MediaType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var _ = Describe("Artifacts", func() {
+	var (
+		fake     *fakeRegistry
+		server   *httptest.Server
+		imageURL string
+		layer    []byte
+		manifest []byte
+	)
+
+	BeforeEach(func() {
+		fake = &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+
+		layer = []byte("layer content")
+		diffID := digest.FromString("uncompressed layer content")
+
+		config, err := json.Marshal(ocispec.Image{
+			Config: ocispec.ImageConfig{Labels: map[string]string{"a": "b"}},
+			RootFS: ocispec.RootFS{Type: "layers", DiffIDs: []digest.Digest{diffID}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		fake.blobs[digest.FromBytes(layer).String()] = layer
+		fake.blobs[digest.FromBytes(config).String()] = config
+
+		manifest, err = json.Marshal(ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config: ocispec.Descriptor{
+				MediaType: ocispec.MediaTypeImageConfig,
+				Digest:    digest.FromBytes(config),
+				Size:      int64(len(config)),
+			},
+			Layers: []ocispec.Descriptor{{
+				MediaType: ocispec.MediaTypeImageLayerGzip,
+				Digest:    digest.FromBytes(layer),
+				Size:      int64(len(layer)),
+			}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		fake.manifests["s1"] = manifest
+
+		server = httptest.NewServer(fake)
+		imageURL = strings.TrimPrefix(server.URL, "http://") + "/apps/demo:s1"
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Inspect", func() {
+		It("returns manifest, labels and layers of the image", func() {
+			info, err := registry.Inspect(context.Background(), imageURL, registry.RegistryCredentials{}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Manifest.Digest).To(Equal(digest.FromBytes(manifest)))
+			Expect(info.Labels).To(HaveKeyWithValue("a", "b"))
+			Expect(info.Layers).To(HaveLen(1))
+			Expect(info.DiffIDs).To(ConsistOf(digest.FromString("uncompressed layer content")))
+		})
+
+		It("fails for an unknown image", func() {
+			unknown := strings.TrimSuffix(imageURL, ":s1") + ":s2"
+			_, err := registry.Inspect(context.Background(), unknown, registry.RegistryCredentials{}, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("FetchBlob", func() {
+		It("returns the blob", func() {
+			data, err := registry.FetchBlob(context.Background(), imageURL,
+				digest.FromBytes(layer).String(), registry.RegistryCredentials{}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(Equal(layer))
+		})
+
+		It("rejects content not matching the digest", func() {
+			fake.blobs[digest.FromBytes(layer).String()] = []byte("tampered")
+			_, err := registry.FetchBlob(context.Background(), imageURL,
+				digest.FromBytes(layer).String(), registry.RegistryCredentials{}, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ImageDigest", func() {
+		It("returns the digest of the image manifest", func() {
+			dgst, err := registry.ImageDigest(context.Background(), imageURL, registry.RegistryCredentials{}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dgst).To(Equal(digest.FromBytes(manifest).String()))
+		})
+
+		It("fails for an unknown image", func() {
+			unknown := strings.TrimSuffix(imageURL, ":s1") + ":s2"
+			_, err := registry.ImageDigest(context.Background(), unknown, registry.RegistryCredentials{}, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("AttachArtifact", func() {
+		content := []byte(`{"stageId":"s1"}`)
+
+		artifact := func(artifactDigest string) ocispec.Manifest {
+			m := ocispec.Manifest{}
+			Expect(json.Unmarshal(fake.manifests[artifactDigest], &m)).To(Succeed())
+			return m
+		}
+
+		It("stores the artifact with the image as subject", func() {
+			fake.referrers = true
+
+			artifactDigest, err := registry.AttachArtifact(context.Background(), imageURL,
+				"application/vnd.example+json", content, registry.RegistryCredentials{}, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			m := artifact(artifactDigest)
+			Expect(m.ArtifactType).To(Equal("application/vnd.example+json"))
+			Expect(m.Subject).ToNot(BeNil())
+			Expect(m.Subject.Digest).To(Equal(digest.FromBytes(manifest)))
+			Expect(m.Layers).To(HaveLen(1))
+			Expect(fake.blobs[m.Layers[0].Digest.String()]).To(Equal(content))
+
+			subject := digest.FromBytes(manifest)
+			Expect(fake.manifests).ToNot(HaveKey("sha256-" + subject.Encoded()))
+		})
+
+		It("indexes the artifact for registries without referrers support", func() {
+			artifactDigest, err := registry.AttachArtifact(context.Background(), imageURL,
+				"application/vnd.example+json", content, registry.RegistryCredentials{}, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			subject := digest.FromBytes(manifest)
+			Expect(fake.manifests).To(HaveKey("sha256-" + subject.Encoded()))
+
+			index := ocispec.Index{}
+			Expect(json.Unmarshal(fake.manifests["sha256-"+subject.Encoded()], &index)).To(Succeed())
+			Expect(index.Manifests).To(HaveLen(1))
+			Expect(index.Manifests[0].Digest.String()).To(Equal(artifactDigest))
+			Expect(index.Manifests[0].ArtifactType).To(Equal("application/vnd.example+json"))
+		})
+	})
+})
@@ -0,0 +1,122 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/bridge/git"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pullCredentialPrefix is the prefix of the names of the secrets holding registry credentials.
+const pullCredentialPrefix = "registry-credential-"
+
+// PullCredential describes a set of registry credentials of a namespace, used to pull the
+// images of applications deployed from private registries.
+type PullCredential struct {
+	Name      string
+	Namespace string
+	URL       string
+	Username  string
+	CreatedAt metav1.Time
+}
+
+// PullCredentialSecretName returns the name of the secret holding the named credentials.
+func PullCredentialSecretName(name string) string {
+	return pullCredentialPrefix + name
+}
+
+// NewPullCredentialSecret returns the image pull secret for the named credentials of the
+// namespace.
+func NewPullCredentialSecret(namespace, name, url, username, password string) (v1.Secret, error) {
+	config := DockerConfigJSON{
+		Auths: map[string]ContainerRegistryAuth{
+			url: {
+				Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+				Username: username,
+				Password: password,
+			},
+		},
+	}
+
+	configjson, err := json.Marshal(config)
+	if err != nil {
+		return v1.Secret{}, err
+	}
+
+	return v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PullCredentialSecretName(name),
+			Namespace: namespace,
+			Labels: map[string]string{
+				kubernetes.EpinioRegistryCredentialLabelKey: name,
+			},
+		},
+		Type: v1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			".dockerconfigjson": configjson,
+		},
+	}, nil
+}
+
+// PullCredentials returns the registry credentials found by the secret lister, i.e. of the
+// namespace it is bound to. Bad secrets are logged and skipped.
+func PullCredentials(secretLoader git.SecretLister) ([]PullCredential, error) {
+	secretList, err := secretLoader.List(context.Background(),
+		metav1.ListOptions{
+			LabelSelector: kubernetes.EpinioRegistryCredentialLabelKey,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := []PullCredential{}
+	for _, secret := range secretList.Items {
+		credential, err := PullCredentialFromSecret(secret)
+		if err != nil {
+			// log the issue, and otherwise ignore the secret
+			helpers.Logger.Errorw("skipping secret", "error", err, "secret", secret.Name)
+			continue
+		}
+
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, nil
+}
+
+// PullCredentialFromSecret returns the registry credentials described by the secret.
+func PullCredentialFromSecret(secret v1.Secret) (PullCredential, error) {
+	credentials, err := dockerConfigCredentials(secret)
+	if err != nil {
+		return PullCredential{}, err
+	}
+
+	return PullCredential{
+		Name:      secret.Labels[kubernetes.EpinioRegistryCredentialLabelKey],
+		Namespace: secret.Namespace,
+		URL:       credentials.URL,
+		Username:  credentials.Username,
+		CreatedAt: secret.CreationTimestamp,
+	}, nil
+}
+
+// PullCredentialAuth returns the registry credentials, with password, held by the secret.
+func PullCredentialAuth(secret v1.Secret) (RegistryCredentials, error) {
+	return dockerConfigCredentials(secret)
+}
@@ -0,0 +1,52 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("PullCredential", func() {
+	It("creates a pull secret for the credentials", func() {
+		secret, err := registry.NewPullCredentialSecret("workspace", "private", "registry.example.com", "user", "pass")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Name).To(Equal("registry-credential-private"))
+		Expect(secret.Namespace).To(Equal("workspace"))
+		Expect(secret.Type).To(Equal(v1.SecretTypeDockerConfigJson))
+		Expect(secret.Labels).To(HaveKeyWithValue(kubernetes.EpinioRegistryCredentialLabelKey, "private"))
+	})
+
+	It("reads the credentials back from the secret", func() {
+		secret, err := registry.NewPullCredentialSecret("workspace", "private", "registry.example.com", "user", "pass")
+		Expect(err).ToNot(HaveOccurred())
+
+		credential, err := registry.PullCredentialFromSecret(secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credential.Name).To(Equal("private"))
+		Expect(credential.Namespace).To(Equal("workspace"))
+		Expect(credential.URL).To(Equal("registry.example.com"))
+		Expect(credential.Username).To(Equal("user"))
+
+		auth, err := registry.PullCredentialAuth(secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(auth.Password).To(Equal("pass"))
+	})
+
+	It("fails for a secret without docker configuration", func() {
+		_, err := registry.PullCredentialFromSecret(v1.Secret{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -168,6 +168,21 @@ func GetRegistryCredentialsFromSecret(secret v1.Secret) (RegistryCredentials, er
 		return empty, fmt.Errorf("missing annotation '%s'", RegistrySecretNamespaceAnnotationKey)
 	}
 
+	credentials, err := dockerConfigCredentials(secret)
+	if err != nil {
+		return empty, err
+	}
+
+	credentials.URL = credentials.URL + "/" + namespace
+	return credentials, nil
+}
+
+// dockerConfigCredentials returns the single set of credentials held by the
+// `.dockerconfigjson` of the secret. The URL of the result is the registry URL, without
+// namespace.
+func dockerConfigCredentials(secret v1.Secret) (RegistryCredentials, error) {
+	empty := RegistryCredentials{}
+
 	configjson, ok := secret.Data[".dockerconfigjson"]
 	if !ok {
 		return empty, errors.New("missing key `.dockerconfigjson`")
@@ -218,14 +233,14 @@ func GetRegistryCredentialsFromSecret(secret v1.Secret) (RegistryCredentials, er
 
 		// return the single credentials, as the first credentials found in the map
 		return RegistryCredentials{
-			URL:      key + "/" + namespace,
+			URL:      key,
 			Username: username,
 			Password: password,
 		}, nil
 	}
 
 	// Cannot be reached
-	return empty, errors.New("registry.go / getRegistryUrlFromSecret - cannot happen")
+	return empty, errors.New("registry.go / dockerConfigCredentials - cannot happen")
 }
 
 // PublicRegistryURL returns the public registry URL from the connection details
@@ -309,6 +324,21 @@ func ExtractImageParts(imageURL string) (string, string, error) {
 	return ref.Registry(), ref.Name(), nil
 }
 
+// PinImage returns the image URL with its tag replaced by the digest, i.e. a reference to the
+// exact image found for the tag. URLs already referencing a digest are returned unchanged.
+func PinImage(imageURL, digest string) string {
+	if digest == "" || strings.Contains(imageURL, "@") {
+		return imageURL
+	}
+
+	name := imageURL
+	if colon := strings.LastIndex(imageURL, ":"); colon > strings.LastIndex(imageURL, "/") {
+		name = imageURL[:colon]
+	}
+
+	return name + "@" + digest
+}
+
 // Validate makes sure the provided settings are valid
 // The user should provide all the mandatory settings or no settings at all.
 func Validate(url, namespace, username, password string) error {
@@ -399,34 +429,7 @@ func DeleteImage(
 
 	helpers.Logger.Infow("Deleting image from registry", "image", imageURL, "repository", repository, "tag", tag)
 
-	// Determine scheme from credentials URL (dockerconfigjson may contain http:// or https://)
-	// or fall back to heuristics based on registry URL and TLS config
-	scheme := "https"
-	credURL := credentials.URL
-
-	// Check if credentials URL has a scheme (it may include namespace suffix like "http://registry.com/namespace")
-	if strings.HasPrefix(credURL, "http://") {
-		scheme = "http"
-	} else if strings.HasPrefix(credURL, "https://") {
-		scheme = "https"
-	} else {
-		// No scheme in credentials URL, use heuristics
-		// First check if registryURL already has a scheme
-		if strings.HasPrefix(registryURL, "http://") {
-			scheme = "http"
-			registryURL = strings.TrimPrefix(registryURL, "http://")
-		} else if strings.HasPrefix(registryURL, "https://") {
-			scheme = "https"
-			registryURL = strings.TrimPrefix(registryURL, "https://")
-		} else if strings.HasPrefix(registryURL, "127.0.0.1") || strings.HasPrefix(registryURL, "localhost") || strings.HasPrefix(registryURL, "0.0.0.0") {
-			// Localhost addresses are typically HTTP
-			scheme = "http"
-		}
-		// Otherwise default to https (already set above)
-		// Note: tlsConfig.InsecureSkipVerify is for skipping certificate verification on HTTPS,
-		// not for switching to HTTP. Self-signed HTTPS registries still use https:// with
-		// InsecureSkipVerify enabled.
-	}
+	scheme, registryURL := registryEndpoint(registryURL, credentials.URL)
 
 	// Get the manifest digest first
 	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, registryURL, repository, tag)
@@ -523,6 +526,46 @@ func DeleteImage(
 	return nil
 }
 
+// registryEndpoint determines the scheme for accessing the registry, from the credentials
+// URL (dockerconfigjson may contain http:// or https://), or falls back to heuristics based
+// on the registry URL. It returns the scheme, and the registry URL without scheme.
+func registryEndpoint(registryURL, credentialsURL string) (string, string) {
+	// Check if credentials URL has a scheme (it may include namespace suffix like "http://registry.com/namespace")
+	if strings.HasPrefix(credentialsURL, "http://") {
+		return "http", registryURL
+	}
+	if strings.HasPrefix(credentialsURL, "https://") {
+		return "https", registryURL
+	}
+
+	// No scheme in credentials URL, use heuristics
+	// First check if registryURL already has a scheme
+	if strings.HasPrefix(registryURL, "http://") {
+		return "http", strings.TrimPrefix(registryURL, "http://")
+	}
+	if strings.HasPrefix(registryURL, "https://") {
+		return "https", strings.TrimPrefix(registryURL, "https://")
+	}
+	if strings.HasPrefix(registryURL, "127.0.0.1") || strings.HasPrefix(registryURL, "localhost") || strings.HasPrefix(registryURL, "0.0.0.0") {
+		// Localhost addresses are typically HTTP
+		return "http", registryURL
+	}
+
+	// Otherwise default to https
+	// Note: tlsConfig.InsecureSkipVerify is for skipping certificate verification on HTTPS,
+	// not for switching to HTTP. Self-signed HTTPS registries still use https:// with
+	// InsecureSkipVerify enabled.
+	return "https", registryURL
+}
+
+// Endpoint returns the scheme and the host of the registry at the given URL. The URL may
+// carry a scheme, and a path for the namespace of the registry.
+func Endpoint(registryURL string) (string, string) {
+	scheme, host := registryEndpoint(registryURL, "")
+	host, _, _ = strings.Cut(host, "/")
+	return scheme, host
+}
+
 // listRepositoryTags lists all tags for a repository
 func listRepositoryTags(ctx context.Context, scheme, registryURL, repository, auth string, client *http.Client) ([]string, error) {
 	tagsListURL := fmt.Sprintf("%s://%s/v2/%s/tags/list", scheme, registryURL, repository)
@@ -187,4 +187,24 @@ var _ = Describe("ConnectionDetails", func() {
 			})
 		})
 	})
+
+	Describe("PinImage", func() {
+		It("replaces the tag with the digest", func() {
+			Expect(registry.PinImage("docker.io/epinio/app:latest", "sha256:abc")).
+				To(Equal("docker.io/epinio/app@sha256:abc"))
+			Expect(registry.PinImage("localhost:5000/app:1.0", "sha256:abc")).
+				To(Equal("localhost:5000/app@sha256:abc"))
+		})
+
+		It("appends the digest to untagged images", func() {
+			Expect(registry.PinImage("localhost:5000/app", "sha256:abc")).
+				To(Equal("localhost:5000/app@sha256:abc"))
+		})
+
+		It("keeps pinned images and empty digests", func() {
+			Expect(registry.PinImage("epinio/app@sha256:def", "sha256:abc")).
+				To(Equal("epinio/app@sha256:def"))
+			Expect(registry.PinImage("epinio/app:1.0", "")).To(Equal("epinio/app:1.0"))
+		})
+	})
 })
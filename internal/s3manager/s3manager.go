@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
@@ -28,9 +29,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/metrics"
+	"github.com/epinio/epinio/internal/tracing"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 	"gopkg.in/ini.v1"
 )
 
@@ -253,6 +257,18 @@ func (m *Manager) Meta(ctx context.Context, blobUID string) (map[string]string,
 	return meta, nil
 }
 
+// Size returns the size in bytes of the blob specified by blobUID.
+func (m *Manager) Size(ctx context.Context, blobUID string) (int64, error) {
+	out, err := m.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(m.connectionDetails.Bucket),
+		Key:    aws.String(blobUID),
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "reading the object size")
+	}
+	return aws.ToInt64(out.ContentLength), nil
+}
+
 // Open retrieves the blob specified by blobUID from the S3 endpoint.
 func (m *Manager) Open(ctx context.Context, blobUID string) (io.ReadCloser, string, int64, error) {
 	out, err := m.s3Client.GetObject(ctx, &s3.GetObjectInput{
@@ -278,14 +294,28 @@ func (m *Manager) Open(ctx context.Context, blobUID string) (io.ReadCloser, stri
 
 // UploadStream uploads the given Reader to the S3 endpoint and returns a blobUID which
 // can later be used to fetch the same file.
-func (m *Manager) UploadStream(ctx context.Context, file io.Reader, size int64, metadata map[string]string) (string, error) {
+func (m *Manager) UploadStream(ctx context.Context, file io.Reader, size int64, metadata map[string]string) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "s3 upload", attribute.String("s3.bucket", m.connectionDetails.Bucket))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	if err := m.EnsureBucket(ctx); err != nil {
 		return "", errors.Wrap(err, "ensuring bucket")
 	}
 
 	objectName := uuid.New().String()
+	span.SetAttributes(attribute.String("s3.key", objectName))
 	contentType := "application/tar"
 
+	// Streams of unknown size are counted as they are read, for the metrics. They are
+	// not seekable anyway.
+	var counter *metrics.CountingReader
+	if size < 0 {
+		counter = &metrics.CountingReader{Reader: file}
+		file = counter
+	}
+
 	putInput := &s3.PutObjectInput{
 		Bucket:      aws.String(m.connectionDetails.Bucket),
 		Key:         aws.String(objectName),
@@ -302,7 +332,7 @@ func (m *Manager) UploadStream(ctx context.Context, file io.Reader, size int64,
 		}
 	}
 
-	_, err := m.s3Client.PutObject(ctx, putInput)
+	_, err = m.s3Client.PutObject(ctx, putInput)
 	if err != nil {
 		if IsQuotaExceededError(err) {
 			return "", errors.Wrap(err, "storage quota exceeded while writing the new object")
@@ -310,9 +340,96 @@ func (m *Manager) UploadStream(ctx context.Context, file io.Reader, size int64,
 		return "", errors.Wrap(err, "writing the new object")
 	}
 
+	if counter != nil {
+		size = counter.Count
+	}
+	metrics.ObserveUpload(metrics.UploadBlob, size)
+	span.SetAttributes(attribute.Int64("s3.size", size))
+
 	return objectName, nil
 }
 
+// PutObject uploads the given Reader to the S3 endpoint, under the given key. Unlike
+// UploadStream the caller chooses the key, for objects found by their name, like the
+// chunks of the log archive.
+func (m *Manager) PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string, metadata map[string]string) (err error) {
+	ctx, span := tracing.Start(ctx, "s3 put",
+		attribute.String("s3.bucket", m.connectionDetails.Bucket),
+		attribute.String("s3.key", key),
+		attribute.Int64("s3.size", size),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	if err := m.EnsureBucket(ctx); err != nil {
+		return errors.Wrap(err, "ensuring bucket")
+	}
+
+	putInput := &s3.PutObjectInput{
+		Bucket:      aws.String(m.connectionDetails.Bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	}
+	if size >= 0 {
+		putInput.ContentLength = aws.Int64(size)
+	}
+	if len(metadata) > 0 {
+		putInput.Metadata = make(map[string]string, len(metadata))
+		for k, v := range metadata {
+			putInput.Metadata[k] = v
+		}
+	}
+
+	_, err = m.s3Client.PutObject(ctx, putInput)
+	if err != nil {
+		if IsQuotaExceededError(err) {
+			return errors.Wrapf(err, "storage quota exceeded while writing object %s", key)
+		}
+		return errors.Wrapf(err, "writing object %s", key)
+	}
+
+	if size >= 0 {
+		metrics.ObserveUpload(metrics.UploadObject, size)
+	}
+
+	return nil
+}
+
+// ObjectInfo describes an object of the storage.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// ListObjects returns the objects whose keys start with the prefix, in key order.
+func (m *Manager) ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := []ObjectInfo{}
+
+	paginator := s3.NewListObjectsV2Paginator(m.s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(m.connectionDetails.Bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing the objects")
+		}
+
+		for _, object := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          aws.ToString(object.Key),
+				Size:         aws.ToInt64(object.Size),
+				LastModified: aws.ToTime(object.LastModified),
+			})
+		}
+	}
+
+	return objects, nil
+}
+
 // Upload uploads the given file to the S3 endpoint and returns a blobUID which
 // can later be used to fetch the same file.
 func (m *Manager) Upload(ctx context.Context, filepath string, metadata map[string]string) (string, error) {
@@ -336,12 +453,17 @@ func (m *Manager) Download(
 	ctx context.Context,
 	blobUID string,
 ) (io.ReadCloser, error) {
+	ctx, span := tracing.Start(ctx, "s3 download",
+		attribute.String("s3.bucket", m.connectionDetails.Bucket),
+		attribute.String("s3.key", blobUID),
+	)
 
 	codeBlob, getError := m.s3Client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(m.connectionDetails.Bucket),
 		Key:    aws.String(blobUID),
 	})
 
+	tracing.End(span, getError)
 	if getError != nil {
 		return nil, errors.Wrap(getError, "getting the object")
 	}
@@ -393,3 +515,34 @@ func (m *Manager) DeleteObject(ctx context.Context, objectID string) error {
 	})
 	return err
 }
+
+// NamespaceUsage returns the total size in bytes of the blobs uploaded for the applications of
+// the given namespace. Blobs are attributed to a namespace through their `namespace` meta data.
+// This lists the whole store and reads the meta data of every object. It is only used to
+// seed the usage recorded for a namespace, see `namespaces.BlobStorageUsage`.
+func (m *Manager) NamespaceUsage(ctx context.Context, namespace string) (int64, error) {
+	var total int64
+
+	paginator := s3.NewListObjectsV2Paginator(m.s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(m.connectionDetails.Bucket),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, errors.Wrap(err, "listing the objects")
+		}
+
+		for _, object := range page.Contents {
+			meta, err := m.Meta(ctx, aws.ToString(object.Key))
+			if err != nil {
+				return 0, err
+			}
+			if meta["namespace"] != namespace {
+				continue
+			}
+			total += aws.ToInt64(object.Size)
+		}
+	}
+
+	return total, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/helm"
 	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
@@ -142,6 +143,22 @@ func (s *ServiceClient) Create(ctx context.Context,
 	// |secret	|"s-"+name	|epinio management data	|
 	// |helm release|see above	|active workload	|
 
+	// Enforce the services quota of the namespace, if any, before creating anything.
+
+	quota, err := namespaces.GetQuota(ctx, s.kubeClient, namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to get namespace quota")
+	}
+	if quota.Services != nil {
+		count, err := s.CountInNamespace(ctx, namespace)
+		if err != nil {
+			return err
+		}
+		if err := namespaces.CheckCount(namespace, models.QuotaServices, quota.Services, count, 1); err != nil {
+			return err
+		}
+	}
+
 	// Create the secret first
 
 	service := serviceResourceName(name)
@@ -169,7 +186,7 @@ func (s *ServiceClient) Create(ctx context.Context,
 		}
 	}
 
-	err := s.kubeClient.CreateLabeledSecret(ctx, namespace, service, data, labels, annotations)
+	err = s.kubeClient.CreateLabeledSecret(ctx, namespace, service, data, labels, annotations)
 	if err != nil {
 		return errors.Wrap(err, "failed to create service secret")
 	}
@@ -304,6 +321,25 @@ func (s *ServiceClient) CatalogServicesInUse(ctx context.Context) (map[string]bo
 	return result, nil
 }
 
+// CountInNamespace returns the number of Epinio Service instances in the specified namespace.
+// It only looks at the instance secrets, and does not query the helm releases.
+func (s *ServiceClient) CountInNamespace(ctx context.Context, namespace string) (int32, error) {
+	listOpts := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf(
+			"%s,%s",
+			ServiceNameLabelKey,
+			CatalogServiceLabelKey,
+		),
+	}
+
+	secrets, err := s.kubeClient.Kubectl.CoreV1().Secrets(namespace).List(ctx, listOpts)
+	if err != nil {
+		return 0, errors.Wrap(err, "listing service instances")
+	}
+
+	return int32(len(secrets.Items)), nil
+}
+
 // ListInNamespace will return all the Epinio Services available in the specified namespace
 func (s *ServiceClient) ListInNamespace(ctx context.Context, namespace string) (models.ServiceList, error) {
 	return s.list(ctx, namespace)
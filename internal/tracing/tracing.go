@@ -0,0 +1,204 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing sets up OpenTelemetry tracing for the epinio server and cli, and
+// provides the helpers to start spans and to carry trace contexts between processes.
+//
+// Trace contexts travel in the W3C `traceparent` and `tracestate` headers between the cli
+// and the server, and in the TRACEPARENT and TRACESTATE environment variables into the
+// staging jobs.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/epinio/epinio"
+
+// Environment variables carrying the trace context, following the OpenTelemetry
+// convention for environment carriers.
+const (
+	EnvTraceParent = "TRACEPARENT"
+	EnvTraceState  = "TRACESTATE"
+)
+
+// propagator is used instead of the global one, so that trace contexts are carried
+// whether or not Setup was called.
+var propagator = propagation.TraceContext{}
+
+// Setup installs the tracer provider of the process, for the named service. Spans are
+// exported to the OTLP/HTTP endpoint, when one is given. Without endpoint spans are still
+// created, to give the requests made by the process a trace context, but not exported. The
+// returned function flushes the pending spans and stops the provider.
+func Setup(ctx context.Context, service, endpoint string) (func(context.Context) error, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", service))),
+	}
+
+	if endpoint != "" {
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+		if err != nil {
+			return nil, errors.Wrap(err, "creating the trace exporter")
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	return Install(sdktrace.NewTracerProvider(options...)), nil
+}
+
+// Install makes the provider the one used by the process, and returns its shutdown. It is
+// used by Setup, and by tests to record spans in memory.
+func Install(provider *sdktrace.TracerProvider) func(context.Context) error {
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown
+}
+
+// Start starts a span with the given name and attributes, as child of the span held by
+// ctx, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartServer starts a span for a request received by the server. See Start.
+func StartServer(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...))
+}
+
+// Record records an operation which took place between start and end as a span, as child
+// of the span held by ctx, if any. It is used for work observed after the fact, like the
+// run of a staging job.
+func Record(ctx context.Context, name string, start, end time.Time, err error, attrs ...attribute.KeyValue) {
+	_, span := otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithTimestamp(start),
+		trace.WithAttributes(attrs...))
+	if err != nil {
+		span.RecordError(err, trace.WithTimestamp(end))
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+// End ends the span, marking it as failed when err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Detach returns a background context holding the span of ctx. It is used for work
+// outliving the request which started it, so that the work is still part of the trace.
+func Detach(ctx context.Context) context.Context {
+	return trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+}
+
+// Inject writes the trace context of ctx into the headers.
+func Inject(ctx context.Context, header http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Extract returns ctx extended with the trace context found in the headers.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Env returns the trace context of ctx as environment variables, by name. The result is
+// empty when ctx holds no trace context.
+func Env(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+
+	env := map[string]string{}
+	for key, value := range carrier {
+		env[strings.ToUpper(key)] = value
+	}
+	return env
+}
+
+// FromEnv returns ctx extended with the trace context held by the environment variables.
+func FromEnv(ctx context.Context, env map[string]string) context.Context {
+	carrier := propagation.MapCarrier{}
+	for _, name := range []string{EnvTraceParent, EnvTraceState} {
+		if value, ok := env[name]; ok {
+			carrier[strings.ToLower(name)] = value
+		}
+	}
+	return propagator.Extract(ctx, carrier)
+}
+
+// Transport wraps the round tripper to trace the requests made on behalf of a traced
+// operation, and to pass the trace context on to the server. Requests without a span in
+// their context, i.e. those of background work, are passed through as is.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return &transport{next: next}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(req.Context()).IsValid() {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, span := StartClient(req.Context(), req)
+
+	// A round tripper must not modify the request it was given.
+	req = req.Clone(ctx)
+	Inject(ctx, req.Header)
+
+	resp, err := t.next.RoundTrip(req)
+	EndClient(span, resp, err)
+
+	return resp, err
+}
+
+// StartClient starts a span for the request about to be made, as child of the span held by
+// ctx, if any. The caller injects the trace context of the returned context into the
+// request.
+func StartClient(ctx context.Context, req *http.Request) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		))
+}
+
+// EndClient ends the span of a request made, with the response received, or the error
+// preventing it.
+func EndClient(span trace.Span, resp *http.Response, err error) {
+	if err == nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	End(span, err)
+}
@@ -0,0 +1,146 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/epinio/epinio/internal/tracing"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracing", func() {
+	var exporter *tracetest.InMemoryExporter
+
+	BeforeEach(func() {
+		exporter = tracetest.NewInMemoryExporter()
+		shutdown := tracing.Install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+		DeferCleanup(shutdown, context.Background())
+	})
+
+	It("records spans, and their failure", func() {
+		ctx, parent := tracing.Start(context.Background(), "parent")
+		_, child := tracing.Start(ctx, "child")
+		tracing.End(child, errors.New("boom"))
+		tracing.End(parent, nil)
+
+		spans := exporter.GetSpans()
+		Expect(spans).To(HaveLen(2))
+		Expect(spans[0].Name).To(Equal("child"))
+		Expect(spans[0].Parent.SpanID()).To(Equal(spans[1].SpanContext.SpanID()))
+		Expect(spans[0].Status.Code).To(Equal(codes.Error))
+		Expect(spans[0].Status.Description).To(Equal("boom"))
+		Expect(spans[1].Status.Code).To(Equal(codes.Unset))
+	})
+
+	It("carries the trace context in headers", func() {
+		ctx, span := tracing.Start(context.Background(), "client")
+		defer span.End()
+
+		header := http.Header{}
+		tracing.Inject(ctx, header)
+		Expect(header.Get("traceparent")).ToNot(BeEmpty())
+
+		remote := trace.SpanContextFromContext(tracing.Extract(context.Background(), header))
+		Expect(remote.IsRemote()).To(BeTrue())
+		Expect(remote.TraceID()).To(Equal(span.SpanContext().TraceID()))
+	})
+
+	It("carries the trace context in environment variables", func() {
+		ctx, span := tracing.Start(context.Background(), "stage")
+		defer span.End()
+
+		env := tracing.Env(ctx)
+		Expect(env).To(HaveKey(tracing.EnvTraceParent))
+
+		remote := trace.SpanContextFromContext(tracing.FromEnv(context.Background(), env))
+		Expect(remote.TraceID()).To(Equal(span.SpanContext().TraceID()))
+		Expect(remote.SpanID()).To(Equal(span.SpanContext().SpanID()))
+	})
+
+	It("has no trace context to carry without span", func() {
+		Expect(tracing.Env(context.Background())).To(BeEmpty())
+	})
+
+	It("keeps the span of detached contexts, but not their cancellation", func() {
+		ctx, span := tracing.Start(context.Background(), "request")
+		defer span.End()
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		detached := tracing.Detach(ctx)
+		Expect(detached.Err()).ToNot(HaveOccurred())
+		Expect(trace.SpanFromContext(detached)).To(Equal(span))
+	})
+
+	Describe("Transport", func() {
+		var traceparent string
+		var client *http.Client
+		var url string
+
+		BeforeEach(func() {
+			traceparent = ""
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				traceparent = r.Header.Get("traceparent")
+				w.WriteHeader(http.StatusTeapot)
+			}))
+			DeferCleanup(srv.Close)
+
+			client = &http.Client{Transport: tracing.Transport(http.DefaultTransport)}
+			url = srv.URL + "/api/v1/namespaces"
+		})
+
+		It("passes requests without span through", func() {
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			resp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+
+			Expect(traceparent).To(BeEmpty())
+			Expect(exporter.GetSpans()).To(BeEmpty())
+		})
+
+		It("traces requests made for a traced operation", func() {
+			ctx, span := tracing.Start(context.Background(), "operation")
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			resp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			span.End()
+
+			Expect(req.Header.Get("traceparent")).To(BeEmpty())
+			Expect(traceparent).To(ContainSubstring(span.SpanContext().TraceID().String()))
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].Name).To(Equal("HTTP GET"))
+			Expect(spans[0].SpanKind).To(Equal(trace.SpanKindClient))
+			Expect(spans[0].Parent.SpanID()).To(Equal(span.SpanContext().SpanID()))
+			Expect(spans[0].Attributes).To(ContainElement(HaveField("Key", BeEquivalentTo("http.response.status_code"))))
+		})
+	})
+})
@@ -0,0 +1,167 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// encodingVersion is the first byte of an encoded history, for future changes of the
+// format.
+const encodingVersion = 1
+
+// encode returns the compact form of the histories of a namespace's applications: a
+// gzipped stream of varints, with the times of the samples as deltas in units of their
+// step.
+func encode(apps map[string]*Series) ([]byte, error) {
+	var raw []byte
+	raw = append(raw, encodingVersion)
+	raw = binary.AppendUvarint(raw, uint64(len(apps)))
+
+	names := make([]string, 0, len(apps))
+	for name := range apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		raw = binary.AppendUvarint(raw, uint64(len(name)))
+		raw = append(raw, name...)
+
+		series := apps[name]
+		raw = binary.AppendUvarint(raw, uint64(len(series.tiers)))
+		for idx, samples := range series.tiers {
+			step := int64(tiers[idx].step.Seconds())
+
+			raw = binary.AppendUvarint(raw, uint64(len(samples)))
+			var last int64
+			for _, sample := range samples {
+				raw = binary.AppendVarint(raw, (sample.Time-last)/step)
+				raw = binary.AppendUvarint(raw, uint64(sample.MilliCPUs))
+				raw = binary.AppendUvarint(raw, uint64(sample.Memory))
+				raw = binary.AppendUvarint(raw, uint64(sample.Instances))
+				raw = binary.AppendUvarint(raw, uint64(sample.Count))
+				last = sample.Time
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	zipper := gzip.NewWriter(&buf)
+	if _, err := zipper.Write(raw); err != nil {
+		return nil, err
+	}
+	if err := zipper.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decode is the inverse of encode.
+func decode(data []byte) (map[string]*Series, error) {
+	unzipper, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "decompressing history")
+	}
+	defer func() {
+		_ = unzipper.Close()
+	}()
+	reader := bufio.NewReader(unzipper)
+
+	version, err := reader.ReadByte()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading history version")
+	}
+	if version != encodingVersion {
+		return nil, errors.Errorf("unknown history version %d", version)
+	}
+
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading application count")
+	}
+
+	apps := map[string]*Series{}
+	for ; count > 0; count-- {
+		size, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading application name")
+		}
+		name := make([]byte, size)
+		if _, err := io.ReadFull(reader, name); err != nil {
+			return nil, errors.Wrap(err, "reading application name")
+		}
+
+		series, err := decodeSeries(reader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading history of %s", name)
+		}
+		apps[string(name)] = series
+	}
+
+	return apps, nil
+}
+
+func decodeSeries(reader io.ByteReader) (*Series, error) {
+	tierCount, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if tierCount != uint64(len(tiers)) {
+		return nil, errors.Errorf("expected %d tiers, found %d", len(tiers), tierCount)
+	}
+
+	series := NewSeries()
+	for idx := range tiers {
+		step := int64(tiers[idx].step.Seconds())
+
+		sampleCount, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		var last int64
+		for ; sampleCount > 0; sampleCount-- {
+			var values [5]int64
+			delta, err := binary.ReadVarint(reader)
+			if err != nil {
+				return nil, err
+			}
+			values[0] = last + delta*step
+			for field := 1; field < len(values); field++ {
+				value, err := binary.ReadUvarint(reader)
+				if err != nil {
+					return nil, err
+				}
+				values[field] = int64(value)
+			}
+
+			series.tiers[idx] = append(series.tiers[idx], Sample{
+				Time:      values[0],
+				MilliCPUs: values[1],
+				Memory:    values[2],
+				Instances: values[3],
+				Count:     values[4],
+			})
+			last = values[0]
+		}
+	}
+
+	return series, nil
+}
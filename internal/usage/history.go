@@ -0,0 +1,183 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package usage records the CPU and memory usage of the applications over time, keeping a
+// rolling, downsampled history of each, for their metrics and the chargeback report.
+package usage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+)
+
+// tier is one resolution of a history. Its samples are step apart, and cover keep.
+type tier struct {
+	step time.Duration
+	keep time.Duration
+}
+
+// tiers are the resolutions of a history, finest first. Every measurement is folded into
+// all of them, so that a coarser tier is the downsampling of the finer ones, and covers a
+// longer range.
+var tiers = []tier{
+	{step: time.Minute, keep: 6 * time.Hour},
+	{step: 10 * time.Minute, keep: 3 * 24 * time.Hour},
+	{step: time.Hour, keep: 35 * 24 * time.Hour},
+}
+
+// MaxRange is the longest range covered by a history.
+var MaxRange = tiers[len(tiers)-1].keep
+
+// Sample holds the measurements of an application taken during the step starting at Time.
+// CPU and memory are kept as sums, for exact averaging and integration.
+type Sample struct {
+	Time      int64 // Unix seconds
+	MilliCPUs int64 // Sum over the measurements
+	Memory    int64 // Sum over the measurements, in bytes
+	Instances int64 // Largest number of instances measured
+	Count     int64 // Number of measurements
+}
+
+// AvgMilliCPUs returns the average CPU usage over the step.
+func (s Sample) AvgMilliCPUs() int64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.MilliCPUs / s.Count
+}
+
+// AvgMemory returns the average memory usage over the step, in bytes.
+func (s Sample) AvgMemory() int64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Memory / s.Count
+}
+
+// Series is the history of a single application, with the samples of each tier, oldest
+// first.
+type Series struct {
+	tiers [][]Sample
+}
+
+// NewSeries returns a new, empty history.
+func NewSeries() *Series {
+	return &Series{tiers: make([][]Sample, len(tiers))}
+}
+
+// Add folds a measurement taken at the given time into all tiers of the history.
+func (s *Series) Add(at time.Time, milliCPUs, memory, instances int64) {
+	for idx, tier := range tiers {
+		start := at.Truncate(tier.step).Unix()
+		samples := s.tiers[idx]
+
+		if last := len(samples) - 1; last >= 0 && samples[last].Time == start {
+			samples[last].MilliCPUs += milliCPUs
+			samples[last].Memory += memory
+			samples[last].Count++
+			if instances > samples[last].Instances {
+				samples[last].Instances = instances
+			}
+			continue
+		}
+
+		s.tiers[idx] = append(samples, Sample{
+			Time:      start,
+			MilliCPUs: milliCPUs,
+			Memory:    memory,
+			Instances: instances,
+			Count:     1,
+		})
+	}
+}
+
+// Prune drops the samples which aged out of their tier, and returns true when the history
+// is empty afterwards.
+func (s *Series) Prune(now time.Time) bool {
+	empty := true
+	for idx, tier := range tiers {
+		limit := now.Add(-tier.keep).Unix()
+		samples := s.tiers[idx]
+
+		keep := 0
+		for keep < len(samples) && samples[keep].Time < limit {
+			keep++
+		}
+		if keep > 0 {
+			s.tiers[idx] = append([]Sample{}, samples[keep:]...)
+		}
+
+		if len(s.tiers[idx]) > 0 {
+			empty = false
+		}
+	}
+	return empty
+}
+
+// Range returns the samples covering the span before now, at the finest resolution having
+// them, and the step of that resolution.
+func (s *Series) Range(now time.Time, span time.Duration) (time.Duration, []Sample) {
+	idx := tierFor(span)
+	limit := now.Add(-span).Truncate(tiers[idx].step).Unix()
+
+	result := []Sample{}
+	for _, sample := range s.tiers[idx] {
+		if sample.Time >= limit {
+			result = append(result, sample)
+		}
+	}
+	return tiers[idx].step, result
+}
+
+// Usage returns the CPU-hours and GB-hours consumed during the span before now. Each
+// measurement stands for one sampling interval of usage.
+func (s *Series) Usage(now time.Time, span time.Duration, interval time.Duration) (float64, float64) {
+	_, samples := s.Range(now, span)
+
+	var milliCPUs, memory float64
+	for _, sample := range samples {
+		milliCPUs += float64(sample.MilliCPUs)
+		memory += float64(sample.Memory)
+	}
+
+	hours := interval.Hours()
+	return milliCPUs / 1000 * hours, memory / 1e9 * hours
+}
+
+// tierFor returns the index of the finest tier covering the span, or of the coarsest one,
+// if none does.
+func tierFor(span time.Duration) int {
+	for idx, tier := range tiers {
+		if span <= tier.keep {
+			return idx
+		}
+	}
+	return len(tiers) - 1
+}
+
+// ParseRange parses the range of a history or report query, e.g. `24h` or `30d`, using the
+// fallback for an empty value. The range has to be positive, and at most MaxRange.
+func ParseRange(value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	span, err := helpers.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if span <= 0 || span > MaxRange {
+		return 0, fmt.Errorf("range %s is not within 0 and %s", value, MaxRange)
+	}
+	return span, nil
+}
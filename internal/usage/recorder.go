@@ -0,0 +1,244 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/background"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+const (
+	// SampleInterval is the period of the measurements of the applications.
+	SampleInterval = time.Minute
+
+	// persistInterval is the period of the saving of the changed histories.
+	persistInterval = 10 * time.Minute
+
+	// AreaLabel is the value of the `epinio.io/area` label of the config maps holding the
+	// histories.
+	AreaLabel = "usage"
+
+	// historyKey is the key of the encoded histories in their config map.
+	historyKey = "history"
+)
+
+// Enabled returns true when the server is configured to record the usage of the
+// applications.
+func Enabled() bool {
+	return viper.GetBool("metrics-history")
+}
+
+// measurement is the summed usage of the running instances of an application.
+type measurement struct {
+	milliCPUs int64
+	memory    int64
+	instances int64
+}
+
+// appKey identifies an application.
+type appKey struct {
+	namespace string
+	app       string
+}
+
+// Recorder measures the usage of all applications every SampleInterval, folds it into the
+// History, and saves the changed histories into config maps of the epinio namespace, one
+// per namespace, to survive restarts of the server.
+type Recorder struct {
+	*background.Worker
+
+	store   *Store
+	failing bool // Suppresses the logging of repeated measurement failures
+}
+
+// NewRecorder returns a new, not yet started, recorder for the store.
+func NewRecorder(store *Store) *Recorder {
+	r := &Recorder{store: store}
+	r.Worker = background.New(r.run)
+	return r
+}
+
+func (r *Recorder) run(ctx context.Context, stop <-chan struct{}) {
+	r.load(ctx)
+
+	ticker := time.NewTicker(SampleInterval)
+	defer ticker.Stop()
+
+	lastPersist := time.Now()
+	for {
+		select {
+		case <-stop:
+			r.persist(ctx)
+			return
+		case now := <-ticker.C:
+			r.sample(ctx, now)
+			if now.Sub(lastPersist) >= persistInterval {
+				r.persist(ctx)
+				lastPersist = now
+			}
+		}
+	}
+}
+
+// load reads the histories saved by a previous run of the server.
+func (r *Recorder) load(ctx context.Context) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		helpers.Logger.Errorw("usage history: cluster access", "error", err)
+		return
+	}
+
+	configMaps, err := cluster.Kubectl.CoreV1().ConfigMaps(helmchart.Namespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: "epinio.io/area=" + AreaLabel,
+	})
+	if err != nil {
+		helpers.Logger.Errorw("usage history: listing histories", "error", err)
+		return
+	}
+
+	for _, configMap := range configMaps.Items {
+		namespace := configMap.Labels["app.kubernetes.io/part-of"]
+		if err := r.store.Load(namespace, configMap.BinaryData[historyKey]); err != nil {
+			helpers.Logger.Errorw("usage history: loading history", "namespace", namespace, "error", err)
+		}
+	}
+}
+
+// sample measures the running applications and records the results.
+func (r *Recorder) sample(ctx context.Context, now time.Time) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		helpers.Logger.Errorw("usage history: cluster access", "error", err)
+		return
+	}
+
+	podMetrics, err := application.GetPodMetrics(ctx, cluster, "", nil)
+	if err != nil {
+		if !r.failing {
+			helpers.Logger.Errorw("usage history: metrics not available", "error", err)
+		}
+		r.failing = true
+		return
+	}
+	r.failing = false
+
+	for key, m := range measure(podMetrics) {
+		r.store.Record(now, key.namespace, key.app, m.milliCPUs, m.memory, m.instances)
+	}
+	r.store.Prune(now)
+}
+
+// measure sums the usage of the application pods by application.
+func measure(podMetrics map[string]metricsv1beta1.PodMetrics) map[appKey]measurement {
+	result := map[appKey]measurement{}
+	for _, podMetric := range podMetrics {
+		if podMetric.Labels["app.kubernetes.io/component"] != "application" {
+			continue
+		}
+		app := podMetric.Labels["app.kubernetes.io/name"]
+		if app == "" {
+			continue
+		}
+
+		cpuUsage := resource.NewQuantity(0, resource.DecimalSI)
+		memUsage := resource.NewQuantity(0, resource.BinarySI)
+		for _, container := range podMetric.Containers {
+			cpuUsage.Add(*container.Usage.Cpu())
+			memUsage.Add(*container.Usage.Memory())
+		}
+
+		key := appKey{namespace: podMetric.Namespace, app: app}
+		m := result[key]
+		m.milliCPUs += int64(math.Round(cpuUsage.ToDec().AsApproximateFloat64() * 1000))
+		m.memory += memUsage.Value()
+		m.instances++
+		result[key] = m
+	}
+	return result
+}
+
+// persist saves the changed histories, and removes the saved histories of namespaces
+// left without any.
+func (r *Recorder) persist(ctx context.Context) {
+	dirty := r.store.Dirty()
+	if len(dirty) == 0 {
+		return
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		helpers.Logger.Errorw("usage history: cluster access", "error", err)
+		return
+	}
+
+	for _, namespace := range dirty {
+		data, err := r.store.Encode(namespace)
+		if err == nil {
+			err = save(ctx, cluster, namespace, data)
+		}
+		if err != nil {
+			helpers.Logger.Errorw("usage history: saving history", "namespace", namespace, "error", err)
+		}
+	}
+}
+
+// save writes the encoded histories of the namespace into its config map, or removes the
+// config map when there is no history.
+func save(ctx context.Context, cluster *kubernetes.Cluster, namespace string, data []byte) error {
+	configMaps := cluster.Kubectl.CoreV1().ConfigMaps(helmchart.Namespace())
+	name := "epinio-usage-" + namespace
+
+	if data == nil {
+		err := configMaps.Delete(ctx, name, metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	configMap, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: helmchart.Namespace(),
+				Labels: map[string]string{
+					"app.kubernetes.io/part-of":    namespace,
+					"app.kubernetes.io/managed-by": "epinio",
+					"epinio.io/area":               AreaLabel,
+				},
+			},
+			BinaryData: map[string][]byte{historyKey: data},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	configMap.BinaryData = map[string][]byte{historyKey: data}
+	_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,156 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"sync"
+	"time"
+)
+
+// History holds the usage histories of the applications, as recorded by the Recorder of
+// the server.
+var History = NewStore()
+
+// Usage is the resource usage of an application over the range of a report.
+type Usage struct {
+	CPUHours float64
+	GBHours  float64
+}
+
+// Store holds the usage histories of the applications, by namespace and name.
+type Store struct {
+	mutex  sync.Mutex
+	series map[string]map[string]*Series
+	dirty  map[string]bool // Namespaces changed since their last persistence
+}
+
+// NewStore returns a new, empty store.
+func NewStore() *Store {
+	return &Store{
+		series: map[string]map[string]*Series{},
+		dirty:  map[string]bool{},
+	}
+}
+
+// Record folds a measurement of the application taken at the given time into its history.
+func (s *Store) Record(at time.Time, namespace, app string, milliCPUs, memory, instances int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	apps, ok := s.series[namespace]
+	if !ok {
+		apps = map[string]*Series{}
+		s.series[namespace] = apps
+	}
+	series, ok := apps[app]
+	if !ok {
+		series = NewSeries()
+		apps[app] = series
+	}
+
+	series.Add(at, milliCPUs, memory, instances)
+	s.dirty[namespace] = true
+}
+
+// Prune drops the samples which aged out of their histories, and the histories left empty.
+// The history of a deleted application is kept until then, for the usage report.
+func (s *Store) Prune(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for namespace, apps := range s.series {
+		for app, series := range apps {
+			if series.Prune(now) {
+				delete(apps, app)
+				s.dirty[namespace] = true
+			}
+		}
+		if len(apps) == 0 {
+			delete(s.series, namespace)
+		}
+	}
+}
+
+// Range returns the history of the application over the span before now, and its step.
+func (s *Store) Range(now time.Time, namespace, app string, span time.Duration) (time.Duration, []Sample) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	series, ok := s.series[namespace][app]
+	if !ok {
+		series = NewSeries()
+	}
+	return series.Range(now, span)
+}
+
+// Usage returns the resources used by the applications during the span before now, by
+// namespace and name.
+func (s *Store) Usage(now time.Time, span time.Duration) map[string]map[string]Usage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result := map[string]map[string]Usage{}
+	for namespace, apps := range s.series {
+		for app, series := range apps {
+			cpuHours, gbHours := series.Usage(now, span, SampleInterval)
+			if cpuHours == 0 && gbHours == 0 {
+				continue
+			}
+			if _, ok := result[namespace]; !ok {
+				result[namespace] = map[string]Usage{}
+			}
+			result[namespace][app] = Usage{CPUHours: cpuHours, GBHours: gbHours}
+		}
+	}
+	return result
+}
+
+// Encode returns the compact form of the histories of the namespace, and clears its dirty
+// flag. The result is nil for a namespace without histories.
+func (s *Store) Encode(namespace string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.dirty, namespace)
+
+	apps, ok := s.series[namespace]
+	if !ok {
+		return nil, nil
+	}
+	return encode(apps)
+}
+
+// Load replaces the histories of the namespace with the decoded data.
+func (s *Store) Load(namespace string, data []byte) error {
+	apps, err := decode(data)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.series[namespace] = apps
+	return nil
+}
+
+// Dirty returns the namespaces whose histories changed since their last encoding.
+func (s *Store) Dirty() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result := make([]string, 0, len(s.dirty))
+	for namespace := range s.dirty {
+		result = append(result, namespace)
+	}
+	return result
+}
@@ -0,0 +1,187 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+var _ = Describe("Series", func() {
+	var (
+		start  time.Time
+		series *Series
+	)
+
+	BeforeEach(func() {
+		start = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		series = NewSeries()
+	})
+
+	It("downsamples the measurements into the coarser tiers", func() {
+		for minute := 0; minute < 20; minute++ {
+			series.Add(start.Add(time.Duration(minute)*time.Minute), int64(100*minute), 1000, int64(1+minute%3))
+		}
+
+		Expect(series.tiers[0]).To(HaveLen(20))
+		Expect(series.tiers[1]).To(HaveLen(2))
+		Expect(series.tiers[2]).To(HaveLen(1))
+
+		first := series.tiers[1][0]
+		Expect(first.Count).To(Equal(int64(10)))
+		Expect(first.AvgMilliCPUs()).To(Equal(int64(450)))
+		Expect(first.AvgMemory()).To(Equal(int64(1000)))
+		Expect(first.Instances).To(Equal(int64(3)))
+	})
+
+	It("returns a range at the finest resolution covering it", func() {
+		for minute := 0; minute < 8*60; minute++ {
+			series.Add(start.Add(time.Duration(minute)*time.Minute), 100, 1000, 1)
+		}
+		now := start.Add(8 * time.Hour)
+
+		step, samples := series.Range(now, time.Hour)
+		Expect(step).To(Equal(time.Minute))
+		Expect(samples).To(HaveLen(60))
+
+		step, samples = series.Range(now, 24*time.Hour)
+		Expect(step).To(Equal(10 * time.Minute))
+		Expect(samples).To(HaveLen(48))
+
+		step, samples = series.Range(now, 7*24*time.Hour)
+		Expect(step).To(Equal(time.Hour))
+		Expect(samples).To(HaveLen(8))
+	})
+
+	It("drops the samples aged out of their tier", func() {
+		series.Add(start, 100, 1000, 1)
+
+		Expect(series.Prune(start.Add(7 * time.Hour))).To(BeFalse())
+		Expect(series.tiers[0]).To(BeEmpty())
+		Expect(series.tiers[1]).To(HaveLen(1))
+
+		Expect(series.Prune(start.Add(36 * 24 * time.Hour))).To(BeTrue())
+	})
+
+	It("integrates the usage over the range", func() {
+		// Two hours of 500 milliCPUs and 2 GB.
+		for minute := 0; minute < 120; minute++ {
+			series.Add(start.Add(time.Duration(minute)*time.Minute), 500, 2e9, 1)
+		}
+
+		cpuHours, gbHours := series.Usage(start.Add(2*time.Hour), 24*time.Hour, time.Minute)
+		Expect(cpuHours).To(BeNumerically("~", 1, 1e-9))
+		Expect(gbHours).To(BeNumerically("~", 4, 1e-9))
+	})
+})
+
+var _ = Describe("Store", func() {
+	It("round-trips its histories through their compact encoding", func() {
+		start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		store := NewStore()
+		for minute := 0; minute < 90; minute++ {
+			at := start.Add(time.Duration(minute) * time.Minute)
+			store.Record(at, "workspace", "web", int64(minute), 256<<20, 2)
+			store.Record(at, "workspace", "api", 10, 64<<20, 1)
+		}
+		Expect(store.Dirty()).To(ConsistOf("workspace"))
+
+		data, err := store.Encode("workspace")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(store.Dirty()).To(BeEmpty())
+		Expect(len(data)).To(BeNumerically("<", 2048))
+
+		loaded := NewStore()
+		Expect(loaded.Load("workspace", data)).To(Succeed())
+		Expect(loaded.series["workspace"]).To(Equal(store.series["workspace"]))
+
+		empty, err := loaded.Encode("other")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(empty).To(BeNil())
+	})
+
+	It("rejects a corrupt encoding", func() {
+		Expect(NewStore().Load("workspace", []byte("garbage"))).ToNot(Succeed())
+	})
+
+	It("reports the usage by namespace and application", func() {
+		start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		store := NewStore()
+		for minute := 0; minute < 60; minute++ {
+			store.Record(start.Add(time.Duration(minute)*time.Minute), "workspace", "web", 1000, 1e9, 1)
+		}
+
+		usages := store.Usage(start.Add(time.Hour), 24*time.Hour)
+		Expect(usages).To(HaveKey("workspace"))
+		Expect(usages["workspace"]["web"].CPUHours).To(BeNumerically("~", 1, 1e-9))
+		Expect(usages["workspace"]["web"].GBHours).To(BeNumerically("~", 1, 1e-9))
+	})
+})
+
+var _ = Describe("ParseRange", func() {
+	It("uses the fallback for an empty range", func() {
+		Expect(ParseRange("", time.Hour)).To(Equal(time.Hour))
+	})
+
+	It("accepts days", func() {
+		Expect(ParseRange("7d", time.Hour)).To(Equal(7 * 24 * time.Hour))
+	})
+
+	It("rejects ranges beyond the history", func() {
+		_, err := ParseRange("60d", time.Hour)
+		Expect(err).To(HaveOccurred())
+		_, err = ParseRange("-1h", time.Hour)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("measure", func() {
+	podMetric := func(namespace, name, app, component, cpu, memory string) metricsv1beta1.PodMetrics {
+		return metricsv1beta1.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels: map[string]string{
+					"app.kubernetes.io/name":      app,
+					"app.kubernetes.io/component": component,
+				},
+			},
+			Containers: []metricsv1beta1.ContainerMetrics{{
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			}},
+		}
+	}
+
+	It("sums the usage of the application pods by application", func() {
+		result := measure(map[string]metricsv1beta1.PodMetrics{
+			"web-1":   podMetric("workspace", "web-1", "web", "application", "250m", "128Mi"),
+			"web-2":   podMetric("workspace", "web-2", "web", "application", "150m", "64Mi"),
+			"stage-1": podMetric("workspace", "stage-1", "web", "staging", "2", "1Gi"),
+		})
+
+		Expect(result).To(HaveLen(1))
+		Expect(result[appKey{namespace: "workspace", app: "web"}]).To(Equal(measurement{
+			milliCPUs: 400,
+			memory:    192 << 20,
+			instances: 2,
+		}))
+	})
+})
@@ -354,6 +354,10 @@ type LogOptions struct {
 	SinceTime         *time.Time
 	IncludeContainers []string // List of container names/patterns to include (regex patterns supported)
 	ExcludeContainers []string // List of container names/patterns to exclude (regex patterns supported)
+	Grep              string   // Text the log messages have to contain
+	GrepRegex         bool     // Grep is a regular expression
+	Level             string   // Minimum level of structured (JSON/logfmt) log lines
+	Fields            []string // key=value matches on the fields of structured log lines
 }
 
 // AppLogs streams the logs of all the application instances, in the targeted namespace
@@ -393,6 +397,18 @@ func (c *Client) AppLogs(namespace, appName, stageID string, follow bool, option
 		if len(options.ExcludeContainers) > 0 {
 			queryParams.Add("exclude_containers", strings.Join(options.ExcludeContainers, ","))
 		}
+		if options.Grep != "" {
+			queryParams.Add("grep", options.Grep)
+		}
+		if options.GrepRegex {
+			queryParams.Add("grep_regex", "true")
+		}
+		if options.Level != "" {
+			queryParams.Add("level", options.Level)
+		}
+		for _, field := range options.Fields {
+			queryParams.Add("field", field)
+		}
 	}
 
 	var endpoint string
@@ -988,6 +1004,192 @@ func (c *Client) AppRestart(namespace string, appName string) (models.Response,
 	return Post(c, endpoint, nil, response)
 }
 
+// StagingQueue returns the stagings waiting for a free slot
+func (c *Client) StagingQueue() (models.StagingQueue, error) {
+	response := models.StagingQueue{}
+	endpoint := api.Routes.Path("StagingQueue")
+
+	return Get(c, endpoint, response)
+}
+
+// StagingQueueMove moves a queued staging to a new position in the queue
+func (c *Client) StagingQueueMove(req models.StagingQueueMoveRequest) (models.StagingQueue, error) {
+	response := models.StagingQueue{}
+	endpoint := api.Routes.Path("StagingQueueMove")
+
+	return Post(c, endpoint, req, response)
+}
+
+// AppCacheClear clears the build cache of an app
+func (c *Client) AppCacheClear(namespace string, appName string) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("AppCacheClear", namespace, appName)
+
+	return Post(c, endpoint, nil, response)
+}
+
+// AppScan returns the image scan report of the last staging of an app
+func (c *Client) AppScan(namespace, appName string) (models.ScanReport, error) {
+	response := models.ScanReport{}
+	endpoint := api.Routes.Path("AppScan", namespace, appName)
+
+	return Get(c, endpoint, response)
+}
+
+// AppMetrics returns the recorded CPU and memory usage of an app over the given range
+func (c *Client) AppMetrics(namespace, appName, span string) (models.AppMetricsResponse, error) {
+	response := models.AppMetricsResponse{}
+
+	queryParams := url.Values{}
+	if span != "" {
+		queryParams.Set("range", span)
+	}
+
+	endpoint := fmt.Sprintf(
+		"%s?%s",
+		api.Routes.Path("AppMetrics", namespace, appName),
+		queryParams.Encode(),
+	)
+
+	return Get(c, endpoint, response)
+}
+
+// AppEvents returns the events timeline of an app
+func (c *Client) AppEvents(namespace, appName string) (models.AppEventsResponse, error) {
+	response := models.AppEventsResponse{}
+	endpoint := api.Routes.Path("AppEvents", namespace, appName)
+
+	return Get(c, endpoint, response)
+}
+
+// AppEventsStream opens a websocket streaming the events timeline of an app, followed by
+// the new events, and hands each to the callback, until the context is cancelled.
+func (c *Client) AppEventsStream(ctx context.Context, namespace, appName string, callback func(models.AppEvent) error) error {
+	tokenResponse, err := c.AuthToken()
+	if err != nil {
+		return err
+	}
+
+	endpoint := api.WsRoutes.Path("AppEventsWs", namespace, appName)
+	queryParams := url.Values{}
+	queryParams.Add("authtoken", tokenResponse.Token)
+	websocketURL := fmt.Sprintf("%s%s/%s?%s", c.Settings.WSS, api.WsRoot, endpoint, queryParams.Encode())
+
+	webSocketConn, resp, err := websocket.DefaultDialer.DialContext(ctx, websocketURL, c.Headers())
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusOK {
+			return handleError(c.log, resp)
+		}
+		return errors.Wrap(err, "failed to connect to app events websocket")
+	}
+	defer func() { _ = webSocketConn.Close() }()
+
+	// Unblock the reading below when the caller is done.
+	go func() {
+		<-ctx.Done()
+		_ = webSocketConn.Close()
+	}()
+
+	for {
+		_, message, readErr := webSocketConn.ReadMessage()
+		if readErr != nil {
+			if ctx.Err() != nil || websocket.IsCloseError(readErr, websocket.CloseNormalClosure) {
+				return nil
+			}
+			return errors.Wrap(readErr, "reading app events websocket message")
+		}
+
+		var event models.AppEvent
+		if unmarshalErr := json.Unmarshal(message, &event); unmarshalErr != nil {
+			return errors.Wrap(unmarshalErr, "decoding app event")
+		}
+
+		if err := callback(event); err != nil {
+			return err
+		}
+	}
+}
+
+// AppPromote deploys the staged image of an app into another namespace
+func (c *Client) AppPromote(namespace, appName string, request models.AppPromoteRequest) (models.AppPromoteResponse, error) {
+	response := models.AppPromoteResponse{}
+	endpoint := api.Routes.Path("AppPromote", namespace, appName)
+
+	return Post(c, endpoint, request, response)
+}
+
+// AppGitTriggerShow returns the push-to-deploy configuration of an app, and its recent webhook deliveries
+func (c *Client) AppGitTriggerShow(namespace, appName string) (models.GitTrigger, error) {
+	response := models.GitTrigger{}
+	endpoint := api.Routes.Path("AppGitTriggerShow", namespace, appName)
+
+	return Get(c, endpoint, response)
+}
+
+// AppGitTriggerEnable enables push-to-deploy for an app, and returns the new webhook secret
+func (c *Client) AppGitTriggerEnable(namespace, appName string) (models.GitTrigger, error) {
+	response := models.GitTrigger{}
+	endpoint := api.Routes.Path("AppGitTriggerEnable", namespace, appName)
+
+	return Post(c, endpoint, nil, response)
+}
+
+// AppGitTriggerDisable disables push-to-deploy for an app
+func (c *Client) AppGitTriggerDisable(namespace, appName string) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("AppGitTriggerDisable", namespace, appName)
+
+	return Delete(c, endpoint, nil, response)
+}
+
+// AppLogDrainList returns the log drains of an app, and of its namespace
+func (c *Client) AppLogDrainList(namespace, appName string) (models.LogDrainsResponse, error) {
+	response := models.LogDrainsResponse{}
+	endpoint := api.Routes.Path("AppLogDrainList", namespace, appName)
+
+	return Get(c, endpoint, response)
+}
+
+// AppLogDrainAdd adds a log drain to an app
+func (c *Client) AppLogDrainAdd(namespace, appName string, request models.LogDrainAddRequest) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("AppLogDrainAdd", namespace, appName)
+
+	return Post(c, endpoint, request, response)
+}
+
+// AppLogDrainRemove removes a log drain from an app
+func (c *Client) AppLogDrainRemove(namespace, appName, drainName string) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("AppLogDrainRemove", namespace, appName, drainName)
+
+	return Delete(c, endpoint, nil, response)
+}
+
+// AppAlertList returns the alerts of an app, with their state
+func (c *Client) AppAlertList(namespace, appName string) (models.AppAlertsResponse, error) {
+	response := models.AppAlertsResponse{}
+	endpoint := api.Routes.Path("AppAlertList", namespace, appName)
+
+	return Get(c, endpoint, response)
+}
+
+// AppAlertAdd adds an alert to an app
+func (c *Client) AppAlertAdd(namespace, appName string, request models.AppAlertAddRequest) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("AppAlertAdd", namespace, appName)
+
+	return Post(c, endpoint, request, response)
+}
+
+// AppAlertRemove removes an alert from an app
+func (c *Client) AppAlertRemove(namespace, appName, alertName string) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("AppAlertRemove", namespace, appName, alertName)
+
+	return Delete(c, endpoint, nil, response)
+}
+
 func (c *Client) AuthToken() (models.AuthTokenResponse, error) {
 	response := models.AuthTokenResponse{}
 	endpoint := api.Routes.Path("AuthToken")
@@ -31,6 +31,7 @@ import (
 // server
 type Client struct {
 	log              logr.Logger
+	ctx              context.Context // Holds the trace the requests belong to, if any
 	Settings         *epiniosettings.Settings
 	HttpClient       *http.Client
 	customHeaders    http.Header
@@ -106,6 +107,7 @@ func New(ctx context.Context, settings *epiniosettings.Settings) *Client {
 
 	return &Client{
 		log:           log,
+		ctx:           ctx,
 		Settings:      settings,
 		HttpClient:    httpClient,
 		customHeaders: http.Header{},
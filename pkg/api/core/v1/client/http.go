@@ -13,6 +13,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -27,6 +28,7 @@ import (
 	"github.com/epinio/epinio/helpers"
 	api "github.com/epinio/epinio/internal/api/v1"
 	"github.com/epinio/epinio/internal/cli/termui"
+	"github.com/epinio/epinio/internal/tracing"
 	"github.com/epinio/epinio/internal/version"
 	apierrors "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"golang.org/x/oauth2"
@@ -135,10 +137,19 @@ func DoWithHandlers[T any](
 		}
 	}
 
+	// Make the request part of the trace of the client, and pass the trace on to the server.
+	traceCtx := c.ctx
+	if traceCtx == nil {
+		traceCtx = context.Background()
+	}
+	traceCtx, span := tracing.StartClient(traceCtx, request)
+	tracing.Inject(traceCtx, request.Header)
+
 	reqLog := requestLogger(c.log, request)
 	reqLog.V(1).Info("executing request")
 
 	httpResponse, err := c.HttpClient.Do(request) // nolint:gosec // API client, request URL from user/config
+	tracing.EndClient(span, httpResponse, err)
 	if err != nil {
 		return response, errors.Wrap(err, "making the request")
 	}
@@ -20,8 +20,11 @@ import (
 	"os"
 
 	"github.com/epinio/epinio/internal/cli/settings"
+	"github.com/epinio/epinio/internal/tracing"
 	"github.com/epinio/epinio/pkg/api/core/v1/client"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -101,6 +104,33 @@ var _ = Describe("Client HTTP", func() {
 		})
 	})
 
+	Describe("executing a traced request", func() {
+
+		BeforeEach(func() {
+			statusHeader = http.StatusOK
+		})
+
+		It("sends the trace context of the client", func() {
+			responseBody = `{"status":"ok"}`
+
+			shutdown := tracing.Install(sdktrace.NewTracerProvider())
+			DeferCleanup(shutdown, context.Background())
+
+			ctx, span := tracing.Start(context.Background(), "epinio app push")
+			defer span.End()
+
+			tracedClient := client.New(ctx, epinioClient.Settings)
+
+			requestInterceptor = func(r *http.Request) {
+				traceparent := r.Header.Get("traceparent")
+				Expect(traceparent).To(ContainSubstring(span.SpanContext().TraceID().String()))
+			}
+
+			_, err := client.Do(tracedClient, "any", http.MethodGet, nil, &models.Response{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("executing a failing request", func() {
 
 		BeforeEach(func() {
@@ -13,10 +13,12 @@ package client
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 
 	api "github.com/epinio/epinio/internal/api/v1"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
 )
 
 // NamespaceCreate creates a namespace
@@ -45,6 +47,33 @@ func (c *Client) NamespaceDelete(namespaces []string) (models.Response, error) {
 	return Delete(c, endpoint, nil, response)
 }
 
+// NamespaceSupportBundle retrieves the support bundle of the named applications of the
+// namespace, as a tar.gz archive. No names selects all applications of the namespace.
+func (c *Client) NamespaceSupportBundle(namespace string, appNames []string) (models.AppPartResponse, error) {
+	response := models.AppPartResponse{}
+
+	queryParams := url.Values{}
+	for _, appName := range appNames {
+		queryParams.Add("applications[]", appName)
+	}
+
+	endpoint := fmt.Sprintf(
+		"%s?%s",
+		api.Routes.Path("NamespaceSupportBundle", namespace),
+		queryParams.Encode(),
+	)
+
+	httpResponse, err := c.Do(endpoint, http.MethodGet, nil)
+	if err != nil {
+		return response, errors.Wrap(err, "executing NamespaceSupportBundle request")
+	}
+
+	return models.AppPartResponse{
+		Data:          httpResponse.Body,
+		ContentLength: httpResponse.ContentLength,
+	}, nil
+}
+
 // NamespaceShow shows a namespace
 func (c *Client) NamespaceShow(namespace string) (models.Namespace, error) {
 	response := models.Namespace{}
@@ -68,3 +97,75 @@ func (c *Client) Namespaces() (models.NamespaceList, error) {
 
 	return Get(c, endpoint, response)
 }
+
+// NamespaceQuotaShow returns the quota of a namespace, and its current usage
+func (c *Client) NamespaceQuotaShow(namespace string) (models.NamespaceQuotaResponse, error) {
+	response := models.NamespaceQuotaResponse{}
+	endpoint := api.Routes.Path("NamespaceQuotaShow", namespace)
+
+	return Get(c, endpoint, response)
+}
+
+// NamespaceQuotaUpdate changes the quota of a namespace
+func (c *Client) NamespaceQuotaUpdate(namespace string, request models.NamespaceQuotaUpdateRequest) (models.NamespaceQuotaResponse, error) {
+	response := models.NamespaceQuotaResponse{}
+	endpoint := api.Routes.Path("NamespaceQuotaUpdate", namespace)
+
+	return Patch(c, endpoint, request, response)
+}
+
+// NamespaceScanPolicyShow returns the image scan policy of a namespace
+func (c *Client) NamespaceScanPolicyShow(namespace string) (models.NamespaceScanPolicy, error) {
+	response := models.NamespaceScanPolicy{}
+	endpoint := api.Routes.Path("NamespaceScanPolicyShow", namespace)
+
+	return Get(c, endpoint, response)
+}
+
+// NamespaceScanPolicyUpdate changes the image scan policy of a namespace
+func (c *Client) NamespaceScanPolicyUpdate(namespace string, request models.NamespaceScanPolicyUpdateRequest) (models.NamespaceScanPolicy, error) {
+	response := models.NamespaceScanPolicy{}
+	endpoint := api.Routes.Path("NamespaceScanPolicyUpdate", namespace)
+
+	return Patch(c, endpoint, request, response)
+}
+
+// NamespaceLogRetentionShow returns the log retention of a namespace
+func (c *Client) NamespaceLogRetentionShow(namespace string) (models.NamespaceLogRetention, error) {
+	response := models.NamespaceLogRetention{}
+	endpoint := api.Routes.Path("NamespaceLogRetentionShow", namespace)
+
+	return Get(c, endpoint, response)
+}
+
+// NamespaceLogRetentionUpdate changes the log retention of a namespace
+func (c *Client) NamespaceLogRetentionUpdate(namespace string, request models.NamespaceLogRetentionUpdateRequest) (models.NamespaceLogRetention, error) {
+	response := models.NamespaceLogRetention{}
+	endpoint := api.Routes.Path("NamespaceLogRetentionUpdate", namespace)
+
+	return Patch(c, endpoint, request, response)
+}
+
+// NamespaceLogDrainList returns the log drains of a namespace
+func (c *Client) NamespaceLogDrainList(namespace string) (models.LogDrainsResponse, error) {
+	response := models.LogDrainsResponse{}
+	endpoint := api.Routes.Path("NamespaceLogDrainList", namespace)
+
+	return Get(c, endpoint, response)
+}
+
+// NamespaceLogDrainAdd adds a log drain to a namespace
+func (c *Client) NamespaceLogDrainAdd(namespace string, request models.LogDrainAddRequest) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("NamespaceLogDrainAdd", namespace)
+
+	return Post(c, endpoint, request, response)
+}
+
+// NamespaceLogDrainRemove removes a log drain from a namespace
+func (c *Client) NamespaceLogDrainRemove(namespace, drainName string) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("NamespaceLogDrainRemove", namespace, drainName)
+
+	return Delete(c, endpoint, nil, response)
+}
@@ -0,0 +1,41 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// RegistryCredentials returns a list of the registry credentials of the namespace
+func (c *Client) RegistryCredentials(namespace string) (models.RegistryCredentialList, error) {
+	response := models.RegistryCredentialList{}
+	endpoint := api.Routes.Path("RegistryCredentials", namespace)
+
+	return Get(c, endpoint, response)
+}
+
+// RegistryCredentialCreate creates registry credentials in the namespace
+func (c *Client) RegistryCredentialCreate(namespace string, request models.RegistryCredentialCreateRequest) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("RegistryCredentialCreate", namespace)
+
+	return Post(c, endpoint, request, response)
+}
+
+// RegistryCredentialDelete deletes the named registry credentials of the namespace
+func (c *Client) RegistryCredentialDelete(namespace, name string) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("RegistryCredentialDelete", namespace, name)
+
+	return Delete(c, endpoint, nil, response)
+}
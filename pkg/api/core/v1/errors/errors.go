@@ -242,3 +242,23 @@ func NewQuotaExceededError(msg string, details ...string) APIError {
 	}
 	return NewAPIError(msg, http.StatusInsufficientStorage)
 }
+
+/////////////////////////
+//
+// Forbidden (403) errors
+//
+/////////////////////////
+
+// NamespaceQuotaExceeded constructs an API error for when an operation would go beyond
+// the quota set for a resource of a namespace
+func NamespaceQuotaExceeded(namespace, resource string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("%s quota of namespace '%s' exceeded", resource, namespace),
+		http.StatusForbidden)
+}
+
+// ImageScanBlocked constructs an API error for when the image scan policy blocks the
+// deployment of an application. The reason describes the findings.
+func ImageScanBlocked(reason string) APIError {
+	return NewAPIError(reason, http.StatusForbidden)
+}
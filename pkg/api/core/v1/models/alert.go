@@ -0,0 +1,137 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Kinds of application alerts. The meaning of the threshold of an alert depends on its kind.
+const (
+	AlertCrashLoop     = "crashloop"      // An instance is not ready, after at least threshold restarts
+	AlertUnready       = "unready"        // Fewer instances are ready than desired, for threshold minutes
+	AlertStagingFailed = "staging-failed" // The last staging of the application failed
+	AlertMemory        = "memory"         // An instance uses more than threshold percent of its memory limit
+	AlertCertificate   = "certificate"    // A certificate of the routes expires within threshold days
+)
+
+// AlertKinds lists the known kinds of alerts.
+var AlertKinds = []string{
+	AlertCrashLoop, AlertUnready, AlertStagingFailed, AlertMemory, AlertCertificate,
+}
+
+// alertDefaultThresholds are the thresholds used for alerts without one.
+var alertDefaultThresholds = map[string]int64{
+	AlertCrashLoop:     3,  // restarts
+	AlertUnready:       5,  // minutes
+	AlertStagingFailed: 0,  // unused
+	AlertMemory:        90, // percent
+	AlertCertificate:   14, // days
+}
+
+// Kinds of alert notification endpoints, the scheme of their URL
+const (
+	AlertNotifyHTTP  = "http"        // JSON over HTTP
+	AlertNotifyHTTPS = "https"       // JSON over HTTPS
+	AlertNotifySlack = "slack+https" // Slack-compatible incoming webhook
+	AlertNotifyEmail = "mailto"      // Email, sent through the SMTP server of Epinio
+)
+
+// AlertNotifyKinds lists the known kinds of alert notification endpoints.
+var AlertNotifyKinds = []string{
+	AlertNotifyHTTP, AlertNotifyHTTPS, AlertNotifySlack, AlertNotifyEmail,
+}
+
+// States of alerts
+const (
+	AlertOK      = "ok"      // The condition of the alert is not met
+	AlertPending = "pending" // The condition is met, but not yet for long enough
+	AlertFiring  = "firing"  // The condition is met, the endpoints are notified
+)
+
+// States notified to the endpoints of alerts
+const (
+	AlertNotifyFiring   = "firing"
+	AlertNotifyResolved = "resolved"
+)
+
+// AppAlert is a condition on the health of an application, and the endpoints to notify
+// when it starts, and stops, to hold.
+type AppAlert struct {
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind"`
+	Threshold int64    `json:"threshold,omitempty"` // Zero for the default of the kind
+	Notify    []string `json:"notify"`
+}
+
+// AppAlertStatus is an alert, with its current state.
+type AppAlertStatus struct {
+	AppAlert
+	State     string `json:"state"`
+	Since     string `json:"since,omitempty"`     // Time the alert entered its state
+	Message   string `json:"message,omitempty"`   // Why the alert is pending, or firing
+	Notified  string `json:"notified,omitempty"`  // Last state the endpoints were notified of
+	LastError string `json:"lastError,omitempty"` // Of the last failed notification
+}
+
+// AppAlertAddRequest adds an alert to an application.
+type AppAlertAddRequest struct {
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind"`
+	Threshold int64    `json:"threshold,omitempty"`
+	Notify    []string `json:"notify"`
+}
+
+// AppAlertsResponse lists the alerts of an application.
+type AppAlertsResponse []AppAlertStatus
+
+// AlertNotification is the JSON body posted to the http(s) endpoints of an alert.
+type AlertNotification struct {
+	Namespace string `json:"namespace"`
+	App       string `json:"app"`
+	Alert     string `json:"alert"`
+	Kind      string `json:"kind"`
+	State     string `json:"state"` // One of AlertNotifyFiring, AlertNotifyResolved
+	Message   string `json:"message,omitempty"`
+	Time      string `json:"time"`
+}
+
+// EffectiveThreshold returns the threshold of the alert, or the default of its kind.
+func (a AppAlert) EffectiveThreshold() int64 {
+	if a.Threshold > 0 {
+		return a.Threshold
+	}
+	return alertDefaultThresholds[a.Kind]
+}
+
+// RedactedEndpoint returns a notification endpoint without its secrets, for display. The
+// password of the user info is hidden, and so is the path of webhooks, as incoming
+// webhooks commonly carry their token in it.
+func RedactedEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == AlertNotifyEmail {
+		return endpoint
+	}
+
+	if u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			u.User = url.UserPassword(u.User.Username(), "xxxxx")
+		}
+	}
+	if strings.Trim(u.Path, "/") != "" {
+		u.Path, u.RawPath = "/xxxxx", ""
+	}
+	u.RawQuery = ""
+
+	return u.String()
+}
@@ -12,6 +12,7 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -25,12 +26,23 @@ const (
 	EpinioStageBlobUIDLabel = "epinio.io/blob-uid"
 
 	EpinioCreatedByAnnotation = "epinio.io/created-by"
+	EpinioWaitingOnAnnotation = "epinio.io/waiting-on"
+	EpinioBuildModeAnnotation = "epinio.io/build-mode"
+	EpinioLineageAnnotation   = "epinio.io/promotion-lineage"
+
+	EpinioRegistryCredentialAnnotation = "epinio.io/registry-credential"
+	EpinioImageDigestAnnotation        = "epinio.io/image-digest"
+
+	EpinioCacheUsageAnnotation    = "epinio.io/cache-usage"
+	EpinioCacheMeasuredAnnotation = "epinio.io/cache-measured"
 
 	ApplicationCreated = "created"
 	ApplicationStaging = "staging"
+	ApplicationWaiting = "waiting"
 	ApplicationRunning = "running"
 	ApplicationError   = "error"
 
+	ApplicationStagingQueued = "queued"
 	ApplicationStagingActive = "active"
 	ApplicationStagingDone   = "done"
 	ApplicationStagingFailed = "failed"
@@ -87,11 +99,103 @@ type ApplicationStatus string
 type ApplicationStagingStatus string
 
 type GitRef struct {
-	Revision  string      `json:"revision,omitempty"  yaml:"revision,omitempty"`
-	URL       string      `json:"repository"          yaml:"url,omitempty"`
-	Provider  GitProvider `json:"provider,omitempty"  yaml:"provider,omitempty"`
-	Branch    string      `json:"branch,omitempty"    yaml:"branch,omitempty"`
-	Gitconfig string      `json:"gitconfig,omitempty" yaml:"gitconfig,omitempty"`
+	Revision     string      `json:"revision,omitempty"     yaml:"revision,omitempty"`
+	URL          string      `json:"repository"             yaml:"url,omitempty"`
+	Provider     GitProvider `json:"provider,omitempty"     yaml:"provider,omitempty"`
+	Branch       string      `json:"branch,omitempty"       yaml:"branch,omitempty"`
+	Gitconfig    string      `json:"gitconfig,omitempty"    yaml:"gitconfig,omitempty"`
+	PollInterval string      `json:"pollInterval,omitempty" yaml:"pollInterval,omitempty"` // Duration, e.g. 5m. Empty: no polling
+}
+
+// GitPollStatus describes the polling of the git origin of an application for new commits
+// on its tracked branch.
+type GitPollStatus struct {
+	Interval    string `json:"interval"`
+	Username    string `json:"username,omitempty"` // Name of the user deployments are made for
+	LastChecked string `json:"lastChecked,omitempty"`
+	LastCommit  string `json:"lastCommit,omitempty"`
+	LastError   string `json:"lastError,omitempty"`
+	Failures    int32  `json:"failures,omitempty"` // Consecutive failed checks
+	NextCheck   string `json:"nextCheck,omitempty"`
+}
+
+// Modes of the image watch, i.e. the reaction to a new digest of the watched tag.
+const (
+	ImageWatchRedeploy = "redeploy" // Deploy the new image
+	ImageWatchNotify   = "notify"   // Only report the new image
+)
+
+// AppImageWatch configures the checks of the tag of a container image application for a
+// new digest.
+type AppImageWatch struct {
+	Interval string `json:"interval"       yaml:"interval"`       // Duration, e.g. 5m. Empty: no watch
+	Mode     string `json:"mode,omitempty" yaml:"mode,omitempty"` // See ImageWatch* above. Default: notify
+}
+
+// ImageWatchStatus describes the watch of the tag of a container image application for
+// new digests.
+type ImageWatchStatus struct {
+	Interval     string `json:"interval"`
+	Mode         string `json:"mode"`
+	Username     string `json:"username,omitempty"` // Name of the user deployments are made for
+	LastChecked  string `json:"lastChecked,omitempty"`
+	LatestDigest string `json:"latestDigest,omitempty"` // Digest of the tag found by the last good check
+	LastError    string `json:"lastError,omitempty"`
+	Failures     int32  `json:"failures,omitempty"` // Consecutive failed checks
+	NextCheck    string `json:"nextCheck,omitempty"`
+}
+
+// AppBuildCache describes the build cache of an application, i.e. the volume kept between
+// stagings.
+type AppBuildCache struct {
+	Size       string `json:"size"`                 // Capacity of the volume
+	UsageBytes int64  `json:"usageBytes,omitempty"` // Usage at the end of the last measured staging
+	MeasuredAt string `json:"measuredAt,omitempty"`
+}
+
+// BuildMetadata describes how the image of a staging run was built, for reproduction and
+// audit of the build.
+type BuildMetadata struct {
+	StageID            string          `json:"stageId"`
+	BuildMode          string          `json:"buildMode,omitempty"`
+	BuilderImage       string          `json:"builderImage,omitempty"`
+	BuilderImageDigest string          `json:"builderImageDigest,omitempty"`
+	Buildpacks         []BuildpackInfo `json:"buildpacks,omitempty"`
+	SourceBlobUID      string          `json:"sourceBlobUID,omitempty"`
+	SourceChecksum     string          `json:"sourceChecksum,omitempty"` // Digest of the source blob
+	GitRevision        string          `json:"gitRevision,omitempty"`
+	Image              string          `json:"image,omitempty"`
+	ImageDigest        string          `json:"imageDigest,omitempty"`
+	SBOM               *BuildSBOM      `json:"sbom,omitempty"`
+	CreatedAt          string          `json:"createdAt,omitempty"`
+}
+
+// BuildpackInfo identifies a buildpack which took part in a build.
+type BuildpackInfo struct {
+	ID       string `json:"id"`
+	Version  string `json:"version,omitempty"`
+	Homepage string `json:"homepage,omitempty"`
+}
+
+// BuildSBOM references the image layer holding the software bill of materials produced by
+// the buildpack lifecycle. The documents are the contents of the layer, by path. They are
+// filled only when the SBOM is retrieved.
+type BuildSBOM struct {
+	Digest    string                     `json:"digest"`
+	MediaType string                     `json:"mediaType,omitempty"`
+	Size      int64                      `json:"size,omitempty"`
+	Documents map[string]json.RawMessage `json:"documents,omitempty"`
+}
+
+// AppPromotion records a step in the promotion of a staged image across namespaces, i.e.
+// the application it was promoted from.
+type AppPromotion struct {
+	Namespace  string `json:"namespace"`
+	App        string `json:"app"`
+	StageID    string `json:"stageId"`
+	ImageURL   string `json:"imageURL"`
+	PromotedAt string `json:"promotedAt"`
+	PromotedBy string `json:"promotedBy,omitempty"`
 }
 
 // App has all the application's properties, for at rest (Configuration), and active (Workload).
@@ -104,10 +208,17 @@ type App struct {
 	Workload      *AppDeployment           `json:"deployment,omitempty"`
 	Staging       ApplicationStage         `json:"staging,omitempty"`
 	StagingStatus ApplicationStagingStatus `json:"stagingstatus"`
+	QueuePosition int                      `json:"queuePosition,omitempty"` // position in the staging queue, when queued
 	Status        ApplicationStatus        `json:"status"`
 	StatusMessage string                   `json:"statusmessage"`
 	StageID       string                   `json:"stage_id,omitempty"` // staging id, last run
 	ImageURL      string                   `json:"image_url"`
+	GitPoll       *GitPollStatus           `json:"gitPoll,omitempty"`
+	ImageWatch    *ImageWatchStatus        `json:"imageWatch,omitempty"`
+	Lineage       []AppPromotion           `json:"lineage,omitempty"` // promotions leading to the image, oldest first
+	BuildCache    *AppBuildCache           `json:"buildCache,omitempty"`
+	LogDrains     []LogDrainStatus         `json:"logDrains,omitempty"`
+	Alerts        []AppAlertStatus         `json:"alerts,omitempty"`
 }
 
 type PodInfo struct {
@@ -118,22 +229,64 @@ type PodInfo struct {
 	CreatedAt   string `json:"createdAt,omitempty"`
 	Restarts    int32  `json:"restarts"`
 	Ready       bool   `json:"ready"`
+	Message     string `json:"message,omitempty"` // reason of a failing health check, or container problem
+
+	Diagnostics *PodDiagnostics `json:"diagnostics,omitempty"` // why the replica fails, or failed
+}
+
+// PodDiagnostics explains why a replica is failing, or failed before. It is only present
+// for replicas with something to explain.
+type PodDiagnostics struct {
+	LastTermination *ContainerTermination `json:"lastTermination,omitempty"` // of the app container
+	OOMKilled       bool                  `json:"oomKilled,omitempty"`
+	ImagePullError  string                `json:"imagePullError,omitempty"`
+	PendingReason   string                `json:"pendingReason,omitempty"` // why the replica is not scheduled
+	PreviousLogs    []string              `json:"previousLogs,omitempty"`  // last lines of the terminated container
+}
+
+// Summary returns a short human-readable explanation of the diagnostics, e.g. `was killed
+// for running out of memory (exit code 137)`.
+func (d *PodDiagnostics) Summary() string {
+	switch {
+	case d.PendingReason != "":
+		return "cannot be scheduled: " + d.PendingReason
+	case d.ImagePullError != "":
+		return "cannot pull its image: " + d.ImagePullError
+	case d.OOMKilled:
+		return fmt.Sprintf("was killed for running out of memory (exit code %d)", d.LastTermination.ExitCode)
+	case d.LastTermination != nil:
+		reason := d.LastTermination.Reason
+		if reason == "" {
+			reason = "an error"
+		}
+		return fmt.Sprintf("terminated with %s (exit code %d)", reason, d.LastTermination.ExitCode)
+	}
+	return "is failing"
+}
+
+// ContainerTermination describes the end of a container.
+type ContainerTermination struct {
+	Reason     string `json:"reason,omitempty"`
+	ExitCode   int32  `json:"exitCode"`
+	Signal     int32  `json:"signal,omitempty"`
+	Message    string `json:"message,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
 }
 
 // AppDeployment contains all the information specific to an active
 // application, i.e. one with a deployment in the cluster.
 type AppDeployment struct {
-	// TODO: Readiness and Liveness fields?
 	Name            string              `json:"name,omitempty"`
 	Active          bool                `json:"active,omitempty"` // app is > 0 replicas
 	CreatedAt       string              `json:"createdAt,omitempty"`
 	DesiredReplicas int32               `json:"desiredreplicas"`
 	ReadyReplicas   int32               `json:"readyreplicas"`
 	Replicas        map[string]*PodInfo `json:"replicas"`
-	Username        string              `json:"username,omitempty"` // app creator
-	StageID         string              `json:"stage_id,omitempty"` // staging id, running app
-	Status          string              `json:"status,omitempty"`   // app replica status
-	Routes          []string            `json:"routes,omitempty"`   // app routes
+	Username        string              `json:"username,omitempty"`    // app creator
+	StageID         string              `json:"stage_id,omitempty"`    // staging id, running app
+	Status          string              `json:"status,omitempty"`      // app replica status
+	Routes          []string            `json:"routes,omitempty"`      // app routes
+	ImageDigest     string              `json:"imageDigest,omitempty"` // digest the container image is pinned to
 }
 
 // AppMatchResponse contains the list of names for matching apps
@@ -236,6 +389,72 @@ func (ar *AppRef) MakeScaleSecretName() string {
 	return names.GenerateResourceName(ar.Name + "-scale")
 }
 
+// MakeResourcesSecretName returns the name of the kube secret holding the cpu and
+// memory requests and limits of the referenced application
+func (ar *AppRef) MakeResourcesSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-resources")
+}
+
+// MakeHealthcheckSecretName returns the name of the kube secret holding the health check
+// configuration of the referenced application
+func (ar *AppRef) MakeHealthcheckSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-healthcheck")
+}
+
+// MakeDependenciesSecretName returns the name of the kube secret holding the dependencies
+// of the referenced application
+func (ar *AppRef) MakeDependenciesSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-dependencies")
+}
+
+// MakeContainersSecretName returns the name of the kube secret holding the sidecars and
+// init containers of the referenced application
+func (ar *AppRef) MakeContainersSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-containers")
+}
+
+// MakeGitTriggerSecretName returns the name of the kube secret holding the webhook secret
+// and the recent webhook deliveries of the referenced application
+func (ar *AppRef) MakeGitTriggerSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-gittrigger")
+}
+
+// MakeGitPollSecretName returns the name of the kube secret holding the git poll interval
+// and polling status of the referenced application
+func (ar *AppRef) MakeGitPollSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-gitpoll")
+}
+
+// MakeImageWatchSecretName returns the name of the kube secret holding the image watch
+// configuration and status of the referenced application
+func (ar *AppRef) MakeImageWatchSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-imagewatch")
+}
+
+// MakeLogDrainsSecretName returns the name of the kube secret holding the log drains of
+// the referenced application, and their status
+func (ar *AppRef) MakeLogDrainsSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-drains")
+}
+
+// MakeAlertsSecretName returns the name of the kube secret holding the alerts of the
+// referenced application, and their state
+func (ar *AppRef) MakeAlertsSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-alerts")
+}
+
+// MakeBuildMetadataSecretName returns the name of the kube secret holding the build
+// metadata of the recent staging runs of the referenced application
+func (ar *AppRef) MakeBuildMetadataSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-build")
+}
+
+// MakeScanSecretName returns the name of the kube secret holding the image scan reports of
+// the recent staging runs of the referenced application
+func (ar *AppRef) MakeScanSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-scan")
+}
+
 // MakePVCName returns the name of the kube pvc to use with/for the referenced application.
 func (ar *AppRef) MakeCachePVCName() string {
 	return names.GenerateResourceName(ar.Namespace, "cache", ar.Name)
@@ -0,0 +1,86 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Kinds of log drains, the scheme of their URL
+const (
+	LogDrainSyslog    = "syslog"     // RFC5424 over TCP
+	LogDrainSyslogTLS = "syslog+tls" // RFC5424 over TLS
+	LogDrainHTTP      = "http"       // JSON over HTTP
+	LogDrainHTTPS     = "https"      // JSON over HTTPS
+	LogDrainOTLP      = "otlp+http"  // OTLP logs, JSON over HTTP
+	LogDrainOTLPS     = "otlp+https" // OTLP logs, JSON over HTTPS
+)
+
+// LogDrainKinds lists the known kinds of log drains.
+var LogDrainKinds = []string{
+	LogDrainSyslog, LogDrainSyslogTLS, LogDrainHTTP, LogDrainHTTPS, LogDrainOTLP, LogDrainOTLPS,
+}
+
+// Scopes of log drains
+const (
+	LogDrainScopeApp       = "app"       // The logs of a single application
+	LogDrainScopeNamespace = "namespace" // The logs of all applications in the namespace
+)
+
+// Health of log drains
+const (
+	LogDrainPending = "pending" // Nothing forwarded yet
+	LogDrainHealthy = "healthy" // The last batch was forwarded
+	LogDrainFailing = "failing" // The last batch failed to be forwarded
+)
+
+// LogDrain is an external endpoint receiving the logs of an application, or of all
+// applications of a namespace.
+type LogDrain struct {
+	Name  string `json:"name"`
+	URL   string `json:"url"`
+	Scope string `json:"scope,omitempty"`
+}
+
+// LogDrainStatus is a log drain, with the statistics of the forwarding of the logs to it.
+type LogDrainStatus struct {
+	LogDrain
+	Health    string `json:"health"`
+	Sent      int64  `json:"sent"`     // Lines forwarded
+	Dropped   int64  `json:"dropped"`  // Lines lost to a full buffer, or failed batches
+	Failures  int32  `json:"failures"` // Consecutive failed attempts to send
+	LastError string `json:"lastError,omitempty"`
+	LastSent  string `json:"lastSent,omitempty"`
+}
+
+// LogDrainAddRequest adds a log drain to an application, or namespace.
+type LogDrainAddRequest struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// LogDrainsResponse lists the log drains of an application, or namespace.
+type LogDrainsResponse []LogDrainStatus
+
+// RedactedURL returns the URL of the drain without the password of its user info, if any,
+// for display.
+func (d LogDrain) RedactedURL() string {
+	u, err := url.Parse(d.URL)
+	if err != nil || u.User == nil {
+		return d.URL
+	}
+	if _, hasPassword := u.User.Password(); hasPassword {
+		return strings.Replace(u.String(), u.User.String()+"@", u.User.Username()+":xxxxx@", 1)
+	}
+	return d.URL
+}
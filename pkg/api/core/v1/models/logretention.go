@@ -0,0 +1,28 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// NamespaceLogRetention is the log retention of a namespace, i.e. how long the log archive
+// keeps the logs of its applications. An empty retention means that the global retention
+// applies.
+type NamespaceLogRetention struct {
+	Namespace string `json:"namespace"`
+	Retention string `json:"retention,omitempty"` // Period, e.g. `7d`. `0` disables archiving
+	Effective string `json:"effective,omitempty"` // Retention in effect, after applying the global retention
+	Archiving bool   `json:"archiving"`           // True if the log archive is enabled for the installation
+}
+
+// NamespaceLogRetentionUpdateRequest changes the log retention of a namespace. An empty
+// retention removes the namespace setting, making the global retention apply.
+type NamespaceLogRetentionUpdateRequest struct {
+	Retention string `json:"retention"`
+}
@@ -20,6 +20,7 @@ package models
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/epinio/epinio/helpers"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -132,11 +133,21 @@ type ApplicationManifest struct {
 	Namespace     string                   `yaml:"namespace,omitempty"`
 }
 
+// Build modes of the staging. Buildpack builds run the Paketo builder image. Dockerfile
+// builds run a daemonless image builder on the Dockerfile found in the sources.
+const (
+	BuildModeBuildpack  = "buildpack"
+	BuildModeDockerfile = "dockerfile"
+)
+
 // ApplicationStage is the part of the manifest holding information
 // relevant to staging the application's sources. This is, currently,
-// only the reference to the Paketo builder image to use.
+// only the reference to the Paketo builder image to use. The build mode
+// is determined by the server and not part of the manifest.
 type ApplicationStage struct {
-	Builder string `yaml:"builder,omitempty" json:"builder,omitempty"`
+	Builder    string `yaml:"builder,omitempty" json:"builder,omitempty"`
+	Mode       string `yaml:"-" json:"mode,omitempty"`
+	ClearCache bool   `yaml:"-" json:"-"` // CLI only. Clear the build cache before staging
 }
 
 // ApplicationConfiguration is the part of the manifest describing the configuration of the application
@@ -150,9 +161,108 @@ type ApplicationConfiguration struct {
 	Routes             []string                    `json:"routes"             yaml:"routes,omitempty"`
 	AppChart           string                      `json:"appchart,omitempty" yaml:"appchart,omitempty"`
 	Settings           ChartValueSettings          `json:"settings,omitempty" yaml:"settings,omitempty"`
+	Resources          *AppResources               `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Healthcheck        *AppHealthcheck             `json:"healthcheck,omitempty" yaml:"healthcheck,omitempty"`
+	DependsOn          []AppDependency             `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	Sidecars           []AppContainer              `json:"sidecars,omitempty" yaml:"sidecars,omitempty"`
+	InitContainers     []AppContainer              `json:"initContainers,omitempty" yaml:"initContainers,omitempty"`
+	RegistryCredential string                      `json:"registryCredential,omitempty" yaml:"registryCredential,omitempty"`
+	ImageWatch         *AppImageWatch              `json:"imageWatch,omitempty" yaml:"imageWatch,omitempty"`
 	Ignore             []string                    `json:"ignore,omitempty"   yaml:"ignore,omitempty"`
 }
 
+// AppResources holds the cpu and memory requests and limits of each instance of an
+// application. The values are kubernetes quantities, i.e. `250m`, `512Mi`. Empty values
+// are not set, leaving the choice to the cluster.
+type AppResources struct {
+	Requests ResourceValues `json:"requests,omitempty" yaml:"requests,omitempty"`
+	Limits   ResourceValues `json:"limits,omitempty"   yaml:"limits,omitempty"`
+}
+
+// ResourceValues holds a cpu and memory amount, as kubernetes quantities.
+type ResourceValues struct {
+	CPU    string `json:"cpu,omitempty"    yaml:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+// IsEmpty returns true if neither requests nor limits are set.
+func (r *AppResources) IsEmpty() bool {
+	return r == nil || (r.Requests == ResourceValues{} && r.Limits == ResourceValues{})
+}
+
+// Types of application health checks
+const (
+	HealthcheckHTTP    = "http"    // HTTP GET of a path, success is any status in [200,400)
+	HealthcheckTCP     = "tcp"     // TCP connection to the port
+	HealthcheckProcess = "process" // Running process, no probes
+)
+
+// AppHealthcheck configures how the instances of an application are checked for readiness
+// and liveness. Zero values are not set, leaving the choice to the app chart, or kubernetes.
+type AppHealthcheck struct {
+	Type                string `json:"type"                          yaml:"type"`
+	Path                string `json:"path,omitempty"                yaml:"path,omitempty"`
+	Port                int32  `json:"port,omitempty"                yaml:"port,omitempty"`
+	TimeoutSeconds      int32  `json:"timeoutSeconds,omitempty"      yaml:"timeoutSeconds,omitempty"`
+	PeriodSeconds       int32  `json:"periodSeconds,omitempty"       yaml:"periodSeconds,omitempty"`
+	SuccessThreshold    int32  `json:"successThreshold,omitempty"    yaml:"successThreshold,omitempty"`
+	FailureThreshold    int32  `json:"failureThreshold,omitempty"    yaml:"failureThreshold,omitempty"`
+	StartupGraceSeconds int32  `json:"startupGraceSeconds,omitempty" yaml:"startupGraceSeconds,omitempty"`
+}
+
+// AppDependency references an application or service instance in the namespace of an
+// application, which has to be ready before the application is staged and deployed.
+// Exactly one of the fields is set.
+type AppDependency struct {
+	App     string `json:"app,omitempty"     yaml:"app,omitempty"`
+	Service string `json:"service,omitempty" yaml:"service,omitempty"`
+}
+
+// ParseAppDependency converts the textual form of a dependency, i.e. `app:NAME`, or
+// `service:NAME`, into the structure.
+func ParseAppDependency(dependency string) (AppDependency, error) {
+	kind, name, found := strings.Cut(dependency, ":")
+	if !found || name == "" {
+		return AppDependency{}, fmt.Errorf("bad dependency '%s', expected app:NAME or service:NAME", dependency)
+	}
+
+	switch kind {
+	case "app":
+		return AppDependency{App: name}, nil
+	case "service":
+		return AppDependency{Service: name}, nil
+	}
+
+	return AppDependency{}, fmt.Errorf("bad dependency '%s', unknown kind '%s'", dependency, kind)
+}
+
+// String returns the textual form of the dependency, i.e. `app:NAME`, or `service:NAME`.
+func (d AppDependency) String() string {
+	if d.Service != "" {
+		return "service:" + d.Service
+	}
+	return "app:" + d.App
+}
+
+// AppContainer declares an additional container of the instances of an application. Sidecars
+// run next to the application container, init containers run to completion, in order, before
+// it starts.
+type AppContainer struct {
+	Name    string           `json:"name"              yaml:"name"`
+	Image   string           `json:"image"             yaml:"image"`
+	Command []string         `json:"command,omitempty" yaml:"command,omitempty"`
+	Env     EnvVariableMap   `json:"env,omitempty"     yaml:"env,omitempty"`
+	Volumes []AppVolumeMount `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+}
+
+// AppVolumeMount mounts a volume shared by the containers of an application instance. All
+// mounts of the same name refer to the same (empty at start) volume. The application
+// container mounts it as well, at the path of the first mount declaring it.
+type AppVolumeMount struct {
+	Name string `json:"name" yaml:"name"`
+	Path string `json:"path" yaml:"path"`
+}
+
 // ApplicationOrigin is the part of the manifest describing the origin of the application
 // (sources). At most one of the fields may be specified / not empty.
 type ApplicationOrigin struct {
@@ -227,11 +337,22 @@ type ApplicationUpdateRequest struct {
 	Routes         []string           `json:"routes"             yaml:"routes,omitempty"`
 	AppChart       string             `json:"appchart,omitempty" yaml:"appchart,omitempty"`
 	Settings       ChartValueSettings `json:"settings,omitempty" yaml:"settings,omitempty"`
+	Resources      *AppResources      `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Healthcheck    *AppHealthcheck    `json:"healthcheck,omitempty" yaml:"healthcheck,omitempty"`
+	DependsOn      []AppDependency    `json:"dependsOn"          yaml:"dependsOn,omitempty"`
+	Sidecars       []AppContainer     `json:"sidecars"           yaml:"sidecars,omitempty"`
+	InitContainers []AppContainer     `json:"initContainers"     yaml:"initContainers,omitempty"`
+	// RegistryCredential names the registry credentials used to pull the image of the
+	// application. Nil is no change, empty removes them.
+	RegistryCredential *string `json:"registryCredential,omitempty" yaml:"registryCredential,omitempty"`
+	// ImageWatch configures the checks of the image tag for new digests. Nil is no
+	// change, an empty interval stops the checks.
+	ImageWatch *AppImageWatch `json:"imageWatch,omitempty" yaml:"imageWatch,omitempty"`
 }
 
 func NewApplicationUpdateRequest(manifest ApplicationManifest) ApplicationUpdateRequest {
 	manifestConfig := manifest.Configuration
-	return ApplicationUpdateRequest{
+	request := ApplicationUpdateRequest{
 		Instances:      manifestConfig.Instances,
 		Configurations: manifestConfig.Configurations,
 		Environment:    manifestConfig.Environment,
@@ -239,7 +360,19 @@ func NewApplicationUpdateRequest(manifest ApplicationManifest) ApplicationUpdate
 		Routes:         manifestConfig.Routes,
 		AppChart:       manifestConfig.AppChart,
 		Settings:       manifestConfig.Settings,
+		Resources:      manifestConfig.Resources,
+		Healthcheck:    manifestConfig.Healthcheck,
+		DependsOn:      manifestConfig.DependsOn,
+		Sidecars:       manifestConfig.Sidecars,
+		InitContainers: manifestConfig.InitContainers,
+		ImageWatch:     manifestConfig.ImageWatch,
 	}
+
+	if manifestConfig.RegistryCredential != "" {
+		request.RegistryCredential = &manifestConfig.RegistryCredential
+	}
+
+	return request
 }
 
 type ImportGitResponse struct {
@@ -260,12 +393,38 @@ type StageRequest struct {
 	App          AppRef `json:"app,omitempty"`
 	BlobUID      string `json:"blobuid,omitempty"`
 	BuilderImage string `json:"builderimage,omitempty"`
+	ClearCache   bool   `json:"clearCache,omitempty"`
 }
 
 // StageResponse represents the server's response to a successful app staging
 type StageResponse struct {
-	Stage    StageRef `json:"stage,omitempty"`
-	ImageURL string   `json:"image,omitempty"`
+	Stage         StageRef `json:"stage,omitempty"`
+	ImageURL      string   `json:"image,omitempty"`
+	QueuePosition int      `json:"queuePosition,omitempty"` // position in the staging queue, when queued
+}
+
+// StagingQueueEntry describes a staging run waiting for a free slot.
+type StagingQueueEntry struct {
+	ID       string `json:"id"` // stage id
+	App      AppRef `json:"app"`
+	Username string `json:"username,omitempty"`
+	QueuedAt string `json:"queuedAt"`
+	Position int    `json:"position"`
+}
+
+// StagingQueue represents the server's response to a request for the staging queue. A
+// limit of zero is no limit.
+type StagingQueue struct {
+	Limit          int                 `json:"limit"`          // concurrent stagings, overall
+	NamespaceLimit int                 `json:"namespaceLimit"` // concurrent stagings, per namespace
+	Entries        []StagingQueueEntry `json:"entries"`
+}
+
+// StagingQueueMoveRequest represents and contains the data needed to move a queued staging
+// run to a new position in the queue.
+type StagingQueueMoveRequest struct {
+	ID       string `json:"id"`
+	Position int    `json:"position"`
 }
 
 // StageCompleteEvent is sent over the staging completion websocket endpoint
@@ -286,6 +445,31 @@ const (
 	StageStatusError     = "error"
 )
 
+// AppEvent is an entry of the events timeline of an application. It is also sent over the
+// events websocket endpoint, one event per message.
+type AppEvent struct {
+	Time    string `json:"time"`
+	Source  string `json:"source"`           // One of the AppEventSource constants
+	Type    string `json:"type"`             // Normal or Warning
+	Reason  string `json:"reason"`           // Short, CamelCase reason of the event
+	Object  string `json:"object,omitempty"` // Kind/name of the resource involved
+	Message string `json:"message"`
+	Count   int32  `json:"count,omitempty"` // Number of occurrences of a repeated kubernetes event
+}
+
+// AppEventsResponse is the events timeline of an application, oldest first.
+type AppEventsResponse struct {
+	Events []AppEvent `json:"events"`
+}
+
+// Sources of application events.
+const (
+	AppEventSourceKubernetes = "kubernetes"
+	AppEventSourceStaging    = "staging"
+	AppEventSourceHelm       = "helm"
+	AppEventSourceEpinio     = "epinio"
+)
+
 // DeployRequest represents and contains the data needed to deploy an application
 // Note that the overall application configuration (instances, configurations, EVs) is
 // already known server side, through AppCreate/AppUpdate requests.
@@ -304,6 +488,25 @@ type DeployResponse struct {
 	Warnings []string `json:"warnings,omitempty"`
 }
 
+// AppPromoteRequest represents and contains the data needed to promote the staged image of
+// an application into another namespace. The configuration of the source application is
+// carried over, with the namespace-specific overrides given here applied on top. The
+// environment overrides are merged into the source environment. Empty routes keep the
+// routes of an existing target application, or use the default route of a new one.
+type AppPromoteRequest struct {
+	Target      string         `json:"target"`
+	Environment EnvVariableMap `json:"environment,omitempty"`
+	Routes      []string       `json:"routes,omitempty"`
+	Instances   *int32         `json:"instances,omitempty"`
+}
+
+// AppPromoteResponse represents the server's response to a successful app promotion
+type AppPromoteResponse struct {
+	Routes   []string       `json:"routes,omitempty"`
+	Warnings []string       `json:"warnings,omitempty"`
+	Lineage  []AppPromotion `json:"lineage,omitempty"`
+}
+
 // AsyncDeployRequest represents and contains the data needed to stage/build and deploy an application asynchronously.
 //
 // For "source-based" deploys, the client should provide `BlobUID` (from /store or /import-git) and optionally
@@ -314,20 +517,54 @@ type AsyncDeployRequest struct {
 	BuilderImage string            `json:"builderimage,omitempty"`
 	ImageURL     string            `json:"image,omitempty"`
 	Origin       ApplicationOrigin `json:"origin,omitempty"`
+	ClearCache   bool              `json:"clearCache,omitempty"`
 }
 
 // AsyncDeployStatus represents the status of an asynchronous deploy operation.
 type AsyncDeployStatus struct {
-	ID         string   `json:"id"`
-	App        AppRef   `json:"app"`
-	Status     string   `json:"status"` // pending, staging, deploying, succeeded, failed
-	StageID    string   `json:"stage_id,omitempty"`
-	ImageURL   string   `json:"image,omitempty"`
-	Error      string   `json:"error,omitempty"`
-	Routes     []string `json:"routes,omitempty"`
-	Warnings   []string `json:"warnings,omitempty"`
-	StartedAt  string   `json:"startedAt,omitempty"`
-	FinishedAt string   `json:"finishedAt,omitempty"`
+	ID            string   `json:"id"`
+	App           AppRef   `json:"app"`
+	Status        string   `json:"status"`                  // pending, waiting, importing, queued, staging, scanning, deploying, succeeded, failed
+	QueuePosition int      `json:"queuePosition,omitempty"` // position in the staging queue, when queued
+	StageID       string   `json:"stage_id,omitempty"`
+	ImageURL      string   `json:"image,omitempty"`
+	Error         string   `json:"error,omitempty"`
+	Routes        []string `json:"routes,omitempty"`
+	Warnings      []string `json:"warnings,omitempty"`
+	StartedAt     string   `json:"startedAt,omitempty"`
+	FinishedAt    string   `json:"finishedAt,omitempty"`
+}
+
+// Results of git webhook deliveries
+const (
+	GitDeliveryReceived = "received" // Verified, and not acted upon yet
+	GitDeliveryDeployed = "deployed"
+	GitDeliveryIgnored  = "ignored"
+	GitDeliveryFailed   = "failed"
+)
+
+// GitTrigger describes the push-to-deploy configuration of an application, and the verified
+// webhook deliveries it recently received. The secret is only returned when the trigger is enabled.
+type GitTrigger struct {
+	Enabled     bool          `json:"enabled"`
+	Secret      string        `json:"secret,omitempty"`
+	Username    string        `json:"username,omitempty"` // Name of the user deployments are made for
+	WebhookPath string        `json:"webhookPath,omitempty"`
+	Deliveries  []GitDelivery `json:"deliveries,omitempty"`
+}
+
+// GitDelivery describes a git webhook delivery received for an application, and what was
+// done with it.
+type GitDelivery struct {
+	ID           string      `json:"id"`
+	Provider     GitProvider `json:"provider,omitempty"`
+	Event        string      `json:"event,omitempty"`
+	Branch       string      `json:"branch,omitempty"`
+	Revision     string      `json:"revision,omitempty"`
+	Result       string      `json:"result"` // received, deployed, ignored, failed
+	Message      string      `json:"message,omitempty"`
+	DeploymentID string      `json:"deploymentId,omitempty"`
+	ReceivedAt   string      `json:"receivedAt"`
 }
 
 // ApplicationDeleteRequest represents and contains the data needed to delete an application
@@ -535,6 +772,27 @@ type ExportregistryResponse struct {
 	URL  string `json:"url,omitempty"`
 }
 
+// RegistryCredential contains the public parts of the registry credentials of a namespace.
+// The password is private and excluded.
+type RegistryCredential struct {
+	Name      string      `json:"name,omitempty"`
+	Namespace string      `json:"namespace,omitempty"`
+	URL       string      `json:"url,omitempty"`
+	Username  string      `json:"username,omitempty"`
+	CreatedAt metav1.Time `json:"createdAt,omitempty"`
+}
+
+// RegistryCredentialList is a list of registry credentials
+type RegistryCredentialList []RegistryCredential
+
+// RegistryCredentialCreateRequest contains the data for new registry credentials.
+type RegistryCredentialCreateRequest struct {
+	Name     string `json:"name,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"` // nolint:gosec // intentional auth field for registry
+}
+
 // ExportregistriesMatchResponse contains the list of names for matching export registries
 type ExportregistriesMatchResponse struct {
 	Names []string `json:"names,omitempty"`
@@ -546,4 +804,5 @@ type AppExportRequest struct {
 	ChartName    string `json:"chart-name,omitempty"`
 	ImageTag     string `json:"image-tag,omitempty"`
 	ChartVersion string `json:"chart-version,omitempty"`
+	SBOM         bool   `json:"sbom,omitempty"` // Attach the build metadata and SBOM to the image
 }
@@ -126,3 +126,24 @@ var _ = Describe("ApplicationOrigin String()", func() {
 		})
 	})
 })
+
+var _ = Describe("AppDependency", func() {
+	It("parses apps and services", func() {
+		dependency, err := models.ParseAppDependency("app:backend")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dependency).To(Equal(models.AppDependency{App: "backend"}))
+		Expect(dependency.String()).To(Equal("app:backend"))
+
+		dependency, err = models.ParseAppDependency("service:mydb")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dependency).To(Equal(models.AppDependency{Service: "mydb"}))
+		Expect(dependency.String()).To(Equal("service:mydb"))
+	})
+
+	It("fails for bad dependencies", func() {
+		for _, bad := range []string{"backend", "app:", "config:x"} {
+			_, err := models.ParseAppDependency(bad)
+			Expect(err).To(HaveOccurred(), bad)
+		}
+	})
+})
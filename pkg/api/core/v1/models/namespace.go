@@ -42,3 +42,64 @@ func (al NamespaceList) Swap(i, j int) {
 func (al NamespaceList) Less(i, j int) bool {
 	return al[i].Meta.Name < al[j].Meta.Name
 }
+
+// Keys of the namespace quota fields. They are used to name the fields to remove in a
+// NamespaceQuotaUpdateRequest.
+const (
+	QuotaApps        = "apps"
+	QuotaInstances   = "instances"
+	QuotaServices    = "services"
+	QuotaCPU         = "cpu"
+	QuotaMemory      = "memory"
+	QuotaBlobStorage = "blob-storage"
+)
+
+// QuotaKeys lists all the known namespace quota keys.
+var QuotaKeys = []string{
+	QuotaApps,
+	QuotaInstances,
+	QuotaServices,
+	QuotaCPU,
+	QuotaMemory,
+	QuotaBlobStorage,
+}
+
+// NamespaceQuota holds the limits placed on the resources of a namespace.
+// A nil or empty field means that the resource is not limited.
+// CPU, Memory, and BlobStorage are Kubernetes quantities, i.e. `500m`, `2`, `4Gi`.
+type NamespaceQuota struct {
+	Apps        *int32 `json:"apps,omitempty"`
+	Instances   *int32 `json:"instances,omitempty"`
+	Services    *int32 `json:"services,omitempty"`
+	CPU         string `json:"cpu,omitempty"`
+	Memory      string `json:"memory,omitempty"`
+	BlobStorage string `json:"blob_storage,omitempty"`
+}
+
+// NamespaceQuotaUsage holds the current consumption of the resources of a namespace.
+// MilliCPUs and MemoryBytes are the measured values of the application pods, as seen
+// by the Workload metrics.
+type NamespaceQuotaUsage struct {
+	Apps             int32 `json:"apps"`
+	Instances        int32 `json:"instances"`
+	Services         int32 `json:"services"`
+	MilliCPUs        int64 `json:"millicpus"`
+	MemoryBytes      int64 `json:"memoryBytes"`
+	BlobStorageBytes int64 `json:"blobStorageBytes"`
+	MetricsOk        bool  `json:"metricsOk"`
+}
+
+// NamespaceQuotaResponse contains the quota of a namespace, and the current usage
+type NamespaceQuotaResponse struct {
+	Namespace string              `json:"namespace"`
+	Quota     NamespaceQuota      `json:"quota"`
+	Usage     NamespaceQuotaUsage `json:"usage"`
+}
+
+// NamespaceQuotaUpdateRequest represents and contains the data needed to change the
+// quota of a namespace (set/change, and remove limits). See QuotaKeys for the names
+// accepted by Remove.
+type NamespaceQuotaUpdateRequest struct {
+	Set    NamespaceQuota `json:"set,omitempty"`
+	Remove []string       `json:"remove,omitempty"`
+}
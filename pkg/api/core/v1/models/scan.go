@@ -0,0 +1,86 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "strings"
+
+// Severities of the findings of an image scan, in increasing order.
+const (
+	SeverityUnknown  = "UNKNOWN"
+	SeverityLow      = "LOW"
+	SeverityMedium   = "MEDIUM"
+	SeverityHigh     = "HIGH"
+	SeverityCritical = "CRITICAL"
+)
+
+// Severities lists the known severities, in increasing order.
+var Severities = []string{
+	SeverityUnknown,
+	SeverityLow,
+	SeverityMedium,
+	SeverityHigh,
+	SeverityCritical,
+}
+
+// SeverityRank returns the position of the severity in the order of severities, and
+// false if the severity is not known. The comparison ignores case.
+func SeverityRank(severity string) (int, bool) {
+	for idx, s := range Severities {
+		if strings.EqualFold(s, severity) {
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+// ScanFinding is a single vulnerability found by an image scan.
+type ScanFinding struct {
+	ID               string `json:"id"`
+	Package          string `json:"package"`
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	FixedVersion     string `json:"fixedVersion,omitempty"`
+	Severity         string `json:"severity"`
+	Title            string `json:"title,omitempty"`
+}
+
+// ScanReport is the result of scanning the image of a staging run for vulnerabilities.
+// The threshold, blocked flag and reason are evaluated against the policy in effect when
+// the report is returned.
+type ScanReport struct {
+	StageID   string         `json:"stageId"`
+	Image     string         `json:"image"`
+	Scanner   string         `json:"scanner"`
+	ScannedAt string         `json:"scannedAt"`
+	Summary   map[string]int `json:"summary,omitempty"` // Number of findings per severity
+	Findings  []ScanFinding  `json:"findings,omitempty"`
+	Threshold string         `json:"threshold,omitempty"` // Lowest severity blocking deployment
+	Blocked   bool           `json:"blocked,omitempty"`
+	Reason    string         `json:"reason,omitempty"`
+}
+
+// NamespaceScanPolicy is the image scan policy of a namespace. An empty threshold means
+// that the global policy applies.
+type NamespaceScanPolicy struct {
+	Namespace string `json:"namespace"`
+	Threshold string `json:"threshold,omitempty"` // Lowest severity blocking deployment, or `none`
+	Effective string `json:"effective,omitempty"` // Threshold in effect, after applying the global policy
+}
+
+// NamespaceScanPolicyUpdateRequest changes the image scan policy of a namespace. An empty
+// threshold removes the namespace policy, making the global policy apply. The threshold
+// `none` disables blocking for the namespace.
+type NamespaceScanPolicyUpdateRequest struct {
+	Threshold string `json:"threshold"`
+}
+
+// ScanThresholdNone is the threshold disabling blocking by a scan policy.
+const ScanThresholdNone = "none"
@@ -0,0 +1,53 @@
+// Copyright © 2026 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// AppMetricsSample is the average usage of an application over one step of its metrics
+// history, starting at Time.
+type AppMetricsSample struct {
+	Time        string `json:"time"`
+	MilliCPUs   int64  `json:"milliCPUs"`
+	MemoryBytes int64  `json:"memoryBytes"`
+	Instances   int64  `json:"instances"` // Largest number of instances seen during the step
+}
+
+// AppMetricsResponse is the metrics history of an application over the requested range.
+type AppMetricsResponse struct {
+	Namespace string             `json:"namespace"`
+	App       string             `json:"app"`
+	Range     string             `json:"range"`
+	Step      string             `json:"step"`
+	Samples   []AppMetricsSample `json:"samples"`
+}
+
+// AppUsage is the resource usage of an application over the range of a usage report.
+type AppUsage struct {
+	Name     string  `json:"name"`
+	CPUHours float64 `json:"cpuHours"`
+	GBHours  float64 `json:"gbHours"`
+}
+
+// NamespaceUsage is the resource usage of a namespace and its applications over the range
+// of a usage report.
+type NamespaceUsage struct {
+	Namespace    string     `json:"namespace"`
+	CPUHours     float64    `json:"cpuHours"`
+	GBHours      float64    `json:"gbHours"`
+	Applications []AppUsage `json:"applications"`
+}
+
+// UsageReportResponse is the chargeback report of the resources used by the namespaces.
+type UsageReportResponse struct {
+	GeneratedAt string           `json:"generatedAt"`
+	Range       string           `json:"range"`
+	Namespaces  []NamespaceUsage `json:"namespaces"`
+}